@@ -23,6 +23,8 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	HaltChain(ctx context.Context, chain string, options ...rpc.Option) error
+	ResumeChain(ctx context.Context, chain string, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
@@ -80,6 +82,18 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) HaltChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.haltChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ResumeChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.resumeChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -168,6 +168,30 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestHaltChain(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.HaltChain(context.Background(), "chain")
+		require.ErrorIs(err, test.Err)
+	}
+}
+
+func TestResumeChain(t *testing.T) {
+	require := require.New(t)
+
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.ResumeChain(context.Background(), "chain")
+		require.ErrorIs(err, test.Err)
+	}
+}
+
 func TestStacktrace(t *testing.T) {
 	require := require.New(t)
 
@@ -209,6 +209,43 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// ChainArgs are the arguments for calling HaltChain and ResumeChain
+type ChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// HaltChain stops the chain from processing consensus messages until
+// ResumeChain is called. The chain continues to serve its accepted containers
+// to peers.
+func (a *Admin) HaltChain(r *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "haltChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.HaltChain(r.Context(), chainID)
+}
+
+// ResumeChain allows a halted chain to process consensus messages again
+func (a *Admin) ResumeChain(r *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "resumeChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.ResumeChain(r.Context(), chainID)
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("API called",
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ Client = (*client)(nil)
+
+// Client interface for the consensus API Endpoint of a chain
+type Client interface {
+	GetState(context.Context, ...rpc.Option) (*GetStateReply, error)
+	GetBootstrapProgress(context.Context, ...rpc.Option) (*GetBootstrapProgressReply, error)
+}
+
+// Client implementation for the consensus API Endpoint of a chain
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a new consensus API Client for [chain]
+func NewClient(uri, chain string) Client {
+	return &client{requester: rpc.NewEndpointRequester(
+		fmt.Sprintf("%s/ext/bc/%s/consensus", uri, chain),
+	)}
+}
+
+func (c *client) GetState(ctx context.Context, options ...rpc.Option) (*GetStateReply, error) {
+	res := &GetStateReply{}
+	err := c.requester.SendRequest(ctx, "consensus.getState", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetBootstrapProgress(ctx context.Context, options ...rpc.Option) (*GetBootstrapProgressReply, error) {
+	res := &GetBootstrapProgressReply{}
+	err := c.requester.SendRequest(ctx, "consensus.getBootstrapProgress", struct{}{}, res, options...)
+	return res, err
+}
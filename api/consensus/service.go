@@ -0,0 +1,206 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errNotRunning = errors.New("snowman consensus is not running")
+
+// Service is the API service for inspecting the snowman consensus instance and
+// the bootstrapping progress of a chain.
+type Service struct {
+	ctx       *snow.ConsensusContext
+	consensus snowman.Consensus
+	progress  common.BootstrapProgressReporter
+}
+
+// NewService returns a new consensus API service for the chain described by
+// [ctx].
+func NewService(
+	ctx *snow.ConsensusContext,
+	consensus snowman.Consensus,
+	progress common.BootstrapProgressReporter,
+) (http.Handler, error) {
+	server := rpc.NewServer()
+	codec := json.NewCodec()
+	server.RegisterCodec(codec, "application/json")
+	server.RegisterCodec(codec, "application/json;charset=UTF-8")
+	return server, server.RegisterService(
+		&Service{
+			ctx:       ctx,
+			consensus: consensus,
+			progress:  progress,
+		},
+		"consensus",
+	)
+}
+
+type APIBlock struct {
+	ID           ids.ID      `json:"id"`
+	Height       json.Uint64 `json:"height"`
+	ParentID     ids.ID      `json:"parentID"`
+	Accepted     bool        `json:"accepted"`
+	Preferred    bool        `json:"preferred"`
+	ShouldFalter bool        `json:"shouldFalter"`
+	Snowball     string      `json:"snowball"`
+	Finalized    bool        `json:"finalized"`
+	Children     []ids.ID    `json:"children"`
+}
+
+type APIPollResult struct {
+	PollNumber         json.Uint64            `json:"pollNumber"`
+	Votes              map[ids.ID]json.Uint64 `json:"votes"`
+	Successful         bool                   `json:"successful"`
+	LastAcceptedHeight json.Uint64            `json:"lastAcceptedHeight"`
+}
+
+type APIPreference struct {
+	Height  json.Uint64 `json:"height"`
+	BlockID ids.ID      `json:"blockID"`
+}
+
+// GetStateReply is the response from GetState
+type GetStateReply struct {
+	LastAcceptedID     ids.ID      `json:"lastAcceptedID"`
+	LastAcceptedHeight json.Uint64 `json:"lastAcceptedHeight"`
+	Preference         ids.ID      `json:"preference"`
+	// Preferences are ordered by height.
+	Preferences []APIPreference `json:"preferences"`
+	// Blocks contains the last accepted block followed by the processing
+	// blocks, ordered by height.
+	Blocks []APIBlock `json:"blocks"`
+	// RecentPolls are ordered from oldest to newest.
+	RecentPolls []APIPollResult `json:"recentPolls"`
+}
+
+// GetState returns the processing block tree, the state of the snowball
+// instances, the preferred block at each processing height, and the results of
+// the most recent polls.
+func (s *Service) GetState(_ *http.Request, _ *struct{}, reply *GetStateReply) error {
+	s.ctx.Log.Debug("API called",
+		zap.String("service", "consensus"),
+		zap.String("method", "getState"),
+	)
+
+	s.ctx.Lock.Lock()
+	defer s.ctx.Lock.Unlock()
+
+	state := s.ctx.State.Get()
+	if state.Type != p2p.EngineType_ENGINE_TYPE_SNOWMAN || state.State != snow.NormalOp {
+		return errNotRunning
+	}
+
+	inspection := s.consensus.Inspect()
+	reply.LastAcceptedID = inspection.LastAcceptedID
+	reply.LastAcceptedHeight = json.Uint64(inspection.LastAcceptedHeight)
+	reply.Preference = inspection.Preference
+
+	heights := maps.Keys(inspection.PreferredIDs)
+	slices.Sort(heights)
+	reply.Preferences = make([]APIPreference, len(heights))
+	for i, height := range heights {
+		reply.Preferences[i] = APIPreference{
+			Height:  json.Uint64(height),
+			BlockID: inspection.PreferredIDs[height],
+		}
+	}
+
+	reply.Blocks = make([]APIBlock, len(inspection.Blocks))
+	for i, blk := range inspection.Blocks {
+		reply.Blocks[i] = APIBlock{
+			ID:           blk.ID,
+			Height:       json.Uint64(blk.Height),
+			ParentID:     blk.ParentID,
+			Accepted:     blk.Accepted,
+			Preferred:    blk.Preferred,
+			ShouldFalter: blk.ShouldFalter,
+			Snowball:     blk.Snowball,
+			Finalized:    blk.Finalized,
+			Children:     blk.Children,
+		}
+	}
+
+	reply.RecentPolls = make([]APIPollResult, len(inspection.RecentPolls))
+	for i, poll := range inspection.RecentPolls {
+		votes := make(map[ids.ID]json.Uint64, len(poll.Votes))
+		for blkID, numVotes := range poll.Votes {
+			votes[blkID] = json.Uint64(numVotes)
+		}
+		reply.RecentPolls[i] = APIPollResult{
+			PollNumber:         json.Uint64(poll.PollNumber),
+			Votes:              votes,
+			Successful:         poll.Successful,
+			LastAcceptedHeight: json.Uint64(poll.LastAcceptedHeight),
+		}
+	}
+	return nil
+}
+
+// GetBootstrapProgressReply is the response from GetBootstrapProgress
+type GetBootstrapProgressReply struct {
+	// Phase is one of "frontier", "fetching", "executing", "waiting" or
+	// "finished".
+	Phase          string    `json:"phase"`
+	PhaseStartTime time.Time `json:"phaseStartTime"`
+	// StartingHeight is the height of the last accepted block when
+	// bootstrapping started.
+	StartingHeight json.Uint64 `json:"startingHeight"`
+	// TipHeight is the greatest height of the blocks fetched so far.
+	TipHeight    json.Uint64 `json:"tipHeight"`
+	NumFetched   json.Uint64 `json:"numFetched"`
+	NumToFetch   json.Uint64 `json:"numToFetch"`
+	NumExecuted  json.Uint64 `json:"numExecuted"`
+	NumToExecute json.Uint64 `json:"numToExecute"`
+	// Rate is the number of blocks per second fetched or executed during the
+	// current phase.
+	Rate json.Float64 `json:"rate"`
+	// ETA is the estimated time until the current phase finishes.
+	ETA time.Duration `json:"eta"`
+}
+
+// GetBootstrapProgress returns the progress of bootstrapping the chain.
+//
+// Chains that were linearized from a DAG report the progress of bootstrapping
+// the DAG until it finishes. Only the number of vertices fetched and executed
+// is known while bootstrapping the DAG.
+//
+// The chain's lock isn't grabbed, as it is held for the entire execution phase
+// of bootstrapping.
+func (s *Service) GetBootstrapProgress(_ *http.Request, _ *struct{}, reply *GetBootstrapProgressReply) error {
+	s.ctx.Log.Debug("API called",
+		zap.String("service", "consensus"),
+		zap.String("method", "getBootstrapProgress"),
+	)
+
+	progress := s.progress.BootstrapProgress()
+	reply.Phase = string(progress.Phase)
+	reply.PhaseStartTime = progress.PhaseStartTime
+	reply.StartingHeight = json.Uint64(progress.StartingHeight)
+	reply.TipHeight = json.Uint64(progress.TipHeight)
+	reply.NumFetched = json.Uint64(progress.NumFetched)
+	reply.NumToFetch = json.Uint64(progress.NumToFetch)
+	reply.NumExecuted = json.Uint64(progress.NumExecuted)
+	reply.NumToExecute = json.Uint64(progress.NumToExecute)
+	reply.Rate = json.Float64(progress.Rate)
+	reply.ETA = progress.ETA
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+var testParams = snowball.Parameters{
+	K:                     1,
+	AlphaPreference:       1,
+	AlphaConfidence:       1,
+	BetaVirtuous:          2,
+	BetaRogue:             2,
+	ConcurrentRepolls:     1,
+	OptimalProcessing:     1,
+	MaxOutstandingItems:   1,
+	MaxItemProcessingTime: 1,
+}
+
+func TestGetStateNotRunning(t *testing.T) {
+	tests := []struct {
+		name  string
+		state snow.EngineState
+	}{
+		{
+			name: "bootstrapping",
+			state: snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.Bootstrapping,
+			},
+		},
+		{
+			name: "avalanche",
+			state: snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+				State: snow.NormalOp,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := snow.DefaultConsensusContextTest()
+			ctx.State.Set(test.state)
+
+			service := &Service{
+				ctx:       ctx,
+				consensus: &snowman.Topological{},
+			}
+			err := service.GetState(nil, nil, &GetStateReply{})
+			require.ErrorIs(t, err, errNotRunning)
+		})
+	}
+}
+
+func TestGetState(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp,
+	})
+
+	genesisID := ids.GenerateTestID()
+	consensus := &snowman.Topological{}
+	require.NoError(consensus.Initialize(ctx, testParams, genesisID, 0, time.Unix(1, 0)))
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: genesisID,
+		HeightV: 1,
+	}
+	require.NoError(consensus.Add(context.Background(), blk))
+	require.NoError(consensus.RecordPoll(context.Background(), bag.Of(blk.ID())))
+
+	service := &Service{
+		ctx:       ctx,
+		consensus: consensus,
+	}
+	reply := GetStateReply{}
+	require.NoError(service.GetState(nil, nil, &reply))
+
+	require.Equal(genesisID, reply.LastAcceptedID)
+	require.Zero(reply.LastAcceptedHeight)
+	require.Equal(blk.ID(), reply.Preference)
+	require.Equal(
+		[]APIPreference{
+			{
+				Height:  1,
+				BlockID: blk.ID(),
+			},
+		},
+		reply.Preferences,
+	)
+
+	require.Len(reply.Blocks, 2)
+	require.Equal(genesisID, reply.Blocks[0].ID)
+	require.True(reply.Blocks[0].Accepted)
+	require.NotEmpty(reply.Blocks[0].Snowball)
+	require.Equal([]ids.ID{blk.ID()}, reply.Blocks[0].Children)
+	require.Equal(
+		APIBlock{
+			ID:        blk.ID(),
+			Height:    1,
+			ParentID:  genesisID,
+			Preferred: true,
+			// The poll didn't reach the children of [blk], so any future
+			// snowball instance under [blk] must reset its confidence.
+			ShouldFalter: true,
+		},
+		reply.Blocks[1],
+	)
+
+	require.Equal(
+		[]APIPollResult{
+			{
+				PollNumber: 1,
+				Votes: map[ids.ID]json.Uint64{
+					blk.ID(): 1,
+				},
+				Successful: true,
+			},
+		},
+		reply.RecentPolls,
+	)
+}
+
+func TestGetBootstrapProgress(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	progress, err := common.NewBootstrapProgressTracker("", ctx.Registerer)
+	require.NoError(err)
+	progress.Fetched(5, 25, 10)
+	progress.SetPhase(common.BootstrapPhaseFetching)
+
+	// The chain's lock is held for the entire execution phase, so progress
+	// must be reported without grabbing it.
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	service := &Service{
+		ctx:      ctx,
+		progress: progress,
+	}
+	reply := GetBootstrapProgressReply{}
+	require.NoError(service.GetBootstrapProgress(nil, nil, &reply))
+	require.Equal(string(common.BootstrapPhaseFetching), reply.Phase)
+	require.Equal(json.Uint64(5), reply.StartingHeight)
+	require.Equal(json.Uint64(25), reply.TipHeight)
+	require.Equal(json.Uint64(10), reply.NumFetched)
+	require.Equal(json.Uint64(20), reply.NumToFetch)
+	require.Zero(reply.NumExecuted)
+	require.Zero(reply.NumToExecute)
+}
@@ -240,6 +240,33 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	return nil
 }
 
+// PeerEventsArgs are the arguments for calling PeerEvents
+type PeerEventsArgs struct {
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// PeerEventsReply are the results from calling PeerEvents
+type PeerEventsReply struct {
+	// Number of elements in [Events]
+	NumEvents json.Uint64 `json:"numEvents"`
+	// Each element is a connection failure, handshake rejection or disconnect,
+	// ordered from oldest to newest
+	Events []peer.Event `json:"events"`
+}
+
+// PeerEvents returns the most recent connection failures, handshake rejections
+// and disconnects
+func (i *Info) PeerEvents(_ *http.Request, args *PeerEventsArgs, reply *PeerEventsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "peerEvents"),
+	)
+
+	reply.Events = i.networking.PeerEvents(args.NodeIDs)
+	reply.NumEvents = json.Uint64(len(reply.Events))
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
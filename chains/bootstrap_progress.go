@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import "github.com/ava-labs/avalanchego/snow/engine/common"
+
+var _ common.BootstrapProgressReporter = (*linearizedBootstrapProgress)(nil)
+
+// linearizedBootstrapProgress reports the progress of bootstrapping a DAG until
+// it has been linearized, and the progress of bootstrapping the linear chain
+// afterwards.
+type linearizedBootstrapProgress struct {
+	dag    common.BootstrapProgressReporter
+	linear common.BootstrapProgressReporter
+}
+
+func (p *linearizedBootstrapProgress) BootstrapProgress() common.BootstrapProgress {
+	progress := p.dag.BootstrapProgress()
+	if progress.Phase != common.BootstrapPhaseFinished {
+		return progress
+	}
+	return p.linear.BootstrapProgress()
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+func TestLinearizedBootstrapProgress(t *testing.T) {
+	require := require.New(t)
+
+	dag, err := common.NewBootstrapProgressTracker("dag", prometheus.NewRegistry())
+	require.NoError(err)
+	linear, err := common.NewBootstrapProgressTracker("linear", prometheus.NewRegistry())
+	require.NoError(err)
+
+	progress := &linearizedBootstrapProgress{
+		dag:    dag,
+		linear: linear,
+	}
+
+	dag.SetPhase(common.BootstrapPhaseFetching)
+	dag.Fetched(0, 0, 5)
+	require.Equal(dag.BootstrapProgress(), progress.BootstrapProgress())
+
+	dag.SetPhase(common.BootstrapPhaseFinished)
+	linear.SetPhase(common.BootstrapPhaseFetching)
+	linear.Fetched(10, 20, 15)
+	require.Equal(linear.BootstrapProgress(), progress.BootstrapProgress())
+}
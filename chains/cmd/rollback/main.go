@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func main() {
+	var (
+		dbDir   string
+		dbType  string
+		chainID string
+		height  uint64
+	)
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Rolls back the last accepted block of a snowman chain the next time the node is started",
+		Long: "Records, in the database of a stopped node, that a snowman chain must be rolled back to the provided height. " +
+			"The chain's VM rewinds its last accepted block and height index when the node is next started, before the chain starts bootstrapping, or, for a linearized chain such as the X-chain, when the chain is linearized. " +
+			"If the chain's VM doesn't support rolling back, the request is logged and dropped.",
+		Args: cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			return rollback(dbDir, dbType, chainID, height)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&dbDir, "db-dir", "", "Path to the database directory of the node's network. For example, ~/.avalanchego/db/mainnet")
+	flags.StringVar(&dbType, "db-type", leveldb.Name, fmt.Sprintf("Database type of the node. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	flags.StringVar(&chainID, "chain-id", "", "ID of the chain to roll back")
+	flags.Uint64Var(&height, "height", 0, "Height of the block to roll back to")
+	for _, name := range []string{"db-dir", "chain-id", "height"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func rollback(dbDir, dbType, chainIDStr string, height uint64) error {
+	chainID, err := ids.FromString(chainIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid chain ID %q: %w", chainIDStr, err)
+	}
+
+	db, err := openDB(dbDir, dbType)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := chains.SetRollbackHeight(db, chainID, height); err != nil {
+		return err
+	}
+	fmt.Printf("chain %s will be rolled back to height %d when the node is next started\n", chainID, height)
+	return nil
+}
+
+// openDB opens the database at the same path the node does.
+func openDB(dbDir, dbType string) (database.Database, error) {
+	var (
+		log = logging.NoLog{}
+		reg = prometheus.NewRegistry()
+	)
+	var (
+		dbPath string
+		newDB  func(string, []byte, logging.Logger, string, prometheus.Registerer) (database.Database, error)
+	)
+	switch dbType {
+	case leveldb.Name:
+		dbPath = filepath.Join(dbDir, version.CurrentDatabase.String())
+		newDB = leveldb.New
+	case pebble.Name:
+		dbPath = filepath.Join(dbDir, pebble.Name)
+		newDB = pebble.New
+	default:
+		return nil, fmt.Errorf("db-type was %q but should have been one of {%s, %s}", dbType, leveldb.Name, pebble.Name)
+	}
+
+	// Opening a database that doesn't exist would create an empty one, which
+	// the node would never read.
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("couldn't find database: %w", err)
+	}
+	return newDB(dbPath, nil, log, "db_internal", reg)
+}
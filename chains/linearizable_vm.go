@@ -18,20 +18,25 @@ import (
 var (
 	_ vertex.LinearizableVM = (*initializeOnLinearizeVM)(nil)
 	_ block.ChainVM         = (*linearizeOnInitializeVM)(nil)
+	_ block.RollbackableVM  = (*linearizeOnInitializeVM)(nil)
 )
 
 // initializeOnLinearizeVM transforms the consensus engine's call to Linearize
 // into a call to Initialize. This enables the proposervm to be initialized by
 // the call to Linearize. This also provides the stopVertexID to the
 // linearizeOnInitializeVM.
+//
+// Once the proposervm is initialized, it is rolled back to the height requested
+// by SetRollbackHeight, if any.
 type initializeOnLinearizeVM struct {
 	vertex.DAGVM
-	vmToInitialize common.VM
+	vmToInitialize block.ChainVM
 	vmToLinearize  *linearizeOnInitializeVM
 
 	registerer   metrics.OptionalGatherer
 	ctx          *snow.Context
 	db           database.Database
+	rollbackDB   database.Database
 	genesisBytes []byte
 	upgradeBytes []byte
 	configBytes  []byte
@@ -43,7 +48,7 @@ type initializeOnLinearizeVM struct {
 func (vm *initializeOnLinearizeVM) Linearize(ctx context.Context, stopVertexID ids.ID) error {
 	vm.vmToLinearize.stopVertexID = stopVertexID
 	vm.ctx.Metrics = vm.registerer
-	return vm.vmToInitialize.Initialize(
+	err := vm.vmToInitialize.Initialize(
 		ctx,
 		vm.ctx,
 		vm.db,
@@ -54,6 +59,10 @@ func (vm *initializeOnLinearizeVM) Linearize(ctx context.Context, stopVertexID i
 		vm.fxs,
 		vm.appSender,
 	)
+	if err != nil {
+		return err
+	}
+	return rollback(ctx, vm.ctx.Log, vm.rollbackDB, vm.vmToInitialize)
 }
 
 // linearizeOnInitializeVM transforms the proposervm's call to Initialize into a
@@ -83,3 +92,11 @@ func (vm *linearizeOnInitializeVM) Initialize(
 ) error {
 	return vm.Linearize(ctx, vm.stopVertexID, toEngine)
 }
+
+func (vm *linearizeOnInitializeVM) Rollback(ctx context.Context, height uint64) error {
+	rollbackVM, ok := vm.LinearizableVMWithEngine.(block.RollbackableVM)
+	if !ok {
+		return block.ErrRollbackableVMNotImplemented
+	}
+	return rollbackVM.Rollback(ctx, height)
+}
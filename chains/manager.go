@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/api/consensus"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
@@ -37,6 +39,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/replay"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/syncer"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -52,6 +55,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/metervm"
@@ -92,8 +96,10 @@ var (
 	errUnknownVMType           = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
+	errUnknownChain            = errors.New("unknown chain")
 	errNoPrimaryNetworkConfig  = errors.New("no subnet config for primary network found")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errNoBootstrapProgress     = errors.New("bootstrapper doesn't report its progress")
 
 	_ Manager = (*manager)(nil)
 )
@@ -129,6 +135,15 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// HaltChain stops the chain with the given ID from processing consensus
+	// messages until ResumeChain is called. The chain continues to serve
+	// requests for its accepted containers.
+	HaltChain(ctx context.Context, chainID ids.ID) error
+
+	// ResumeChain allows the halted chain with the given ID to process
+	// consensus messages again.
+	ResumeChain(ctx context.Context, chainID ids.ID) error
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -153,11 +168,13 @@ type ChainParameters struct {
 }
 
 type chain struct {
-	Name    string
-	Context *snow.ConsensusContext
-	VM      common.VM
-	Handler handler.Handler
-	Beacons validators.Manager
+	Name              string
+	Context           *snow.ConsensusContext
+	VM                common.VM
+	Handler           handler.Handler
+	Beacons           validators.Manager
+	Consensus         smcon.Consensus
+	BootstrapProgress common.BootstrapProgressReporter
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -228,6 +245,15 @@ type ManagerConfig struct {
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
+
+	// SnowmanRecordChains are the chains whose snowman engine messages are
+	// recorded to [SnowmanRecordDir].
+	SnowmanRecordChains set.Set[ids.ID]
+	SnowmanRecordDir    string
+
+	// AdaptivePollsConfig optionally adjusts snowman polling based on the
+	// query latency observed by [TimeoutManager].
+	AdaptivePollsConfig smeng.AdaptivePollsConfig
 }
 
 type manager struct {
@@ -407,6 +433,17 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	// Notify those that registered to be notified when a new chain is created
 	m.notifyRegistrants(chain.Name, chain.Context, chain.VM)
 
+	// Expose the internal state of the chain's snowman consensus instance and
+	// the progress of bootstrapping it
+	if err := m.registerConsensusAPI(chain); err != nil {
+		m.Log.Error("failed to register consensus API",
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+			zap.Error(err),
+		)
+	}
+
 	// Allows messages to be routed to the new chain. If the handler hasn't been
 	// started and a message is forwarded, then the message will block until the
 	// handler is started.
@@ -796,6 +833,7 @@ func (m *manager) createAvalancheChain(
 		registerer:   snowmanRegisterer,
 		ctx:          ctx.Context,
 		db:           vmDB,
+		rollbackDB:   prefixDB,
 		genesisBytes: genesisData,
 		upgradeBytes: chainConfig.Upgrade,
 		configBytes:  chainConfig.Config,
@@ -877,8 +915,9 @@ func (m *manager) createAvalancheChain(
 		Validators:    vdrs,
 		Params:        consensusParams,
 		Consensus:     snowmanConsensus,
+		WeightedPolls: sb.Config().WeightedPolls,
 	}
-	snowmanEngine, err := smeng.New(snowmanEngineConfig)
+	snowmanEngine, err := m.newSnowmanEngine(snowmanEngineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
@@ -901,6 +940,10 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman bootstrapper: %w", err)
 	}
+	snowmanBootstrapProgress, ok := snowmanBootstrapper.(common.BootstrapProgressReporter)
+	if !ok {
+		return nil, fmt.Errorf("%w: snowman bootstrapper", errNoBootstrapProgress)
+	}
 
 	if m.TracingEnabled {
 		snowmanBootstrapper = common.TraceBootstrapableEngine(snowmanBootstrapper, m.Tracer)
@@ -954,6 +997,14 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, fmt.Errorf("error initializing avalanche bootstrapper: %w", err)
 	}
+	avalancheBootstrapProgress, ok := avalancheBootstrapper.(common.BootstrapProgressReporter)
+	if !ok {
+		return nil, fmt.Errorf("%w: avalanche bootstrapper", errNoBootstrapProgress)
+	}
+	bootstrapProgress := &linearizedBootstrapProgress{
+		dag:    avalancheBootstrapProgress,
+		linear: snowmanBootstrapProgress,
+	}
 
 	if m.TracingEnabled {
 		avalancheBootstrapper = common.TraceBootstrapableEngine(avalancheBootstrapper, m.Tracer)
@@ -978,13 +1029,67 @@ func (m *manager) createAvalancheChain(
 	}
 
 	return &chain{
-		Name:    chainAlias,
-		Context: ctx,
-		VM:      dagVM,
-		Handler: h,
+		Name:              chainAlias,
+		Context:           ctx,
+		VM:                dagVM,
+		Handler:           h,
+		Consensus:         snowmanConsensus,
+		BootstrapProgress: bootstrapProgress,
 	}, nil
 }
 
+// newSnowmanEngine creates the snowman engine described by [config]. If the
+// chain was selected for recording, the inbound messages of the engine are
+// written to a new file in [SnowmanRecordDir].
+func (m *manager) newSnowmanEngine(config smeng.Config) (smeng.Engine, error) {
+	config.AdaptivePolls = m.AdaptivePollsConfig
+	config.QueryLatency = &chainQueryLatency{
+		timeouts: m.TimeoutManager,
+		chainID:  config.Ctx.ChainID,
+	}
+	if !m.SnowmanRecordChains.Contains(config.Ctx.ChainID) {
+		return smeng.New(config)
+	}
+
+	if err := os.MkdirAll(m.SnowmanRecordDir, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("error creating snowman recording directory: %w", err)
+	}
+	fileName := fmt.Sprintf("%s-%d.jsonl", config.Ctx.ChainID, time.Now().Unix())
+	recordingPath := filepath.Join(m.SnowmanRecordDir, fileName)
+	file, err := perms.Create(recordingPath, perms.ReadWrite)
+	if err != nil {
+		return nil, fmt.Errorf("error creating snowman recording: %w", err)
+	}
+
+	recorder := replay.NewRecorder(config.Ctx.Log, file)
+	config.VM = replay.RecordVM(config.VM, recorder)
+	config.Validators = replay.RecordValidators(config.Validators, recorder)
+	config.QueryLatency = replay.RecordQueryLatency(config.QueryLatency, recorder)
+	// The engine's clock is moved to the time of each recorded message, so
+	// that the expiration of adaptive polls can be replayed.
+	config.Clock = &mockable.Clock{}
+	engine, err := smeng.New(config)
+	if err != nil {
+		_ = recorder.Close()
+		return nil, err
+	}
+
+	config.Ctx.Log.Info("recording snowman engine",
+		zap.String("path", recordingPath),
+	)
+	return replay.RecordEngine(engine, config, recorder), nil
+}
+
+// chainQueryLatency reports the latency of the queries sent by a chain.
+type chainQueryLatency struct {
+	timeouts timeout.Manager
+	chainID  ids.ID
+}
+
+func (c *chainQueryLatency) QueryLatency(percentile float64) (time.Duration, bool) {
+	return c.timeouts.QueryLatency(c.chainID, percentile)
+}
+
 // Create a linear chain using the Snowman consensus engine
 func (m *manager) createSnowmanChain(
 	ctx *snow.ConsensusContext,
@@ -1139,8 +1244,10 @@ func (m *manager) createSnowmanChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
+	// The VM is rolled back with the context that it was initialized with.
+	initCtx := context.TODO()
 	if err := vm.Initialize(
-		context.TODO(),
+		initCtx,
 		ctx.Context,
 		vmDB,
 		genesisData,
@@ -1153,6 +1260,10 @@ func (m *manager) createSnowmanChain(
 		return nil, err
 	}
 
+	if err := rollback(initCtx, ctx.Log, prefixDB, vm); err != nil {
+		return nil, err
+	}
+
 	bootstrapWeight, err := beacons.TotalWeight(ctx.SubnetID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching weight for subnet %s: %w", ctx.SubnetID, err)
@@ -1228,8 +1339,9 @@ func (m *manager) createSnowmanChain(
 		Params:        consensusParams,
 		Consensus:     consensus,
 		PartialSync:   m.PartialSyncPrimaryNetwork && commonCfg.Ctx.ChainID == constants.PlatformChainID,
+		WeightedPolls: sb.Config().WeightedPolls,
 	}
-	engine, err := smeng.New(engineConfig)
+	engine, err := m.newSnowmanEngine(engineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
@@ -1253,6 +1365,10 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman bootstrapper: %w", err)
 	}
+	bootstrapProgress, ok := bootstrapper.(common.BootstrapProgressReporter)
+	if !ok {
+		return nil, errNoBootstrapProgress
+	}
 
 	if m.TracingEnabled {
 		bootstrapper = common.TraceBootstrapableEngine(bootstrapper, m.Tracer)
@@ -1292,10 +1408,12 @@ func (m *manager) createSnowmanChain(
 	}
 
 	return &chain{
-		Name:    chainAlias,
-		Context: ctx,
-		VM:      vm,
-		Handler: h,
+		Name:              chainAlias,
+		Context:           ctx,
+		VM:                vm,
+		Handler:           h,
+		Consensus:         consensus,
+		BootstrapProgress: bootstrapProgress,
 	}, nil
 }
 
@@ -1310,6 +1428,33 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) HaltChain(ctx context.Context, chainID ids.ID) error {
+	chain, err := m.getChain(chainID)
+	if err != nil {
+		return err
+	}
+	return chain.Halt(ctx)
+}
+
+func (m *manager) ResumeChain(ctx context.Context, chainID ids.ID) error {
+	chain, err := m.getChain(chainID)
+	if err != nil {
+		return err
+	}
+	return chain.Resume(ctx)
+}
+
+func (m *manager) getChain(chainID ids.ID) (handler.Handler, error) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	chain, ok := m.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return chain, nil
+}
+
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
 	m.subnetsLock.RLock()
 	defer m.subnetsLock.RUnlock()
@@ -1430,6 +1575,20 @@ func (m *manager) LookupVM(alias string) (ids.ID, error) {
 	return m.VMManager.Lookup(alias)
 }
 
+// registerConsensusAPI adds the consensus API of [chain] to the chain's
+// endpoints.
+func (m *manager) registerConsensusAPI(chain *chain) error {
+	service, err := consensus.NewService(chain.Context, chain.Consensus, chain.BootstrapProgress)
+	if err != nil {
+		return err
+	}
+	return m.Server.AddRoute(
+		service,
+		path.Join(constants.ChainAliasPrefix, chain.Context.ChainID.String()),
+		"/consensus",
+	)
+}
+
 // Notify registrants [those who want to know about the creation of chains]
 // that the specified chain has been created
 func (m *manager) notifyRegistrants(name string, ctx *snow.ConsensusContext, vm common.VM) {
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// rollbackKey is stored in the chain's database, alongside the prefixed
+// databases of the chain.
+var rollbackKey = []byte("rollback")
+
+// SetRollbackHeight requests that the snowman chain [chainID] is rolled back to
+// [height] the next time the chain is created. [db] is the node's database.
+//
+// The rollback is performed once, after the chain's VM is initialized and
+// before the chain starts bootstrapping. For a chain that was linearized, the
+// VM is initialized, and rolled back, when the chain is linearized. If the VM
+// doesn't support rolling back, the request is dropped and the chain is created
+// as usual. If the VM fails to roll back, the chain fails to be created and the
+// request is kept.
+func SetRollbackHeight(db database.Database, chainID ids.ID, height uint64) error {
+	chainDB := prefixdb.New(chainID[:], db)
+	return database.PutUInt64(chainDB, rollbackKey, height)
+}
+
+// rollback rolls [vm] back to the height requested by SetRollbackHeight, if
+// any. [db] is the chain's database and [ctx] is the context [vm] was
+// initialized with.
+//
+// Assumes the chain's lock is held.
+func rollback(
+	ctx context.Context,
+	log logging.Logger,
+	db database.Database,
+	vm block.ChainVM,
+) error {
+	height, err := database.GetUInt64(db, rollbackKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("rolling back chain",
+		zap.Uint64("height", height),
+	)
+	err = block.ErrRollbackableVMNotImplemented
+	if rollbackVM, ok := vm.(block.RollbackableVM); ok {
+		err = rollbackVM.Rollback(ctx, height)
+	}
+	if errors.Is(err, block.ErrRollbackableVMNotImplemented) {
+		// Keeping the request would prevent the chain from ever being created.
+		log.Error("dropping rollback request",
+			zap.String("reason", "vm doesn't support rolling back"),
+			zap.String("vmType", fmt.Sprintf("%T", vm)),
+			zap.Uint64("height", height),
+		)
+		return db.Delete(rollbackKey)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back to height %d: %w", height, err)
+	}
+	return db.Delete(rollbackKey)
+}
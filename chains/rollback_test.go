@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+type rollbackableVM struct {
+	*block.TestVM
+	*block.TestRollbackableVM
+}
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db      = memdb.New()
+		chainID = ids.GenerateTestID()
+		chainDB = prefixdb.New(chainID[:], db)
+		heights []uint64
+		vm      = &rollbackableVM{
+			TestVM: &block.TestVM{},
+			TestRollbackableVM: &block.TestRollbackableVM{
+				T: t,
+				RollbackF: func(_ context.Context, height uint64) error {
+					heights = append(heights, height)
+					return nil
+				},
+			},
+		}
+	)
+
+	// No rollback was requested
+	require.NoError(rollback(context.Background(), logging.NoLog{}, chainDB, vm))
+	require.Empty(heights)
+
+	require.NoError(SetRollbackHeight(db, chainID, 5))
+	require.NoError(rollback(context.Background(), logging.NoLog{}, chainDB, vm))
+	require.Equal([]uint64{5}, heights)
+
+	// The rollback is only performed once
+	require.NoError(rollback(context.Background(), logging.NoLog{}, chainDB, vm))
+	require.Equal([]uint64{5}, heights)
+}
+
+func TestRollbackNotImplemented(t *testing.T) {
+	tests := []struct {
+		name string
+		vm   block.ChainVM
+	}{
+		{
+			name: "not rollbackable",
+			vm:   &block.TestVM{},
+		},
+		{
+			name: "wrapped vm not rollbackable",
+			vm: &rollbackableVM{
+				TestVM: &block.TestVM{},
+				TestRollbackableVM: &block.TestRollbackableVM{
+					RollbackF: func(context.Context, uint64) error {
+						return block.ErrRollbackableVMNotImplemented
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				db      = memdb.New()
+				chainID = ids.GenerateTestID()
+				chainDB = prefixdb.New(chainID[:], db)
+			)
+
+			// The request is dropped so that the chain can still be created.
+			require.NoError(SetRollbackHeight(db, chainID, 5))
+			require.NoError(rollback(context.Background(), logging.NoLog{}, chainDB, test.vm))
+
+			has, err := chainDB.Has(rollbackKey)
+			require.NoError(err)
+			require.False(has)
+		})
+	}
+}
+
+func TestRollbackFailed(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db      = memdb.New()
+		chainID = ids.GenerateTestID()
+		chainDB = prefixdb.New(chainID[:], db)
+		vm      = &rollbackableVM{
+			TestVM: &block.TestVM{},
+			TestRollbackableVM: &block.TestRollbackableVM{
+				RollbackF: func(context.Context, uint64) error {
+					return errTest
+				},
+			},
+		}
+	)
+
+	require.NoError(SetRollbackHeight(db, chainID, 5))
+	err := rollback(context.Background(), logging.NoLog{}, chainDB, vm)
+	require.ErrorIs(err, errTest)
+
+	// The request is kept to be retried when the chain is next created.
+	has, err := chainDB.Has(rollbackKey)
+	require.NoError(err)
+	require.True(has)
+}
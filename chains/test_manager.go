@@ -4,6 +4,8 @@
 package chains
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 )
@@ -56,6 +58,14 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) HaltChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (testManager) ResumeChain(context.Context, ids.ID) error {
+	return nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
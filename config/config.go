@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/hooks"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/nat"
@@ -50,8 +51,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/storage"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
 )
 
 const (
@@ -90,6 +94,12 @@ var (
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
 	errTracingEndpointEmpty                   = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
+	errInvalidAdaptivePollsFastLatency        = fmt.Errorf("%s must be > 0", SnowAdaptivePollsFastLatencyKey)
+	errInvalidAdaptivePollsMaxRepolls         = fmt.Errorf("%s must be > 0", SnowAdaptivePollsMaxConcurrentRepollsKey)
+	errInvalidAdaptivePollsCoefficient        = fmt.Errorf("%s must be >= 1", SnowAdaptivePollsTimeoutCoefficientKey)
+	errInvalidAcceptorHooksMaxPendingEvents   = fmt.Errorf("%s must be > 0", AcceptorHooksMaxPendingEventsKey)
+	errInvalidAcceptorHooksExpiry             = fmt.Errorf("%s must be > 0", AcceptorHooksDetachedSubscriptionExpiryKey)
+	errInvalidAcceptorHooksRetryFrequency     = fmt.Errorf("%s must be > 0", AcceptorHooksRetryFrequencyKey)
 	errPluginDirNotADirectory                 = errors.New("plugin dir is not a directory")
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
@@ -121,6 +131,48 @@ func getConsensusConfig(v *viper.Viper) snowball.Parameters {
 	return p
 }
 
+func getAdaptivePollsConfig(v *viper.Viper) (smeng.AdaptivePollsConfig, error) {
+	config := smeng.AdaptivePollsConfig{
+		Enabled:              v.GetBool(SnowAdaptivePollsEnabledKey),
+		FastLatency:          v.GetDuration(SnowAdaptivePollsFastLatencyKey),
+		MaxConcurrentRepolls: v.GetInt(SnowAdaptivePollsMaxConcurrentRepollsKey),
+		TimeoutCoefficient:   v.GetFloat64(SnowAdaptivePollsTimeoutCoefficientKey),
+		MinimumTimeout:       v.GetDuration(SnowAdaptivePollsMinimumTimeoutKey),
+	}
+	if !config.Enabled {
+		return config, nil
+	}
+
+	switch {
+	case config.FastLatency <= 0:
+		return smeng.AdaptivePollsConfig{}, errInvalidAdaptivePollsFastLatency
+	case config.MaxConcurrentRepolls <= 0:
+		return smeng.AdaptivePollsConfig{}, errInvalidAdaptivePollsMaxRepolls
+	case config.TimeoutCoefficient < 1:
+		return smeng.AdaptivePollsConfig{}, errInvalidAdaptivePollsCoefficient
+	default:
+		return config, nil
+	}
+}
+
+func getAcceptorHooksConfig(v *viper.Viper) (hooks.Config, error) {
+	config := hooks.Config{
+		MaxPendingEvents:           v.GetUint64(AcceptorHooksMaxPendingEventsKey),
+		DetachedSubscriptionExpiry: v.GetDuration(AcceptorHooksDetachedSubscriptionExpiryKey),
+		RetryFrequency:             v.GetDuration(AcceptorHooksRetryFrequencyKey),
+	}
+	switch {
+	case config.MaxPendingEvents == 0:
+		return hooks.Config{}, errInvalidAcceptorHooksMaxPendingEvents
+	case config.DetachedSubscriptionExpiry <= 0:
+		return hooks.Config{}, errInvalidAcceptorHooksExpiry
+	case config.RetryFrequency <= 0:
+		return hooks.Config{}, errInvalidAcceptorHooksRetryFrequency
+	default:
+		return config, nil
+	}
+}
+
 func getLoggingConfig(v *viper.Viper) (logging.Config, error) {
 	loggingConfig := logging.Config{}
 	loggingConfig.Directory = GetExpandedArg(v, LogsDirKey)
@@ -334,6 +386,27 @@ func getNetworkConfig(
 		return network.Config{}, err
 	}
 
+	compressionTypeStrs := v.GetStringSlice(NetworkCompressionTypesKey)
+	compressionTypes := make([]compression.Type, len(compressionTypeStrs))
+	for i, compressionTypeStr := range compressionTypeStrs {
+		compressionTypes[i], err = compression.TypeFromString(compressionTypeStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse %q: %w", NetworkCompressionTypesKey, err)
+		}
+	}
+
+	var zstdDictionary []byte
+	if v.IsSet(NetworkCompressionZstdDictionaryFileKey) {
+		zstdDictionaryPath := GetExpandedArg(v, NetworkCompressionZstdDictionaryFileKey)
+		zstdDictionary, err = os.ReadFile(filepath.Clean(zstdDictionaryPath))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't read zstd dictionary: %w", err)
+		}
+		if _, err := compression.ZstdDictionaryID(zstdDictionary); err != nil {
+			return network.Config{}, err
+		}
+	}
+
 	allowPrivateIPs := !constants.ProductionNetworkIDs.Contains(networkID)
 	if v.IsSet(NetworkAllowPrivateIPsKey) {
 		allowPrivateIPs = v.GetBool(NetworkAllowPrivateIPsKey)
@@ -404,6 +477,8 @@ func getNetworkConfig(
 			PeerListNonValidatorGossipSize: v.GetUint32(NetworkPeerListNonValidatorGossipSizeKey),
 			PeerListPeersGossipSize:        v.GetUint32(NetworkPeerListPeersGossipSizeKey),
 			PeerListGossipFreq:             v.GetDuration(NetworkPeerListGossipFreqKey),
+			PeerListMaxPeersPerIPPrefix:    v.GetUint32(NetworkPeerListMaxPeersPerIPPrefixKey),
+			PeerListMinSubnetPeers:         v.GetUint32(NetworkPeerListMinSubnetPeersKey),
 		},
 
 		DelayConfig: network.DelayConfig{
@@ -413,6 +488,8 @@ func getNetworkConfig(
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionType:              compressionType,
+		CompressionTypes:             compressionTypes,
+		ZstdDictionary:               zstdDictionary,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              allowPrivateIPs,
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -421,6 +498,8 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerEventsSize:            int(v.GetUint(NetworkPeerEventsSizeKey)),
+		QUICEnabled:               v.GetBool(NetworkQUICEnabledKey),
 	}
 
 	switch {
@@ -456,6 +535,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.PeerEventsSize <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerEventsSizeKey)
 	}
 	return config, nil
 }
@@ -865,6 +946,11 @@ func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 			AddPrimaryNetworkDelegatorFee: v.GetUint64(AddPrimaryNetworkDelegatorFeeKey),
 			AddSubnetValidatorFee:         v.GetUint64(AddSubnetValidatorFeeKey),
 			AddSubnetDelegatorFee:         v.GetUint64(AddSubnetDelegatorFeeKey),
+			DynamicFeeConfig: gas.Config{
+				TargetBlockGas:    v.GetUint64(DynamicFeeTargetBlockGasKey),
+				MaxPrice:          v.GetUint64(DynamicFeeMaxPriceKey),
+				ChangeDenominator: v.GetUint64(DynamicFeeChangeDenominatorKey),
+			},
 		}
 	}
 	return genesis.GetTxFeeConfig(networkID)
@@ -908,6 +994,22 @@ func getTrackedSubnets(v *viper.Viper) (set.Set[ids.ID], error) {
 	return trackedSubnetIDs, nil
 }
 
+func getSnowmanRecordChains(v *viper.Viper) (set.Set[ids.ID], error) {
+	recordChainsStrs := strings.Split(v.GetString(SnowmanRecordChainsKey), ",")
+	recordChainIDs := set.NewSet[ids.ID](len(recordChainsStrs))
+	for _, chain := range recordChainsStrs {
+		if chain == "" {
+			continue
+		}
+		chainID, err := ids.FromString(chain)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse chainID %q: %w", chain, err)
+		}
+		recordChainIDs.Add(chainID)
+	}
+	return recordChainIDs, nil
+}
+
 func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, error) {
 	var (
 		configBytes []byte
@@ -1509,6 +1611,22 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 
 	nodeConfig.ChainDataDir = GetExpandedArg(v, ChainDataDirKey)
 
+	nodeConfig.SnowmanRecordChains, err = getSnowmanRecordChains(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.SnowmanRecordDir = GetExpandedArg(v, SnowmanRecordDirKey)
+
+	nodeConfig.AdaptivePollsConfig, err = getAdaptivePollsConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.AcceptorHooksConfig, err = getAcceptorHooksConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.ProcessContextFilePath = GetExpandedArg(v, ProcessContextFileKey)
 
 	nodeConfig.ProvidedFlags = providedFlags(v)
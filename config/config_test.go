@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -516,7 +517,8 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 						"alphaPreference": 16,
 						"alphaConfidence": 20
 					},
-					"validatorOnly": true
+					"validatorOnly": true,
+					"weightedPolls": true
 				}
 			}`,
 			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
@@ -524,6 +526,7 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 				config, ok := given[id]
 				require.True(ok)
 				require.Equal(true, config.ValidatorOnly)
+				require.True(config.WeightedPolls)
 				require.Equal(16, config.ConsensusParameters.AlphaPreference)
 				require.Equal(20, config.ConsensusParameters.AlphaConfidence)
 				require.Equal(30, config.ConsensusParameters.K)
@@ -571,6 +574,127 @@ func setupFile(t *testing.T, path string, fileName string, value string) {
 	require.NoError(os.WriteFile(filePath, []byte(value), 0o600))
 }
 
+func TestGetAdaptivePollsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       map[string]interface{}
+		expectedErr error
+	}{
+		{
+			name:  "disabled by default",
+			flags: map[string]interface{}{},
+		},
+		{
+			name: "enabled with defaults",
+			flags: map[string]interface{}{
+				SnowAdaptivePollsEnabledKey: true,
+			},
+		},
+		{
+			name: "invalid fast latency ignored when disabled",
+			flags: map[string]interface{}{
+				SnowAdaptivePollsFastLatencyKey: time.Duration(0),
+			},
+		},
+		{
+			name: "invalid fast latency",
+			flags: map[string]interface{}{
+				SnowAdaptivePollsEnabledKey:     true,
+				SnowAdaptivePollsFastLatencyKey: time.Duration(0),
+			},
+			expectedErr: errInvalidAdaptivePollsFastLatency,
+		},
+		{
+			name: "invalid max concurrent repolls",
+			flags: map[string]interface{}{
+				SnowAdaptivePollsEnabledKey:              true,
+				SnowAdaptivePollsMaxConcurrentRepollsKey: 0,
+			},
+			expectedErr: errInvalidAdaptivePollsMaxRepolls,
+		},
+		{
+			name: "invalid timeout coefficient",
+			flags: map[string]interface{}{
+				SnowAdaptivePollsEnabledKey:            true,
+				SnowAdaptivePollsTimeoutCoefficientKey: .5,
+			},
+			expectedErr: errInvalidAdaptivePollsCoefficient,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			for key, value := range test.flags {
+				v.Set(key, value)
+			}
+			config, err := getAdaptivePollsConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(v.GetBool(SnowAdaptivePollsEnabledKey), config.Enabled)
+			}
+		})
+	}
+}
+
+func TestGetAcceptorHooksConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       map[string]interface{}
+		expectedErr error
+	}{
+		{
+			name:  "defaults",
+			flags: map[string]interface{}{},
+		},
+		{
+			name: "max pending events",
+			flags: map[string]interface{}{
+				AcceptorHooksMaxPendingEventsKey: uint64(16),
+			},
+		},
+		{
+			name: "unbounded pending events",
+			flags: map[string]interface{}{
+				AcceptorHooksMaxPendingEventsKey: uint64(0),
+			},
+			expectedErr: errInvalidAcceptorHooksMaxPendingEvents,
+		},
+		{
+			name: "invalid expiry",
+			flags: map[string]interface{}{
+				AcceptorHooksDetachedSubscriptionExpiryKey: time.Duration(0),
+			},
+			expectedErr: errInvalidAcceptorHooksExpiry,
+		},
+		{
+			name: "invalid retry frequency",
+			flags: map[string]interface{}{
+				AcceptorHooksRetryFrequencyKey: time.Duration(0),
+			},
+			expectedErr: errInvalidAcceptorHooksRetryFrequency,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			for key, value := range test.flags {
+				v.Set(key, value)
+			}
+			config, err := getAcceptorHooksConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(v.GetUint64(AcceptorHooksMaxPendingEventsKey), config.MaxPendingEvents)
+				require.Equal(v.GetDuration(AcceptorHooksDetachedSubscriptionExpiryKey), config.DetachedSubscriptionExpiry)
+				require.Equal(v.GetDuration(AcceptorHooksRetryFrequencyKey), config.RetryFrequency)
+			}
+		})
+	}
+}
+
 func setupViperFlags() *viper.Viper {
 	v := viper.New()
 	fs := BuildFlagSet()
@@ -53,6 +53,7 @@ var (
 	defaultSubnetConfigDir      = filepath.Join(defaultConfigDir, "subnets")
 	defaultPluginDir            = filepath.Join(defaultUnexpandedDataDir, "plugins")
 	defaultChainDataDir         = filepath.Join(defaultUnexpandedDataDir, "chainData")
+	defaultSnowmanRecordDir     = filepath.Join(defaultUnexpandedDataDir, "snowmanRecordings")
 	defaultProcessContextPath   = filepath.Join(defaultUnexpandedDataDir, DefaultProcessContextFilename)
 )
 
@@ -102,6 +103,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(AddPrimaryNetworkDelegatorFeeKey, genesis.LocalParams.AddPrimaryNetworkDelegatorFee, "Transaction fee, in nAVAX, for transactions that add new primary network delegators")
 	fs.Uint64(AddSubnetValidatorFeeKey, genesis.LocalParams.AddSubnetValidatorFee, "Transaction fee, in nAVAX, for transactions that add new subnet validators")
 	fs.Uint64(AddSubnetDelegatorFeeKey, genesis.LocalParams.AddSubnetDelegatorFee, "Transaction fee, in nAVAX, for transactions that add new subnet delegators")
+	fs.Uint64(DynamicFeeTargetBlockGasKey, genesis.LocalParams.DynamicFeeConfig.TargetBlockGas, "Amount of gas a P-chain block is expected to consume. The fee price increases after blocks that consume more and decreases after blocks that consume less")
+	fs.Uint64(DynamicFeeMaxPriceKey, genesis.LocalParams.DynamicFeeConfig.MaxPrice, "Highest P-chain fee price. The static fees are scaled by the price divided by 1,000,000")
+	fs.Uint64(DynamicFeeChangeDenominatorKey, genesis.LocalParams.DynamicFeeConfig.ChangeDenominator, "Bounds the change of the P-chain fee price after a single block to 1/change-denominator of the price")
 
 	// Database
 	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Must be one of {%s, %s, %s}", leveldb.Name, memdb.Name, pebble.Name))
@@ -126,6 +130,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(NetworkPeerListNonValidatorGossipSizeKey, constants.DefaultNetworkPeerListNonValidatorGossipSize, "Number of non-validators that the node will gossip peer list to")
 	fs.Uint(NetworkPeerListPeersGossipSizeKey, constants.DefaultNetworkPeerListPeersGossipSize, "Number of total peers (including non-validators and validators) that the node will gossip peer list to")
 	fs.Duration(NetworkPeerListGossipFreqKey, constants.DefaultNetworkPeerListGossipFreq, "Frequency to gossip peers to other nodes")
+	fs.Uint(NetworkPeerListMaxPeersPerIPPrefixKey, constants.DefaultNetworkPeerListMaxPeersPerIPPrefix, "Maximum number of validators in the same IP prefix (/16 for IPv4, /32 for IPv6) that a non-validator will connect to. If 0, the number of validators per IP prefix isn't limited")
+	fs.Uint(NetworkPeerListMinSubnetPeersKey, constants.DefaultNetworkPeerListMinSubnetPeers, fmt.Sprintf("Minimum number of validators of each tracked subnet that a non-validator will connect to, regardless of --%s", NetworkPeerListMaxPeersPerIPPrefixKey))
 
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT")
@@ -149,7 +155,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
-	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s, %s]", compression.TypeGzip, compression.TypeZstd, compression.TypeNone))
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages to peers that don't negotiate compression. Must be one of [%s, %s, %s]", compression.TypeGzip, compression.TypeZstd, compression.TypeNone))
+	compressionTypes := make([]string, len(constants.DefaultNetworkCompressionTypes))
+	for i, compressionType := range constants.DefaultNetworkCompressionTypes {
+		compressionTypes[i] = compressionType.String()
+	}
+	fs.StringSlice(NetworkCompressionTypesKey, compressionTypes, fmt.Sprintf("Compression types advertised to peers, in order of preference. Outbound messages are compressed with the first type that the peer also supports. Each must be one of [%s, %s, %s, %s, %s]. Ignored if %s is %s", compression.TypeLZ4, compression.TypeSnappy, compression.TypeZstd, compression.TypeGzip, compression.TypeNone, NetworkCompressionTypeKey, compression.TypeNone))
+	fs.String(NetworkCompressionZstdDictionaryFileKey, "", "Path to a zstd dictionary used to compress messages with peers that advertise the same dictionary. The dictionary should be trained on marshalled Put and Ancestors messages, e.g. with message/cmd/zstd-dictionary")
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
@@ -159,6 +171,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint(NetworkPeerEventsSizeKey, constants.DefaultNetworkPeerEventsSize, "Maximum number of recent connection failures, handshake rejections and disconnects to keep in memory")
+	fs.Bool(NetworkQUICEnabledKey, constants.DefaultNetworkQUICEnabled, "If true, connect to peers over QUIC on the staking port, falling back to TCP for peers that don't support QUIC")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -311,6 +325,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Int(SnowOptimalProcessingKey, snowball.DefaultParameters.OptimalProcessing, "Optimal number of processing containers in consensus")
 	fs.Int(SnowMaxProcessingKey, snowball.DefaultParameters.MaxOutstandingItems, "Maximum number of processing items to be considered healthy")
 	fs.Duration(SnowMaxTimeProcessingKey, snowball.DefaultParameters.MaxItemProcessingTime, "Maximum amount of time an item should be processing and still be healthy")
+	fs.Bool(SnowAdaptivePollsEnabledKey, false, fmt.Sprintf("If true, the snowman engine adjusts the number of concurrent polls and the poll timeout based on the observed query latency. %s, %s, %s and %s are never modified", SnowSampleSizeKey, SnowQuorumSizeKey, SnowVirtuousCommitThresholdKey, SnowRogueCommitThresholdKey))
+	fs.Duration(SnowAdaptivePollsFastLatencyKey, 100*time.Millisecond, fmt.Sprintf("Median query latency at or below which %s polls are kept outstanding. Ignored if %s is false", SnowAdaptivePollsMaxConcurrentRepollsKey, SnowAdaptivePollsEnabledKey))
+	fs.Int(SnowAdaptivePollsMaxConcurrentRepollsKey, 2*snowball.DefaultParameters.ConcurrentRepolls, fmt.Sprintf("Maximum number of concurrent polls when the network is fast. Capped by %s. Ignored if %s is false", SnowRogueCommitThresholdKey, SnowAdaptivePollsEnabledKey))
+	fs.Float64(SnowAdaptivePollsTimeoutCoefficientKey, 2, fmt.Sprintf("Polls outstanding for longer than this multiple of the 99th percentile query latency are finished early. Ignored if %s is false", SnowAdaptivePollsEnabledKey))
+	fs.Duration(SnowAdaptivePollsMinimumTimeoutKey, 250*time.Millisecond, fmt.Sprintf("Minimum amount of time a poll is outstanding before it can be finished early. Ignored if %s is false", SnowAdaptivePollsEnabledKey))
 
 	// ProposerVM
 	fs.Bool(ProposerVMUseCurrentHeightKey, false, "Have the ProposerVM always report the last accepted P-chain block height")
@@ -327,6 +346,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
 
+	// Acceptor hooks
+	fs.Uint64(AcceptorHooksMaxPendingEventsKey, 16384, "Maximum number of accepted and rejected containers persisted for an acceptor hook subscription that haven't been handled yet. Once reached, the oldest containers are dropped")
+	fs.Duration(AcceptorHooksDetachedSubscriptionExpiryKey, 7*24*time.Hour, "Duration an acceptor hook subscription can go without a handler attached before it is deleted, along with its unhandled containers")
+	fs.Duration(AcceptorHooksRetryFrequencyKey, time.Second, "Frequency to redeliver an event that an acceptor hook failed to handle")
+
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
 	fs.String(ChainConfigContentKey, "", "Specifies base64 encoded chains configurations")
@@ -336,6 +360,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Chain Data Directory
 	fs.String(ChainDataDirKey, defaultChainDataDir, "Chain specific data directory")
 
+	// Snowman Recording
+	fs.String(SnowmanRecordChainsKey, "", "Comma separated list of chain IDs whose snowman engine messages should be recorded for replay. Should only be specified for debugging")
+	fs.String(SnowmanRecordDirKey, defaultSnowmanRecordDir, "Directory that snowman engine recordings are written to")
+
 	// Profiles
 	fs.String(ProfileDirKey, defaultProfileDir, "Path to the profile directory")
 	fs.Bool(ProfileContinuousEnabledKey, false, "Whether the app should continuously produce performance profiles")
@@ -22,6 +22,9 @@ const (
 	AddPrimaryNetworkDelegatorFeeKey                   = "add-primary-network-delegator-fee"
 	AddSubnetValidatorFeeKey                           = "add-subnet-validator-fee"
 	AddSubnetDelegatorFeeKey                           = "add-subnet-delegator-fee"
+	DynamicFeeTargetBlockGasKey                        = "dynamic-fee-target-block-gas"
+	DynamicFeeMaxPriceKey                              = "dynamic-fee-max-price"
+	DynamicFeeChangeDenominatorKey                     = "dynamic-fee-change-denominator"
 	UptimeRequirementKey                               = "uptime-requirement"
 	MinValidatorStakeKey                               = "min-validator-stake"
 	MaxValidatorStakeKey                               = "max-validator-stake"
@@ -91,17 +94,23 @@ const (
 	NetworkPeerListNonValidatorGossipSizeKey           = "network-peer-list-non-validator-gossip-size"
 	NetworkPeerListPeersGossipSizeKey                  = "network-peer-list-peers-gossip-size"
 	NetworkPeerListGossipFreqKey                       = "network-peer-list-gossip-frequency"
+	NetworkPeerListMaxPeersPerIPPrefixKey              = "network-peer-list-max-peers-per-ip-prefix"
+	NetworkPeerListMinSubnetPeersKey                   = "network-peer-list-min-subnet-peers"
 	NetworkInitialReconnectDelayKey                    = "network-initial-reconnect-delay"
 	NetworkReadHandshakeTimeoutKey                     = "network-read-handshake-timeout"
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkCompressionTypesKey                         = "network-compression-types"
+	NetworkCompressionZstdDictionaryFileKey            = "network-compression-zstd-dictionary-file"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerEventsSizeKey                           = "network-peer-events-size"
+	NetworkQUICEnabledKey                              = "network-quic-enabled"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
@@ -131,6 +140,11 @@ const (
 	SnowOptimalProcessingKey                           = "snow-optimal-processing"
 	SnowMaxProcessingKey                               = "snow-max-processing"
 	SnowMaxTimeProcessingKey                           = "snow-max-time-processing"
+	SnowAdaptivePollsEnabledKey                        = "snow-adaptive-polls-enabled"
+	SnowAdaptivePollsFastLatencyKey                    = "snow-adaptive-polls-fast-latency"
+	SnowAdaptivePollsMaxConcurrentRepollsKey           = "snow-adaptive-polls-max-concurrent-repolls"
+	SnowAdaptivePollsTimeoutCoefficientKey             = "snow-adaptive-polls-timeout-coefficient"
+	SnowAdaptivePollsMinimumTimeoutKey                 = "snow-adaptive-polls-minimum-timeout"
 	PartialSyncPrimaryNetworkKey                       = "partial-sync-primary-network"
 	TrackSubnetsKey                                    = "track-subnets"
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
@@ -158,6 +172,9 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	AcceptorHooksMaxPendingEventsKey                   = "acceptor-hooks-max-pending-events"
+	AcceptorHooksDetachedSubscriptionExpiryKey         = "acceptor-hooks-detached-subscription-expiry"
+	AcceptorHooksRetryFrequencyKey                     = "acceptor-hooks-retry-frequency"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -170,6 +187,8 @@ const (
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
 	ChainDataDirKey                                    = "chain-data-dir"
+	SnowmanRecordChainsKey                             = "snowman-record-chains"
+	SnowmanRecordDirKey                                = "snowman-record-dir"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
 	SubnetConfigDirKey                                 = "subnet-config-dir"
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 )
 
@@ -52,6 +53,9 @@ type TxFeeConfig struct {
 	AddSubnetValidatorFee uint64 `json:"addSubnetValidatorFee"`
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64 `json:"addSubnetDelegatorFee"`
+	// DynamicFeeConfig is the config for scaling the P-Chain fees with the
+	// usage of the chain after the E network upgrade.
+	DynamicFeeConfig gas.Config `json:"dynamicFeeConfig"`
 }
 
 type Params struct {
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	Accepted EventType = iota + 1
+	Rejected
+)
+
+var errInvalidEvent = errors.New("invalid event")
+
+type EventType byte
+
+func (t EventType) String() string {
+	switch t {
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is the acceptance or rejection of a container on a chain.
+type Event struct {
+	// Index is the position of this event in the subscription it was
+	// delivered to. Indices start at 0 and increase by 1 with every event.
+	Index       uint64
+	Type        EventType
+	ChainID     ids.ID
+	ContainerID ids.ID
+	Container   []byte
+}
+
+// eventBytes returns the persisted representation of an event.
+func eventBytes(eventType EventType, containerID ids.ID, container []byte) []byte {
+	b := make([]byte, 1+ids.IDLen+len(container))
+	b[0] = byte(eventType)
+	copy(b[1:], containerID[:])
+	copy(b[1+ids.IDLen:], container)
+	return b
+}
+
+func parseEvent(chainID ids.ID, index uint64, b []byte) (Event, error) {
+	if len(b) < 1+ids.IDLen {
+		return Event{}, fmt.Errorf("%w: expected at least %d bytes but got %d", errInvalidEvent, 1+ids.IDLen, len(b))
+	}
+	containerID, err := ids.ToID(b[1 : 1+ids.IDLen])
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		Index:       index,
+		Type:        EventType(b[0]),
+		ChainID:     chainID,
+		ContainerID: containerID,
+		Container:   b[1+ids.IDLen:],
+	}, nil
+}
@@ -0,0 +1,381 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package hooks allows external code to subscribe to the containers accepted
+// and rejected by the chains running on this node.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// acceptorName is the name the registry's acceptors are registered with in
+// the acceptor group.
+const acceptorName = "hooks"
+
+// maxExpiryCheckFrequency is the longest the registry waits between checking
+// for expired subscriptions.
+const maxExpiryCheckFrequency = time.Minute
+
+var (
+	_ Registry      = (*registry)(nil)
+	_ snow.Acceptor = (*chainHooks)(nil)
+	_ snow.Rejector = (*chainHooks)(nil)
+
+	subscriptionsPrefix = []byte("subscriptions")
+	eventsPrefix        = []byte("events")
+
+	errClosed              = errors.New("hook registry closed")
+	errAlreadySubscribed   = errors.New("handler already subscribed")
+	errUnknownSubscription = errors.New("unknown subscription")
+	errInvalidSubscription = errors.New("invalid subscription key")
+	errNoMaxPendingEvents  = errors.New("max pending events must be > 0")
+	errNoExpiry            = errors.New("detached subscription expiry must be > 0")
+)
+
+type Config struct {
+	// MaxPendingEvents is the maximum number of events persisted for a
+	// subscription that haven't been handled yet, whether or not a handler is
+	// attached. Once reached, the oldest of them is dropped to record the next
+	// event.
+	//
+	// Events are recorded without waiting for the handler, so a slow or
+	// detached handler never stalls the chain. Instead, the undelivered events
+	// accumulate on disk, up to this limit.
+	MaxPendingEvents uint64 `json:"maxPendingEvents"`
+	// DetachedSubscriptionExpiry is how long a subscription can go without a
+	// handler attached before it is deleted, along with its undelivered
+	// events.
+	DetachedSubscriptionExpiry time.Duration `json:"detachedSubscriptionExpiry"`
+	// RetryFrequency is how long to wait before redelivering an event that a
+	// handler failed to handle.
+	RetryFrequency time.Duration `json:"retryFrequency"`
+}
+
+// Handler is implemented by external code that wants to be notified of the
+// containers accepted and rejected by a chain.
+type Handler interface {
+	// Handle is called with the events of a chain, one at a time, in the
+	// order they occurred. If a non-nil error is returned, the same event is
+	// redelivered after [Config.RetryFrequency]. Otherwise, the event is
+	// acknowledged and won't be delivered again.
+	//
+	// Because containers are recorded before they are committed, a container
+	// may be delivered more than once if the node stops unexpectedly.
+	// Similarly, an event may be redelivered, with the same index, if the
+	// node stops before the event is acknowledged.
+	//
+	// If the handler falls more than [Config.MaxPendingEvents] events behind,
+	// the oldest events are dropped, which shows up as a gap in the indices
+	// of the delivered events.
+	//
+	// [ctx] is cancelled once the handler is unsubscribed or the registry is
+	// shut down.
+	Handle(ctx context.Context, event Event) error
+}
+
+// Registry persists the accept and reject events of every chain with a
+// subscription until the events are delivered to the subscription's handler.
+//
+// Subscriptions are persisted across restarts. Events that occur while no
+// handler is attached to a subscription, including before the subscription is
+// re-attached after a restart, are delivered once a handler is attached. A
+// subscription that stays detached for longer than
+// [Config.DetachedSubscriptionExpiry] is unsubscribed.
+type Registry interface {
+	// Subscribe attaches [handler] to the subscription named [name] on chain
+	// [chainID], creating the subscription if it doesn't exist yet.
+	Subscribe(chainID ids.ID, name string, handler Handler) error
+
+	// Unsubscribe detaches the handler, if any, from the subscription and
+	// deletes the subscription along with its undelivered events.
+	Unsubscribe(chainID ids.ID, name string) error
+
+	// Shutdown detaches every handler. Events continue to be recorded so that
+	// they can be delivered after the node restarts, and subscriptions are
+	// no longer expired.
+	Shutdown()
+}
+
+type registry struct {
+	log             logging.Logger
+	config          Config
+	acceptorGroup   snow.AcceptorGroup
+	subscriptionsDB database.Database
+	eventsDB        database.Database
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock   sync.Mutex
+	closed bool
+	chains map[ids.ID]*chainHooks
+}
+
+// NewRegistry returns a registry that records the events of the chains
+// registered with [acceptorGroup]. The subscriptions persisted in [db] start
+// recording events immediately.
+func NewRegistry(
+	log logging.Logger,
+	db database.Database,
+	acceptorGroup snow.AcceptorGroup,
+	config Config,
+) (Registry, error) {
+	switch {
+	case config.MaxPendingEvents == 0:
+		return nil, errNoMaxPendingEvents
+	case config.DetachedSubscriptionExpiry <= 0:
+		return nil, errNoExpiry
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &registry{
+		log:             log,
+		config:          config,
+		acceptorGroup:   acceptorGroup,
+		subscriptionsDB: prefixdb.New(subscriptionsPrefix, db),
+		eventsDB:        prefixdb.New(eventsPrefix, db),
+		ctx:             ctx,
+		cancel:          cancel,
+		chains:          make(map[ids.ID]*chainHooks),
+	}
+
+	it := r.subscriptionsDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) < ids.IDLen {
+			cancel()
+			return nil, fmt.Errorf("%w: %x", errInvalidSubscription, key)
+		}
+		chainID, err := ids.ToID(key[:ids.IDLen])
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if _, err := r.getSubscription(chainID, string(key[ids.IDLen:])); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go r.expireSubscriptions()
+	return r, nil
+}
+
+func (r *registry) Subscribe(chainID ids.ID, name string, handler Handler) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return errClosed
+	}
+
+	if err := r.subscriptionsDB.Put(subscriptionKey(chainID, name), nil); err != nil {
+		return err
+	}
+	s, err := r.getSubscription(chainID, name)
+	if err != nil {
+		return err
+	}
+	if err := s.attach(r.ctx, handler); err != nil {
+		return fmt.Errorf("%w: %s on chain %s", err, name, chainID)
+	}
+	return nil
+}
+
+func (r *registry) Unsubscribe(chainID ids.ID, name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.unsubscribe(chainID, name)
+}
+
+func (r *registry) Shutdown() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.cancel()
+
+	for _, hooks := range r.chains {
+		hooks.lock.RLock()
+		subscriptions := maps.Values(hooks.subscriptions)
+		hooks.lock.RUnlock()
+
+		for _, s := range subscriptions {
+			s.stop()
+		}
+	}
+}
+
+// expireSubscriptions periodically unsubscribes the subscriptions that have
+// been detached for longer than [Config.DetachedSubscriptionExpiry], until the
+// registry is shut down.
+func (r *registry) expireSubscriptions() {
+	ticker := time.NewTicker(safemath.Min(r.config.DetachedSubscriptionExpiry, maxExpiryCheckFrequency))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.expire(time.Now()); err != nil {
+			r.log.Error("failed to expire acceptor hook subscriptions",
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// expire unsubscribes the subscriptions that have been detached for longer
+// than [Config.DetachedSubscriptionExpiry] as of [now].
+func (r *registry) expire(now time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	for chainID, hooks := range r.chains {
+		hooks.lock.RLock()
+		var expired []string
+		for name, s := range hooks.subscriptions {
+			if s.expired(now) {
+				expired = append(expired, name)
+			}
+		}
+		hooks.lock.RUnlock()
+
+		for _, name := range expired {
+			r.log.Info("expiring detached acceptor hook subscription",
+				zap.Stringer("chainID", chainID),
+				zap.String("subscription", name),
+				zap.Duration("expiry", r.config.DetachedSubscriptionExpiry),
+			)
+			if err := r.unsubscribe(chainID, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unsubscribe deletes the subscription named [name] on chain [chainID].
+//
+// Assumes [r.lock] is held.
+func (r *registry) unsubscribe(chainID ids.ID, name string) error {
+	hooks, ok := r.chains[chainID]
+	if !ok {
+		return fmt.Errorf("%w: %s on chain %s", errUnknownSubscription, name, chainID)
+	}
+
+	hooks.lock.Lock()
+	s, ok := hooks.subscriptions[name]
+	delete(hooks.subscriptions, name)
+	hooks.lock.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s on chain %s", errUnknownSubscription, name, chainID)
+	}
+
+	if err := r.subscriptionsDB.Delete(subscriptionKey(chainID, name)); err != nil {
+		return err
+	}
+	return s.remove()
+}
+
+// getSubscription returns the subscription named [name] on chain [chainID],
+// loading it from the database if needed.
+//
+// Assumes [r.lock] is held.
+func (r *registry) getSubscription(chainID ids.ID, name string) (*subscription, error) {
+	hooks, ok := r.chains[chainID]
+	if !ok {
+		hooks = &chainHooks{
+			subscriptions: make(map[string]*subscription),
+		}
+		// Note: chainHooks never grabs [r.lock], so it is safe to register
+		// with the acceptor group while holding [r.lock].
+		if err := r.acceptorGroup.RegisterAcceptor(chainID, acceptorName, hooks, true); err != nil {
+			return nil, err
+		}
+		r.chains[chainID] = hooks
+	}
+
+	hooks.lock.Lock()
+	defer hooks.lock.Unlock()
+
+	if s, ok := hooks.subscriptions[name]; ok {
+		return s, nil
+	}
+
+	db := prefixdb.New(subscriptionKey(chainID, name), r.eventsDB)
+	s, err := newSubscription(r.log, r.config, chainID, name, db, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	hooks.subscriptions[name] = s
+	return s, nil
+}
+
+func subscriptionKey(chainID ids.ID, name string) []byte {
+	key := make([]byte, ids.IDLen+len(name))
+	copy(key, chainID[:])
+	copy(key[ids.IDLen:], name)
+	return key
+}
+
+// chainHooks records the events of a chain into every subscription of the
+// chain.
+type chainHooks struct {
+	lock          sync.RWMutex
+	subscriptions map[string]*subscription
+}
+
+func (c *chainHooks) Accept(_ *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	return c.record(Accepted, containerID, container)
+}
+
+func (c *chainHooks) Reject(_ *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	return c.record(Rejected, containerID, container)
+}
+
+func (c *chainHooks) record(eventType EventType, containerID ids.ID, container []byte) error {
+	c.lock.RLock()
+	subscriptions := maps.Values(c.subscriptions)
+	c.lock.RUnlock()
+
+	for _, s := range subscriptions {
+		if err := s.record(eventType, containerID, container); err != nil {
+			return fmt.Errorf("failed to record %s event for subscription %s: %w", eventType, s.name, err)
+		}
+	}
+	return nil
+}
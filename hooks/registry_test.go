@@ -0,0 +1,374 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	testConfig = Config{
+		MaxPendingEvents:           1024,
+		DetachedSubscriptionExpiry: time.Hour,
+		RetryFrequency:             time.Millisecond,
+	}
+
+	errTest = errors.New("non-nil error")
+)
+
+// testHandler delivers every event it handles to [events], failing the first
+// [failures] events it is called with.
+type testHandler struct {
+	events   chan Event
+	failures int
+}
+
+func newTestHandler() *testHandler {
+	return &testHandler{
+		events: make(chan Event),
+	}
+}
+
+func (h *testHandler) Handle(ctx context.Context, event Event) error {
+	if h.failures > 0 {
+		h.failures--
+		return errTest
+	}
+
+	select {
+	case h.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newTestRegistry(t *testing.T, db database.Database, config Config) (Registry, snow.AcceptorGroup) {
+	acceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	r, err := NewRegistry(logging.NoLog{}, db, acceptorGroup, config)
+	require.NoError(t, err)
+	return r, acceptorGroup
+}
+
+func TestRegistryDeliversEvents(t *testing.T) {
+	require := require.New(t)
+
+	r, acceptorGroup := newTestRegistry(t, memdb.New(), testConfig)
+	defer r.Shutdown()
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	handler := newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+
+	var (
+		acceptedID = ids.GenerateTestID()
+		rejectedID = ids.GenerateTestID()
+	)
+	require.NoError(acceptorGroup.Accept(ctx, acceptedID, []byte{1}))
+	require.NoError(acceptorGroup.Reject(ctx, rejectedID, []byte{2}))
+
+	require.Equal(Event{
+		Index:       0,
+		Type:        Accepted,
+		ChainID:     ctx.ChainID,
+		ContainerID: acceptedID,
+		Container:   []byte{1},
+	}, <-handler.events)
+	require.Equal(Event{
+		Index:       1,
+		Type:        Rejected,
+		ChainID:     ctx.ChainID,
+		ContainerID: rejectedID,
+		Container:   []byte{2},
+	}, <-handler.events)
+
+	// Events of other chains aren't delivered.
+	otherCtx := snow.DefaultConsensusContextTest()
+	otherCtx.ChainID = ids.GenerateTestID()
+	require.NoError(acceptorGroup.Accept(otherCtx, ids.GenerateTestID(), nil))
+	select {
+	case event := <-handler.events:
+		require.FailNow("unexpected event", event)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestRegistryPersistsEventsAcrossRestarts(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	r, acceptorGroup := newTestRegistry(t, db, testConfig)
+	handler := newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+
+	containerIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	require.NoError(acceptorGroup.Accept(ctx, containerIDs[0], nil))
+	require.Equal(containerIDs[0], (<-handler.events).ContainerID)
+
+	// Events recorded after the handler is detached are delivered after the
+	// restart.
+	r.Shutdown()
+	require.NoError(acceptorGroup.Accept(ctx, containerIDs[1], nil))
+
+	// The persisted subscription records events before a handler is attached.
+	r, acceptorGroup = newTestRegistry(t, db, testConfig)
+	defer r.Shutdown()
+	require.NoError(acceptorGroup.Accept(ctx, containerIDs[2], nil))
+
+	handler = newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+	for i, containerID := range containerIDs[1:] {
+		event := <-handler.events
+		require.Equal(uint64(i+1), event.Index)
+		require.Equal(containerID, event.ContainerID)
+	}
+}
+
+func TestRegistryDoesNotDropEvents(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	r, acceptorGroup := newTestRegistry(t, db, testConfig)
+	handler := newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+
+	// Recording doesn't wait for the attached handler, which never reads the
+	// events it is delivered. Events are only dropped once more than
+	// [MaxPendingEvents] are pending.
+	var containerIDs []ids.ID
+	for i := 0; i < 64; i++ {
+		containerID := ids.GenerateTestID()
+		containerIDs = append(containerIDs, containerID)
+		require.NoError(acceptorGroup.Accept(ctx, containerID, nil))
+	}
+	r.Shutdown()
+
+	// Events recorded while no handler is attached are kept as well.
+	for i := 0; i < 64; i++ {
+		containerID := ids.GenerateTestID()
+		containerIDs = append(containerIDs, containerID)
+		require.NoError(acceptorGroup.Accept(ctx, containerID, nil))
+	}
+
+	r, _ = newTestRegistry(t, db, testConfig)
+	defer r.Shutdown()
+
+	// Every event is delivered after the restart, without any gaps.
+	handler = newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+	for i, containerID := range containerIDs {
+		event := <-handler.events
+		require.Equal(uint64(i), event.Index)
+		require.Equal(containerID, event.ContainerID)
+	}
+}
+
+func TestRegistryDropsOldestEvents(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	config := testConfig
+	config.MaxPendingEvents = 2
+	r, acceptorGroup := newTestRegistry(t, db, config)
+	handler := newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+
+	// Recording doesn't wait for the attached handler, which never reads the
+	// events it is delivered.
+	for i := 0; i < 5; i++ {
+		require.NoError(acceptorGroup.Accept(ctx, ids.GenerateTestID(), nil))
+	}
+	r.Shutdown()
+
+	// Without a handler attached, only the most recent events are kept.
+	containerIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	for _, containerID := range containerIDs {
+		require.NoError(acceptorGroup.Accept(ctx, containerID, nil))
+	}
+
+	r, _ = newTestRegistry(t, db, config)
+	defer r.Shutdown()
+
+	handler = newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+	for i, containerID := range containerIDs {
+		event := <-handler.events
+		require.Equal(uint64(i+5), event.Index)
+		require.Equal(containerID, event.ContainerID)
+	}
+}
+
+func TestRegistryRetriesFailedEvents(t *testing.T) {
+	require := require.New(t)
+
+	r, acceptorGroup := newTestRegistry(t, memdb.New(), testConfig)
+	defer r.Shutdown()
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	handler := newTestHandler()
+	handler.failures = 2
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+
+	containerID := ids.GenerateTestID()
+	require.NoError(acceptorGroup.Accept(ctx, containerID, nil))
+
+	event := <-handler.events
+	require.Zero(event.Index)
+	require.Equal(containerID, event.ContainerID)
+	require.Zero(handler.failures)
+}
+
+func TestRegistryUnsubscribe(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	r, acceptorGroup := newTestRegistry(t, db, testConfig)
+	defer r.Shutdown()
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	err := r.Unsubscribe(ctx.ChainID, "test")
+	require.ErrorIs(err, errUnknownSubscription)
+
+	handler := newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+
+	err = r.Subscribe(ctx.ChainID, "test", newTestHandler())
+	require.ErrorIs(err, errAlreadySubscribed)
+
+	require.NoError(acceptorGroup.Accept(ctx, ids.GenerateTestID(), nil))
+	require.NoError(r.Unsubscribe(ctx.ChainID, "test"))
+
+	// Unsubscribing removes the subscription and its undelivered events.
+	isEmpty, err := database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	// Events aren't recorded after unsubscribing.
+	require.NoError(acceptorGroup.Accept(ctx, ids.GenerateTestID(), nil))
+	isEmpty, err = database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	err = r.Unsubscribe(ctx.ChainID, "test")
+	require.ErrorIs(err, errUnknownSubscription)
+
+	// Subscribing again starts a new subscription.
+	handler = newTestHandler()
+	require.NoError(r.Subscribe(ctx.ChainID, "test", handler))
+	require.NoError(acceptorGroup.Accept(ctx, ids.GenerateTestID(), nil))
+	require.Zero((<-handler.events).Index)
+}
+
+func TestRegistryExpiresDetachedSubscriptions(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+
+	r, acceptorGroup := newTestRegistry(t, db, testConfig)
+	require.NoError(r.Subscribe(ctx.ChainID, "test", newTestHandler()))
+	require.NoError(acceptorGroup.Accept(ctx, ids.GenerateTestID(), nil))
+
+	// Subscriptions with a handler attached never expire.
+	require.NoError(r.(*registry).expire(time.Now().Add(2 * testConfig.DetachedSubscriptionExpiry)))
+	isEmpty, err := database.IsEmpty(db)
+	require.NoError(err)
+	require.False(isEmpty)
+
+	detachedAt := time.Now()
+	r.Shutdown()
+
+	// The subscription isn't re-attached after the restart, so it expires once
+	// it has been detached for longer than the expiry, counted from before the
+	// restart.
+	r, _ = newTestRegistry(t, db, testConfig)
+	defer r.Shutdown()
+
+	require.NoError(r.(*registry).expire(detachedAt.Add(testConfig.DetachedSubscriptionExpiry / 2)))
+	isEmpty, err = database.IsEmpty(db)
+	require.NoError(err)
+	require.False(isEmpty)
+
+	require.NoError(r.(*registry).expire(detachedAt.Add(2 * testConfig.DetachedSubscriptionExpiry)))
+	isEmpty, err = database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	err = r.Unsubscribe(ctx.ChainID, "test")
+	require.ErrorIs(err, errUnknownSubscription)
+}
+
+func TestNewRegistryInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "unbounded pending events",
+			config: Config{
+				DetachedSubscriptionExpiry: time.Hour,
+			},
+			expectedErr: errNoMaxPendingEvents,
+		},
+		{
+			name: "no expiry",
+			config: Config{
+				MaxPendingEvents: 1,
+			},
+			expectedErr: errNoExpiry,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			acceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+			_, err := NewRegistry(logging.NoLog{}, memdb.New(), acceptorGroup, test.config)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRegistryShutdown(t *testing.T) {
+	require := require.New(t)
+
+	r, _ := newTestRegistry(t, memdb.New(), testConfig)
+	r.Shutdown()
+
+	err := r.Subscribe(ids.GenerateTestID(), "test", newTestHandler())
+	require.ErrorIs(err, errClosed)
+}
@@ -0,0 +1,340 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const eventPrefix byte = 0x00
+
+var (
+	nextKey     = []byte{0x01}
+	cursorKey   = []byte{0x02}
+	detachedKey = []byte{0x03}
+)
+
+// subscription persists the events of a chain until they are delivered to the
+// subscription's handler.
+//
+// The events in [cursor, next) are persisted and haven't been delivered yet.
+// Events are recorded while the acceptor group's lock is held, so recording
+// never waits for the handler. Instead, undelivered events are persisted until
+// they are delivered, and the oldest undelivered events are dropped once more
+// than [Config.MaxPendingEvents] events are persisted.
+//
+// The time the handler was detached is persisted so that the registry can
+// expire subscriptions that stay detached, across restarts.
+type subscription struct {
+	log     logging.Logger
+	config  Config
+	chainID ids.ID
+	name    string
+	db      database.Database
+
+	lock sync.Mutex
+	// cond is signalled whenever [next] or [delivering] change.
+	cond *sync.Cond
+	// next is the index of the next event to be recorded.
+	next uint64
+	// cursor is the index of the next event to be delivered.
+	cursor uint64
+	// delivering is true while a handler is attached to the subscription.
+	delivering bool
+	// detachedAt is when the last handler was detached, or the zero time if a
+	// handler is attached.
+	detachedAt time.Time
+	// removed is true once the subscription has been unsubscribed.
+	removed bool
+	// cancel and done stop and wait for the goroutine delivering events.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSubscription(
+	log logging.Logger,
+	config Config,
+	chainID ids.ID,
+	name string,
+	db database.Database,
+	now time.Time,
+) (*subscription, error) {
+	next, err := getIndex(db, nextKey)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := getIndex(db, cursorKey)
+	if err != nil {
+		return nil, err
+	}
+	// If the node stopped while a handler was attached, the subscription is
+	// considered detached from when it is loaded.
+	detachedAt, err := database.GetTimestamp(db, detachedKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		detachedAt = now
+	case err != nil:
+		return nil, err
+	}
+
+	s := &subscription{
+		log:        log,
+		config:     config,
+		chainID:    chainID,
+		name:       name,
+		db:         db,
+		next:       next,
+		cursor:     cursor,
+		detachedAt: detachedAt,
+	}
+	s.cond = sync.NewCond(&s.lock)
+	return s, nil
+}
+
+// record persists an event without waiting for it to be delivered. If
+// [MaxPendingEvents] events are already persisted, the oldest of them is
+// dropped.
+func (s *subscription) record(eventType EventType, containerID ids.ID, container []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.removed {
+		return nil
+	}
+
+	batch := s.db.NewBatch()
+	if err := batch.Put(eventKey(s.next), eventBytes(eventType, containerID, container)); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(batch, nextKey, s.next+1); err != nil {
+		return err
+	}
+	cursor := s.cursor
+	if s.next-cursor >= s.config.MaxPendingEvents {
+		if err := batch.Delete(eventKey(cursor)); err != nil {
+			return err
+		}
+		cursor++
+		if err := database.PutUInt64(batch, cursorKey, cursor); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	if cursor != s.cursor {
+		s.log.Warn("dropping undelivered event",
+			zap.Stringer("chainID", s.chainID),
+			zap.String("subscription", s.name),
+			zap.Uint64("index", s.cursor),
+			zap.Uint64("maxPendingEvents", s.config.MaxPendingEvents),
+		)
+		s.cursor = cursor
+	}
+	s.next++
+	s.cond.Broadcast()
+	return nil
+}
+
+// attach starts delivering the persisted events to [handler].
+func (s *subscription) attach(ctx context.Context, handler Handler) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.delivering {
+		return errAlreadySubscribed
+	}
+
+	if err := s.db.Delete(detachedKey); err != nil {
+		return err
+	}
+	if s.cancel != nil {
+		// The previous handler was detached after failing.
+		s.cancel()
+	}
+	s.delivering = true
+	s.detachedAt = time.Time{}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go s.deliver(ctx, handler, s.done)
+	return nil
+}
+
+// stop stops delivering events and waits for any in-progress delivery to
+// return. Events continue to be persisted after stop returns.
+func (s *subscription) stop() {
+	s.lock.Lock()
+	s.detach()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.lock.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// remove stops delivering events and deletes every persisted event.
+func (s *subscription) remove() error {
+	s.stop()
+
+	s.lock.Lock()
+	s.removed = true
+	s.lock.Unlock()
+
+	return database.AtomicClear(s.db, s.db)
+}
+
+// expired returns true if no handler has been attached to the subscription for
+// longer than [Config.DetachedSubscriptionExpiry] as of [now].
+func (s *subscription) expired(now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return !s.delivering && !s.removed && now.Sub(s.detachedAt) > s.config.DetachedSubscriptionExpiry
+}
+
+// detach stops delivering events and records when the handler was detached.
+//
+// Assumes [s.lock] is held.
+func (s *subscription) detach() {
+	if s.delivering {
+		s.delivering = false
+		s.detachedAt = time.Now()
+		if !s.removed {
+			if err := database.PutTimestamp(s.db, detachedKey, s.detachedAt); err != nil {
+				s.log.Warn("failed to persist when the handler was detached",
+					zap.Stringer("chainID", s.chainID),
+					zap.String("subscription", s.name),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+	s.cond.Broadcast()
+}
+
+func (s *subscription) deliver(ctx context.Context, handler Handler, done chan struct{}) {
+	defer close(done)
+
+	for {
+		s.lock.Lock()
+		for s.delivering && s.cursor == s.next {
+			s.cond.Wait()
+		}
+		if !s.delivering {
+			s.lock.Unlock()
+			return
+		}
+		// The event is read while holding the lock so that it can't be
+		// dropped concurrently.
+		index := s.cursor
+		event, err := s.getEvent(index)
+		s.lock.Unlock()
+		if err != nil {
+			s.fail("failed to read event", index, err)
+			return
+		}
+
+		if err := handler.Handle(ctx, event); err != nil {
+			s.log.Warn("failed to handle event",
+				zap.Stringer("chainID", s.chainID),
+				zap.String("subscription", s.name),
+				zap.Uint64("index", index),
+				zap.Duration("retryFrequency", s.config.RetryFrequency),
+				zap.Error(err),
+			)
+
+			timer := time.NewTimer(s.config.RetryFrequency)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			continue
+		}
+
+		if err := s.ack(index); err != nil {
+			s.fail("failed to advance cursor", index, err)
+			return
+		}
+	}
+}
+
+func (s *subscription) getEvent(index uint64) (Event, error) {
+	b, err := s.db.Get(eventKey(index))
+	if err != nil {
+		return Event{}, err
+	}
+	return parseEvent(s.chainID, index, b)
+}
+
+// ack deletes the event at [index] and advances the cursor past it. If the
+// event was dropped while it was being handled, the cursor has already been
+// advanced past it.
+func (s *subscription) ack(index uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if index != s.cursor {
+		return nil
+	}
+
+	batch := s.db.NewBatch()
+	if err := batch.Delete(eventKey(index)); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(batch, cursorKey, index+1); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	s.cursor = index + 1
+	return nil
+}
+
+// fail detaches the handler after an unrecoverable error. Events continue to
+// be recorded and are delivered once a handler is attached again.
+func (s *subscription) fail(msg string, index uint64, err error) {
+	s.log.Error(msg,
+		zap.Stringer("chainID", s.chainID),
+		zap.String("subscription", s.name),
+		zap.Uint64("index", index),
+		zap.Error(err),
+	)
+
+	s.lock.Lock()
+	s.detach()
+	s.lock.Unlock()
+}
+
+func eventKey(index uint64) []byte {
+	key := make([]byte, 1+wrappers.LongLen)
+	key[0] = eventPrefix
+	copy(key[1:], database.PackUInt64(index))
+	return key
+}
+
+func getIndex(db database.KeyValueReader, key []byte) (uint64, error) {
+	index, err := database.GetUInt64(db, key)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	return index, err
+}
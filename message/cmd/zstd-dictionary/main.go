@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// zstd-dictionary trains a zstd dictionary that can be provided to a node with
+// --network-compression-zstd-dictionary-file.
+//
+// The dictionary is trained on Put and Ancestors messages built from the
+// containers most recently accepted by a chain, as served by the index API of
+// a node running with --index-enabled. Every peer must be configured with the
+// same dictionary for it to be used, so the resulting file should be
+// distributed alongside the node configuration rather than regenerated per
+// node.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/indexer"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	chainIDKey       = "chain-id"
+	numContainersKey = "num-containers"
+	ancestorsSizeKey = "ancestors-size"
+	maxSizeKey       = "max-size"
+	outputKey        = "output"
+
+	defaultNumContainers = 10_000
+	defaultAncestorsSize = 32
+	// Matches the default dictionary size of the zstd CLI.
+	defaultMaxSize = 110 * units.KiB
+)
+
+var errNoContainers = errors.New("no containers accepted")
+
+func main() {
+	cmd := &cobra.Command{
+		Use:   "zstd-dictionary [index uri]",
+		Short: "Trains a zstd dictionary on Put and Ancestors messages built from the containers served by an index",
+		Long:  "Trains a zstd dictionary on Put and Ancestors messages built from the containers served by an index, e.g. http://127.0.0.1:9650/ext/index/P/block",
+		Args:  cobra.ExactArgs(1),
+		RunE:  trainFunc,
+	}
+	cmd.Flags().String(chainIDKey, ids.Empty.String(), "ID of the chain the containers belong to")
+	cmd.Flags().Uint64(numContainersKey, defaultNumContainers, "Number of recently accepted containers to sample")
+	cmd.Flags().Int(ancestorsSizeKey, defaultAncestorsSize, "Number of containers in each sampled Ancestors message")
+	cmd.Flags().Int(maxSizeKey, defaultMaxSize, "Maximum size, in bytes, of the dictionary")
+	cmd.Flags().String(outputKey, "zstd.dict", "Path to write the dictionary to")
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func trainFunc(c *cobra.Command, args []string) error {
+	flags := c.Flags()
+	chainIDStr, err := flags.GetString(chainIDKey)
+	if err != nil {
+		return err
+	}
+	chainID, err := ids.FromString(chainIDStr)
+	if err != nil {
+		return err
+	}
+	numContainers, err := flags.GetUint64(numContainersKey)
+	if err != nil {
+		return err
+	}
+	ancestorsSize, err := flags.GetInt(ancestorsSizeKey)
+	if err != nil {
+		return err
+	}
+	maxSize, err := flags.GetInt(maxSizeKey)
+	if err != nil {
+		return err
+	}
+	output, err := flags.GetString(outputKey)
+	if err != nil {
+		return err
+	}
+
+	client := indexer.NewClient(args[0])
+	_, lastIndex, err := client.GetLastAccepted(c.Context())
+	if err != nil {
+		return fmt.Errorf("couldn't fetch last accepted container: %w", err)
+	}
+
+	// Index i holds the container accepted at index [startIndex + i].
+	var (
+		startIndex = lastIndex + 1 - safemath.Min(numContainers, lastIndex+1)
+		containers = make([][]byte, 0, lastIndex+1-startIndex)
+	)
+	for index := startIndex; index <= lastIndex; {
+		numToFetch := safemath.Min(lastIndex+1-index, indexer.MaxFetchedByRange)
+		fetched, err := client.GetContainerRange(c.Context(), index, int(numToFetch))
+		if err != nil {
+			return fmt.Errorf("couldn't fetch containers starting at %d: %w", index, err)
+		}
+		if len(fetched) == 0 {
+			break
+		}
+		for _, container := range fetched {
+			containers = append(containers, container.Bytes)
+		}
+		index += uint64(len(fetched))
+	}
+	if len(containers) == 0 {
+		return errNoContainers
+	}
+
+	samples, err := buildSamples(chainID, containers, ancestorsSize)
+	if err != nil {
+		return err
+	}
+	dictionary, err := compression.TrainZstdDictionary(samples, maxSize)
+	if err != nil {
+		return err
+	}
+	dictionaryID, err := compression.ZstdDictionaryID(dictionary)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, dictionary, 0o600); err != nil {
+		return err
+	}
+
+	fmt.Printf("sampled containers: %d\n", len(containers))
+	fmt.Printf("sampled messages: %d\n", len(samples))
+	fmt.Printf("wrote dictionary %d (%d bytes) to %s\n", dictionaryID, len(dictionary), output)
+	return nil
+}
+
+// buildSamples returns the uncompressed bytes of a Put message for every
+// container and of Ancestors messages covering [containers] in groups of
+// [ancestorsSize], ordered from the most recently accepted container like a
+// real Ancestors response.
+func buildSamples(chainID ids.ID, containers [][]byte, ancestorsSize int) ([][]byte, error) {
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		10*time.Second,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([][]byte, 0, len(containers)+len(containers)/safemath.Max(ancestorsSize, 1)+1)
+	for _, container := range containers {
+		msg, err := mc.Put(chainID, 0, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, msg.Bytes())
+	}
+
+	if ancestorsSize <= 0 {
+		return samples, nil
+	}
+	for end := len(containers); end > 0; end -= ancestorsSize {
+		start := safemath.Max(end-ancestorsSize, 0)
+		ancestors := make([][]byte, 0, end-start)
+		for i := end - 1; i >= start; i-- {
+			ancestors = append(ancestors, containers[i])
+		}
+		msg, err := mc.Ancestors(chainID, 0, ancestors)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, msg.Bytes())
+	}
+	return samples, nil
+}
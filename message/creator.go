@@ -4,6 +4,7 @@
 package message
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,16 +14,38 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
-var _ Creator = (*creator)(nil)
+var (
+	_ Creator = (*creator)(nil)
+
+	errInvalidZstdDictionaryUsage = errors.New("zstd dictionary can only be used with zstd compression")
+)
 
 type Creator interface {
 	OutboundMsgBuilder
 	InboundMsgBuilder
+
+	// Compress returns [msg] compressed with [compressionType]. If
+	// [useZstdDictionary] is true, [compressionType] must be zstd and the
+	// message is compressed with the configured zstd dictionary.
+	//
+	// If [msg] doesn't support compression, or is already compressed as
+	// requested, [msg] is returned.
+	Compress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+		useZstdDictionary bool,
+	) (OutboundMessage, error)
+
+	// ZstdDictionaryID returns the ID of the configured zstd dictionary, or 0
+	// if no dictionary was configured.
+	ZstdDictionaryID() uint32
 }
 
 type creator struct {
 	OutboundMsgBuilder
 	InboundMsgBuilder
+
+	builder *msgBuilder
 }
 
 func NewCreator(
@@ -31,6 +54,7 @@ func NewCreator(
 	parentNamespace string,
 	compressionType compression.Type,
 	maxMessageTimeout time.Duration,
+	zstdDictionary []byte,
 ) (Creator, error) {
 	namespace := fmt.Sprintf("%s_codec", parentNamespace)
 	builder, err := newMsgBuilder(
@@ -38,6 +62,7 @@ func NewCreator(
 		namespace,
 		metrics,
 		maxMessageTimeout,
+		zstdDictionary,
 	)
 	if err != nil {
 		return nil, err
@@ -46,5 +71,28 @@ func NewCreator(
 	return &creator{
 		OutboundMsgBuilder: newOutboundBuilder(compressionType, builder),
 		InboundMsgBuilder:  newInboundBuilder(builder),
+		builder:            builder,
 	}, nil
 }
+
+func (c *creator) Compress(
+	msg OutboundMessage,
+	compressionType compression.Type,
+	useZstdDictionary bool,
+) (OutboundMessage, error) {
+	outboundMsg, ok := msg.(*outboundMessage)
+	if !ok {
+		return msg, nil
+	}
+	if useZstdDictionary && compressionType != compression.TypeZstd {
+		return nil, errInvalidZstdDictionaryUsage
+	}
+	return c.builder.recompress(outboundMsg, codec{
+		compressionType:   compressionType,
+		useZstdDictionary: useZstdDictionary,
+	})
+}
+
+func (c *creator) ZstdDictionaryID() uint32 {
+	return c.builder.zstdDictionaryID
+}
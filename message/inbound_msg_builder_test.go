@@ -26,6 +26,7 @@ func Test_newMsgBuilder(t *testing.T) {
 		"test",
 		prometheus.NewRegistry(),
 		10*time.Second,
+		nil,
 	)
 	require.NoError(err)
 	require.NotNil(mb)
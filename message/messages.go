@@ -6,6 +6,7 @@ package message
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -112,6 +113,16 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	codec                 codec
+
+	// uncompressedBytes is only set if the message supports compression, so
+	// that it can be compressed differently for each peer.
+	uncompressedBytes []byte
+
+	// lock protects [recompressed], which caches the message compressed with
+	// other codecs.
+	lock         sync.Mutex
+	recompressed map[codec]*outboundMessage
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -130,17 +141,32 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
+// codec describes how the bytes of a message are compressed.
+type codec struct {
+	compressionType compression.Type
+	// useZstdDictionary is only meaningful if [compressionType] is zstd.
+	useZstdDictionary bool
+}
+
+func (c codec) String() string {
+	if c.compressionType == compression.TypeZstd && c.useZstdDictionary {
+		return "zstd_dictionary"
+	}
+	return c.compressionType.String()
+}
+
+type codecCompressor struct {
+	compressor            compression.Compressor
+	compressTimeMetrics   map[Op]metric.Averager
+	decompressTimeMetrics map[Op]metric.Averager
+}
+
 type msgBuilder struct {
 	log logging.Logger
 
-	gzipCompressor            compression.Compressor
-	gzipCompressTimeMetrics   map[Op]metric.Averager
-	gzipDecompressTimeMetrics map[Op]metric.Averager
-
-	zstdCompressor            compression.Compressor
-	zstdCompressTimeMetrics   map[Op]metric.Averager
-	zstdDecompressTimeMetrics map[Op]metric.Averager
+	compressors map[codec]*codecCompressor
+	// zstdDictionaryID is 0 if no zstd dictionary was provided.
+	zstdDictionaryID uint32
 
 	maxMessageTimeout time.Duration
 }
@@ -150,6 +176,7 @@ func newMsgBuilder(
 	namespace string,
 	metrics prometheus.Registerer,
 	maxMessageTimeout time.Duration,
+	zstdDictionary []byte,
 ) (*msgBuilder, error) {
 	gzipCompressor, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
 	if err != nil {
@@ -159,67 +186,108 @@ func newMsgBuilder(
 	if err != nil {
 		return nil, err
 	}
+	lz4Compressor, err := compression.NewLZ4Compressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	snappyCompressor, err := compression.NewSnappyCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	compressors := map[codec]compression.Compressor{
+		{compressionType: compression.TypeGzip}:   gzipCompressor,
+		{compressionType: compression.TypeZstd}:   zstdCompressor,
+		{compressionType: compression.TypeLZ4}:    lz4Compressor,
+		{compressionType: compression.TypeSnappy}: snappyCompressor,
+	}
 
 	mb := &msgBuilder{
-		log: log,
-
-		gzipCompressor:            gzipCompressor,
-		gzipCompressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		gzipDecompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
-
-		zstdCompressor:            zstdCompressor,
-		zstdCompressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		zstdDecompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
-
+		log:               log,
+		compressors:       make(map[codec]*codecCompressor, len(compressors)+1),
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
+	if len(zstdDictionary) > 0 {
+		mb.zstdDictionaryID, err = compression.ZstdDictionaryID(zstdDictionary)
+		if err != nil {
+			return nil, err
+		}
+		zstdDictionaryCompressor, err := compression.NewZstdDictionaryCompressor(constants.DefaultMaxMessageSize, zstdDictionary)
+		if err != nil {
+			return nil, err
+		}
+		compressors[codec{
+			compressionType:   compression.TypeZstd,
+			useZstdDictionary: true,
+		}] = zstdDictionaryCompressor
+	}
+
 	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		mb.gzipCompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("gzip_%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages with gzip", op),
-			metrics,
-			&errs,
-		)
-		mb.gzipDecompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("gzip_%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages with gzip", op),
-			metrics,
-			&errs,
-		)
-		mb.zstdCompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("zstd_%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages with zstd", op),
-			metrics,
-			&errs,
-		)
-		mb.zstdDecompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("zstd_%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages with zstd", op),
-			metrics,
-			&errs,
-		)
+	for c, compressor := range compressors {
+		cc := &codecCompressor{
+			compressor:            compressor,
+			compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
+			decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		}
+		for _, op := range ExternalOps {
+			cc.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_compress_time", c, op),
+				fmt.Sprintf("time (in ns) to compress %s messages with %s", op, c),
+				metrics,
+				&errs,
+			)
+			cc.decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_decompress_time", c, op),
+				fmt.Sprintf("time (in ns) to decompress %s messages with %s", op, c),
+				metrics,
+				&errs,
+			)
+		}
+		mb.compressors[c] = cc
 	}
 	return mb, errs.Err
 }
 
+// marshal returns the bytes of [uncompressedMsg] compressed with
+// [compressionType], along with the bytes of the uncompressed message.
 func (mb *msgBuilder) marshal(
 	uncompressedMsg *p2p.Message,
 	compressionType compression.Type,
-) ([]byte, int, Op, error) {
+) ([]byte, []byte, int, Op, error) {
 	uncompressedMsgBytes, err := proto.Marshal(uncompressedMsg)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, nil, 0, 0, err
 	}
 
 	op, err := ToOp(uncompressedMsg)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, nil, 0, 0, err
+	}
+
+	compressedMsgBytes, bytesSaved, err := mb.compress(
+		uncompressedMsgBytes,
+		op,
+		codec{compressionType: compressionType},
+	)
+	return compressedMsgBytes, uncompressedMsgBytes, bytesSaved, op, err
+}
+
+// compress returns [uncompressedMsgBytes], which is the marshalled message with
+// the provided [op], compressed with [c].
+func (mb *msgBuilder) compress(
+	uncompressedMsgBytes []byte,
+	op Op,
+	c codec,
+) ([]byte, int, error) {
+	if c.compressionType == compression.TypeNone {
+		return uncompressedMsgBytes, 0, nil
+	}
+
+	compressor, ok := mb.compressors[c]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s", errUnknownCompressionType, c)
 	}
 
 	// If compression is enabled, we marshal twice:
@@ -228,58 +296,54 @@ func (mb *msgBuilder) marshal(
 	//
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
-	var (
-		startTime               = time.Now()
-		compressedMsg           p2p.Message
-		opToCompressTimeMetrics map[Op]metric.Averager
-	)
-	switch compressionType {
-	case compression.TypeNone:
-		return uncompressedMsgBytes, 0, op, nil
-	case compression.TypeGzip:
-		compressedBytes, err := mb.gzipCompressor.Compress(uncompressedMsgBytes)
-		if err != nil {
-			return nil, 0, 0, err
+	startTime := time.Now()
+	compressedBytes, err := compressor.compressor.Compress(uncompressedMsgBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var compressedMsg p2p.Message
+	switch {
+	case c.compressionType == compression.TypeGzip:
+		compressedMsg.Message = &p2p.Message_CompressedGzip{
+			CompressedGzip: compressedBytes,
 		}
-		compressedMsg = p2p.Message{
-			Message: &p2p.Message_CompressedGzip{
-				CompressedGzip: compressedBytes,
-			},
+	case c.compressionType == compression.TypeZstd && c.useZstdDictionary:
+		compressedMsg.Message = &p2p.Message_CompressedZstdDictionary{
+			CompressedZstdDictionary: compressedBytes,
 		}
-		opToCompressTimeMetrics = mb.gzipCompressTimeMetrics
-	case compression.TypeZstd:
-		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
-		if err != nil {
-			return nil, 0, 0, err
+	case c.compressionType == compression.TypeZstd:
+		compressedMsg.Message = &p2p.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
 		}
-		compressedMsg = p2p.Message{
-			Message: &p2p.Message_CompressedZstd{
-				CompressedZstd: compressedBytes,
-			},
+	case c.compressionType == compression.TypeLZ4:
+		compressedMsg.Message = &p2p.Message_CompressedLz4{
+			CompressedLz4: compressedBytes,
+		}
+	case c.compressionType == compression.TypeSnappy:
+		compressedMsg.Message = &p2p.Message_CompressedSnappy{
+			CompressedSnappy: compressedBytes,
 		}
-		opToCompressTimeMetrics = mb.zstdCompressTimeMetrics
-	default:
-		return nil, 0, 0, errUnknownCompressionType
 	}
 
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 	compressTook := time.Since(startTime)
 
-	if compressTimeMetric, ok := opToCompressTimeMetrics[op]; ok {
+	if compressTimeMetric, ok := compressor.compressTimeMetrics[op]; ok {
 		compressTimeMetric.Observe(float64(compressTook))
 	} else {
 		// Should never happen
 		mb.log.Warn("no compression metric found for op",
 			zap.Stringer("op", op),
-			zap.Stringer("compressionType", compressionType),
+			zap.Stringer("compressionType", c),
 		)
 	}
 
 	bytesSaved := len(uncompressedMsgBytes) - len(compressedMsgBytes)
-	return compressedMsgBytes, bytesSaved, op, nil
+	return compressedMsgBytes, bytesSaved, nil
 }
 
 func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
@@ -290,30 +354,40 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 
 	// Figure out what compression type, if any, was used to compress the message.
 	var (
-		opToDecompressTimeMetrics map[Op]metric.Averager
-		compressor                compression.Compressor
-		compressedBytes           []byte
-		gzipCompressed            = m.GetCompressedGzip()
-		zstdCompressed            = m.GetCompressedZstd()
+		c               codec
+		compressedBytes []byte
 	)
-	switch {
-	case len(gzipCompressed) > 0:
-		opToDecompressTimeMetrics = mb.gzipDecompressTimeMetrics
-		compressor = mb.gzipCompressor
-		compressedBytes = gzipCompressed
-	case len(zstdCompressed) > 0:
-		opToDecompressTimeMetrics = mb.zstdDecompressTimeMetrics
-		compressor = mb.zstdCompressor
-		compressedBytes = zstdCompressed
+	switch compressedMsg := m.GetMessage().(type) {
+	case *p2p.Message_CompressedGzip:
+		c.compressionType = compression.TypeGzip
+		compressedBytes = compressedMsg.CompressedGzip
+	case *p2p.Message_CompressedZstd:
+		c.compressionType = compression.TypeZstd
+		compressedBytes = compressedMsg.CompressedZstd
+	case *p2p.Message_CompressedZstdDictionary:
+		c.compressionType = compression.TypeZstd
+		c.useZstdDictionary = true
+		compressedBytes = compressedMsg.CompressedZstdDictionary
+	case *p2p.Message_CompressedLz4:
+		c.compressionType = compression.TypeLZ4
+		compressedBytes = compressedMsg.CompressedLz4
+	case *p2p.Message_CompressedSnappy:
+		c.compressionType = compression.TypeSnappy
+		compressedBytes = compressedMsg.CompressedSnappy
 	default:
 		// The message wasn't compressed
 		op, err := ToOp(m)
 		return m, 0, op, err
 	}
 
+	compressor, ok := mb.compressors[c]
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("%w: %s", errUnknownCompressionType, c)
+	}
+
 	startTime := time.Now()
 
-	decompressed, err := compressor.Decompress(compressedBytes)
+	decompressed, err := compressor.compressor.Decompress(compressedBytes)
 	if err != nil {
 		return nil, 0, 0, err
 	}
@@ -329,7 +403,7 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	if err != nil {
 		return nil, 0, 0, err
 	}
-	if decompressTimeMetric, ok := opToDecompressTimeMetrics[op]; ok {
+	if decompressTimeMetric, ok := compressor.decompressTimeMetrics[op]; ok {
 		decompressTimeMetric.Observe(float64(decompressTook))
 	} else {
 		// Should never happen
@@ -342,17 +416,59 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 }
 
 func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
-	b, saved, op, err := mb.marshal(m, compressionType)
+	b, uncompressedBytes, saved, op, err := mb.marshal(m, compressionType)
 	if err != nil {
 		return nil, err
 	}
 
-	return &outboundMessage{
+	msg := &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
-	}, nil
+		codec: codec{
+			compressionType: compressionType,
+		},
+	}
+	// Messages that aren't compressed don't support compression, so they
+	// should never be re-compressed.
+	if compressionType != compression.TypeNone {
+		msg.uncompressedBytes = uncompressedBytes
+	}
+	return msg, nil
+}
+
+// recompress returns [msg] compressed with [c]. If [msg] doesn't support
+// compression, or is already compressed with [c], [msg] is returned.
+func (mb *msgBuilder) recompress(msg *outboundMessage, c codec) (*outboundMessage, error) {
+	if msg.uncompressedBytes == nil || msg.codec == c {
+		return msg, nil
+	}
+
+	msg.lock.Lock()
+	defer msg.lock.Unlock()
+
+	if compressedMsg, ok := msg.recompressed[c]; ok {
+		return compressedMsg, nil
+	}
+
+	b, saved, err := mb.compress(msg.uncompressedBytes, msg.op, c)
+	if err != nil {
+		return nil, err
+	}
+
+	compressedMsg := &outboundMessage{
+		bypassThrottling:      msg.bypassThrottling,
+		op:                    msg.op,
+		bytes:                 b,
+		bytesSavedCompression: saved,
+		codec:                 c,
+	}
+	if msg.recompressed == nil {
+		msg.recompressed = make(map[codec]*outboundMessage)
+	}
+	msg.recompressed[c] = compressedMsg
+	return compressedMsg, nil
 }
 
 func (mb *msgBuilder) parseInbound(
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestMessage(t *testing.T) {
@@ -30,6 +31,7 @@ func TestMessage(t *testing.T) {
 		"test",
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -592,6 +594,40 @@ func TestMessage(t *testing.T) {
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
+		{
+			desc: "put message with lz4 compression",
+			op:   PutOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_Put{
+					Put: &p2p.Put{
+						ChainId:    testID[:],
+						RequestId:  1,
+						Container:  compressibleContainers[0],
+						EngineType: p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+					},
+				},
+			},
+			compressionType:  compression.TypeLZ4,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "put message with snappy compression",
+			op:   PutOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_Put{
+					Put: &p2p.Put{
+						ChainId:    testID[:],
+						RequestId:  1,
+						Container:  compressibleContainers[0],
+						EngineType: p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+					},
+				},
+			},
+			compressionType:  compression.TypeSnappy,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
 		{
 			desc: "push_query message with no compression",
 			op:   PushQueryOp,
@@ -857,6 +893,7 @@ func TestInboundMessageToString(t *testing.T) {
 		"test",
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -890,6 +927,7 @@ func TestEmptyInboundMessage(t *testing.T) {
 		"test",
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -911,6 +949,7 @@ func TestNilInboundMessage(t *testing.T) {
 		"test",
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -929,3 +968,139 @@ func TestNilInboundMessage(t *testing.T) {
 	pingMsg := parsedMsg.message.(*p2p.Ping)
 	require.NotNil(pingMsg)
 }
+
+func TestRecompress(t *testing.T) {
+	t.Parallel()
+
+	chainID := ids.GenerateTestID()
+	samples := make([][]byte, 100)
+	for i := range samples {
+		putMsg := &p2p.Message{
+			Message: &p2p.Message_Put{
+				Put: &p2p.Put{
+					ChainId:   chainID[:],
+					RequestId: uint32(i),
+					Container: bytes.Repeat([]byte{byte(i)}, 256),
+				},
+			},
+		}
+		sample, err := proto.Marshal(putMsg)
+		require.NoError(t, err)
+		samples[i] = sample
+	}
+	zstdDictionary, err := compression.TrainZstdDictionary(samples, 4*units.KiB)
+	require.NoError(t, err)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+		zstdDictionary,
+	)
+	require.NoError(t, err)
+
+	msg := &p2p.Message{
+		Message: &p2p.Message_Put{
+			Put: &p2p.Put{
+				ChainId:    chainID[:],
+				RequestId:  1,
+				Container:  bytes.Repeat([]byte{0}, 100),
+				EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+			},
+		},
+	}
+	outboundMsg, err := mb.createOutbound(msg, compression.TypeGzip, false)
+	require.NoError(t, err)
+
+	codecs := []codec{
+		{compressionType: compression.TypeNone},
+		{compressionType: compression.TypeGzip},
+		{compressionType: compression.TypeZstd},
+		{compressionType: compression.TypeZstd, useZstdDictionary: true},
+		{compressionType: compression.TypeLZ4},
+		{compressionType: compression.TypeSnappy},
+	}
+	for _, c := range codecs {
+		t.Run(c.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			recompressedMsg, err := mb.recompress(outboundMsg, c)
+			require.NoError(err)
+			require.Equal(c, recompressedMsg.codec)
+			require.Equal(outboundMsg.Op(), recompressedMsg.Op())
+
+			// Re-compressed messages are cached.
+			cachedMsg, err := mb.recompress(outboundMsg, c)
+			require.NoError(err)
+			require.Same(recompressedMsg, cachedMsg)
+
+			parsedMsg, err := mb.parseInbound(recompressedMsg.Bytes(), ids.EmptyNodeID, func() {})
+			require.NoError(err)
+			require.Equal(PutOp, parsedMsg.Op())
+			require.Equal(msg.GetPut().Container, parsedMsg.Message().(*p2p.Put).Container)
+		})
+	}
+}
+
+func TestRecompressUncompressibleMessage(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+		nil,
+	)
+	require.NoError(err)
+
+	msg := &p2p.Message{
+		Message: &p2p.Message_Ping{
+			Ping: &p2p.Ping{
+				Uptime: 100,
+			},
+		},
+	}
+	outboundMsg, err := mb.createOutbound(msg, compression.TypeNone, false)
+	require.NoError(err)
+
+	recompressedMsg, err := mb.recompress(outboundMsg, codec{compressionType: compression.TypeZstd})
+	require.NoError(err)
+	require.Same(outboundMsg, recompressedMsg)
+}
+
+func TestRecompressUnknownZstdDictionary(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+		nil,
+	)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	msg := &p2p.Message{
+		Message: &p2p.Message_AppGossip{
+			AppGossip: &p2p.AppGossip{
+				ChainId:  chainID[:],
+				AppBytes: bytes.Repeat([]byte{0}, 100),
+			},
+		},
+	}
+	outboundMsg, err := mb.createOutbound(msg, compression.TypeZstd, false)
+	require.NoError(err)
+
+	_, err = mb.recompress(outboundMsg, codec{
+		compressionType:   compression.TypeZstd,
+		useZstdDictionary: true,
+	})
+	require.ErrorIs(err, errUnknownCompressionType)
+}
@@ -26,6 +26,8 @@ type OutboundMsgBuilder interface {
 		myVersionTime uint64,
 		sig []byte,
 		trackedSubnets []ids.ID,
+		supportedCompressionTypes []compression.Type,
+		supportsQUIC bool,
 	) (OutboundMessage, error)
 
 	PeerList(
@@ -93,6 +95,14 @@ type OutboundMsgBuilder interface {
 		engineType p2p.EngineType,
 	) (OutboundMessage, error)
 
+	GetAcceptedHeights(
+		chainID ids.ID,
+		requestID uint32,
+		deadline time.Duration,
+		heights []uint64,
+		engineType p2p.EngineType,
+	) (OutboundMessage, error)
+
 	Accepted(
 		chainID ids.ID,
 		requestID uint32,
@@ -232,21 +242,30 @@ func (b *outMsgBuilder) Version(
 	myVersionTime uint64,
 	sig []byte,
 	trackedSubnets []ids.ID,
+	supportedCompressionTypes []compression.Type,
+	supportsQUIC bool,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
+	compressionTypes := make([]uint32, len(supportedCompressionTypes))
+	for i, compressionType := range supportedCompressionTypes {
+		compressionTypes[i] = uint32(compressionType)
+	}
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Version{
 				Version: &p2p.Version{
-					NetworkId:      networkID,
-					MyTime:         myTime,
-					IpAddr:         ip.IP.To16(),
-					IpPort:         uint32(ip.Port),
-					MyVersion:      myVersion,
-					MyVersionTime:  myVersionTime,
-					Sig:            sig,
-					TrackedSubnets: subnetIDBytes,
+					NetworkId:                 networkID,
+					MyTime:                    myTime,
+					IpAddr:                    ip.IP.To16(),
+					IpPort:                    uint32(ip.Port),
+					MyVersion:                 myVersion,
+					MyVersionTime:             myVersionTime,
+					Sig:                       sig,
+					TrackedSubnets:            subnetIDBytes,
+					SupportedCompressionTypes: compressionTypes,
+					ZstdDictionaryId:          b.builder.zstdDictionaryID,
+					SupportsQuic:              supportsQUIC,
 				},
 			},
 		},
@@ -446,6 +465,30 @@ func (b *outMsgBuilder) GetAccepted(
 	)
 }
 
+func (b *outMsgBuilder) GetAcceptedHeights(
+	chainID ids.ID,
+	requestID uint32,
+	deadline time.Duration,
+	heights []uint64,
+	engineType p2p.EngineType,
+) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_GetAccepted{
+				GetAccepted: &p2p.GetAccepted{
+					ChainId:    chainID[:],
+					RequestId:  requestID,
+					Deadline:   uint64(deadline),
+					EngineType: engineType,
+					Heights:    heights,
+				},
+			},
+		},
+		compression.TypeNone,
+		false,
+	)
+}
+
 func (b *outMsgBuilder) Accepted(
 	chainID ids.ID,
 	requestID uint32,
@@ -24,6 +24,7 @@ func Test_newOutboundBuilder(t *testing.T) {
 		"test",
 		prometheus.NewRegistry(),
 		10*time.Second,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -6,6 +6,7 @@ package network
 import (
 	"crypto"
 	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -73,6 +74,17 @@ type PeerListGossipConfig struct {
 	// PeerListGossipFreq is the frequency that this node will attempt to gossip
 	// signed IPs to its peers.
 	PeerListGossipFreq time.Duration `json:"peerListGossipFreq"`
+
+	// PeerListMaxPeersPerIPPrefix is the maximum number of validators in the
+	// same IP prefix (/16 for IPv4, /32 for IPv6) that a non-validator will
+	// attempt to connect to after learning their IPs from peer list gossip.
+	// If 0, the number of validators per IP prefix isn't limited.
+	PeerListMaxPeersPerIPPrefix uint32 `json:"peerListMaxPeersPerIPPrefix"`
+
+	// PeerListMinSubnetPeers is the minimum number of validators of each
+	// tracked subnet that a non-validator will attempt to connect to,
+	// regardless of [PeerListMaxPeersPerIPPrefix].
+	PeerListMinSubnetPeers uint32 `json:"peerListMinSubnetPeers"`
 }
 
 type TimeoutConfig struct {
@@ -116,6 +128,18 @@ type Config struct {
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
+	// QUICEnabled enables connecting to peers over QUIC. Support is advertised
+	// during the handshake, so peers are first connected to over TCP and only
+	// connected to over QUIC once they advertised that they accept it. Peers
+	// that can't be reached over QUIC are connected to over TCP.
+	QUICEnabled bool `json:"quicEnabled"`
+	// QUICListener accepts inbound QUIC connections. Must be set if
+	// [QUICEnabled] is true.
+	QUICListener net.Listener `json:"-"`
+	// QUICDialer makes outbound QUIC connections. Must be set if
+	// [QUICEnabled] is true.
+	QUICDialer dialer.Dialer `json:"-"`
+
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
 	Namespace          string            `json:"namespace"`
@@ -130,6 +154,16 @@ type Config struct {
 	// Assumes all peers support this compression type.
 	CompressionType compression.Type `json:"compressionType"`
 
+	// The compression types advertised to peers, in order of preference.
+	// Messages sent to peers that advertise their supported compression types
+	// are compressed with the first mutually supported type rather than
+	// [CompressionType].
+	CompressionTypes []compression.Type `json:"compressionTypes"`
+
+	// ZstdDictionary, if non-empty, is used to compress messages with zstd for
+	// peers that advertise the same dictionary.
+	ZstdDictionary []byte `json:"-"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 
@@ -182,4 +216,8 @@ type Config struct {
 
 	// Tracks which validators have been sent to which peers
 	GossipTracker peer.GossipTracker `json:"-"`
+
+	// PeerEventsSize is the maximum number of recent connection failures,
+	// handshake rejections and disconnects to keep in memory.
+	PeerEventsSize int `json:"peerEventsSize"`
 }
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	// ipv4PrefixLength is the number of leading bits of an IPv4 address that
+	// are considered to be operated by the same network provider.
+	ipv4PrefixLength = 16
+	// ipv6PrefixLength is the number of leading bits of an IPv6 address that
+	// are considered to be operated by the same network provider.
+	ipv6PrefixLength = 32
+)
+
+var (
+	ipv4PrefixMask = net.CIDRMask(ipv4PrefixLength, 8*net.IPv4len)
+	ipv6PrefixMask = net.CIDRMask(ipv6PrefixLength, 8*net.IPv6len)
+)
+
+// ipDiversity tracks the IP prefixes of the peers that we are connected to, or
+// attempting to connect to, so that we can avoid connecting to too many peers
+// that are likely operated by the same network provider.
+//
+// ipDiversity is not thread safe.
+type ipDiversity struct {
+	// maxPeersPerPrefix is the maximum number of peers that should be tracked
+	// in a single IP prefix. If 0, the number of peers isn't limited.
+	maxPeersPerPrefix int
+	// minSubnetPeers is the minimum number of validators of every tracked
+	// subnet that should be tracked, regardless of their IP prefix.
+	minSubnetPeers int
+
+	validators     validators.Manager
+	trackedSubnets set.Set[ids.ID]
+
+	// prefixes maps the tracked peers to their IP prefix.
+	prefixes map[ids.NodeID]string
+	// numPeers maps an IP prefix to the number of tracked peers in it.
+	numPeers map[string]int
+}
+
+func newIPDiversity(
+	maxPeersPerPrefix int,
+	minSubnetPeers int,
+	validators validators.Manager,
+	trackedSubnets set.Set[ids.ID],
+) *ipDiversity {
+	return &ipDiversity{
+		maxPeersPerPrefix: maxPeersPerPrefix,
+		minSubnetPeers:    minSubnetPeers,
+		validators:        validators,
+		trackedSubnets:    trackedSubnets,
+		prefixes:          make(map[ids.NodeID]string),
+		numPeers:          make(map[string]int),
+	}
+}
+
+// Add marks [nodeID] as being tracked at [ip]. If [nodeID] was previously
+// tracked at a different IP, the previous IP is replaced.
+func (d *ipDiversity) Add(nodeID ids.NodeID, ip ips.IPPort) {
+	d.Remove(nodeID)
+
+	prefix := ipPrefix(ip.IP)
+	d.prefixes[nodeID] = prefix
+	d.numPeers[prefix]++
+}
+
+// Remove marks [nodeID] as no longer being tracked.
+func (d *ipDiversity) Remove(nodeID ids.NodeID) {
+	prefix, ok := d.prefixes[nodeID]
+	if !ok {
+		return
+	}
+
+	delete(d.prefixes, nodeID)
+	d.numPeers[prefix]--
+	if d.numPeers[prefix] == 0 {
+		delete(d.numPeers, prefix)
+	}
+}
+
+// ShouldTrack returns true if tracking [nodeID] at [ip] would keep the tracked
+// peers diverse, or if [nodeID] is needed to reach the minimum number of peers
+// for one of our tracked subnets.
+func (d *ipDiversity) ShouldTrack(nodeID ids.NodeID, ip ips.IPPort) bool {
+	if d.maxPeersPerPrefix == 0 || d.numPeers[ipPrefix(ip.IP)] < d.maxPeersPerPrefix {
+		return true
+	}
+
+	for subnetID := range d.trackedSubnets {
+		if _, ok := d.validators.GetValidator(subnetID, nodeID); !ok {
+			continue
+		}
+		if d.numSubnetPeers(subnetID) < d.minSubnetPeers {
+			return true
+		}
+	}
+	return false
+}
+
+// numSubnetPeers returns the number of tracked peers that are validators of
+// [subnetID].
+func (d *ipDiversity) numSubnetPeers(subnetID ids.ID) int {
+	numPeers := 0
+	for nodeID := range d.prefixes {
+		if _, ok := d.validators.GetValidator(subnetID, nodeID); ok {
+			numPeers++
+		}
+	}
+	return numPeers
+}
+
+// ipPrefix returns the network prefix of [ip] that is used to group peers.
+func ipPrefix(ip net.IP) string {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return ipv4.Mask(ipv4PrefixMask).String()
+	}
+	return ip.Mask(ipv6PrefixMask).String()
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestIPPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       net.IP
+		expected string
+	}{
+		{
+			name:     "ipv4",
+			ip:       net.IPv4(1, 2, 3, 4),
+			expected: "1.2.0.0",
+		},
+		{
+			name:     "ipv4 in ipv6",
+			ip:       net.ParseIP("::ffff:1.2.3.4"),
+			expected: "1.2.0.0",
+		},
+		{
+			name:     "ipv6",
+			ip:       net.ParseIP("2001:db8:1:2::1"),
+			expected: "2001:db8::",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, ipPrefix(test.ip))
+		})
+	}
+}
+
+func TestIPDiversityMaxPeersPerPrefix(t *testing.T) {
+	require := require.New(t)
+
+	d := newIPDiversity(2, 0, validators.NewManager(), nil)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	ip0 := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := ips.IPPort{IP: net.IPv4(1, 2, 4, 5), Port: 9651}
+	ip2 := ips.IPPort{IP: net.IPv4(1, 2, 5, 6), Port: 9651}
+	otherPrefixIP := ips.IPPort{IP: net.IPv4(1, 3, 5, 6), Port: 9651}
+
+	require.True(d.ShouldTrack(nodeID0, ip0))
+	d.Add(nodeID0, ip0)
+	require.True(d.ShouldTrack(nodeID1, ip1))
+	d.Add(nodeID1, ip1)
+
+	// The prefix is full
+	require.False(d.ShouldTrack(nodeID2, ip2))
+	require.True(d.ShouldTrack(nodeID2, otherPrefixIP))
+
+	// Moving a peer to a different prefix frees up its previous prefix
+	d.Add(nodeID1, otherPrefixIP)
+	require.True(d.ShouldTrack(nodeID2, ip2))
+	d.Add(nodeID2, ip2)
+	require.False(d.ShouldTrack(ids.GenerateTestNodeID(), ip0))
+
+	// Removing a peer frees up its prefix
+	d.Remove(nodeID0)
+	require.True(d.ShouldTrack(ids.GenerateTestNodeID(), ip0))
+	require.Len(d.prefixes, 2)
+	require.Len(d.numPeers, 2)
+
+	// Removing an untracked peer is a noop
+	d.Remove(nodeID0)
+	require.Len(d.prefixes, 2)
+	require.Len(d.numPeers, 2)
+}
+
+func TestIPDiversityUnlimited(t *testing.T) {
+	require := require.New(t)
+
+	d := newIPDiversity(0, 0, validators.NewManager(), nil)
+
+	ip := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	for i := 0; i < 10; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.True(d.ShouldTrack(nodeID, ip))
+		d.Add(nodeID, ip)
+	}
+}
+
+func TestIPDiversityMinSubnetPeers(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs := validators.NewManager()
+	d := newIPDiversity(1, 2, vdrs, set.Of(subnetID))
+
+	ip := ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	d.Add(ids.GenerateTestNodeID(), ip)
+
+	// The prefix is full and the node isn't a subnet validator
+	nonValidatorID := ids.GenerateTestNodeID()
+	require.False(d.ShouldTrack(nonValidatorID, ip))
+
+	subnetValidatorIDs := make([]ids.NodeID, 3)
+	for i := range subnetValidatorIDs {
+		subnetValidatorIDs[i] = ids.GenerateTestNodeID()
+		require.NoError(vdrs.AddStaker(subnetID, subnetValidatorIDs[i], nil, ids.Empty, 1))
+	}
+
+	// Subnet validators are tracked until the subnet has enough peers
+	require.True(d.ShouldTrack(subnetValidatorIDs[0], ip))
+	d.Add(subnetValidatorIDs[0], ip)
+	require.True(d.ShouldTrack(subnetValidatorIDs[1], ip))
+	d.Add(subnetValidatorIDs[1], ip)
+	require.False(d.ShouldTrack(subnetValidatorIDs[2], ip))
+	require.False(d.ShouldTrack(nonValidatorID, ip))
+}
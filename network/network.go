@@ -97,6 +97,12 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// PeerEvents returns the most recent connection failures, handshake
+	// rejections and disconnects, from oldest to newest. If [nodeIDs] is
+	// empty, returns all recorded events. Otherwise, returns the events about
+	// the peers in [nodeIDs].
+	PeerEvents(nodeIDs []ids.NodeID) []peer.Event
 }
 
 type UptimeResult struct {
@@ -127,6 +133,7 @@ type network struct {
 	config     *Config
 	peerConfig *peer.Config
 	metrics    *metrics
+	peerEvents *peerEvents
 
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
@@ -140,6 +147,14 @@ type network struct {
 	serverUpgrader peer.Upgrader
 	// Does TLS handshakes for outbound connections
 	clientUpgrader peer.Upgrader
+	// Verifies the TLS handshakes of inbound and outbound QUIC connections
+	quicUpgrader peer.Upgrader
+
+	quicPeersLock sync.Mutex
+	// quicPeers contains the peers that advertised in their last handshake
+	// that they accept QUIC connections. All other peers are only connected to
+	// over TCP.
+	quicPeers set.Set[ids.NodeID]
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -158,6 +173,8 @@ type network struct {
 	// Note: The txID provided inside of a claimed IP is not verified and should
 	//       not be accessed from this map.
 	peerIPs map[ids.NodeID]*ips.ClaimedIPPort
+	// ipDiversity tracks the IP prefixes of the nodes in [peerIPs].
+	ipDiversity *ipDiversity
 	// trackedIPs contains the set of IPs that we are currently attempting to
 	// connect to. An entry is added to this set when we first start attempting
 	// to connect to the peer. An entry is deleted from this set once we have
@@ -254,6 +271,11 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing network metrics failed with: %w", err)
 	}
 
+	peerEvents, err := newPeerEvents(config.Namespace, metricsRegisterer, config.PeerEventsSize)
+	if err != nil {
+		return nil, fmt.Errorf("initializing peer events failed with: %w", err)
+	}
+
 	peerConfig := &peer.Config{
 		ReadBufferSize:  config.PeerReadBufferSize,
 		WriteBufferSize: config.PeerWriteBufferSize,
@@ -271,9 +293,12 @@ func NewNetwork(
 		PingFrequency:        config.PingFrequency,
 		PongTimeout:          config.PingPongTimeout,
 		MaxClockDifference:   config.MaxClockDifference,
+		CompressionTypes:     config.CompressionTypes,
+		QUICEnabled:          config.QUICEnabled,
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
+		EventRecorder:        peerEvents,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -281,6 +306,7 @@ func NewNetwork(
 		config:               config,
 		peerConfig:           peerConfig,
 		metrics:              metrics,
+		peerEvents:           peerEvents,
 		outboundMsgThrottler: outboundMsgThrottler,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
@@ -288,6 +314,7 @@ func NewNetwork(
 		dialer:                      dialer,
 		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
 		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		quicUpgrader:                peer.NewQUICUpgrader(metrics.tlsConnRejected),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -304,6 +331,13 @@ func NewNetwork(
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
 		router:          router,
+
+		ipDiversity: newIPDiversity(
+			int(config.PeerListMaxPeersPerIPPrefix),
+			int(config.PeerListMinSubnetPeers),
+			config.Validators,
+			config.TrackedSubnets,
+		),
 	}
 	n.peerConfig.Network = n
 	return n, nil
@@ -435,9 +469,11 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		// gossiped it. This means we don't need to reset the validator's
 		// tracked set.
 		n.peerIPs[nodeID] = newIP
+		n.ipDiversity.Add(nodeID, newIP.IPPort)
 	} else if prevIP.Timestamp < newIP.Timestamp {
 		// The previous IP was stale, so we should gossip the newer IP.
 		n.peerIPs[nodeID] = newIP
+		n.ipDiversity.Add(nodeID, newIP.IPPort)
 
 		if !prevIP.IPPort.Equal(newIP.IPPort) {
 			// This IP is actually different, so we should gossip it.
@@ -458,6 +494,14 @@ func (n *network) Connected(nodeID ids.NodeID) {
 
 	n.metrics.markConnected(peer)
 
+	n.quicPeersLock.Lock()
+	if peer.SupportsQUIC() {
+		n.quicPeers.Add(nodeID)
+	} else {
+		n.quicPeers.Remove(nodeID)
+	}
+	n.quicPeersLock.Unlock()
+
 	peerVersion := peer.Version()
 	n.router.Connected(nodeID, peerVersion, constants.PrimaryNetworkID)
 	for subnetID := range peer.TrackedSubnets() {
@@ -544,6 +588,7 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 
 			// In the future, we should gossip this IP rather than the old IP.
 			n.peerIPs[nodeID] = ip
+			n.ipDiversity.Add(nodeID, ip.IPPort)
 
 			// If the new IP is equal to the old IP, there is no reason to
 			// refresh the references to it. This can happen when a node
@@ -577,6 +622,7 @@ func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort)
 			// We don't need to reset gossip about this validator because
 			// we've never gossiped it before.
 			n.peerIPs[nodeID] = ip
+			n.ipDiversity.Add(nodeID, ip.IPPort)
 
 			tracked := newTrackedIP(ip.IPPort)
 			n.trackedIPs[nodeID] = tracked
@@ -732,6 +778,9 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 func (n *network) Dispatch() error {
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
+	if n.config.QUICEnabled {
+		go n.acceptQUIC()
+	}
 	for { // Continuously accept new connections
 		if n.onCloseCtx.Err() != nil {
 			break
@@ -749,45 +798,7 @@ func (n *network) Dispatch() error {
 
 		// Note: listener.Accept is rate limited outside of this package, so a
 		// peer can not just arbitrarily spin up goroutines here.
-		go func() {
-			// Note: Calling [RemoteAddr] with the Proxy protocol enabled may
-			// block for up to ProxyReadHeaderTimeout. Therefore, we ensure to
-			// call this function inside the go-routine, rather than the main
-			// accept loop.
-			remoteAddr := conn.RemoteAddr().String()
-			ip, err := ips.ToIPPort(remoteAddr)
-			if err != nil {
-				n.peerConfig.Log.Error("failed to parse remote address",
-					zap.String("peerIP", remoteAddr),
-					zap.Error(err),
-				)
-				_ = conn.Close()
-				return
-			}
-
-			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
-				n.peerConfig.Log.Debug("failed to upgrade connection",
-					zap.String("reason", "rate-limiting"),
-					zap.Stringer("peerIP", ip),
-				)
-				n.metrics.inboundConnRateLimited.Inc()
-				_ = conn.Close()
-				return
-			}
-			n.metrics.inboundConnAllowed.Inc()
-
-			n.peerConfig.Log.Verbo("starting to upgrade connection",
-				zap.String("direction", "inbound"),
-				zap.Stringer("peerIP", ip),
-			)
-
-			if err := n.upgrade(conn, n.serverUpgrader); err != nil {
-				n.peerConfig.Log.Verbo("failed to upgrade connection",
-					zap.String("direction", "inbound"),
-					zap.Error(err),
-				)
-			}
-		}()
+		go n.upgradeInbound(conn, n.serverUpgrader)
 	}
 	n.inboundConnUpgradeThrottler.Stop()
 	n.StartClose()
@@ -804,6 +815,66 @@ func (n *network) Dispatch() error {
 	return errs.Err
 }
 
+// acceptQUIC accepts QUIC connections from other nodes attempting to connect
+// to this node until the network is closed.
+func (n *network) acceptQUIC() {
+	for n.onCloseCtx.Err() == nil {
+		conn, err := n.config.QUICListener.Accept() // Returns error when n.Close() is called
+		if err != nil {
+			n.peerConfig.Log.Debug("error during QUIC server accept", zap.Error(err))
+			// Sleep for a small amount of time to try to wait for the
+			// error to go away.
+			time.Sleep(time.Millisecond)
+			n.metrics.acceptFailed.Inc()
+			continue
+		}
+
+		go n.upgradeInbound(conn, n.quicUpgrader)
+	}
+}
+
+// upgradeInbound upgrades the accepted connection [conn] with the provided
+// [upgrader], subject to the inbound connection upgrade throttler.
+func (n *network) upgradeInbound(conn net.Conn, upgrader peer.Upgrader) {
+	// Note: Calling [RemoteAddr] with the Proxy protocol enabled may block for
+	// up to ProxyReadHeaderTimeout. Therefore, we ensure to call this function
+	// inside the go-routine, rather than the main accept loop.
+	remoteAddr := conn.RemoteAddr().String()
+	ip, err := ips.ToIPPort(remoteAddr)
+	if err != nil {
+		n.peerConfig.Log.Error("failed to parse remote address",
+			zap.String("peerIP", remoteAddr),
+			zap.Error(err),
+		)
+		_ = conn.Close()
+		return
+	}
+
+	if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
+		n.peerConfig.Log.Debug("failed to upgrade connection",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("peerIP", ip),
+		)
+		n.metrics.inboundConnRateLimited.Inc()
+		_ = conn.Close()
+		return
+	}
+	n.metrics.inboundConnAllowed.Inc()
+
+	n.peerConfig.Log.Verbo("starting to upgrade connection",
+		zap.String("direction", "inbound"),
+		zap.Stringer("peerIP", ip),
+	)
+
+	if err := n.upgrade(conn, upgrader); err != nil {
+		n.peerConfig.Log.Verbo("failed to upgrade connection",
+			zap.String("direction", "inbound"),
+			zap.Error(err),
+		)
+		n.recordPeerEvent(peer.UpgradeFailedEvent, ids.EmptyNodeID, remoteAddr, peer.ReasonUpgradeFailed, err)
+	}
+}
+
 func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 	if _, ok := n.config.Validators.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
 		return true
@@ -970,6 +1041,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 		} else {
 			tracked.stopTracking()
 			delete(n.peerIPs, nodeID)
+			n.ipDiversity.Remove(nodeID)
 			delete(n.trackedIPs, nodeID)
 		}
 	}
@@ -993,6 +1065,7 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 		n.dial(nodeID, tracked)
 	} else {
 		delete(n.peerIPs, nodeID)
+		n.ipDiversity.Remove(nodeID)
 	}
 
 	n.metrics.markDisconnected(peer)
@@ -1042,10 +1115,28 @@ func (n *network) authenticateIPs(ips []*ips.ClaimedIPPort) ([]*ipAuth, error) {
 func (n *network) peerIPStatus(nodeID ids.NodeID, ip *ips.ClaimedIPPort) (*ips.ClaimedIPPort, bool, bool, bool) {
 	prevIP, previouslyTracked := n.peerIPs[nodeID]
 	shouldUpdateOurIP := previouslyTracked && prevIP.Timestamp < ip.Timestamp
-	shouldDial := !previouslyTracked && n.WantsConnection(nodeID)
+	shouldDial := !previouslyTracked && n.WantsConnection(nodeID) && n.isDiverseIP(nodeID, ip.IPPort)
 	return prevIP, previouslyTracked, shouldUpdateOurIP, shouldDial
 }
 
+// isDiverseIP returns true if connecting to [nodeID] at [ip] wouldn't result in
+// too many connections to the same IP prefix. Validators connect to all other
+// validators, so the IP diversity is only enforced for non-validators.
+// Manually tracked nodes are always connected to.
+//
+// isDiverseIP assumes the caller holds [peersLock]
+func (n *network) isDiverseIP(nodeID ids.NodeID, ip ips.IPPort) bool {
+	if _, isValidator := n.config.Validators.GetValidator(constants.PrimaryNetworkID, n.config.MyNodeID); isValidator {
+		return true
+	}
+
+	n.manuallyTrackedIDsLock.RLock()
+	isManuallyTracked := n.manuallyTrackedIDs.Contains(nodeID)
+	n.manuallyTrackedIDsLock.RUnlock()
+
+	return isManuallyTracked || n.ipDiversity.ShouldTrack(nodeID, ip)
+}
+
 // dial will spin up a new goroutine and attempt to establish a connection with
 // [nodeID] at [ip].
 //
@@ -1095,6 +1186,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				if ip, exists := n.trackedIPs[nodeID]; exists {
 					ip.stopTracking()
 					delete(n.peerIPs, nodeID)
+					n.ipDiversity.Remove(nodeID)
 					delete(n.trackedIPs, nodeID)
 				}
 				n.peersLock.Unlock()
@@ -1145,13 +1237,14 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
-			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
+			conn, upgrader, err := n.dialPeer(nodeID, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
 					zap.Stringer("peerIP", ip.ip.IP),
 					zap.Duration("delay", ip.delay),
 				)
+				n.recordPeerEvent(peer.DialFailedEvent, nodeID, ip.ip.String(), peer.ReasonConnectionFailed, err)
 				continue
 			}
 
@@ -1160,13 +1253,14 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				zap.Stringer("peerIP", ip.ip.IP),
 			)
 
-			err = n.upgrade(conn, n.clientUpgrader)
+			err = n.upgrade(conn, upgrader)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
 					zap.Stringer("peerIP", ip.ip.IP),
 					zap.Duration("delay", ip.delay),
 				)
+				n.recordPeerEvent(peer.UpgradeFailedEvent, nodeID, ip.ip.String(), peer.ReasonUpgradeFailed, err)
 				continue
 			}
 			return
@@ -1174,6 +1268,49 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 	}()
 }
 
+// dialPeer connects to [ip] over QUIC if it is enabled and [nodeID] advertised
+// that it accepts QUIC connections when we were last connected to it.
+// Otherwise, or if the QUIC connection can't be established, [ip] is connected
+// to over TCP.
+//
+// Returns the connection along with the upgrader that should be used for it.
+func (n *network) dialPeer(nodeID ids.NodeID, ip ips.IPPort) (net.Conn, peer.Upgrader, error) {
+	if n.shouldDialQUIC(nodeID) {
+		conn, err := n.config.QUICDialer.Dial(n.onCloseCtx, ip)
+		if err == nil {
+			return conn, n.quicUpgrader, nil
+		}
+
+		n.peerConfig.Log.Verbo(
+			"failed to reach peer over QUIC, falling back to TCP",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIP", ip),
+			zap.Error(err),
+		)
+		n.metrics.quicDialFailed.Inc()
+
+		// The peer is unreachable over QUIC, so only TCP is used until it
+		// advertises QUIC support again.
+		n.quicPeersLock.Lock()
+		n.quicPeers.Remove(nodeID)
+		n.quicPeersLock.Unlock()
+	}
+
+	conn, err := n.dialer.Dial(n.onCloseCtx, ip)
+	return conn, n.clientUpgrader, err
+}
+
+func (n *network) shouldDialQUIC(nodeID ids.NodeID) bool {
+	if !n.config.QUICEnabled {
+		return false
+	}
+
+	n.quicPeersLock.Lock()
+	defer n.quicPeersLock.Unlock()
+
+	return n.quicPeers.Contains(nodeID)
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
 // outbound connection, with the provided [upgrader].
 //
@@ -1305,6 +1442,27 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerEvents(nodeIDs []ids.NodeID) []peer.Event {
+	return n.peerEvents.List(nodeIDs)
+}
+
+func (n *network) recordPeerEvent(
+	eventType peer.EventType,
+	nodeID ids.NodeID,
+	ip string,
+	reason string,
+	err error,
+) {
+	n.peerEvents.Record(peer.Event{
+		Time:   n.peerConfig.Clock.Time(),
+		Type:   eventType,
+		NodeID: nodeID,
+		IP:     ip,
+		Reason: reason,
+		Error:  err.Error(),
+	})
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1314,6 +1472,13 @@ func (n *network) StartClose() {
 				zap.Error(err),
 			)
 		}
+		if n.config.QUICEnabled {
+			if err := n.config.QUICListener.Close(); err != nil {
+				n.peerConfig.Log.Debug("closing the QUIC listener",
+					zap.Error(err),
+				)
+			}
+		}
 
 		n.peersLock.Lock()
 		defer n.peersLock.Unlock()
@@ -1324,6 +1489,7 @@ func (n *network) StartClose() {
 		for nodeID, tracked := range n.trackedIPs {
 			tracked.stopTracking()
 			delete(n.peerIPs, nodeID)
+			n.ipDiversity.Remove(nodeID)
 			delete(n.trackedIPs, nodeID)
 		}
 
@@ -13,13 +13,14 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-
+	quicgo "github.com/quic-go/quic-go"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -122,6 +123,7 @@ var (
 		RequireValidatorToConnect: false,
 
 		MaximumInboundMessageTimeout: 30 * time.Second,
+		PeerEventsSize:               constants.DefaultNetworkPeerEventsSize,
 		ResourceTracker:              newDefaultResourceTracker(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
@@ -192,6 +194,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 		"",
 		constants.DefaultNetworkCompressionType,
 		10*time.Second,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -199,9 +202,19 @@ func newMessageCreator(t *testing.T) message.Creator {
 }
 
 func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler) ([]ids.NodeID, []*network, *sync.WaitGroup) {
-	require := require.New(t)
-
 	dialer, listeners, nodeIDs, configs := newTestNetwork(t, len(handlers))
+	return startFullyConnectedTestNetwork(t, handlers, dialer, listeners, nodeIDs, configs)
+}
+
+func startFullyConnectedTestNetwork(
+	t *testing.T,
+	handlers []router.InboundHandler,
+	dialer *testDialer,
+	listeners []*testListener,
+	nodeIDs []ids.NodeID,
+	configs []*Config,
+) ([]ids.NodeID, []*network, *sync.WaitGroup) {
+	require := require.New(t)
 
 	var (
 		networks = make([]*network, len(configs))
@@ -310,6 +323,81 @@ func TestNewNetwork(t *testing.T) {
 	wg.Wait()
 }
 
+func TestNewNetworkQUIC(t *testing.T) {
+	require := require.New(t)
+
+	handlers := []router.InboundHandler{nil, nil, nil}
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, len(handlers))
+	for i, config := range configs {
+		// Peers are first connected to over TCP, so the test dialer must be
+		// able to reach the nodes at their QUIC addresses.
+		enableQUIC(t, config)
+		dialer.AddListener(config.MyIPPort.IPPort(), listeners[i])
+	}
+
+	_, networks, wg := startFullyConnectedTestNetwork(t, handlers, dialer, listeners, nodeIDs, configs)
+	for i, net := range networks {
+		for j, nodeID := range nodeIDs {
+			if i != j {
+				require.True(net.shouldDialQUIC(nodeID))
+			}
+		}
+	}
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestNetworkOnlyDialsQUICToAdvertisingPeers(t *testing.T) {
+	require := require.New(t)
+
+	handlers := []router.InboundHandler{nil, nil, nil}
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, len(handlers))
+	enableQUIC(t, configs[0])
+	dialer.AddListener(configs[0].MyIPPort.IPPort(), listeners[0])
+
+	_, networks, wg := startFullyConnectedTestNetwork(t, handlers, dialer, listeners, nodeIDs, configs)
+	for i, net := range networks {
+		for j, nodeID := range nodeIDs {
+			if i != j {
+				require.False(net.shouldDialQUIC(nodeID))
+			}
+		}
+	}
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+// enableQUIC configures [config] to accept and dial QUIC connections on a new
+// local UDP port, which replaces the node's IP.
+func enableQUIC(t *testing.T, config *Config) {
+	require := require.New(t)
+
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+
+	transport := &quicgo.Transport{Conn: udpConn}
+	t.Cleanup(func() {
+		require.NoError(transport.Close())
+	})
+
+	quicListener, err := quic.NewListener(logging.NoLog{}, transport, config.TLSConfig, time.Second)
+	require.NoError(err)
+
+	ip, err := ips.ToIPPort(udpConn.LocalAddr().String())
+	require.NoError(err)
+
+	config.MyIPPort = ips.NewDynamicIPPort(ip.IP, ip.Port)
+	config.QUICEnabled = true
+	config.QUICListener = quicListener
+	config.QUICDialer = quic.NewDialer(logging.NoLog{}, transport, config.TLSConfig, defaultDialerConfig)
+}
+
 func TestSend(t *testing.T) {
 	require := require.New(t)
 
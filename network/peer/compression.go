@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import "github.com/ava-labs/avalanchego/utils/compression"
+
+// negotiatedCompression is how messages sent to a peer are compressed.
+type negotiatedCompression struct {
+	compressionType   compression.Type
+	useZstdDictionary bool
+}
+
+// negotiateCompression returns the first of the [preferred] compression types
+// that is also supported by the peer. The zstd dictionary is used if both nodes
+// advertised the same non-zero dictionary ID.
+//
+// If the peer didn't advertise any compression types, it may not support
+// negotiation, so false is returned and messages should be sent using the
+// default compression type.
+//
+// If there is no mutually supported compression type, messages are sent
+// uncompressed.
+func negotiateCompression(
+	preferred []compression.Type,
+	zstdDictionaryID uint32,
+	peerSupported []uint32,
+	peerZstdDictionaryID uint32,
+) (negotiatedCompression, bool) {
+	if len(peerSupported) == 0 {
+		return negotiatedCompression{}, false
+	}
+
+	for _, compressionType := range preferred {
+		for _, peerCompressionType := range peerSupported {
+			if uint32(compressionType) != peerCompressionType {
+				continue
+			}
+			return negotiatedCompression{
+				compressionType: compressionType,
+				useZstdDictionary: compressionType == compression.TypeZstd &&
+					zstdDictionaryID != 0 &&
+					zstdDictionaryID == peerZstdDictionaryID,
+			}, true
+		}
+	}
+	return negotiatedCompression{
+		compressionType: compression.TypeNone,
+	}, true
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	tests := []struct {
+		name                 string
+		preferred            []compression.Type
+		zstdDictionaryID     uint32
+		peerSupported        []uint32
+		peerZstdDictionaryID uint32
+		expected             negotiatedCompression
+		expectedOk           bool
+	}{
+		{
+			name:       "peer doesn't negotiate",
+			preferred:  []compression.Type{compression.TypeLZ4},
+			expectedOk: false,
+		},
+		{
+			name:      "first preferred type",
+			preferred: []compression.Type{compression.TypeLZ4, compression.TypeZstd},
+			peerSupported: []uint32{
+				uint32(compression.TypeZstd),
+				uint32(compression.TypeLZ4),
+			},
+			expected: negotiatedCompression{
+				compressionType: compression.TypeLZ4,
+			},
+			expectedOk: true,
+		},
+		{
+			name:      "skip unsupported type",
+			preferred: []compression.Type{compression.TypeLZ4, compression.TypeZstd},
+			peerSupported: []uint32{
+				uint32(compression.TypeSnappy),
+				uint32(compression.TypeZstd),
+			},
+			expected: negotiatedCompression{
+				compressionType: compression.TypeZstd,
+			},
+			expectedOk: true,
+		},
+		{
+			name:      "no mutually supported type",
+			preferred: []compression.Type{compression.TypeLZ4},
+			peerSupported: []uint32{
+				uint32(compression.TypeSnappy),
+			},
+			expected: negotiatedCompression{
+				compressionType: compression.TypeNone,
+			},
+			expectedOk: true,
+		},
+		{
+			name:             "matching zstd dictionary",
+			preferred:        []compression.Type{compression.TypeZstd},
+			zstdDictionaryID: 1,
+			peerSupported: []uint32{
+				uint32(compression.TypeZstd),
+			},
+			peerZstdDictionaryID: 1,
+			expected: negotiatedCompression{
+				compressionType:   compression.TypeZstd,
+				useZstdDictionary: true,
+			},
+			expectedOk: true,
+		},
+		{
+			name:             "mismatched zstd dictionary",
+			preferred:        []compression.Type{compression.TypeZstd},
+			zstdDictionaryID: 1,
+			peerSupported: []uint32{
+				uint32(compression.TypeZstd),
+			},
+			peerZstdDictionaryID: 2,
+			expected: negotiatedCompression{
+				compressionType: compression.TypeZstd,
+			},
+			expectedOk: true,
+		},
+		{
+			name:      "no zstd dictionary",
+			preferred: []compression.Type{compression.TypeZstd},
+			peerSupported: []uint32{
+				uint32(compression.TypeZstd),
+			},
+			expected: negotiatedCompression{
+				compressionType: compression.TypeZstd,
+			},
+			expectedOk: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			negotiated, ok := negotiateCompression(
+				test.preferred,
+				test.zstdDictionaryID,
+				test.peerSupported,
+				test.peerZstdDictionaryID,
+			)
+			require.Equal(test.expectedOk, ok)
+			require.Equal(test.expected, negotiated)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -40,6 +41,15 @@ type Config struct {
 	PongTimeout          time.Duration
 	MaxClockDifference   time.Duration
 
+	// Compression types advertised to the peer, in order of preference. The
+	// first one that the peer also supports is used to compress messages sent
+	// to the peer. If empty, messages are sent as they were created.
+	CompressionTypes []compression.Type
+
+	// QUICEnabled is advertised to the peer so that it only connects to this
+	// node over QUIC if QUIC connections are accepted.
+	QUICEnabled bool
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
@@ -52,4 +62,7 @@ type Config struct {
 
 	// Signs my IP so I can send my signed IP address in the Version message
 	IPSigner *IPSigner
+
+	// Notified of handshake rejections and disconnects. May be nil.
+	EventRecorder EventRecorder
 }
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// EventType classifies a connection lifecycle event.
+type EventType string
+
+const (
+	// DialFailedEvent is recorded when an outbound connection attempt could
+	// not be established.
+	DialFailedEvent EventType = "dialFailed"
+	// UpgradeFailedEvent is recorded when a connection could not be upgraded
+	// to an authenticated TLS connection.
+	UpgradeFailedEvent EventType = "upgradeFailed"
+	// HandshakeRejectedEvent is recorded when the peer's Version message was
+	// rejected.
+	HandshakeRejectedEvent EventType = "handshakeRejected"
+	// DisconnectedEvent is recorded when an upgraded connection is closed for
+	// any reason other than a rejected handshake.
+	DisconnectedEvent EventType = "disconnected"
+)
+
+// Reasons are kept to a small, fixed set so that they can be used as metric
+// labels. Any additional detail is reported in [Event.Error].
+const (
+	ReasonConnectionFailed = "connectionFailed"
+	ReasonUpgradeFailed    = "upgradeFailed"
+
+	ReasonNetworkIDMismatch     = "networkIDMismatch"
+	ReasonClockSkew             = "clockSkew"
+	ReasonInvalidVersion        = "invalidVersion"
+	ReasonIncompatibleVersion   = "incompatibleVersion"
+	ReasonVersionTimeInFuture   = "versionTimeInFuture"
+	ReasonInvalidTrackedSubnets = "invalidTrackedSubnets"
+	ReasonInvalidIP             = "invalidIP"
+	ReasonInvalidIPSignature    = "invalidIPSignature"
+
+	ReasonReadFailed          = "readFailed"
+	ReasonWriteFailed         = "writeFailed"
+	ReasonInvalidMessage      = "invalidMessage"
+	ReasonConnectionUndesired = "connectionUndesired"
+	ReasonClosedLocally       = "closedLocally"
+)
+
+// Event describes a single connection attempt failure, handshake rejection or
+// disconnect.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	// NodeID is empty if the event occurred before the remote node ID was
+	// known, such as a failed inbound TLS upgrade.
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+	Reason string     `json:"reason"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// EventRecorder is notified of connection lifecycle events.
+type EventRecorder interface {
+	Record(Event)
+}
@@ -76,6 +76,10 @@ type Peer interface {
 	// be called after [Ready] returns true.
 	TrackedSubnets() set.Set[ids.ID]
 
+	// SupportsQUIC returns true if this peer advertised that it accepts QUIC
+	// connections. It should only be called after [Ready] returns true.
+	SupportsQUIC() bool
+
 	// ObservedUptime returns the local node's subnet uptime according to the
 	// peer. The value ranges from [0, 100]. It should only be called after
 	// [Ready] returns true.
@@ -128,6 +132,9 @@ type peer struct {
 	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
 	// message that we are also tracking.
 	trackedSubnets set.Set[ids.ID]
+	// supportsQUIC is true if the peer advertised in the Version message that
+	// it accepts QUIC connections.
+	supportsQUIC bool
 
 	observedUptimesLock sync.RWMutex
 	// [observedUptimesLock] must be held while accessing [observedUptime]
@@ -139,6 +146,10 @@ type peer struct {
 	// Only modified on the connection's reader routine.
 	gotVersion utils.Atomic[bool]
 
+	// compression is set once the peer's Version message is handled, if the
+	// peer advertised the compression types it supports.
+	compression utils.Atomic[*negotiatedCompression]
+
 	// True if the peer:
 	// * Has sent us a Version message
 	// * Has sent us a PeerList message
@@ -152,6 +163,9 @@ type peer struct {
 	// numExecuting is the number of goroutines this peer is currently using
 	numExecuting     int64
 	startClosingOnce sync.Once
+	// closeEvent describes why the peer was closed. It is set when the peer
+	// starts closing and is reported once the peer has fully shutdown.
+	closeEvent Event
 	// onClosingCtx is canceled when the peer starts closing
 	onClosingCtx context.Context
 	// onClosingCtxCancel cancels onClosingCtx
@@ -287,6 +301,10 @@ func (p *peer) TrackedSubnets() set.Set[ids.ID] {
 	return p.trackedSubnets
 }
 
+func (p *peer) SupportsQUIC() bool {
+	return p.supportsQUIC
+}
+
 func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 	p.observedUptimesLock.RLock()
 	defer p.observedUptimesLock.RUnlock()
@@ -296,6 +314,19 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
+	if c := p.compression.Get(); c != nil {
+		compressedMsg, err := p.MessageCreator.Compress(msg, c.compressionType, c.useZstdDictionary)
+		if err != nil {
+			p.Log.Debug("failed to compress message",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Stringer("compressionType", c.compressionType),
+				zap.Error(err),
+			)
+		} else {
+			msg = compressedMsg
+		}
+	}
 	return p.messageQueue.Push(ctx, msg)
 }
 
@@ -307,7 +338,24 @@ func (p *peer) StartSendPeerList() {
 }
 
 func (p *peer) StartClose() {
+	p.startCloseWithReason(DisconnectedEvent, ReasonClosedLocally, nil)
+}
+
+// startCloseWithReason begins shutting down the peer. If the peer isn't already
+// closing, the provided reason is recorded as the cause of the disconnect.
+func (p *peer) startCloseWithReason(eventType EventType, reason string, err error) {
 	p.startClosingOnce.Do(func() {
+		p.closeEvent = Event{
+			Time:   p.Clock.Time(),
+			Type:   eventType,
+			NodeID: p.id,
+			IP:     p.conn.RemoteAddr().String(),
+			Reason: reason,
+		}
+		if err != nil {
+			p.closeEvent.Error = err.Error()
+		}
+
 		if err := p.conn.Close(); err != nil {
 			p.Log.Debug("failed to close connection",
 				zap.Stringer("nodeID", p.id),
@@ -345,6 +393,9 @@ func (p *peer) close() {
 		return
 	}
 
+	if p.EventRecorder != nil {
+		p.EventRecorder.Record(p.closeEvent)
+	}
 	p.Network.Disconnected(p.id)
 	close(p.onClosed)
 }
@@ -379,6 +430,7 @@ func (p *peer) readMessages() {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.startCloseWithReason(DisconnectedEvent, ReasonReadFailed, err)
 			return
 		}
 
@@ -389,6 +441,7 @@ func (p *peer) readMessages() {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, err)
 			return
 		}
 
@@ -435,6 +488,7 @@ func (p *peer) readMessages() {
 				zap.Error(err),
 			)
 			onFinishedHandling()
+			p.startCloseWithReason(DisconnectedEvent, ReasonReadFailed, err)
 			return
 		}
 
@@ -505,6 +559,8 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.Signature,
 		p.MySubnets.List(),
+		p.CompressionTypes,
+		p.QUICEnabled,
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -531,6 +587,7 @@ func (p *peer) writeMessages() {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.startCloseWithReason(DisconnectedEvent, ReasonWriteFailed, err)
 			return
 		}
 
@@ -635,6 +692,7 @@ func (p *peer) sendNetworkMessages() {
 					zap.String("reason", "connection is no longer desired"),
 					zap.Stringer("nodeID", p.id),
 				)
+				p.startCloseWithReason(DisconnectedEvent, ReasonConnectionUndesired, nil)
 				return
 			}
 
@@ -646,6 +704,7 @@ func (p *peer) sendNetworkMessages() {
 						zap.Stringer("peerVersion", p.version),
 						zap.Error(err),
 					)
+					p.startCloseWithReason(DisconnectedEvent, ReasonIncompatibleVersion, err)
 					return
 				}
 			}
@@ -779,7 +838,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 			zap.Stringer("subnetID", constants.PrimaryNetworkID),
 			zap.Uint32("uptime", primaryUptime),
 		)
-		p.StartClose()
+		p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 		return
 	}
 	p.observeUptime(constants.PrimaryNetworkID, primaryUptime)
@@ -791,7 +850,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 			return
 		}
 
@@ -800,7 +859,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Stringer("subnetID", subnetID),
 			)
-			p.StartClose()
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 			return
 		}
 
@@ -811,7 +870,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("subnetID", subnetID),
 				zap.Uint32("uptime", uptime),
 			)
-			p.StartClose()
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 			return
 		}
 		p.observeUptime(subnetID, uptime)
@@ -843,7 +902,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Uint32("peerNetworkID", msg.NetworkId),
 			zap.Uint32("ourNetworkID", p.NetworkID),
 		)
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonNetworkIDMismatch, nil)
 		return
 	}
 
@@ -866,7 +925,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 				zap.Uint64("myTime", myTime),
 			)
 		}
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonClockSkew, nil)
 		return
 	}
 
@@ -876,7 +935,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonInvalidVersion, err)
 		return
 	}
 	p.version = peerVersion
@@ -901,7 +960,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("peerVersion", peerVersion),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonIncompatibleVersion, err)
 		return
 	}
 
@@ -913,7 +972,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Uint64("versionTime", msg.MyVersionTime),
 		)
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonVersionTimeInFuture, nil)
 		return
 	}
 
@@ -925,7 +984,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.startCloseWithReason(HandshakeRejectedEvent, ReasonInvalidTrackedSubnets, err)
 			return
 		}
 		// add only if we also track this subnet
@@ -942,7 +1001,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.String("field", "IP"),
 			zap.Int("ipLen", ipLen),
 		)
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonInvalidIP, nil)
 		return
 	}
 
@@ -961,10 +1020,23 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.startCloseWithReason(HandshakeRejectedEvent, ReasonInvalidIPSignature, err)
 		return
 	}
 
+	if len(p.CompressionTypes) > 0 {
+		negotiated, ok := negotiateCompression(
+			p.CompressionTypes,
+			p.MessageCreator.ZstdDictionaryID(),
+			msg.SupportedCompressionTypes,
+			msg.ZstdDictionaryId,
+		)
+		if ok {
+			p.compression.Set(&negotiated)
+		}
+	}
+
+	p.supportsQUIC = msg.SupportsQuic
 	p.gotVersion.Set(true)
 
 	peerIPs, err := p.Network.Peers(p.id)
@@ -1020,7 +1092,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "Cert"),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 			return
 		}
 
@@ -1032,7 +1104,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "IP"),
 				zap.Int("ipLen", ipLen),
 			)
-			p.StartClose()
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 			return
 		}
 
@@ -1044,7 +1116,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "txID"),
 				zap.Error(err),
 			)
-			p.StartClose()
+			p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 			return
 		}
 
@@ -1068,7 +1140,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 		return
 	}
 	if len(trackedPeers) == 0 {
@@ -1104,7 +1176,7 @@ func (p *peer) handlePeerListAck(msg *p2p.PeerListAck) {
 			zap.String("field", "txID"),
 			zap.Error(err),
 		)
-		p.StartClose()
+		p.startCloseWithReason(DisconnectedEvent, ReasonInvalidMessage, nil)
 	}
 }
 
@@ -6,6 +6,7 @@ package peer
 import (
 	"context"
 	"crypto"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -23,12 +24,14 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/version"
 )
 
@@ -54,6 +57,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 		"",
 		constants.DefaultNetworkCompressionType,
 		10*time.Second,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -262,6 +266,65 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendNegotiatedCompression(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+	rawPeer0.config.CompressionTypes = []compression.Type{compression.TypeSnappy, compression.TypeLZ4}
+	rawPeer1.config.CompressionTypes = []compression.Type{compression.TypeLZ4, compression.TypeSnappy}
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// Each peer compresses messages with its own preferred compression type.
+	require.Equal(
+		&negotiatedCompression{compressionType: compression.TypeSnappy},
+		peer0.(*peer).compression.Get(),
+	)
+	require.Equal(
+		&negotiatedCompression{compressionType: compression.TypeLZ4},
+		peer1.(*peer).compression.Get(),
+	)
+
+	mc := newMessageCreator(t)
+	outboundPutMsg, err := mc.Put(ids.Empty, 1, make([]byte, units.KiB), p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+
+	require.True(peer0.Send(context.Background(), outboundPutMsg))
+
+	inboundPutMsg := <-rawPeer1.inboundMsgChan
+	require.Equal(message.PutOp, inboundPutMsg.Op())
+	require.Positive(inboundPutMsg.BytesSavedCompression())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
@@ -389,3 +452,67 @@ func sendAndFlush(t *testing.T, sender *testPeer, receiver *testPeer) {
 	inboundGetMsg := <-receiver.inboundMsgChan
 	require.Equal(t, message.GetOp, inboundGetMsg.Op())
 }
+
+type eventRecorderFunc func(Event)
+
+func (f eventRecorderFunc) Record(event Event) {
+	f(event)
+}
+
+func TestHandshakeRejectedEvent(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+
+	var events []Event
+	rawPeer0.config.EventRecorder = eventRecorderFunc(func(event Event) {
+		events = append(events, event)
+	})
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+
+	// Drain the messages sent by peer0 so that it never blocks on writing.
+	go func() {
+		_, _ = io.Copy(io.Discard, rawPeer1.conn)
+	}()
+
+	signedIP, err := rawPeer1.config.IPSigner.GetSignedIP()
+	require.NoError(err)
+
+	versionMsg, err := rawPeer1.config.MessageCreator.Version(
+		constants.LocalID+1,
+		rawPeer1.config.Clock.Unix(),
+		signedIP.IPPort,
+		rawPeer1.config.VersionCompatibility.Version().String(),
+		signedIP.Timestamp,
+		signedIP.Signature,
+		nil,
+		nil,
+		false,
+	)
+	require.NoError(err)
+
+	msgBytes := versionMsg.Bytes()
+	msgLenBytes, err := writeMsgLen(uint32(len(msgBytes)), constants.DefaultMaxMessageSize)
+	require.NoError(err)
+	_, err = rawPeer1.conn.Write(append(msgLenBytes[:], msgBytes...))
+	require.NoError(err)
+
+	require.NoError(peer0.AwaitClosed(context.Background()))
+
+	require.Len(events, 1)
+	require.Equal(HandshakeRejectedEvent, events[0].Type)
+	require.Equal(ReasonNetworkIDMismatch, events[0].Reason)
+	require.Equal(rawPeer1.nodeID, events[0].NodeID)
+}
@@ -78,6 +78,7 @@ func StartTestPeer(
 		"",
 		constants.DefaultNetworkCompressionType,
 		10*time.Second,
+		nil,
 	)
 	if err != nil {
 		return nil, err
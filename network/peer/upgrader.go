@@ -15,10 +15,12 @@ import (
 )
 
 var (
-	errNoCert = errors.New("tls handshake finished with no peer certificate")
+	errNoCert          = errors.New("tls handshake finished with no peer certificate")
+	errNoTLSConnection = errors.New("connection didn't perform a tls handshake")
 
 	_ Upgrader = (*tlsServerUpgrader)(nil)
 	_ Upgrader = (*tlsClientUpgrader)(nil)
+	_ Upgrader = (*quicUpgrader)(nil)
 )
 
 type Upgrader interface {
@@ -58,12 +60,43 @@ func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staki
 	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
 }
 
+// tlsConn is implemented by connections whose TLS handshake is performed by
+// the transport itself, such as QUIC streams.
+type tlsConn interface {
+	net.Conn
+
+	ConnectionState() tls.ConnectionState
+}
+
+type quicUpgrader struct {
+	invalidCerts prometheus.Counter
+}
+
+// NewQUICUpgrader returns an Upgrader for connections that were established
+// over QUIC. Because QUIC performs the TLS handshake while establishing the
+// connection, the same Upgrader is used for inbound and outbound connections.
+func NewQUICUpgrader(invalidCerts prometheus.Counter) Upgrader {
+	return &quicUpgrader{
+		invalidCerts: invalidCerts,
+	}
+}
+
+func (q *quicUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	tlsConn, ok := conn.(tlsConn)
+	if !ok {
+		return ids.NodeID{}, nil, nil, errNoTLSConnection
+	}
+	return stateToIDAndCert(tlsConn, tlsConn.ConnectionState(), q.invalidCerts)
+}
+
 func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if err := conn.Handshake(); err != nil {
 		return ids.NodeID{}, nil, nil, err
 	}
+	return stateToIDAndCert(conn, conn.ConnectionState(), invalidCerts)
+}
 
-	state := conn.ConnectionState()
+func stateToIDAndCert(conn net.Conn, state tls.ConnectionState, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if len(state.PeerCertificates) == 0 {
 		return ids.NodeID{}, nil, nil, errNoCert
 	}
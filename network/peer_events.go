@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ peer.EventRecorder = (*peerEvents)(nil)
+
+// peerEvents keeps the most recent connection lifecycle events in memory so
+// that they can be inspected through the API.
+type peerEvents struct {
+	count *prometheus.CounterVec
+
+	lock   sync.RWMutex
+	events buffer.Queue[peer.Event]
+}
+
+func newPeerEvents(
+	namespace string,
+	registerer prometheus.Registerer,
+	maxSize int,
+) (*peerEvents, error) {
+	events, err := buffer.NewBoundedQueue[peer.Event](maxSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &peerEvents{
+		count: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_events",
+				Help:      "Number of connection failures, handshake rejections and disconnects by type and reason",
+			},
+			[]string{"type", "reason"},
+		),
+		events: events,
+	}
+	return p, registerer.Register(p.count)
+}
+
+func (p *peerEvents) Record(event peer.Event) {
+	p.count.WithLabelValues(string(event.Type), event.Reason).Inc()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.events.Push(event)
+}
+
+// List returns the recorded events, from oldest to newest. If [nodeIDs] is
+// non-empty, only events about the provided nodes are returned.
+func (p *peerEvents) List(nodeIDs []ids.NodeID) []peer.Event {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	events := p.events.List()
+	if len(nodeIDs) == 0 {
+		return events
+	}
+
+	filter := set.Of(nodeIDs...)
+	filtered := events[:0]
+	for _, event := range events {
+		if filter.Contains(event.NodeID) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+func TestPeerEvents(t *testing.T) {
+	require := require.New(t)
+
+	events, err := newPeerEvents("", prometheus.NewRegistry(), 2)
+	require.NoError(err)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	event0 := peer.Event{
+		Type:   peer.DialFailedEvent,
+		NodeID: nodeID0,
+		Reason: peer.ReasonConnectionFailed,
+	}
+	event1 := peer.Event{
+		Type:   peer.HandshakeRejectedEvent,
+		NodeID: nodeID1,
+		Reason: peer.ReasonClockSkew,
+	}
+	event2 := peer.Event{
+		Type:   peer.DisconnectedEvent,
+		NodeID: nodeID0,
+		Reason: peer.ReasonReadFailed,
+	}
+
+	events.Record(event0)
+	require.Equal([]peer.Event{event0}, events.List(nil))
+
+	// The oldest event should be evicted once the ring is full.
+	events.Record(event1)
+	events.Record(event2)
+	require.Equal([]peer.Event{event1, event2}, events.List(nil))
+	require.Equal([]peer.Event{event2}, events.List([]ids.NodeID{nodeID0}))
+	require.Equal([]peer.Event{event1}, events.List([]ids.NodeID{nodeID1}))
+	require.Empty(events.List([]ids.NodeID{ids.GenerateTestNodeID()}))
+}
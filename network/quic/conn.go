@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+var _ net.Conn = (*conn)(nil)
+
+// conn exposes a single bidirectional stream of a QUIC connection as a
+// [net.Conn].
+type conn struct {
+	conn   quic.Connection
+	stream quic.Stream
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	return c.stream.Read(b)
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	return c.stream.Write(b)
+}
+
+// Close tears down the whole QUIC connection, as only a single stream is ever
+// opened per connection.
+func (c *conn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
+
+func (c *conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	return c.stream.SetDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	return c.stream.SetReadDeadline(t)
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	return c.stream.SetWriteDeadline(t)
+}
+
+// ConnectionState returns the state of the TLS handshake performed while
+// establishing the QUIC connection.
+func (c *conn) ConnectionState() tls.ConnectionState {
+	return c.conn.ConnectionState().TLS
+}
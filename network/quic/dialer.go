@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"github.com/quic-go/quic-go"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var _ dialer.Dialer = (*quicDialer)(nil)
+
+type quicDialer struct {
+	log       logging.Logger
+	transport *quic.Transport
+	tlsConfig *tls.Config
+	config    *quic.Config
+	throttler throttling.DialThrottler
+}
+
+// NewDialer returns a new Dialer that establishes QUIC connections over
+// [transport] and opens a single stream on each of them.
+// [dialerConfig.ConnectionTimeout] gives the timeout of the QUIC handshake.
+// [dialerConfig.ThrottleRps] gives the max number of outgoing connection
+// attempts/second. If [dialerConfig.ThrottleRps] == 0, outgoing connections
+// aren't rate-limited.
+func NewDialer(
+	log logging.Logger,
+	transport *quic.Transport,
+	tlsConfig *tls.Config,
+	dialerConfig dialer.Config,
+) dialer.Dialer {
+	var throttler throttling.DialThrottler
+	if dialerConfig.ThrottleRps <= 0 {
+		throttler = throttling.NewNoDialThrottler()
+	} else {
+		throttler = throttling.NewDialThrottler(int(dialerConfig.ThrottleRps))
+	}
+	return &quicDialer{
+		log:       log,
+		transport: transport,
+		tlsConfig: TLSConfig(tlsConfig),
+		config:    newConfig(dialerConfig.ConnectionTimeout),
+		throttler: throttler,
+	}
+}
+
+func (d *quicDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	if err := d.throttler.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	d.log.Verbo("dialing over QUIC",
+		zap.Stringer("ip", ip),
+	)
+
+	addr := &net.UDPAddr{
+		IP:   ip.IP,
+		Port: int(ip.Port),
+	}
+	quicConn, err := d.transport.Dial(ctx, addr, d.tlsConfig, d.config)
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+	}
+
+	stream, err := quicConn.OpenStreamSync(ctx)
+	if err != nil {
+		_ = quicConn.CloseWithError(0, "")
+		return nil, fmt.Errorf("error while opening stream to %s: %w", ip, err)
+	}
+	return &conn{
+		conn:   quicConn,
+		stream: stream,
+	}, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	_ net.Listener = (*listener)(nil)
+
+	errClosed = errors.New("listener closed")
+)
+
+type listener struct {
+	log              logging.Logger
+	listener         *quic.Listener
+	handshakeTimeout time.Duration
+
+	// [ctx] is cancelled when Close() is called
+	ctx           context.Context
+	ctxCancelFunc func()
+
+	conns chan net.Conn
+}
+
+// NewListener returns a net.Listener that accepts QUIC connections on
+// [transport]. A connection is only returned by Accept once the remote peer
+// has opened its stream, which must happen within [handshakeTimeout].
+func NewListener(
+	log logging.Logger,
+	transport *quic.Transport,
+	tlsConfig *tls.Config,
+	handshakeTimeout time.Duration,
+) (net.Listener, error) {
+	quicListener, err := transport.Listen(TLSConfig(tlsConfig), newConfig(handshakeTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &listener{
+		log:              log,
+		listener:         quicListener,
+		handshakeTimeout: handshakeTimeout,
+		ctx:              ctx,
+		ctxCancelFunc:    cancel,
+		conns:            make(chan net.Conn),
+	}
+	go l.acceptConnections()
+	return l, nil
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.ctx.Done():
+		return nil, errClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.ctxCancelFunc()
+	return l.listener.Close()
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+func (l *listener) acceptConnections() {
+	for {
+		quicConn, err := l.listener.Accept(l.ctx)
+		if err != nil {
+			l.log.Debug("stopped accepting QUIC connections",
+				zap.Error(err),
+			)
+			return
+		}
+
+		// Accepting the stream is done asynchronously so that a peer that
+		// never opens its stream can't stall other inbound connections.
+		go l.acceptStream(quicConn)
+	}
+}
+
+func (l *listener) acceptStream(quicConn quic.Connection) {
+	ctx, cancel := context.WithTimeout(l.ctx, l.handshakeTimeout)
+	defer cancel()
+
+	stream, err := quicConn.AcceptStream(ctx)
+	if err != nil {
+		l.log.Verbo("failed to accept QUIC stream",
+			zap.Stringer("peerIP", quicConn.RemoteAddr()),
+			zap.Error(err),
+		)
+		_ = quicConn.CloseWithError(0, "")
+		return
+	}
+
+	c := &conn{
+		conn:   quicConn,
+		stream: stream,
+	}
+	select {
+	case l.conns <- c:
+	case <-l.ctx.Done():
+		_ = c.Close()
+	}
+}
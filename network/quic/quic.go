@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package quic provides a QUIC based transport for peer connections.
+//
+// Each peer connection is carried over a single bidirectional QUIC stream, so
+// the message framing is identical to the TCP transport. Peers are
+// authenticated with the same staking certificate during the QUIC handshake.
+package quic
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+// NextProto is the ALPN protocol ID that peers negotiate over QUIC.
+const NextProto = "avalanche"
+
+// keepAlivePeriod is sufficiently below the default QUIC idle timeout to keep
+// connections open even if no application messages are sent.
+const keepAlivePeriod = 10 * time.Second
+
+// TLSConfig returns a copy of [config] that can be used to establish QUIC
+// connections.
+func TLSConfig(config *tls.Config) *tls.Config {
+	config = config.Clone()
+	config.NextProtos = []string{NextProto}
+	return config
+}
+
+func newConfig(handshakeTimeout time.Duration) *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout: handshakeTimeout,
+		KeepAlivePeriod:      keepAlivePeriod,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTransport(t *testing.T) *quic.Transport {
+	require := require.New(t)
+
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+
+	transport := &quic.Transport{Conn: udpConn}
+	t.Cleanup(func() {
+		require.NoError(transport.Close())
+	})
+	return transport
+}
+
+func newTLSConfig(t *testing.T) *tls.Config {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+	return peer.TLSConfig(*tlsCert, nil)
+}
+
+func TestDialAndAccept(t *testing.T) {
+	require := require.New(t)
+
+	listenerTLSConfig := newTLSConfig(t)
+	listener, err := NewListener(logging.NoLog{}, newTransport(t), listenerTLSConfig, time.Second)
+	require.NoError(err)
+	defer listener.Close()
+
+	dialerTLSConfig := newTLSConfig(t)
+	d := NewDialer(logging.NoLog{}, newTransport(t), dialerTLSConfig, dialer.Config{
+		ConnectionTimeout: time.Second,
+	})
+
+	ip, err := ips.ToIPPort(listener.Addr().String())
+	require.NoError(err)
+
+	dialedConn, err := d.Dial(context.Background(), ip)
+	require.NoError(err)
+	defer dialedConn.Close()
+
+	// The listener only returns the connection once the stream has been
+	// opened, which happens when the first bytes are sent.
+	msg := []byte("hello")
+	_, err = dialedConn.Write(msg)
+	require.NoError(err)
+
+	acceptedConn, err := listener.Accept()
+	require.NoError(err)
+	defer acceptedConn.Close()
+
+	received := make([]byte, len(msg))
+	_, err = io.ReadFull(acceptedConn, received)
+	require.NoError(err)
+	require.Equal(msg, received)
+
+	// Both sides must be able to authenticate the other with the upgrader used
+	// for QUIC connections.
+	upgrader := peer.NewQUICUpgrader(prometheus.NewCounter(prometheus.CounterOpts{}))
+
+	_, _, acceptedCert, err := upgrader.Upgrade(acceptedConn)
+	require.NoError(err)
+	require.Equal(dialerTLSConfig.Certificates[0].Leaf.Raw, acceptedCert.Raw)
+
+	_, _, dialedCert, err := upgrader.Upgrade(dialedConn)
+	require.NoError(err)
+	require.Equal(listenerTLSConfig.Certificates[0].Leaf.Raw, dialedCert.Raw)
+}
+
+func TestListenerClose(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := NewListener(logging.NoLog{}, newTransport(t), newTLSConfig(t), time.Second)
+	require.NoError(err)
+	require.NoError(listener.Close())
+
+	_, err = listener.Accept()
+	require.ErrorIs(err, errClosed)
+}
@@ -85,6 +85,7 @@ func NewTestNetwork(
 		"",
 		constants.DefaultNetworkCompressionType,
 		constants.DefaultNetworkMaximumInboundTimeout,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -157,6 +158,8 @@ func NewTestNetwork(
 			PeerListNonValidatorGossipSize: constants.DefaultNetworkPeerListNonValidatorGossipSize,
 			PeerListPeersGossipSize:        constants.DefaultNetworkPeerListPeersGossipSize,
 			PeerListGossipFreq:             constants.DefaultNetworkPeerListGossipFreq,
+			PeerListMaxPeersPerIPPrefix:    constants.DefaultNetworkPeerListMaxPeersPerIPPrefix,
+			PeerListMinSubnetPeers:         constants.DefaultNetworkPeerListMinSubnetPeers,
 		},
 
 		DelayConfig: DelayConfig{
@@ -166,6 +169,7 @@ func NewTestNetwork(
 
 		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
 		CompressionType:              constants.DefaultNetworkCompressionType,
+		CompressionTypes:             constants.DefaultNetworkCompressionTypes,
 		PingFrequency:                constants.DefaultPingFrequency,
 		AllowPrivateIPs:              !constants.ProductionNetworkIDs.Contains(networkID),
 		UptimeMetricFreq:             constants.DefaultUptimeMetricFreq,
@@ -174,6 +178,7 @@ func NewTestNetwork(
 		RequireValidatorToConnect: constants.DefaultNetworkRequireValidatorToConnect,
 		PeerReadBufferSize:        constants.DefaultNetworkPeerReadBufferSize,
 		PeerWriteBufferSize:       constants.DefaultNetworkPeerWriteBufferSize,
+		PeerEventsSize:            constants.DefaultNetworkPeerEventsSize,
 	}
 
 	networkConfig.NetworkID = networkID
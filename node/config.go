@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/hooks"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
@@ -25,6 +26,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
 )
 
 type IPCConfig struct {
@@ -235,6 +238,19 @@ type Config struct {
 	// write arbitrary data.
 	ChainDataDir string `json:"chainDataDir"`
 
+	// SnowmanRecordChains are the chains whose snowman engine messages are
+	// written to [SnowmanRecordDir] so that they can be replayed.
+	SnowmanRecordChains set.Set[ids.ID] `json:"snowmanRecordChains"`
+	SnowmanRecordDir    string          `json:"snowmanRecordDir"`
+
+	// AdaptivePollsConfig optionally adjusts snowman polling based on the
+	// observed query latency.
+	AdaptivePollsConfig smeng.AdaptivePollsConfig `json:"adaptivePollsConfig"`
+
+	// AcceptorHooksConfig configures the delivery of accepted and rejected
+	// blocks to the subscribed acceptor hooks.
+	AcceptorHooksConfig hooks.Config `json:"acceptorHooksConfig"`
+
 	// Path to write process context to (including PID, API URI, and
 	// staking address).
 	ProcessContextFilePath string `json:"processContextFilePath"`
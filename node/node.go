@@ -23,6 +23,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	quicgo "github.com/quic-go/quic-go"
+
 	"go.uber.org/zap"
 
 	coreth "github.com/ava-labs/coreth/plugin/evm"
@@ -43,6 +45,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/pebble"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/hooks"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/indexer"
 	"github.com/ava-labs/avalanchego/ipcs"
@@ -50,6 +53,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -93,8 +97,9 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix       = []byte{0x00}
+	keystoreDBPrefix      = []byte("keystore")
+	acceptorHooksDBPrefix = []byte("acceptorHooks")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -147,6 +152,10 @@ type Node struct {
 	TxAcceptorGroup     snow.AcceptorGroup
 	VertexAcceptorGroup snow.AcceptorGroup
 
+	// AcceptorHooks delivers the blocks accepted and rejected by each chain to
+	// the subscribed hooks.
+	AcceptorHooks hooks.Registry
+
 	IPCs *ipcs.ChainIPCs
 
 	// Net runs the networking stack
@@ -162,6 +171,10 @@ type Node struct {
 	// session keys. This value should only be non-nil during debugging.
 	tlsKeyLogWriterCloser io.WriteCloser
 
+	// quicTransport owns the UDP socket used for QUIC peer connections. This
+	// value is only non-nil if QUIC is enabled.
+	quicTransport *quicgo.Transport
+
 	// this node's initial connections to the network
 	bootstrappers validators.Manager
 
@@ -290,6 +303,32 @@ func (n *Node) initNetworking() error {
 
 	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
 
+	if n.Config.NetworkConfig.QUICEnabled {
+		// QUIC connections are accepted on the same port as TCP connections so
+		// that peers can reach us over either transport with the same IP.
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		if err != nil {
+			return err
+		}
+		udpConn, err := net.ListenPacket("udp", net.JoinHostPort(n.Config.ListenHost, port))
+		if err != nil {
+			return err
+		}
+		n.quicTransport = &quicgo.Transport{Conn: udpConn}
+
+		quicListener, err := quic.NewListener(
+			n.Log,
+			n.quicTransport,
+			tlsConfig,
+			n.Config.NetworkConfig.ReadHandshakeTimeout,
+		)
+		if err != nil {
+			return err
+		}
+		n.Config.NetworkConfig.QUICListener = throttling.NewThrottledListener(quicListener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
+		n.Config.NetworkConfig.QUICDialer = quic.NewDialer(n.Log, n.quicTransport, tlsConfig, n.Config.NetworkConfig.DialerConfig)
+	}
+
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
@@ -469,6 +508,14 @@ func (n *Node) Dispatch() error {
 	// If node is already shutting down, this does nothing.
 	n.Shutdown(1)
 
+	if n.quicTransport != nil {
+		if err := n.quicTransport.Close(); err != nil {
+			n.Log.Error("closing QUIC transport failed",
+				zap.Error(err),
+			)
+		}
+	}
+
 	if n.tlsKeyLogWriterCloser != nil {
 		err := n.tlsKeyLogWriterCloser.Close()
 		if err != nil {
@@ -681,6 +728,20 @@ func (n *Node) initMetrics() {
 	n.MetricsGatherer = metrics.NewMultiGatherer()
 }
 
+// Initialize [n.AcceptorHooks].
+// Should only be called after [n.DB] and [n.BlockAcceptorGroup] are
+// initialized, and before any chain is created.
+func (n *Node) initAcceptorHooks() error {
+	var err error
+	n.AcceptorHooks, err = hooks.NewRegistry(
+		n.Log,
+		prefixdb.New(acceptorHooksDBPrefix, n.DB),
+		n.BlockAcceptorGroup,
+		n.Config.AcceptorHooksConfig,
+	)
+	return err
+}
+
 // initAPIServer initializes the server that handles HTTP calls
 func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
@@ -872,6 +933,9 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
 		ChainDataDir:                            n.Config.ChainDataDir,
+		SnowmanRecordChains:                     n.Config.SnowmanRecordChains,
+		SnowmanRecordDir:                        n.Config.SnowmanRecordDir,
+		AdaptivePollsConfig:                     n.Config.AdaptivePollsConfig,
 	})
 
 	// Notify the API server when new chains are created
@@ -931,6 +995,8 @@ func (n *Node) initVMs() error {
 				BanffTime:                     version.GetBanffTime(n.Config.NetworkID),
 				CortinaTime:                   version.GetCortinaTime(n.Config.NetworkID),
 				DTime:                         version.GetDTime(n.Config.NetworkID),
+				ETime:                         version.GetETime(n.Config.NetworkID),
+				DynamicFeeConfig:              n.Config.DynamicFeeConfig,
 				UseCurrentHeight:              n.Config.UseCurrentHeight,
 			},
 		}),
@@ -1417,6 +1483,7 @@ func (n *Node) Initialize(
 		n.networkNamespace,
 		n.Config.NetworkConfig.CompressionType,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
+		n.Config.NetworkConfig.ZstdDictionary,
 	)
 	if err != nil {
 		return fmt.Errorf("problem initializing message creator: %w", err)
@@ -1473,6 +1540,9 @@ func (n *Node) Initialize(
 	if err := n.initIndexer(); err != nil {
 		return fmt.Errorf("couldn't initialize indexer: %w", err)
 	}
+	if err := n.initAcceptorHooks(); err != nil {
+		return fmt.Errorf("couldn't initialize acceptor hooks: %w", err)
+	}
 
 	n.health.Start(context.TODO(), n.Config.HealthCheckFreq)
 	n.initProfiler()
@@ -1528,6 +1598,11 @@ func (n *Node) shutdown() {
 		}
 	}
 	n.timeoutManager.Stop()
+	// Acceptor hooks stop delivering events before the chains are shut down.
+	// Events accepted while the chains shut down are still recorded.
+	if n.AcceptorHooks != nil {
+		n.AcceptorHooks.Shutdown()
+	}
 	if n.chainManager != nil {
 		n.chainManager.Shutdown()
 	}
@@ -32,6 +32,15 @@ type Acceptor interface {
 	Accept(ctx *ConsensusContext, containerID ids.ID, container []byte) error
 }
 
+// Rejector is implemented when a struct is monitoring if a message is rejected
+type Rejector interface {
+	// Reject is called after [containerID] has been rejected.
+	//
+	// If the returned error is non-nil, the chain associated with [ctx] should
+	// shut down.
+	Reject(ctx *ConsensusContext, containerID ids.ID, container []byte) error
+}
+
 type noOpAcceptor struct{}
 
 func (noOpAcceptor) Accept(*ConsensusContext, ids.ID, []byte) error {
@@ -78,6 +87,10 @@ type AcceptorGroup interface {
 	// chain.
 	Acceptor
 
+	// Calling Reject() calls all of the registered acceptors for the relevant
+	// chain that implement Rejector.
+	Rejector
+
 	// RegisterAcceptor causes [acceptor] to be called every time an operation
 	// is accepted on chain [chainID].
 	// If [dieOnError], chain [chainID] stops if Accept returns a non-nil error.
@@ -122,6 +135,30 @@ func (a *acceptorGroup) Accept(ctx *ConsensusContext, containerID ids.ID, contai
 	return nil
 }
 
+func (a *acceptorGroup) Reject(ctx *ConsensusContext, containerID ids.ID, container []byte) error {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	for acceptorName, acceptor := range a.acceptors[ctx.ChainID] {
+		rejector, ok := acceptor.Acceptor.(Rejector)
+		if !ok {
+			continue
+		}
+		if err := rejector.Reject(ctx, containerID, container); err != nil {
+			a.log.Error("failed rejecting container",
+				zap.String("acceptorName", acceptorName),
+				zap.Stringer("chainID", ctx.ChainID),
+				zap.Stringer("containerID", containerID),
+				zap.Error(err),
+			)
+			if acceptor.dieOnError {
+				return fmt.Errorf("acceptor %s on chain %s erred while rejecting %s: %w", acceptorName, ctx.ChainID, containerID, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (a *acceptorGroup) RegisterAcceptor(chainID ids.ID, acceptorName string, acceptor Acceptor, dieOnError bool) error {
 	a.lock.Lock()
 	defer a.lock.Unlock()
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/simulation"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+func main() {
+	var (
+		config = simulation.Config{
+			Params: snowball.DefaultParameters,
+		}
+		numBuckets int
+	)
+	cmd := &cobra.Command{
+		Use:   "simulation",
+		Short: "Simulates snowball consensus between virtual validators to measure finality time and safety failures",
+		Args:  cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			return simulate(config, numBuckets)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&config.NumNodes, "nodes", 100, "Number of validators")
+	flags.Float64Var(&config.ByzantineFraction, "byzantine-fraction", 0, "Fraction of validators that vote against the querier's preference")
+	flags.IntVar(&config.NumColors, "colors", 2, "Number of conflicting colors")
+	flags.IntVar(&config.Runs, "runs", 100, "Number of independent runs")
+	flags.DurationVar(&config.MaxTime, "max-time", time.Minute, "Virtual time after which a run is stopped")
+	flags.Int64Var(&config.Seed, "seed", 0, "Seed of the first run")
+	flags.IntVar(&numBuckets, "buckets", 20, "Number of histogram buckets")
+
+	params := &config.Params
+	flags.IntVar(&params.K, "k", params.K, "Sample size")
+	flags.IntVar(&params.AlphaPreference, "alpha-preference", params.AlphaPreference, "Quorum size to change preference")
+	flags.IntVar(&params.AlphaConfidence, "alpha-confidence", params.AlphaConfidence, "Quorum size to increase confidence")
+	flags.IntVar(&params.BetaVirtuous, "beta-virtuous", params.BetaVirtuous, "Consecutive successful polls to finalize a virtuous color")
+	flags.IntVar(&params.BetaRogue, "beta-rogue", params.BetaRogue, "Consecutive successful polls to finalize a rogue color")
+	flags.IntVar(&params.ConcurrentRepolls, "concurrent-repolls", params.ConcurrentRepolls, "Number of outstanding polls per validator")
+
+	latency := &config.Latency
+	flags.StringVar(&latency.Distribution, "latency-distribution", simulation.NormalLatency, fmt.Sprintf(
+		"Message latency distribution. One of %q, %q, %q or %q",
+		simulation.ConstantLatency,
+		simulation.UniformLatency,
+		simulation.NormalLatency,
+		simulation.ExponentialLatency,
+	))
+	flags.DurationVar(&latency.Min, "latency-min", 10*time.Millisecond, "Minimum message latency")
+	flags.DurationVar(&latency.Max, "latency-max", 200*time.Millisecond, "Maximum message latency of the uniform distribution")
+	flags.DurationVar(&latency.Mean, "latency-mean", 50*time.Millisecond, "Mean message latency")
+	flags.DurationVar(&latency.StdDev, "latency-std-dev", 20*time.Millisecond, "Standard deviation of the normal distribution")
+
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func simulate(config simulation.Config, numBuckets int) error {
+	result, err := simulation.Run(config)
+	if err != nil {
+		return err
+	}
+
+	numHonest := len(result.FinalityTimes) + result.Unfinalized
+	fmt.Printf("runs: %d\n", result.Runs)
+	fmt.Printf("safety failures: %d (%.4f%%)\n", result.SafetyFailures, 100*float64(result.SafetyFailures)/float64(result.Runs))
+	fmt.Printf("unfinalized nodes: %d (%.4f%%)\n", result.Unfinalized, 100*float64(result.Unfinalized)/float64(numHonest))
+	if len(result.FinalityTimes) == 0 {
+		return nil
+	}
+
+	times := make([]float64, len(result.FinalityTimes))
+	for i, t := range result.FinalityTimes {
+		times[i] = float64(t)
+	}
+	polls := make([]float64, len(result.FinalityPolls))
+	for i, p := range result.FinalityPolls {
+		polls[i] = float64(p)
+	}
+
+	formatDuration := func(f float64) string {
+		return time.Duration(f).Round(time.Millisecond).String()
+	}
+	formatCount := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 1, 64)
+	}
+
+	fmt.Printf(
+		"\nfinality time: p50 %s, p90 %s, p99 %s\n",
+		formatDuration(simulation.Percentile(times, .5)),
+		formatDuration(simulation.Percentile(times, .9)),
+		formatDuration(simulation.Percentile(times, .99)),
+	)
+	fmt.Print(simulation.FormatHistogram(simulation.Histogram(times, numBuckets), formatDuration))
+
+	fmt.Printf(
+		"\npolls to finality: p50 %s, p90 %s, p99 %s\n",
+		formatCount(simulation.Percentile(polls, .5)),
+		formatCount(simulation.Percentile(polls, .9)),
+		formatCount(simulation.Percentile(polls, .99)),
+	)
+	fmt.Print(simulation.FormatHistogram(simulation.Histogram(polls, numBuckets), formatCount))
+	return nil
+}
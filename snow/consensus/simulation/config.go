@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+const (
+	ConstantLatency    = "constant"
+	UniformLatency     = "uniform"
+	NormalLatency      = "normal"
+	ExponentialLatency = "exponential"
+)
+
+var (
+	errNoNodes                    = errors.New("at least one node is required")
+	errInvalidByzantineFraction   = errors.New("byzantine fraction must be in [0, 1)")
+	errTooFewColors               = errors.New("at least one color is required")
+	errNoRuns                     = errors.New("at least one run is required")
+	errNonPositiveMaxTime         = errors.New("max time must be positive")
+	errUnknownLatencyDistribution = errors.New("unknown latency distribution")
+	errInvalidLatencyRange        = errors.New("latency min can't be greater than latency max")
+	errNegativeLatency            = errors.New("latency can't be negative")
+)
+
+// LatencyConfig describes the distribution of the one-way latency of every
+// message sent between two nodes.
+type LatencyConfig struct {
+	// Distribution is one of [ConstantLatency], [UniformLatency],
+	// [NormalLatency] or [ExponentialLatency].
+	Distribution string `json:"distribution"`
+	// Min is the minimum latency of the uniform, normal and exponential
+	// distributions.
+	Min time.Duration `json:"min"`
+	// Max is the maximum latency of the uniform distribution.
+	Max time.Duration `json:"max"`
+	// Mean is the latency of the constant distribution and the mean latency
+	// of the normal and exponential distributions.
+	Mean time.Duration `json:"mean"`
+	// StdDev is the standard deviation of the normal distribution.
+	StdDev time.Duration `json:"stdDev"`
+}
+
+func (c LatencyConfig) verify() error {
+	switch {
+	case c.Min < 0 || c.Max < 0 || c.Mean < 0 || c.StdDev < 0:
+		return errNegativeLatency
+	case c.Distribution == UniformLatency && c.Min > c.Max:
+		return fmt.Errorf("%w: %s > %s", errInvalidLatencyRange, c.Min, c.Max)
+	}
+	switch c.Distribution {
+	case ConstantLatency, UniformLatency, NormalLatency, ExponentialLatency:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownLatencyDistribution, c.Distribution)
+	}
+}
+
+// sample returns a latency drawn from the distribution.
+func (c LatencyConfig) sample(rng *rand.Rand) time.Duration {
+	switch c.Distribution {
+	case UniformLatency:
+		if c.Max == c.Min {
+			return c.Min
+		}
+		return c.Min + time.Duration(rng.Int63n(int64(c.Max-c.Min)+1))
+	case NormalLatency:
+		latency := time.Duration(rng.NormFloat64()*float64(c.StdDev)) + c.Mean
+		if latency < c.Min {
+			return c.Min
+		}
+		return latency
+	case ExponentialLatency:
+		mean := float64(c.Mean - c.Min)
+		return c.Min + time.Duration(math.Max(rng.ExpFloat64()*mean, 0))
+	default:
+		return c.Mean
+	}
+}
+
+type Config struct {
+	// NumNodes is the number of equally weighted validators to simulate.
+	NumNodes int `json:"numNodes"`
+	// ByzantineFraction is the fraction of [NumNodes] that respond to every
+	// query with a color other than the querier's preference. Byzantine nodes
+	// don't issue queries.
+	ByzantineFraction float64 `json:"byzantineFraction"`
+	// NumColors is the number of conflicting colors. Every honest node
+	// initially prefers a random color.
+	NumColors int `json:"numColors"`
+	// Params are the consensus parameters used by every honest node.
+	Params snowball.Parameters `json:"params"`
+	// Latency is the distribution of the one-way latency of every message.
+	Latency LatencyConfig `json:"latency"`
+	// Runs is the number of independent simulations to run.
+	Runs int `json:"runs"`
+	// MaxTime is the amount of virtual time after which a run is stopped,
+	// even if not every honest node has finalized.
+	MaxTime time.Duration `json:"maxTime"`
+	// Seed initializes the randomness of the first run. Every subsequent run
+	// uses the next seed.
+	Seed int64 `json:"seed"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.NumNodes <= 0:
+		return errNoNodes
+	case c.ByzantineFraction < 0 || c.ByzantineFraction >= 1:
+		return fmt.Errorf("%w: %f", errInvalidByzantineFraction, c.ByzantineFraction)
+	case c.NumColors <= 0:
+		return errTooFewColors
+	case c.Runs <= 0:
+		return errNoRuns
+	case c.MaxTime <= 0:
+		return errNonPositiveMaxTime
+	}
+	if err := c.Params.Verify(); err != nil {
+		return err
+	}
+	return c.Latency.verify()
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// histogramBarWidth is the number of characters of the largest bucket's bar.
+const histogramBarWidth = 50
+
+type Bucket struct {
+	// Lower is the inclusive lower bound of the bucket.
+	Lower float64
+	// Upper is the exclusive upper bound of the bucket. The last bucket of a
+	// histogram includes its upper bound.
+	Upper float64
+	Count int
+}
+
+// Histogram partitions [values] into [numBuckets] equally sized buckets
+// spanning the smallest and largest value.
+func Histogram(values []float64, numBuckets int) []Bucket {
+	if len(values) == 0 || numBuckets <= 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	minValue := sorted[0]
+	maxValue := sorted[len(sorted)-1]
+	if minValue == maxValue {
+		return []Bucket{{
+			Lower: minValue,
+			Upper: maxValue,
+			Count: len(values),
+		}}
+	}
+
+	width := (maxValue - minValue) / float64(numBuckets)
+	buckets := make([]Bucket, numBuckets)
+	for i := range buckets {
+		buckets[i].Lower = minValue + float64(i)*width
+		buckets[i].Upper = minValue + float64(i+1)*width
+	}
+	buckets[numBuckets-1].Upper = maxValue
+
+	for _, value := range sorted {
+		index := int((value - minValue) / width)
+		if index >= numBuckets {
+			index = numBuckets - 1
+		}
+		buckets[index].Count++
+	}
+	return buckets
+}
+
+// Percentile returns the [percentile], in [0, 1], of [values] using the
+// nearest-rank method.
+func Percentile(values []float64, percentile float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	index := int(math.Ceil(percentile*float64(len(sorted)))) - 1
+	switch {
+	case index < 0:
+		index = 0
+	case index >= len(sorted):
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// FormatHistogram renders [buckets] as rows of text bars, formatting the
+// bucket bounds with [formatBound].
+func FormatHistogram(buckets []Bucket, formatBound func(float64) string) string {
+	maxCount := 0
+	for _, bucket := range buckets {
+		if bucket.Count > maxCount {
+			maxCount = bucket.Count
+		}
+	}
+
+	sb := strings.Builder{}
+	for _, bucket := range buckets {
+		barLength := 0
+		if maxCount > 0 {
+			barLength = bucket.Count * histogramBarWidth / maxCount
+		}
+		fmt.Fprintf(
+			&sb,
+			"[%10s, %10s] %-*s %d\n",
+			formatBound(bucket.Lower),
+			formatBound(bucket.Upper),
+			histogramBarWidth,
+			strings.Repeat("#", barLength),
+			bucket.Count,
+		)
+	}
+	return sb.String()
+}
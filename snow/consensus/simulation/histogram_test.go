@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		numBuckets int
+		expected   []Bucket
+	}{
+		{
+			name:       "no values",
+			values:     nil,
+			numBuckets: 2,
+			expected:   nil,
+		},
+		{
+			name:       "no buckets",
+			values:     []float64{1},
+			numBuckets: 0,
+			expected:   nil,
+		},
+		{
+			name:       "single value",
+			values:     []float64{3, 3, 3},
+			numBuckets: 2,
+			expected: []Bucket{
+				{Lower: 3, Upper: 3, Count: 3},
+			},
+		},
+		{
+			name:       "multiple buckets",
+			values:     []float64{4, 0, 1, 2, 3, 4},
+			numBuckets: 2,
+			expected: []Bucket{
+				{Lower: 0, Upper: 2, Count: 2},
+				{Lower: 2, Upper: 4, Count: 4},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, Histogram(test.values, test.numBuckets))
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{5, 1, 4, 2, 3}
+	tests := []struct {
+		percentile float64
+		expected   float64
+	}{
+		{percentile: 0, expected: 1},
+		{percentile: .5, expected: 3},
+		{percentile: .9, expected: 5},
+		{percentile: 1, expected: 5},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, Percentile(values, test.percentile))
+	}
+	require.Zero(t, Percentile(nil, .5))
+}
+
+func TestFormatHistogram(t *testing.T) {
+	require := require.New(t)
+
+	buckets := []Bucket{
+		{Lower: 0, Upper: 1, Count: 1},
+		{Lower: 1, Upper: 2, Count: 2},
+	}
+	formatted := FormatHistogram(buckets, func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 0, 64)
+	})
+
+	lines := strings.Split(strings.TrimSuffix(formatted, "\n"), "\n")
+	require.Len(lines, 2)
+	require.Equal(histogramBarWidth/2, strings.Count(lines[0], "#"))
+	require.Equal(histogramBarWidth, strings.Count(lines[1], "#"))
+	require.True(strings.HasSuffix(lines[0], " 1"))
+	require.True(strings.HasSuffix(lines[1], " 2"))
+}
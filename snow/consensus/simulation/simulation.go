@@ -0,0 +1,306 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/heap"
+)
+
+// byzantineColor is voted for by byzantine nodes when there is no color other
+// than the querier's preference to vote for.
+var byzantineColor = ids.Empty.Prefix(0).Prefix(0)
+
+type eventType uint8
+
+const (
+	// queryEvent is a query arriving at the queried node.
+	queryEvent eventType = iota
+	// chitsEvent is a response arriving at the querier.
+	chitsEvent
+)
+
+type event struct {
+	time time.Duration
+	// seq breaks ties between events that happen at the same time so that
+	// runs are deterministic.
+	seq       uint64
+	eventType eventType
+	from      int
+	to        int
+	requestID uint32
+	color     ids.ID
+}
+
+type node struct {
+	nodeID    ids.NodeID
+	byzantine bool
+
+	consensus     snowball.Consensus
+	requestID     uint32
+	polls         map[uint32]poll.Poll
+	numPolls      int
+	finalized     bool
+	finalizedTime time.Duration
+}
+
+// Result aggregates the outcome of every run of a simulation.
+type Result struct {
+	Runs int
+	// FinalityTimes is the virtual time at which each honest node finalized,
+	// across every run.
+	FinalityTimes []time.Duration
+	// FinalityPolls is the number of polls each honest node completed before
+	// finalizing, across every run.
+	FinalityPolls []int
+	// SafetyFailures is the number of runs in which two honest nodes
+	// finalized different colors.
+	SafetyFailures int
+	// Unfinalized is the number of honest nodes, across every run, that
+	// hadn't finalized by the max time of their run.
+	Unfinalized int
+}
+
+// Run runs the simulations described by [config].
+func Run(config Config) (*Result, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Runs: config.Runs,
+	}
+	for i := 0; i < config.Runs; i++ {
+		s := newSimulation(config, config.Seed+int64(i))
+		s.run()
+		s.record(result)
+	}
+	return result, nil
+}
+
+type simulation struct {
+	config  Config
+	rng     *rand.Rand
+	factory poll.Factory
+	colors  []ids.ID
+	nodes   []*node
+	// nodeIndices maps each nodeID to its index in [nodes].
+	nodeIndices map[ids.NodeID]int
+
+	now    time.Duration
+	seq    uint64
+	events heap.Queue[*event]
+}
+
+func newSimulation(config Config, seed int64) *simulation {
+	s := &simulation{
+		config:      config,
+		rng:         rand.New(rand.NewSource(seed)), //#nosec G404
+		factory:     poll.NewEarlyTermNoTraversalFactory(config.Params.AlphaPreference, config.Params.AlphaConfidence),
+		colors:      make([]ids.ID, config.NumColors),
+		nodes:       make([]*node, config.NumNodes),
+		nodeIndices: make(map[ids.NodeID]int, config.NumNodes),
+		events: heap.NewQueue(func(a, b *event) bool {
+			if a.time != b.time {
+				return a.time < b.time
+			}
+			return a.seq < b.seq
+		}),
+	}
+	for i := range s.colors {
+		s.colors[i] = ids.Empty.Prefix(uint64(i + 1))
+	}
+
+	numByzantine := int(config.ByzantineFraction * float64(config.NumNodes))
+	for i := range s.nodes {
+		var nodeID ids.NodeID
+		binary.BigEndian.PutUint64(nodeID[:], uint64(i))
+		n := &node{
+			nodeID:    nodeID,
+			byzantine: i < numByzantine,
+			polls:     make(map[uint32]poll.Poll),
+		}
+		if !n.byzantine {
+			preference := s.rng.Intn(len(s.colors))
+			n.consensus = snowball.NewTree(config.Params, s.colors[preference])
+			for j, color := range s.colors {
+				if j != preference {
+					n.consensus.Add(color)
+				}
+			}
+		}
+		s.nodes[i] = n
+		s.nodeIndices[nodeID] = i
+	}
+	return s
+}
+
+func (s *simulation) run() {
+	for _, n := range s.nodes {
+		s.repoll(n)
+	}
+
+	for s.events.Len() > 0 {
+		e, _ := s.events.Pop()
+		if e.time > s.config.MaxTime {
+			return
+		}
+		s.now = e.time
+
+		switch e.eventType {
+		case queryEvent:
+			s.handleQuery(e)
+		case chitsEvent:
+			s.handleChits(e)
+		}
+	}
+}
+
+// repoll issues polls until [n] has ConcurrentRepolls polls outstanding.
+func (s *simulation) repoll(n *node) {
+	if n.byzantine || n.finalized {
+		return
+	}
+	for len(n.polls) < s.config.Params.ConcurrentRepolls {
+		s.issuePoll(n)
+	}
+}
+
+// issuePoll queries K nodes sampled uniformly with replacement.
+func (s *simulation) issuePoll(n *node) {
+	n.requestID++
+	requestID := n.requestID
+
+	var (
+		from = s.nodeIndices[n.nodeID]
+		vdrs = bag.Bag[ids.NodeID]{}
+		// queried is ordered by when each node was first sampled, rather than
+		// by the randomized iteration order of [vdrs], so that runs are
+		// deterministic.
+		queried []int
+	)
+	for i := 0; i < s.config.Params.K; i++ {
+		to := s.rng.Intn(len(s.nodes))
+		nodeID := s.nodes[to].nodeID
+		if vdrs.Count(nodeID) == 0 {
+			queried = append(queried, to)
+		}
+		vdrs.Add(nodeID)
+	}
+	n.polls[requestID] = s.factory.New(vdrs)
+
+	for _, to := range queried {
+		s.push(&event{
+			eventType: queryEvent,
+			from:      from,
+			to:        to,
+			requestID: requestID,
+			// Queries carry the querier's preference so that byzantine nodes
+			// can vote against it.
+			color: n.consensus.Preference(),
+		})
+	}
+}
+
+func (s *simulation) handleQuery(e *event) {
+	n := s.nodes[e.to]
+	color := s.vote(n, e.color)
+	s.push(&event{
+		eventType: chitsEvent,
+		from:      e.to,
+		to:        e.from,
+		requestID: e.requestID,
+		color:     color,
+	})
+}
+
+// vote returns the color [n] responds with when queried by a node preferring
+// [querierPreference].
+func (s *simulation) vote(n *node, querierPreference ids.ID) ids.ID {
+	if !n.byzantine {
+		return n.consensus.Preference()
+	}
+	if len(s.colors) == 1 {
+		return byzantineColor
+	}
+	for {
+		color := s.colors[s.rng.Intn(len(s.colors))]
+		if color != querierPreference {
+			return color
+		}
+	}
+}
+
+func (s *simulation) handleChits(e *event) {
+	n := s.nodes[e.to]
+	p, ok := n.polls[e.requestID]
+	if !ok {
+		return
+	}
+
+	p.Vote(s.nodes[e.from].nodeID, e.color)
+	if !p.Finished() {
+		return
+	}
+	delete(n.polls, e.requestID)
+
+	// Once finalized, a node keeps responding to queries but stops applying
+	// the results of its outstanding polls.
+	if n.finalized {
+		return
+	}
+
+	n.numPolls++
+	n.consensus.RecordPoll(p.Result())
+	if n.consensus.Finalized() {
+		n.finalized = true
+		n.finalizedTime = s.now
+		return
+	}
+	s.repoll(n)
+}
+
+func (s *simulation) push(e *event) {
+	e.time = s.now + s.config.Latency.sample(s.rng)
+	e.seq = s.seq
+	s.seq++
+	s.events.Push(e)
+}
+
+func (s *simulation) record(result *Result) {
+	var (
+		finalizedColor ids.ID
+		anyFinalized   bool
+		safetyFailure  bool
+	)
+	for _, n := range s.nodes {
+		if n.byzantine {
+			continue
+		}
+		if !n.finalized {
+			result.Unfinalized++
+			continue
+		}
+
+		result.FinalityTimes = append(result.FinalityTimes, n.finalizedTime)
+		result.FinalityPolls = append(result.FinalityPolls, n.numPolls)
+
+		color := n.consensus.Preference()
+		if anyFinalized && color != finalizedColor {
+			safetyFailure = true
+		}
+		finalizedColor = color
+		anyFinalized = true
+	}
+	if safetyFailure {
+		result.SafetyFailures++
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+func testConfig() Config {
+	return Config{
+		NumNodes:  50,
+		NumColors: 2,
+		Params: snowball.Parameters{
+			K:                     10,
+			AlphaPreference:       7,
+			AlphaConfidence:       7,
+			BetaVirtuous:          5,
+			BetaRogue:             5,
+			ConcurrentRepolls:     2,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Latency: LatencyConfig{
+			Distribution: UniformLatency,
+			Min:          10 * time.Millisecond,
+			Max:          100 * time.Millisecond,
+		},
+		Runs:    5,
+		MaxTime: time.Minute,
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			modify:      func(*Config) {},
+			expectedErr: nil,
+		},
+		{
+			name: "no nodes",
+			modify: func(c *Config) {
+				c.NumNodes = 0
+			},
+			expectedErr: errNoNodes,
+		},
+		{
+			name: "every node byzantine",
+			modify: func(c *Config) {
+				c.ByzantineFraction = 1
+			},
+			expectedErr: errInvalidByzantineFraction,
+		},
+		{
+			name: "no colors",
+			modify: func(c *Config) {
+				c.NumColors = 0
+			},
+			expectedErr: errTooFewColors,
+		},
+		{
+			name: "no runs",
+			modify: func(c *Config) {
+				c.Runs = 0
+			},
+			expectedErr: errNoRuns,
+		},
+		{
+			name: "no max time",
+			modify: func(c *Config) {
+				c.MaxTime = 0
+			},
+			expectedErr: errNonPositiveMaxTime,
+		},
+		{
+			name: "invalid params",
+			modify: func(c *Config) {
+				c.Params.AlphaConfidence = c.Params.K + 1
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+		{
+			name: "unknown latency distribution",
+			modify: func(c *Config) {
+				c.Latency.Distribution = "pareto"
+			},
+			expectedErr: errUnknownLatencyDistribution,
+		},
+		{
+			name: "invalid latency range",
+			modify: func(c *Config) {
+				c.Latency.Min = c.Latency.Max + 1
+			},
+			expectedErr: errInvalidLatencyRange,
+		},
+		{
+			name: "negative latency",
+			modify: func(c *Config) {
+				c.Latency.StdDev = -1
+			},
+			expectedErr: errNegativeLatency,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig()
+			test.modify(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestLatencySample(t *testing.T) {
+	tests := []struct {
+		name   string
+		config LatencyConfig
+	}{
+		{
+			name: "constant",
+			config: LatencyConfig{
+				Distribution: ConstantLatency,
+				Mean:         50 * time.Millisecond,
+			},
+		},
+		{
+			name: "uniform",
+			config: LatencyConfig{
+				Distribution: UniformLatency,
+				Min:          10 * time.Millisecond,
+				Max:          100 * time.Millisecond,
+			},
+		},
+		{
+			name: "normal",
+			config: LatencyConfig{
+				Distribution: NormalLatency,
+				Min:          10 * time.Millisecond,
+				Mean:         50 * time.Millisecond,
+				StdDev:       50 * time.Millisecond,
+			},
+		},
+		{
+			name: "exponential",
+			config: LatencyConfig{
+				Distribution: ExponentialLatency,
+				Min:          10 * time.Millisecond,
+				Mean:         50 * time.Millisecond,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rng := rand.New(rand.NewSource(0)) //#nosec G404
+			for i := 0; i < 1000; i++ {
+				latency := test.config.sample(rng)
+				require.GreaterOrEqual(latency, test.config.Min)
+				if test.config.Distribution == UniformLatency {
+					require.LessOrEqual(latency, test.config.Max)
+				}
+				if test.config.Distribution == ConstantLatency {
+					require.Equal(test.config.Mean, latency)
+				}
+			}
+		})
+	}
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.ByzantineFraction = .2
+
+	result0, err := Run(config)
+	require.NoError(err)
+
+	result1, err := Run(config)
+	require.NoError(err)
+
+	require.Equal(result0, result1)
+}
+
+// Although this can theoretically fail with a correct implementation, it
+// shouldn't in practice
+func TestRunHonestNetworkFinalizes(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumColors = 3
+
+	result, err := Run(config)
+	require.NoError(err)
+
+	require.Equal(config.Runs, result.Runs)
+	require.Zero(result.SafetyFailures)
+	require.Zero(result.Unfinalized)
+	require.Len(result.FinalityTimes, config.Runs*config.NumNodes)
+	require.Len(result.FinalityPolls, config.Runs*config.NumNodes)
+	for i, finalityTime := range result.FinalityTimes {
+		require.Positive(finalityTime)
+		require.LessOrEqual(finalityTime, config.MaxTime)
+		require.GreaterOrEqual(result.FinalityPolls[i], config.Params.BetaVirtuous)
+	}
+}
+
+// Although this can theoretically fail with a correct implementation, it
+// shouldn't in practice
+func TestRunByzantineNetworkFailsSafety(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.ByzantineFraction = .4
+	config.Params = snowball.Parameters{
+		K:                     5,
+		AlphaPreference:       3,
+		AlphaConfidence:       3,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+
+	result, err := Run(config)
+	require.NoError(err)
+	require.Positive(result.SafetyFailures)
+}
+
+func TestRunMaxTime(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.Latency = LatencyConfig{
+		Distribution: ConstantLatency,
+		Mean:         time.Second,
+	}
+	// A poll requires a query and a response, so no poll can finish before
+	// the max time.
+	config.MaxTime = time.Second
+
+	result, err := Run(config)
+	require.NoError(err)
+	require.Zero(result.SafetyFailures)
+	require.Equal(config.Runs*config.NumNodes, result.Unfinalized)
+	require.Empty(result.FinalityTimes)
+	require.Empty(result.FinalityPolls)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 type testFunc func(*testing.T, Factory)
@@ -51,6 +52,7 @@ var (
 		RecordPollSplitVoteNoChangeTest,
 		RecordPollWhenFinalizedTest,
 		RecordPollRejectTransitivelyTest,
+		RecordPollNotifiesRejectorTest,
 		RecordPollTransitivelyResetConfidenceTest,
 		RecordPollInvalidVoteTest,
 		RecordPollTransitiveVotingTest,
@@ -69,6 +71,7 @@ var (
 		ErrorOnAddDecidedBlockTest,
 		ErrorOnAddDuplicateBlockIDTest,
 		RecordPollWithDefaultParameters,
+		InspectTest,
 	}
 
 	errTest = errors.New("non-nil error")
@@ -677,6 +680,78 @@ func RecordPollRejectTransitivelyTest(t *testing.T, factory Factory) {
 	require.Equal(choices.Rejected, block2.Status())
 }
 
+type testRejector struct {
+	accepted []ids.ID
+	rejected set.Set[ids.ID]
+}
+
+func (r *testRejector) Accept(_ *snow.ConsensusContext, containerID ids.ID, _ []byte) error {
+	r.accepted = append(r.accepted, containerID)
+	return nil
+}
+
+func (r *testRejector) Reject(_ *snow.ConsensusContext, containerID ids.ID, _ []byte) error {
+	r.rejected.Add(containerID)
+	return nil
+}
+
+func RecordPollNotifiesRejectorTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	rejector := &testRejector{}
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.BlockAcceptor = rejector
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1.IDV,
+		HeightV: block1.HeightV + 1,
+	}
+
+	require.NoError(sm.Add(context.Background(), block0))
+	require.NoError(sm.Add(context.Background(), block1))
+	require.NoError(sm.Add(context.Background(), block2))
+
+	votes := bag.Of(block0.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+
+	require.Equal([]ids.ID{block0.ID()}, rejector.accepted)
+	require.Equal(set.Of(block1.ID(), block2.ID()), rejector.rejected)
+}
+
 func RecordPollTransitivelyResetConfidenceTest(t *testing.T, factory Factory) {
 	require := require.New(t)
 
@@ -1748,3 +1823,110 @@ func RecordPollWithDefaultParameters(t *testing.T, factory Factory) {
 	}
 	require.Zero(sm.NumProcessing())
 }
+
+func InspectTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          2,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block0.IDV,
+		HeightV: block0.HeightV + 1,
+	}
+
+	require.NoError(sm.Add(context.Background(), block0))
+	require.NoError(sm.Add(context.Background(), block1))
+	require.NoError(sm.Add(context.Background(), block2))
+
+	votes := bag.Of(block2.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+
+	inspection := sm.Inspect()
+	require.Equal(GenesisID, inspection.LastAcceptedID)
+	require.Equal(GenesisHeight, inspection.LastAcceptedHeight)
+	require.Equal(block2.ID(), inspection.Preference)
+	require.Equal(
+		map[uint64]ids.ID{
+			block0.HeightV: block0.ID(),
+			block2.HeightV: block2.ID(),
+		},
+		inspection.PreferredIDs,
+	)
+
+	require.Len(inspection.Blocks, 4)
+	blocks := make(map[ids.ID]BlockInspection)
+	for _, blk := range inspection.Blocks {
+		blocks[blk.ID] = blk
+	}
+
+	genesis := blocks[GenesisID]
+	require.True(genesis.Accepted)
+	require.False(genesis.Finalized)
+	require.NotEmpty(genesis.Snowball)
+	require.ElementsMatch([]ids.ID{block0.ID(), block1.ID()}, genesis.Children)
+
+	require.True(blocks[block0.ID()].Preferred)
+	require.Equal([]ids.ID{block2.ID()}, blocks[block0.ID()].Children)
+	require.False(blocks[block1.ID()].Preferred)
+	require.Empty(blocks[block1.ID()].Snowball)
+	require.True(blocks[block2.ID()].Preferred)
+	require.Equal(block0.ID(), blocks[block2.ID()].ParentID)
+	require.Equal(inspection.Blocks[3], blocks[block2.ID()])
+
+	require.Equal(
+		[]PollResult{
+			{
+				PollNumber: 1,
+				Votes: map[ids.ID]int{
+					block2.ID(): 1,
+				},
+				Successful:         true,
+				LastAcceptedHeight: GenesisHeight,
+			},
+		},
+		inspection.RecentPolls,
+	)
+
+	// Only the most recent polls should be kept.
+	for i := 0; i < maxRecentPolls; i++ {
+		require.NoError(sm.RecordPoll(context.Background(), bag.Bag[ids.ID]{}))
+	}
+	inspection = sm.Inspect()
+	require.Len(inspection.RecentPolls, maxRecentPolls)
+	require.Equal(uint64(2), inspection.RecentPolls[0].PollNumber)
+	require.False(inspection.RecentPolls[0].Successful)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+var _ utils.Sortable[BlockInspection] = BlockInspection{}
+
+// maxRecentPolls is the number of poll results that are kept for inspection.
+const maxRecentPolls = 32
+
+// Inspection is a snapshot of the internal state of a snowman instance. It is
+// intended to be used to debug consensus, and should not be relied upon for
+// correctness.
+type Inspection struct {
+	LastAcceptedID     ids.ID
+	LastAcceptedHeight uint64
+	// Preference is the tail of the preferred chain.
+	Preference ids.ID
+	// PreferredIDs maps a processing height to the preferred block at that
+	// height.
+	PreferredIDs map[uint64]ids.ID
+	// Blocks contains the last accepted block followed by the processing
+	// blocks, ordered by height.
+	Blocks []BlockInspection
+	// RecentPolls contains the most recently recorded polls, oldest first.
+	RecentPolls []PollResult
+}
+
+type BlockInspection struct {
+	ID     ids.ID
+	Height uint64
+	// ParentID is empty for the last accepted block.
+	ParentID  ids.ID
+	Accepted  bool
+	Preferred bool
+	// ShouldFalter is true if the confidence of the snowball instance
+	// deciding between the children of this block will be reset before the
+	// next vote is applied to it.
+	ShouldFalter bool
+	// Snowball is the state of the snowball instance deciding between the
+	// children of this block. If the block has no children, Snowball is empty.
+	Snowball string
+	// Finalized is true if the snowball instance deciding between the
+	// children of this block has finalized.
+	Finalized bool
+	Children  []ids.ID
+}
+
+// Less orders blocks by height, breaking ties by ID.
+func (b BlockInspection) Less(other BlockInspection) bool {
+	if b.Height != other.Height {
+		return b.Height < other.Height
+	}
+	return b.ID.Less(other.ID)
+}
+
+type PollResult struct {
+	PollNumber uint64
+	// Votes maps block IDs to the number of votes they received.
+	Votes map[ids.ID]int
+	// Successful is true if the poll increased the confidence of at least one
+	// snowball instance.
+	Successful         bool
+	LastAcceptedHeight uint64
+}
+
+func newPollResult(pollNumber uint64, votes bag.Bag[ids.ID], successful bool, lastAcceptedHeight uint64) PollResult {
+	voteList := votes.List()
+	result := PollResult{
+		PollNumber:         pollNumber,
+		Votes:              make(map[ids.ID]int, len(voteList)),
+		Successful:         successful,
+		LastAcceptedHeight: lastAcceptedHeight,
+	}
+	for _, blkID := range voteList {
+		result.Votes[blkID] = votes.Count(blkID)
+	}
+	return result
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+// pollWeightPrecision is the number of bits of precision of the weight of a
+// response.
+const pollWeightPrecision = 53
+
+type earlyTermWeightedFactory struct {
+	k               int
+	alphaPreference int
+	alphaConfidence int
+	getWeight       func(ids.NodeID) uint64
+	getTotalWeight  func() (uint64, error)
+}
+
+// NewEarlyTermWeightedFactory returns a factory that returns polls with early
+// termination, where each polled validator's response is weighted by its
+// stake rather than by the number of times the validator was sampled.
+//
+// The alpha thresholds are interpreted as fractions of [k], so a poll reaches
+// an alpha threshold once the validators that responded with the same block
+// hold at least alpha/k of the weight of the polled validators.
+//
+// Validators are sampled in proportion to their weight, so weighting each
+// polled validator by its weight alone would count the weight of the heavier
+// validators twice. Instead, each polled validator is weighted by its weight
+// divided by the probability that it was included in a sample of size [k].
+// Validators that are unlikely to be sampled are weighted equally, regardless
+// of their weight, while a validator that is sampled in nearly every poll is
+// weighted by its weight. As a result, the polled validators that responded
+// with a block estimate the share of [getTotalWeight] that prefers it.
+func NewEarlyTermWeightedFactory(
+	k int,
+	alphaPreference int,
+	alphaConfidence int,
+	getWeight func(ids.NodeID) uint64,
+	getTotalWeight func() (uint64, error),
+) Factory {
+	return &earlyTermWeightedFactory{
+		k:               k,
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+		getWeight:       getWeight,
+		getTotalWeight:  getTotalWeight,
+	}
+}
+
+func (f *earlyTermWeightedFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	p := &earlyTermWeightedPoll{
+		k:               f.k,
+		alphaPreference: f.alphaPreference,
+		alphaConfidence: f.alphaConfidence,
+		polled:          make(map[ids.NodeID]*big.Int, vdrs.Len()),
+		totalWeight:     new(big.Int),
+		remainingWeight: new(big.Int),
+		receivedWeight:  new(big.Int),
+		votes:           make(map[ids.ID]*big.Int),
+	}
+	subnetWeight, err := f.getTotalWeight()
+	if err != nil || subnetWeight == 0 {
+		// Without the weight of the subnet, the responses can't be weighted,
+		// so the poll finishes without any votes.
+		return p
+	}
+
+	for _, vdr := range vdrs.List() {
+		weight := pollWeight(f.getWeight(vdr), subnetWeight, f.k)
+		p.polled[vdr] = weight
+		p.remainingWeight.Add(p.remainingWeight, weight)
+	}
+	p.totalWeight.Set(p.remainingWeight)
+	return p
+}
+
+// pollWeight returns the weight of the response of a validator with [weight],
+// out of [totalWeight], that was included in a sample of size [k]. The
+// returned weight is proportional to [weight] divided by the probability that
+// the validator was sampled.
+func pollWeight(weight uint64, totalWeight uint64, k int) *big.Int {
+	if weight == 0 {
+		return new(big.Int)
+	}
+
+	// The validator set may have changed since the validators were sampled,
+	// so a validator may hold more than the total weight.
+	share := 1.
+	if weight < totalWeight {
+		share = float64(weight) / float64(totalWeight)
+	}
+	// inclusion = 1 - (1 - share)^k
+	inclusion := -math.Expm1(float64(k) * math.Log1p(-share))
+	if inclusion <= 0 {
+		return new(big.Int)
+	}
+
+	// The ratio is in (0, 1], so it is scaled by the float64 precision to
+	// be compared exactly once it is summed.
+	scaled := new(big.Float).SetFloat64(share / inclusion)
+	scaled.SetMantExp(scaled, pollWeightPrecision)
+	result, _ := scaled.Int(nil)
+	return result
+}
+
+// earlyTermWeightedPoll finishes when any remaining validators can't change the
+// result of the poll, where the responses are weighted by the weight of the
+// responding validator.
+type earlyTermWeightedPoll struct {
+	k               int
+	alphaPreference int
+	alphaConfidence int
+
+	// validators that haven't responded -> weight
+	polled map[ids.NodeID]*big.Int
+	// weight of every polled validator
+	totalWeight     *big.Int
+	remainingWeight *big.Int
+	receivedWeight  *big.Int
+	// block ID -> weight of the validators that voted for the block
+	votes map[ids.ID]*big.Int
+}
+
+// Vote registers a response for this poll
+func (p *earlyTermWeightedPoll) Vote(vdr ids.NodeID, vote ids.ID) {
+	weight, ok := p.polled[vdr]
+	if !ok {
+		return
+	}
+	// make sure that a validator can't respond multiple times
+	delete(p.polled, vdr)
+
+	p.remainingWeight.Sub(p.remainingWeight, weight)
+	p.receivedWeight.Add(p.receivedWeight, weight)
+
+	voteWeight, ok := p.votes[vote]
+	if !ok {
+		voteWeight = new(big.Int)
+		p.votes[vote] = voteWeight
+	}
+	voteWeight.Add(voteWeight, weight)
+}
+
+// Drop any future response for this poll
+func (p *earlyTermWeightedPoll) Drop(vdr ids.NodeID) {
+	weight, ok := p.polled[vdr]
+	if !ok {
+		return
+	}
+	delete(p.polled, vdr)
+	p.remainingWeight.Sub(p.remainingWeight, weight)
+}
+
+// Finished returns true when one of the following conditions is met.
+//
+//  1. There are no outstanding votes.
+//  2. It is impossible for the poll to achieve an alphaPreference majority
+//     of the weight.
+//  3. A single element has achieved an alphaPreference majority of the weight
+//     and it is impossible for it to achieve an alphaConfidence majority of
+//     the weight.
+//  4. A single element has achieved an alphaConfidence majority of the
+//     weight.
+func (p *earlyTermWeightedPoll) Finished() bool {
+	if len(p.polled) == 0 {
+		return true // Case 1
+	}
+
+	maxPossibleWeight := new(big.Int).Add(p.receivedWeight, p.remainingWeight)
+	if !p.reaches(maxPossibleWeight, p.alphaPreference) {
+		return true // Case 2
+	}
+
+	modeWeight := p.modeWeight()
+	return p.reaches(modeWeight, p.alphaPreference) && !p.reaches(maxPossibleWeight, p.alphaConfidence) || // Case 3
+		p.reaches(modeWeight, p.alphaConfidence) // Case 4
+}
+
+// Result returns the result of this poll. The weight of each vote is
+// normalized to be out of [k], rounding down, so that the result can be
+// compared against the alpha thresholds.
+func (p *earlyTermWeightedPoll) Result() bag.Bag[ids.ID] {
+	result := bag.Bag[ids.ID]{}
+	if p.totalWeight.Sign() == 0 {
+		return result
+	}
+
+	k := big.NewInt(int64(p.k))
+	for vote, weight := range p.votes {
+		count := new(big.Int).Mul(weight, k)
+		count.Quo(count, p.totalWeight)
+		if count.Sign() > 0 {
+			result.AddCount(vote, int(count.Int64()))
+		}
+	}
+	return result
+}
+
+// reaches returns true if [weight] is at least [alpha]/[k] of the weight of
+// the polled validators.
+func (p *earlyTermWeightedPoll) reaches(weight *big.Int, alpha int) bool {
+	lhs := new(big.Int).Mul(weight, big.NewInt(int64(p.k)))
+	rhs := new(big.Int).Mul(p.totalWeight, big.NewInt(int64(alpha)))
+	return lhs.Cmp(rhs) >= 0
+}
+
+func (p *earlyTermWeightedPoll) modeWeight() *big.Int {
+	modeWeight := new(big.Int)
+	for _, weight := range p.votes {
+		if weight.Cmp(modeWeight) > 0 {
+			modeWeight = weight
+		}
+	}
+	return modeWeight
+}
+
+func (p *earlyTermWeightedPoll) PrefixedString(prefix string) string {
+	return fmt.Sprintf(
+		"waiting on %d validators with %s of the weight\n%sreceived %s of the weight",
+		len(p.polled),
+		p.percentOfTotal(p.remainingWeight),
+		prefix,
+		p.percentOfTotal(p.receivedWeight),
+	)
+}
+
+func (p *earlyTermWeightedPoll) percentOfTotal(weight *big.Int) string {
+	if p.totalWeight.Sign() == 0 {
+		return "0.00%"
+	}
+	percent := new(big.Rat).SetFrac(weight, p.totalWeight)
+	percent.Mul(percent, big.NewRat(100, 1))
+	return percent.FloatString(2) + "%"
+}
+
+func (p *earlyTermWeightedPoll) String() string {
+	return p.PrefixedString("")
+}
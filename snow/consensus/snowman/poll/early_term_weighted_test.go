@@ -0,0 +1,269 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+var errTest = errors.New("non-nil error")
+
+func testWeights(weights map[ids.NodeID]uint64) func(ids.NodeID) uint64 {
+	return func(nodeID ids.NodeID) uint64 {
+		return weights[nodeID]
+	}
+}
+
+func testTotalWeight(weights map[ids.NodeID]uint64) func() (uint64, error) {
+	return func() (uint64, error) {
+		var totalWeight uint64
+		for _, weight := range weights {
+			totalWeight += weight
+		}
+		return totalWeight, nil
+	}
+}
+
+func TestEarlyTermWeightedResults(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2) // k = 2
+	alpha := 2
+
+	weights := map[ids.NodeID]uint64{
+		vdr1: 1,
+		vdr2: 1,
+	}
+	factory := NewEarlyTermWeightedFactory(2, alpha, alpha, testWeights(weights), testTotalWeight(weights))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Len(result.List(), 1)
+	require.Equal(2, result.Count(blkID1))
+}
+
+func TestEarlyTermWeightedDropsDuplicatedVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2) // k = 2
+	alpha := 2
+
+	weights := map[ids.NodeID]uint64{
+		vdr1: 1,
+		vdr2: 1,
+	}
+	factory := NewEarlyTermWeightedFactory(2, alpha, alpha, testWeights(weights), testTotalWeight(weights))
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID1)
+	require.True(poll.Finished())
+}
+
+func TestEarlyTermWeightedTermination(t *testing.T) {
+	weights := map[ids.NodeID]uint64{
+		vdr1: 60,
+		vdr2: 20,
+		vdr3: 10,
+		vdr4: 10,
+	}
+
+	type response struct {
+		vdr  ids.NodeID
+		vote ids.ID
+		drop bool
+	}
+	tests := []struct {
+		name            string
+		alphaPreference int
+		alphaConfidence int
+		responses       []response
+		expectedResult  map[ids.ID]int
+	}{
+		{
+			name:            "terminates with alpha confidence from a single heavy validator",
+			alphaPreference: 2,
+			alphaConfidence: 2,
+			responses: []response{
+				{vdr: vdr1, vote: blkID1},
+			},
+			expectedResult: map[ids.ID]int{
+				blkID1: 2,
+			},
+		},
+		{
+			name:            "terminates without alpha preference",
+			alphaPreference: 3,
+			alphaConfidence: 3,
+			responses: []response{
+				{vdr: vdr1, drop: true},
+			},
+			expectedResult: map[ids.ID]int{},
+		},
+		{
+			name:            "terminates with alpha preference",
+			alphaPreference: 2,
+			alphaConfidence: 4,
+			responses: []response{
+				{vdr: vdr3, vote: blkID1},
+				{vdr: vdr4, vote: blkID1},
+				{vdr: vdr2, vote: blkID1},
+				{vdr: vdr1, drop: true},
+			},
+			expectedResult: map[ids.ID]int{
+				blkID1: 2,
+			},
+		},
+		{
+			name:            "terminates with split votes",
+			alphaPreference: 3,
+			alphaConfidence: 3,
+			responses: []response{
+				{vdr: vdr2, vote: blkID1},
+				{vdr: vdr3, vote: blkID2},
+				{vdr: vdr4, vote: blkID2},
+				{vdr: vdr1, vote: blkID3},
+			},
+			expectedResult: map[ids.ID]int{
+				blkID1: 1,
+				blkID2: 1,
+				blkID3: 2,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			factory := NewEarlyTermWeightedFactory(5, test.alphaPreference, test.alphaConfidence, testWeights(weights), testTotalWeight(weights))
+			poll := factory.New(bag.Of(vdr1, vdr2, vdr3, vdr4))
+
+			for i, response := range test.responses {
+				require.False(poll.Finished())
+				if response.drop {
+					poll.Drop(response.vdr)
+				} else {
+					poll.Vote(response.vdr, response.vote)
+				}
+				// The poll should finish exactly on the last response.
+				require.Equal(i == len(test.responses)-1, poll.Finished())
+			}
+
+			result := poll.Result()
+			require.Len(result.List(), len(test.expectedResult))
+			for blkID, count := range test.expectedResult {
+				require.Equal(count, result.Count(blkID))
+			}
+		})
+	}
+}
+
+// Test that the weight of a heavy validator isn't counted twice, once by being
+// sampled more often and once by its weight, so that a validator holding less
+// than alphaConfidence/k of the weight can't reach alphaConfidence alone just
+// because few other validators were sampled.
+func TestEarlyTermWeightedHeavyValidator(t *testing.T) {
+	require := require.New(t)
+
+	weights := map[ids.NodeID]uint64{
+		vdr1: 70,
+		vdr2: 10,
+		vdr3: 10,
+		vdr4: 10,
+	}
+	factory := NewEarlyTermWeightedFactory(4, 3, 3, testWeights(weights), testTotalWeight(weights))
+	poll := factory.New(bag.Of(vdr1, vdr1, vdr1, vdr2))
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr2)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(2, result.Count(blkID1))
+}
+
+// Test that polls can finish with an alpha majority when the weight is evenly
+// spread across many more validators than are sampled.
+func TestEarlyTermWeightedEvenlySpreadWeight(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := []ids.NodeID{vdr1, vdr2, vdr3, vdr4, vdr5}
+	weights := make(map[ids.NodeID]uint64)
+	for _, vdr := range vdrs {
+		weights[vdr] = 1
+	}
+	// The polled validators hold a tenth of the weight.
+	for i := 0; i < 45; i++ {
+		weights[ids.GenerateTestNodeID()] = 1
+	}
+	factory := NewEarlyTermWeightedFactory(5, 4, 4, testWeights(weights), testTotalWeight(weights))
+	poll := factory.New(bag.Of(vdrs...))
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr4, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(4, result.Count(blkID1))
+}
+
+func TestEarlyTermWeightedTotalWeightError(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewEarlyTermWeightedFactory(
+		1,
+		1,
+		1,
+		testWeights(map[ids.NodeID]uint64{
+			vdr1: 1,
+		}),
+		func() (uint64, error) {
+			return 0, errTest
+		},
+	)
+	poll := factory.New(bag.Of(vdr1))
+	require.True(poll.Finished())
+
+	poll.Vote(vdr1, blkID1)
+	result := poll.Result()
+	require.Zero(result.Len())
+}
+
+func TestEarlyTermWeightedString(t *testing.T) {
+	weights := map[ids.NodeID]uint64{
+		vdr1: 1,
+		vdr2: 2,
+	}
+	factory := NewEarlyTermWeightedFactory(2, 2, 2, testWeights(weights), testTotalWeight(weights))
+	poll := factory.New(bag.Of(vdr1, vdr2))
+
+	poll.Vote(vdr1, blkID1)
+
+	expected := `waiting on 1 validators with 55.56% of the weight
+received 44.44% of the weight`
+	require.Equal(t, expected, poll.String())
+}
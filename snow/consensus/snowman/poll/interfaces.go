@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/bag"
@@ -18,6 +19,10 @@ type Set interface {
 	Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool
 	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID]
 	Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID]
+	// Expire finishes the polls that were created before [cutoff], dropping
+	// any outstanding votes, and returns the results of the polls that
+	// finished as a result.
+	Expire(cutoff time.Time) []bag.Bag[ids.ID]
 	Len() int
 }
 
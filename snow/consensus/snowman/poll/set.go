@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 type pollHolder interface {
@@ -42,16 +43,18 @@ type set struct {
 	numPolls prometheus.Gauge
 	durPolls metric.Averager
 	factory  Factory
+	clock    *mockable.Clock
 	// maps requestID -> poll
 	polls linkedhashmap.LinkedHashmap[uint32, pollHolder]
 }
 
-// NewSet returns a new empty set of polls
+// NewSet returns a new empty set of polls that are timed by [clock]
 func NewSet(
 	factory Factory,
 	log logging.Logger,
 	namespace string,
 	reg prometheus.Registerer,
+	clock *mockable.Clock,
 ) Set {
 	numPolls := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -81,6 +84,7 @@ func NewSet(
 		numPolls: numPolls,
 		durPolls: durPolls,
 		factory:  factory,
+		clock:    clock,
 		polls:    linkedhashmap.New[uint32, pollHolder](),
 	}
 }
@@ -104,7 +108,7 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 
 	s.polls.Put(requestID, poll{
 		Poll:  s.factory.New(vdrs), // create the new poll
-		start: time.Now(),
+		start: s.clock.Time(),
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -158,7 +162,7 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 			zap.Uint32("requestID", iter.Key()),
 			zap.Stringer("poll", holder.GetPoll()),
 		)
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		s.durPolls.Observe(float64(s.clock.Time().Sub(holder.StartTime())))
 		s.numPolls.Dec() // decrease the metrics
 
 		results = append(results, p.Result())
@@ -198,6 +202,43 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 	return s.processFinishedPolls()
 }
 
+// Expire finishes the polls that were created before [cutoff], dropping any
+// outstanding votes, and returns the results of the polls that finished as a
+// result. Polls are only finished in the order they were created, so newer
+// polls that have already finished are returned as well.
+func (s *set) Expire(cutoff time.Time) []bag.Bag[ids.ID] {
+	var results []bag.Bag[ids.ID]
+
+	// iterate from oldest to newest
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		holder := iter.Value()
+		p := holder.GetPoll()
+		expired := holder.StartTime().Before(cutoff)
+		if !expired && !p.Finished() {
+			break
+		}
+
+		if expired {
+			s.log.Verbo("poll expired",
+				zap.Uint32("requestID", iter.Key()),
+				zap.Stringer("poll", p),
+			)
+		} else {
+			s.log.Verbo("poll finished",
+				zap.Uint32("requestID", iter.Key()),
+				zap.Stringer("poll", p),
+			)
+		}
+		s.durPolls.Observe(float64(s.clock.Time().Sub(holder.StartTime())))
+		s.numPolls.Dec() // decrease the metrics
+
+		results = append(results, p.Result())
+		s.polls.Delete(iter.Key())
+	}
+	return results
+}
+
 // Len returns the number of outstanding polls
 func (s *set) Len() int {
 	return s.polls.Len()
@@ -5,6 +5,7 @@ package poll
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 var (
@@ -43,7 +45,7 @@ func TestNewSetErrorOnMetrics(t *testing.T) {
 		Name: "poll_duration",
 	})))
 
-	require.NotNil(NewSet(factory, log, namespace, registerer))
+	require.NotNil(NewSet(factory, log, namespace, registerer, &mockable.Clock{}))
 }
 
 func TestCreateAndFinishPollOutOfOrder_NewerFinishesFirst(t *testing.T) {
@@ -56,7 +58,7 @@ func TestCreateAndFinishPollOutOfOrder_NewerFinishesFirst(t *testing.T) {
 	log := logging.NoLog{}
 	namespace := ""
 	registerer := prometheus.NewRegistry()
-	s := NewSet(factory, log, namespace, registerer)
+	s := NewSet(factory, log, namespace, registerer, &mockable.Clock{})
 
 	// create two polls for the two blocks
 	vdrBag := bag.Of(vdrs...)
@@ -92,7 +94,7 @@ func TestCreateAndFinishPollOutOfOrder_OlderFinishesFirst(t *testing.T) {
 	log := logging.NoLog{}
 	namespace := ""
 	registerer := prometheus.NewRegistry()
-	s := NewSet(factory, log, namespace, registerer)
+	s := NewSet(factory, log, namespace, registerer, &mockable.Clock{})
 
 	// create two polls for the two blocks
 	vdrBag := bag.Of(vdrs...)
@@ -128,7 +130,7 @@ func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
 	log := logging.NoLog{}
 	namespace := ""
 	registerer := prometheus.NewRegistry()
-	s := NewSet(factory, log, namespace, registerer)
+	s := NewSet(factory, log, namespace, registerer, &mockable.Clock{})
 
 	// create three polls for the two blocks
 	vdrBag := bag.Of(vdrs...)
@@ -172,7 +174,7 @@ func TestCreateAndFinishSuccessfulPoll(t *testing.T) {
 	log := logging.NoLog{}
 	namespace := ""
 	registerer := prometheus.NewRegistry()
-	s := NewSet(factory, log, namespace, registerer)
+	s := NewSet(factory, log, namespace, registerer, &mockable.Clock{})
 
 	require.Zero(s.Len())
 
@@ -204,7 +206,7 @@ func TestCreateAndFinishFailedPoll(t *testing.T) {
 	log := logging.NoLog{}
 	namespace := ""
 	registerer := prometheus.NewRegistry()
-	s := NewSet(factory, log, namespace, registerer)
+	s := NewSet(factory, log, namespace, registerer, &mockable.Clock{})
 
 	require.Zero(s.Len())
 
@@ -223,6 +225,47 @@ func TestCreateAndFinishFailedPoll(t *testing.T) {
 	require.Empty(results[0].List())
 }
 
+func TestExpirePolls(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := []ids.NodeID{vdr1, vdr2} // k = 2
+	alpha := 2
+
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	clock := &mockable.Clock{}
+	start := time.Unix(1_000_000, 0)
+	clock.Set(start)
+	s := NewSet(factory, log, namespace, registerer, clock)
+
+	require.True(s.Add(1, bag.Of(vdrs...)))
+	require.True(s.Add(2, bag.Of(vdrs...)))
+	require.True(s.Add(3, bag.Of(vdrs...)))
+
+	require.Empty(s.Vote(1, vdr1, blkID1))
+	require.Empty(s.Vote(2, vdr1, blkID2))
+	require.Empty(s.Vote(3, vdr1, blkID3))
+
+	// poll 3 finished, but can't be returned until polls 1 and 2 finish
+	require.Empty(s.Vote(3, vdr2, blkID3))
+
+	// no polls were created before the cutoff
+	require.Empty(s.Expire(start))
+	require.Equal(3, s.Len())
+
+	results := s.Expire(start.Add(time.Nanosecond))
+	require.Len(results, 3)
+	require.Equal(1, results[0].Count(blkID1))
+	require.Equal(1, results[1].Count(blkID2))
+	require.Equal(2, results[2].Count(blkID3))
+	require.Zero(s.Len())
+
+	// votes for expired polls are dropped
+	require.Empty(s.Vote(1, vdr2, blkID1))
+}
+
 func TestSetString(t *testing.T) {
 	require := require.New(t)
 
@@ -233,7 +276,7 @@ func TestSetString(t *testing.T) {
 	log := logging.NoLog{}
 	namespace := ""
 	registerer := prometheus.NewRegistry()
-	s := NewSet(factory, log, namespace, registerer)
+	s := NewSet(factory, log, namespace, registerer, &mockable.Clock{})
 
 	expected := `current polls: (Size = 1)
     RequestID 0:
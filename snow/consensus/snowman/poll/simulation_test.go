@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+// pollNetwork simulates polling the validators of a subnet.
+type pollNetwork struct {
+	k               int
+	alphaPreference int
+	alphaConfidence int
+
+	subnetID    ids.ID
+	vdrs        validators.Manager
+	preferences map[ids.NodeID]ids.ID
+	offline     map[ids.NodeID]bool
+	rng         *rand.Rand
+}
+
+func newPollNetwork(k, alphaPreference, alphaConfidence int, seed int64) *pollNetwork {
+	sampler.Seed(seed)
+	return &pollNetwork{
+		k:               k,
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+		subnetID:        ids.GenerateTestID(),
+		vdrs:            validators.NewManager(),
+		preferences:     make(map[ids.NodeID]ids.ID),
+		offline:         make(map[ids.NodeID]bool),
+		rng:             rand.New(rand.NewSource(seed)), // #nosec G404
+	}
+}
+
+// addValidators adds [num] validators with [weight] that prefer [preference].
+func (n *pollNetwork) addValidators(require *require.Assertions, num int, weight uint64, preference ids.ID, online bool) {
+	for i := 0; i < num; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.NoError(n.vdrs.AddStaker(n.subnetID, nodeID, nil, ids.Empty, weight))
+		n.preferences[nodeID] = preference
+		n.offline[nodeID] = !online
+	}
+}
+
+// sample samples [k] validators proportionally to their stake, with
+// replacement.
+func (n *pollNetwork) sample(require *require.Assertions) []ids.NodeID {
+	vdrIDs, err := n.vdrs.Sample(n.subnetID, n.k)
+	require.NoError(err)
+	return vdrIDs
+}
+
+// poll runs a poll of [vdrs], where the validators respond in a random order,
+// and returns the result of the poll.
+func (n *pollNetwork) poll(factory Factory, vdrs bag.Bag[ids.NodeID]) bag.Bag[ids.ID] {
+	p := factory.New(vdrs)
+	polled := vdrs.List()
+	n.rng.Shuffle(len(polled), func(i, j int) {
+		polled[i], polled[j] = polled[j], polled[i]
+	})
+	for _, vdr := range polled {
+		if p.Finished() {
+			break
+		}
+		if n.offline[vdr] {
+			p.Drop(vdr)
+			continue
+		}
+		p.Vote(vdr, n.preferences[vdr])
+	}
+	return p.Result()
+}
+
+// run performs [numPolls] polls with both poll implementations and returns
+// the number of polls that reached an alphaConfidence majority for each
+// block.
+func (n *pollNetwork) run(require *require.Assertions, numPolls int) (map[ids.ID]int, map[ids.ID]int) {
+	unweightedFactory := NewEarlyTermNoTraversalFactory(n.alphaPreference, n.alphaConfidence)
+	weightedFactory := NewEarlyTermWeightedFactory(
+		n.k,
+		n.alphaPreference,
+		n.alphaConfidence,
+		func(nodeID ids.NodeID) uint64 {
+			return n.vdrs.GetWeight(n.subnetID, nodeID)
+		},
+		func() (uint64, error) {
+			return n.vdrs.TotalWeight(n.subnetID)
+		},
+	)
+
+	unweighted := make(map[ids.ID]int)
+	weighted := make(map[ids.ID]int)
+	for i := 0; i < numPolls; i++ {
+		// Both polls are of the same sampled validators.
+		vdrIDs := n.sample(require)
+		result := n.poll(unweightedFactory, bag.Of(vdrIDs...))
+		if blkID, count := result.Mode(); count >= n.alphaConfidence {
+			unweighted[blkID]++
+		}
+
+		result = n.poll(weightedFactory, bag.Of(vdrIDs...))
+		if blkID, count := result.Mode(); count >= n.alphaConfidence {
+			weighted[blkID]++
+		}
+	}
+	return unweighted, weighted
+}
+
+// Test that when validators holding little stake are offline, both poll
+// implementations are still able to reach alphaConfidence majorities.
+func TestWeightedPollsOfflineLightValidators(t *testing.T) {
+	require := require.New(t)
+
+	n := newPollNetwork(20, 15, 15, 0)
+	n.addValidators(require, 3, 300, blkID1, true)
+	n.addValidators(require, 50, 2, blkID1, false)
+
+	numPolls := 1000
+	unweighted, weighted := n.run(require, numPolls)
+
+	// Although this can theoretically fail with a correct implementation, it
+	// shouldn't in practice
+	require.Greater(unweighted[blkID1], 9*numPolls/10)
+	require.Greater(weighted[blkID1], 9*numPolls/10)
+}
+
+// Test that a validator holding less than alphaConfidence/k of the stake
+// drives a weighted poll to an alphaConfidence majority on its own no more
+// often than an unweighted poll, even though it is sampled far more often than
+// the other validators.
+func TestWeightedPollsHeavyAdversary(t *testing.T) {
+	require := require.New(t)
+
+	n := newPollNetwork(20, 15, 15, 0)
+	n.addValidators(require, 1, 70, blkID2, true)
+	n.addValidators(require, 30, 1, blkID1, false)
+
+	numPolls := 1000
+	unweighted, weighted := n.run(require, numPolls)
+
+	require.LessOrEqual(weighted[blkID2], unweighted[blkID2])
+	require.Zero(unweighted[blkID1])
+	require.Zero(weighted[blkID1])
+}
+
+// Test that when the stake is evenly spread across many more validators than
+// are sampled, both poll implementations are able to reach alphaConfidence
+// majorities.
+func TestWeightedPollsEvenlySpreadStake(t *testing.T) {
+	require := require.New(t)
+
+	n := newPollNetwork(20, 15, 15, 0)
+	n.addValidators(require, 190, 10, blkID1, true)
+	n.addValidators(require, 10, 10, blkID2, true)
+
+	numPolls := 1000
+	unweighted, weighted := n.run(require, numPolls)
+
+	require.Zero(unweighted[blkID2])
+	require.Zero(weighted[blkID2])
+
+	// Although this can theoretically fail with a correct implementation, it
+	// shouldn't in practice
+	require.Greater(unweighted[blkID1], 9*numPolls/10)
+	require.Greater(weighted[blkID1], 9*numPolls/10)
+}
+
+// Test that a large number of validators with little stake can't drive either
+// poll implementation to an alphaConfidence majority.
+func TestWeightedPollsManyLightValidators(t *testing.T) {
+	require := require.New(t)
+
+	n := newPollNetwork(20, 15, 15, 0)
+	n.addValidators(require, 4, 1000, blkID1, true)
+	n.addValidators(require, 200, 1, blkID2, true)
+
+	numPolls := 1000
+	unweighted, weighted := n.run(require, numPolls)
+
+	require.Zero(unweighted[blkID2])
+	require.Zero(weighted[blkID2])
+
+	// Although this can theoretically fail with a correct implementation, it
+	// shouldn't in practice
+	require.Greater(unweighted[blkID1], numPolls/2)
+	require.Greater(weighted[blkID1], numPolls/2)
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/metrics"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
@@ -86,6 +88,9 @@ type Topological struct {
 	// We use this one map instead of creating a new map
 	// during each call to [calculateInDegree].
 	kahnNodes map[ids.ID]kahnNode
+
+	// recentPolls contains the results of the last [maxRecentPolls] polls.
+	recentPolls buffer.Deque[PollResult]
 }
 
 // Used to track the kahn topological sort status
@@ -151,6 +156,7 @@ func (ts *Topological) Initialize(
 	}
 	ts.preferredHeights = make(map[uint64]ids.ID)
 	ts.tail = rootID
+	ts.recentPolls = buffer.NewUnboundedDeque[PollResult](maxRecentPolls)
 
 	// Initially set the metrics for the last accepted block.
 	ts.Height.Accepted(ts.height)
@@ -194,8 +200,9 @@ func (ts *Topological) Add(ctx context.Context, blk Block) error {
 		if err := blk.Reject(ctx); err != nil {
 			return err
 		}
-		ts.Latency.Rejected(blkID, ts.pollNumber, len(blk.Bytes()))
-		return nil
+		bytes := blk.Bytes()
+		ts.Latency.Rejected(blkID, ts.pollNumber, len(bytes))
+		return ts.notifyRejected(blkID, bytes)
 	}
 
 	// add the block as a child of its parent, and add the block to the tree
@@ -306,11 +313,16 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 	}
 
 	// Runtime = |live set| ; Space = Constant
-	preferred, err := ts.vote(ctx, voteStack)
+	preferred, successful, err := ts.vote(ctx, voteStack)
 	if err != nil {
 		return err
 	}
 
+	if ts.recentPolls.Len() >= maxRecentPolls {
+		_, _ = ts.recentPolls.PopLeft()
+	}
+	ts.recentPolls.PushRight(newPollResult(ts.pollNumber, voteBag, successful, ts.height))
+
 	// If the set of preferred IDs already contains the preference, then the
 	// tail is guaranteed to already be set correctly. This is because the value
 	// returned from vote reports the next preferred block after the last
@@ -350,6 +362,45 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 	return nil
 }
 
+func (ts *Topological) Inspect() Inspection {
+	inspection := Inspection{
+		LastAcceptedID:     ts.head,
+		LastAcceptedHeight: ts.height,
+		Preference:         ts.tail,
+		PreferredIDs:       maps.Clone(ts.preferredHeights),
+		Blocks:             make([]BlockInspection, 0, len(ts.blocks)),
+	}
+	if ts.recentPolls != nil {
+		inspection.RecentPolls = ts.recentPolls.List()
+	}
+
+	for blkID, block := range ts.blocks {
+		blockInspection := BlockInspection{
+			ID:           blkID,
+			Accepted:     blkID == ts.head,
+			ShouldFalter: block.shouldFalter,
+		}
+		if blockInspection.Accepted {
+			// The last accepted block may not have been provided to consensus,
+			// so its height is tracked separately.
+			blockInspection.Height = ts.height
+		} else {
+			blockInspection.Height = block.blk.Height()
+			blockInspection.ParentID = block.blk.Parent()
+			blockInspection.Preferred = ts.preferredIDs.Contains(blkID)
+		}
+		if block.sb != nil {
+			blockInspection.Snowball = block.sb.String()
+			blockInspection.Finalized = block.sb.Finalized()
+			blockInspection.Children = maps.Keys(block.children)
+			utils.Sort(blockInspection.Children)
+		}
+		inspection.Blocks = append(inspection.Blocks, blockInspection)
+	}
+	utils.Sort(inspection.Blocks)
+	return inspection
+}
+
 // HealthCheck returns information about the consensus health.
 func (ts *Topological) HealthCheck(context.Context) (interface{}, error) {
 	numOutstandingBlks := ts.Latency.NumProcessing()
@@ -490,8 +541,8 @@ func (ts *Topological) pushVotes() []votes {
 
 // apply votes to the branch that received an Alpha threshold and returns the
 // next preferred block after the last preferred block that received an Alpha
-// threshold.
-func (ts *Topological) vote(ctx context.Context, voteStack []votes) (ids.ID, error) {
+// threshold. Also returns whether the poll was successful.
+func (ts *Topological) vote(ctx context.Context, voteStack []votes) (ids.ID, bool, error) {
 	// If the voteStack is empty, then the full tree should falter. This won't
 	// change the preferred branch.
 	if len(voteStack) == 0 {
@@ -504,7 +555,7 @@ func (ts *Topological) vote(ctx context.Context, voteStack []votes) (ids.ID, err
 			)
 			ts.Polls.Failed()
 		}
-		return ts.tail, nil
+		return ts.tail, false, nil
 	}
 
 	// keep track of the new preferred block
@@ -547,7 +598,7 @@ func (ts *Topological) vote(ctx context.Context, voteStack []votes) (ids.ID, err
 		// Only accept when you are finalized and the head.
 		if parentBlock.sb.Finalized() && ts.head == vote.parentID {
 			if err := ts.acceptPreferredChild(ctx, parentBlock); err != nil {
-				return ids.ID{}, err
+				return ids.ID{}, false, err
 			}
 
 			// by accepting the child of parentBlock, the last accepted block is
@@ -611,7 +662,7 @@ func (ts *Topological) vote(ctx context.Context, voteStack []votes) (ids.ID, err
 	} else {
 		ts.Polls.Failed()
 	}
-	return newPreferred, nil
+	return newPreferred, pollSuccessful, nil
 }
 
 // Accepts the preferred child of the provided snowman block. By accepting the
@@ -671,7 +722,11 @@ func (ts *Topological) acceptPreferredChild(ctx context.Context, n *snowmanBlock
 		if err := child.Reject(ctx); err != nil {
 			return err
 		}
-		ts.Latency.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+		childBytes := child.Bytes()
+		ts.Latency.Rejected(childID, ts.pollNumber, len(childBytes))
+		if err := ts.notifyRejected(childID, childBytes); err != nil {
+			return err
+		}
 
 		// Track which blocks have been directly rejected
 		rejects = append(rejects, childID)
@@ -699,7 +754,11 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 			if err := child.Reject(ctx); err != nil {
 				return err
 			}
-			ts.Latency.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+			childBytes := child.Bytes()
+			ts.Latency.Rejected(childID, ts.pollNumber, len(childBytes))
+			if err := ts.notifyRejected(childID, childBytes); err != nil {
+				return err
+			}
 
 			// add the newly rejected block to the end of the stack
 			rejected = append(rejected, childID)
@@ -707,3 +766,13 @@ func (ts *Topological) rejectTransitively(ctx context.Context, rejected []ids.ID
 	}
 	return nil
 }
+
+// notifyRejected notifies the block acceptor that [blkID] was rejected, if the
+// block acceptor is monitoring rejections.
+func (ts *Topological) notifyRejected(blkID ids.ID, bytes []byte) error {
+	rejector, ok := ts.ctx.BlockAcceptor.(snow.Rejector)
+	if !ok {
+		return nil
+	}
+	return rejector.Reject(ts.ctx, blkID, bytes)
+}
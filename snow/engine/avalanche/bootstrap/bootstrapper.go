@@ -31,12 +31,20 @@ const (
 	cacheSize      = 100000
 )
 
-var _ common.BootstrapableEngine = (*bootstrapper)(nil)
+var (
+	_ common.BootstrapableEngine       = (*bootstrapper)(nil)
+	_ common.BootstrapProgressReporter = (*bootstrapper)(nil)
+)
 
 func New(
 	config Config,
 	onFinished func(ctx context.Context, lastReqID uint32) error,
 ) (common.BootstrapableEngine, error) {
+	progress, err := common.NewBootstrapProgressTracker("bs", config.Ctx.AvalancheRegisterer)
+	if err != nil {
+		return nil, err
+	}
+
 	b := &bootstrapper{
 		Config: config,
 
@@ -48,6 +56,7 @@ func New(
 		AppHandler:                  config.VM,
 
 		processedCache: &cache.LRU[ids.ID, struct{}]{Size: cacheSize},
+		progress:       progress,
 		Fetcher: common.Fetcher{
 			OnFinished: onFinished,
 		},
@@ -87,6 +96,11 @@ type bootstrapper struct {
 
 	// Contains IDs of vertices that have recently been processed
 	processedCache *cache.LRU[ids.ID, struct{}]
+
+	// progress records the progress of bootstrapping the DAG. Vertices don't
+	// have a height that bounds the number left to fetch, so only the number
+	// of fetched vertices is reported while fetching.
+	progress *common.BootstrapProgressTracker
 }
 
 func (b *bootstrapper) Clear(context.Context) error {
@@ -326,6 +340,9 @@ func (b *bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 	}); err != nil {
 		return err
 	}
+	b.VtxBlocked.SetProgressTracker(b.progress)
+	b.TxBlocked.SetProgressTracker(b.progress)
+	b.progress.SetPhase(common.BootstrapPhaseFrontier)
 
 	b.Config.SharedCfg.RequestID = startReqID
 
@@ -386,6 +403,10 @@ func (b *bootstrapper) GetVM() common.VM {
 	return b.VM
 }
 
+func (b *bootstrapper) BootstrapProgress() common.BootstrapProgress {
+	return b.progress.BootstrapProgress()
+}
+
 // Add the vertices in [vtxIDs] to the set of vertices that we need to fetch,
 // and then fetch vertices (and their ancestors) until either there are no more
 // to fetch or we are at the maximum number of outstanding requests.
@@ -497,6 +518,7 @@ func (b *bootstrapper) process(ctx context.Context, vtxs ...avalanche.Vertex) er
 			b.numFetchedVts.Inc()
 
 			verticesFetchedSoFar := b.VtxBlocked.Jobs.PendingJobs()
+			b.progress.Fetched(0, 0, verticesFetchedSoFar)
 			if verticesFetchedSoFar%common.StatusUpdateFrequency == 0 { // Periodically print progress
 				if !b.Config.SharedCfg.Restarted {
 					b.Ctx.Log.Info("fetched vertices",
@@ -559,6 +581,8 @@ func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs [
 		zap.Int("numMissingVertices", len(pendingContainerIDs)),
 		zap.Int("numAcceptedVertices", len(acceptedContainerIDs)),
 	)
+	b.progress.Fetched(0, 0, b.VtxBlocked.Jobs.PendingJobs())
+	b.progress.SetPhase(common.BootstrapPhaseFetching)
 	toProcess := make([]avalanche.Vertex, 0, len(pendingContainerIDs))
 	for _, vtxID := range pendingContainerIDs {
 		if vtx, err := b.Manager.GetVtx(ctx, vtxID); err == nil {
@@ -590,6 +614,7 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 		b.Ctx.Log.Debug("executing transactions")
 	}
 
+	b.progress.SetPhase(common.BootstrapPhaseExecuting)
 	_, err := b.TxBlocked.ExecuteAll(
 		ctx,
 		b.Config.Ctx,
@@ -607,6 +632,7 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 		b.Ctx.Log.Debug("executing vertices")
 	}
 
+	b.progress.SetPhase(common.BootstrapPhaseExecuting)
 	_, err = b.VtxBlocked.ExecuteAll(
 		ctx,
 		b.Config.Ctx,
@@ -626,6 +652,7 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 	}
 	if !linearized {
 		b.Ctx.Log.Debug("checking for stop vertex before finishing bootstrapping")
+		b.progress.SetPhase(common.BootstrapPhaseFrontier)
 		return b.Restart(ctx, true)
 	}
 
@@ -637,6 +664,7 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 	}
 
 	b.processedCache.Flush()
+	b.progress.SetPhase(common.BootstrapPhaseFinished)
 	return b.OnFinished(ctx, b.Config.SharedCfg.RequestID)
 }
 
@@ -174,6 +174,8 @@ func TestBootstrapperSingleFrontier(t *testing.T) {
 
 	vm.CantSetState = false
 	require.NoError(bs.Start(context.Background(), 0))
+	progress := bs.(common.BootstrapProgressReporter)
+	require.Equal(common.BootstrapPhaseFrontier, progress.BootstrapProgress().Phase)
 
 	acceptedIDs := []ids.ID{vtxID0, vtxID1, vtxID2}
 
@@ -224,6 +226,10 @@ func TestBootstrapperSingleFrontier(t *testing.T) {
 	require.Equal(choices.Accepted, vtx0.Status())
 	require.Equal(choices.Accepted, vtx1.Status())
 	require.Equal(choices.Accepted, vtx2.Status())
+
+	bootstrapProgress := progress.BootstrapProgress()
+	require.Equal(common.BootstrapPhaseFinished, bootstrapProgress.Phase)
+	require.Equal(uint64(3), bootstrapProgress.NumFetched)
 }
 
 // Accepted frontier has one vertex, which has one vertex as a dependency.
@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+const (
+	// BootstrapPhaseFrontier is the phase where the accepted frontier is
+	// requested from the bootstrap beacons.
+	BootstrapPhaseFrontier BootstrapPhase = "frontier"
+	// BootstrapPhaseFetching is the phase where the blocks between the last
+	// accepted block and the accepted frontier are fetched.
+	BootstrapPhaseFetching BootstrapPhase = "fetching"
+	// BootstrapPhaseExecuting is the phase where the fetched blocks are
+	// executed.
+	BootstrapPhaseExecuting BootstrapPhase = "executing"
+	// BootstrapPhaseWaiting is the phase where the chain has been bootstrapped
+	// but is waiting for the other chains in its subnet to finish
+	// bootstrapping.
+	BootstrapPhaseWaiting BootstrapPhase = "waiting"
+	// BootstrapPhaseFinished is the phase after bootstrapping has finished.
+	BootstrapPhaseFinished BootstrapPhase = "finished"
+)
+
+var bootstrapPhases = []BootstrapPhase{
+	BootstrapPhaseFrontier,
+	BootstrapPhaseFetching,
+	BootstrapPhaseExecuting,
+	BootstrapPhaseWaiting,
+	BootstrapPhaseFinished,
+}
+
+type BootstrapPhase string
+
+// BootstrapProgress is a snapshot of the progress of bootstrapping a chain.
+type BootstrapProgress struct {
+	Phase BootstrapPhase
+	// PhaseStartTime is the time the current phase started.
+	PhaseStartTime time.Time
+
+	// StartingHeight is the height of the last accepted block when
+	// bootstrapping started.
+	StartingHeight uint64
+	// TipHeight is the greatest height of the blocks that have been fetched.
+	TipHeight uint64
+
+	// NumFetched is the number of blocks that have been fetched, including
+	// blocks fetched by previous runs of the bootstrapper.
+	NumFetched uint64
+	// NumToFetch is the number of blocks between [StartingHeight] and
+	// [TipHeight].
+	NumToFetch uint64
+
+	NumExecuted  uint64
+	NumToExecute uint64
+
+	// Rate is the number of blocks per second that have been fetched or
+	// executed during the current phase.
+	Rate float64
+	// ETA is the estimated time until the current phase finishes.
+	ETA time.Duration
+}
+
+// BootstrapProgressReporter reports the progress of bootstrapping a chain.
+type BootstrapProgressReporter interface {
+	BootstrapProgress() BootstrapProgress
+}
+
+// BootstrapProgressTracker records the progress of bootstrapping a chain.
+//
+// Progress is updated while the chain's lock is held, which can be held for
+// the entire execution phase, so the tracker synchronizes access itself to
+// allow the progress to be reported concurrently.
+type BootstrapProgressTracker struct {
+	lock     sync.RWMutex
+	progress BootstrapProgress
+	// phaseStartCount is the number of blocks that had already been fetched or
+	// executed when the current phase started.
+	phaseStartCount uint64
+
+	phase        *prometheus.GaugeVec
+	tipHeight    prometheus.Gauge
+	numToFetch   prometheus.Gauge
+	numExecuted  prometheus.Gauge
+	numToExecute prometheus.Gauge
+	rate         prometheus.Gauge
+}
+
+func NewBootstrapProgressTracker(namespace string, registerer prometheus.Registerer) (*BootstrapProgressTracker, error) {
+	t := &BootstrapProgressTracker{
+		phase: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "phase",
+				Help:      "1 if bootstrapping is in the labeled phase, 0 otherwise",
+			},
+			[]string{"phase"},
+		),
+		tipHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "tip_height",
+			Help:      "Greatest height of the blocks fetched during bootstrapping",
+		}),
+		numToFetch: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "to_fetch",
+			Help:      "Number of blocks between the last accepted block and the tip when bootstrapping started",
+		}),
+		numExecuted: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "executed",
+			Help:      "Number of blocks executed during the current execution phase of bootstrapping",
+		}),
+		numToExecute: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "to_execute",
+			Help:      "Number of blocks to execute during the current execution phase of bootstrapping",
+		}),
+		rate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate",
+			Help:      "Number of blocks per second fetched or executed during the current phase of bootstrapping",
+		}),
+	}
+	err := utils.Err(
+		registerer.Register(t.phase),
+		registerer.Register(t.tipHeight),
+		registerer.Register(t.numToFetch),
+		registerer.Register(t.numExecuted),
+		registerer.Register(t.numToExecute),
+		registerer.Register(t.rate),
+	)
+	t.SetPhase(BootstrapPhaseFrontier)
+	return t, err
+}
+
+// SetPhase records that bootstrapping has entered [phase].
+func (t *BootstrapProgressTracker) SetPhase(phase BootstrapPhase) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.progress.Phase = phase
+	t.progress.PhaseStartTime = time.Now()
+	t.progress.Rate = 0
+	t.progress.ETA = 0
+	switch phase {
+	case BootstrapPhaseFetching:
+		t.phaseStartCount = t.progress.NumFetched
+	case BootstrapPhaseExecuting:
+		t.progress.NumExecuted = 0
+		t.progress.NumToExecute = 0
+		t.phaseStartCount = 0
+	}
+
+	for _, p := range bootstrapPhases {
+		value := 0.0
+		if p == phase {
+			value = 1
+		}
+		t.phase.WithLabelValues(string(p)).Set(value)
+	}
+	t.rate.Set(0)
+}
+
+// Fetched records that [numFetched] blocks have been fetched out of the blocks
+// between [startingHeight] and [tipHeight].
+func (t *BootstrapProgressTracker) Fetched(startingHeight, tipHeight, numFetched uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.progress.StartingHeight = startingHeight
+	t.progress.TipHeight = tipHeight
+	t.progress.NumFetched = numFetched
+	t.progress.NumToFetch = 0
+	if tipHeight > startingHeight {
+		t.progress.NumToFetch = tipHeight - startingHeight
+	}
+	if t.progress.Phase == BootstrapPhaseFetching {
+		t.updateRate(numFetched, t.progress.NumToFetch)
+	}
+
+	t.tipHeight.Set(float64(tipHeight))
+	t.numToFetch.Set(float64(t.progress.NumToFetch))
+}
+
+// Executed records that [numExecuted] out of [numToExecute] blocks have been
+// executed during the current execution phase.
+func (t *BootstrapProgressTracker) Executed(numExecuted, numToExecute uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.progress.NumExecuted = numExecuted
+	t.progress.NumToExecute = numToExecute
+	if t.progress.Phase == BootstrapPhaseExecuting {
+		t.updateRate(numExecuted, numToExecute)
+	}
+
+	t.numExecuted.Set(float64(numExecuted))
+	t.numToExecute.Set(float64(numToExecute))
+}
+
+// updateRate assumes the lock is held.
+func (t *BootstrapProgressTracker) updateRate(done, total uint64) {
+	if done <= t.phaseStartCount || total <= t.phaseStartCount {
+		return
+	}
+	elapsed := time.Since(t.progress.PhaseStartTime)
+	if elapsed <= 0 {
+		return
+	}
+
+	progress := done - t.phaseStartCount
+	end := total - t.phaseStartCount
+	t.progress.Rate = float64(progress) / elapsed.Seconds()
+	t.progress.ETA = 0
+	if progress < end {
+		t.progress.ETA = timer.EstimateETA(t.progress.PhaseStartTime, progress, end)
+	}
+	t.rate.Set(t.progress.Rate)
+}
+
+func (t *BootstrapProgressTracker) BootstrapProgress() BootstrapProgress {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.progress
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrapProgressTracker(t *testing.T) {
+	require := require.New(t)
+
+	tracker, err := NewBootstrapProgressTracker("", prometheus.NewRegistry())
+	require.NoError(err)
+	require.Equal(BootstrapPhaseFrontier, tracker.BootstrapProgress().Phase)
+
+	// Blocks fetched by a previous run shouldn't count towards the rate
+	tracker.Fetched(10, 110, 20)
+	tracker.SetPhase(BootstrapPhaseFetching)
+	progress := tracker.BootstrapProgress()
+	require.Equal(BootstrapPhaseFetching, progress.Phase)
+	require.Equal(uint64(10), progress.StartingHeight)
+	require.Equal(uint64(110), progress.TipHeight)
+	require.Equal(uint64(20), progress.NumFetched)
+	require.Equal(uint64(100), progress.NumToFetch)
+	require.Zero(progress.Rate)
+
+	tracker.Fetched(10, 110, 60)
+	progress = tracker.BootstrapProgress()
+	require.Equal(uint64(60), progress.NumFetched)
+	require.Positive(progress.Rate)
+
+	tracker.SetPhase(BootstrapPhaseExecuting)
+	progress = tracker.BootstrapProgress()
+	require.Equal(BootstrapPhaseExecuting, progress.Phase)
+	require.Zero(progress.Rate)
+	require.Zero(progress.ETA)
+
+	tracker.Executed(100, 100)
+	progress = tracker.BootstrapProgress()
+	require.Equal(uint64(100), progress.NumExecuted)
+	require.Equal(uint64(100), progress.NumToExecute)
+	require.Positive(progress.Rate)
+	require.Zero(progress.ETA)
+
+	tracker.SetPhase(BootstrapPhaseFinished)
+	progress = tracker.BootstrapProgress()
+	require.Equal(BootstrapPhaseFinished, progress.Phase)
+	require.Equal(uint64(100), progress.NumExecuted)
+}
+
+func TestBootstrapProgressTrackerRateBeforeProgress(t *testing.T) {
+	require := require.New(t)
+
+	tracker, err := NewBootstrapProgressTracker("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// The tip height can be below the starting height before any blocks have
+	// been fetched
+	tracker.Fetched(10, 0, 0)
+	tracker.SetPhase(BootstrapPhaseFetching)
+	tracker.Fetched(10, 0, 0)
+
+	progress := tracker.BootstrapProgress()
+	require.Zero(progress.NumToFetch)
+	require.Zero(progress.Rate)
+	require.Zero(progress.ETA)
+}
@@ -184,6 +184,19 @@ type GetAcceptedHandler interface {
 		requestID uint32,
 		containerIDs []ids.ID,
 	) error
+
+	// Notify this engine of a request for an Accepted message with the same
+	// requestID and the IDs of the containers that this node has accepted at
+	// [heights], in order, up to the first height that this node hasn't
+	// accepted a container at.
+	//
+	// This function can be called by any node at any time.
+	GetAcceptedHeights(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		requestID uint32,
+		heights []uint64,
+	) error
 }
 
 type AcceptedHandler interface {
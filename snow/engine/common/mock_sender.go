@@ -234,6 +234,18 @@ func (mr *MockSenderMockRecorder) SendGetAcceptedFrontier(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetAcceptedFrontier", reflect.TypeOf((*MockSender)(nil).SendGetAcceptedFrontier), arg0, arg1, arg2)
 }
 
+// SendGetAcceptedHeights mocks base method.
+func (m *MockSender) SendGetAcceptedHeights(arg0 context.Context, arg1 ids.NodeID, arg2 uint32, arg3 []uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendGetAcceptedHeights", arg0, arg1, arg2, arg3)
+}
+
+// SendGetAcceptedHeights indicates an expected call of SendGetAcceptedHeights.
+func (mr *MockSenderMockRecorder) SendGetAcceptedHeights(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetAcceptedHeights", reflect.TypeOf((*MockSender)(nil).SendGetAcceptedHeights), arg0, arg1, arg2, arg3)
+}
+
 // SendGetAcceptedStateSummary mocks base method.
 func (m *MockSender) SendGetAcceptedStateSummary(arg0 context.Context, arg1 set.Set[ids.NodeID], arg2 uint32, arg3 []uint64) {
 	m.ctrl.T.Helper()
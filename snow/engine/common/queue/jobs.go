@@ -32,6 +32,8 @@ type Jobs struct {
 	state *state
 	// Measures the ETA until bootstrapping finishes in nanoseconds.
 	etaMetric prometheus.Gauge
+	// progress, if non-nil, records the progress of ExecuteAll.
+	progress *common.BootstrapProgressTracker
 }
 
 // New attempts to create a new job queue from the provided database.
@@ -65,6 +67,12 @@ func (j *Jobs) SetParser(parser Parser) error {
 	return nil
 }
 
+// SetProgressTracker tells this job queue where to record the progress of
+// executing jobs.
+func (j *Jobs) SetProgressTracker(progress *common.BootstrapProgressTracker) {
+	j.progress = progress
+}
+
 func (j *Jobs) Has(jobID ids.ID) (bool, error) {
 	return j.state.HasJob(jobID)
 }
@@ -124,6 +132,9 @@ func (j *Jobs) ExecuteAll(
 	numToExecute := j.state.numJobs
 	startTime := time.Now()
 	lastProgressUpdate := startTime
+	if j.progress != nil {
+		j.progress.Executed(0, numToExecute)
+	}
 
 	// Disable and clear state caches to prevent us from attempting to execute
 	// a vertex that was previously parsed, but not saved to the VM. Some VMs
@@ -191,6 +202,9 @@ func (j *Jobs) ExecuteAll(
 		}
 
 		numExecuted++
+		if j.progress != nil {
+			j.progress.Executed(uint64(numExecuted), numToExecute)
+		}
 		if time.Since(lastProgressUpdate) > progressUpdateFrequency { // Periodically print progress
 			eta := timer.EstimateETA(
 				startTime,
@@ -100,6 +100,16 @@ type AcceptedSender interface {
 		containerIDs []ids.ID,
 	)
 
+	// SendGetAcceptedHeights requests that node [nodeID] sends an Accepted
+	// message with the IDs of the containers that the node accepted at
+	// [heights].
+	SendGetAcceptedHeights(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		requestID uint32,
+		heights []uint64,
+	)
+
 	// SendAccepted responds to a GetAccepted message with a set of IDs of
 	// containers that are accepted.
 	SendAccepted(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID)
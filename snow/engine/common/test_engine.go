@@ -30,6 +30,7 @@ var (
 	errGetAcceptedFrontierFailed     = errors.New("unexpectedly called GetAcceptedFrontierFailed")
 	errAcceptedFrontier              = errors.New("unexpectedly called AcceptedFrontier")
 	errGetAccepted                   = errors.New("unexpectedly called GetAccepted")
+	errGetAcceptedHeights            = errors.New("unexpectedly called GetAcceptedHeights")
 	errGetAcceptedFailed             = errors.New("unexpectedly called GetAcceptedFailed")
 	errAccepted                      = errors.New("unexpectedly called Accepted")
 	errGet                           = errors.New("unexpectedly called Get")
@@ -76,6 +77,7 @@ type EngineTest struct {
 	CantAcceptedFrontier,
 
 	CantGetAccepted,
+	CantGetAcceptedHeights,
 	CantGetAcceptedFailed,
 	CantAccepted,
 
@@ -127,6 +129,7 @@ type EngineTest struct {
 	AppRequestFailedF           func(ctx context.Context, nodeID ids.NodeID, requestID uint32) error
 	StateSummaryFrontierF       func(ctx context.Context, nodeID ids.NodeID, requestID uint32, summary []byte) error
 	GetAcceptedStateSummaryF    func(ctx context.Context, nodeID ids.NodeID, requestID uint32, keys []uint64) error
+	GetAcceptedHeightsF         func(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) error
 	AcceptedStateSummaryF       func(ctx context.Context, nodeID ids.NodeID, requestID uint32, summaryIDs []ids.ID) error
 	ConnectedF                  func(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error
 	DisconnectedF               func(ctx context.Context, nodeID ids.NodeID) error
@@ -159,6 +162,7 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantGetAcceptedFrontierFailed = cant
 	e.CantAcceptedFrontier = cant
 	e.CantGetAccepted = cant
+	e.CantGetAcceptedHeights = cant
 	e.CantGetAcceptedFailed = cant
 	e.CantAccepted = cant
 	e.CantGet = cant
@@ -405,6 +409,19 @@ func (e *EngineTest) GetAccepted(ctx context.Context, nodeID ids.NodeID, request
 	return errGetAccepted
 }
 
+func (e *EngineTest) GetAcceptedHeights(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) error {
+	if e.GetAcceptedHeightsF != nil {
+		return e.GetAcceptedHeightsF(ctx, nodeID, requestID, heights)
+	}
+	if !e.CantGetAcceptedHeights {
+		return nil
+	}
+	if e.T != nil {
+		require.FailNow(e.T, errGetAcceptedHeights.Error())
+	}
+	return errGetAcceptedHeights
+}
+
 func (e *EngineTest) GetAcceptedFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
 	if e.GetAcceptedFailedF != nil {
 		return e.GetAcceptedFailedF(ctx, nodeID, requestID)
@@ -33,7 +33,7 @@ type SenderTest struct {
 	CantSendGetStateSummaryFrontier, CantSendStateSummaryFrontier,
 	CantSendGetAcceptedStateSummary, CantSendAcceptedStateSummary,
 	CantSendGetAcceptedFrontier, CantSendAcceptedFrontier,
-	CantSendGetAccepted, CantSendAccepted,
+	CantSendGetAccepted, CantSendGetAcceptedHeights, CantSendAccepted,
 	CantSendGet, CantSendGetAncestors, CantSendPut, CantSendAncestors,
 	CantSendPullQuery, CantSendPushQuery, CantSendChits,
 	CantSendGossip,
@@ -48,6 +48,7 @@ type SenderTest struct {
 	SendGetAcceptedFrontierF     func(context.Context, set.Set[ids.NodeID], uint32)
 	SendAcceptedFrontierF        func(context.Context, ids.NodeID, uint32, ids.ID)
 	SendGetAcceptedF             func(context.Context, set.Set[ids.NodeID], uint32, []ids.ID)
+	SendGetAcceptedHeightsF      func(context.Context, ids.NodeID, uint32, []uint64)
 	SendAcceptedF                func(context.Context, ids.NodeID, uint32, []ids.ID)
 	SendGetF                     func(context.Context, ids.NodeID, uint32, ids.ID)
 	SendGetAncestorsF            func(context.Context, ids.NodeID, uint32, ids.ID)
@@ -75,6 +76,7 @@ func (s *SenderTest) Default(cant bool) {
 	s.CantSendGetAcceptedFrontier = cant
 	s.CantSendAcceptedFrontier = cant
 	s.CantSendGetAccepted = cant
+	s.CantSendGetAcceptedHeights = cant
 	s.CantSendAccepted = cant
 	s.CantSendGet = cant
 	s.CantSendGetAccepted = cant
@@ -185,6 +187,17 @@ func (s *SenderTest) SendGetAccepted(ctx context.Context, nodeIDs set.Set[ids.No
 	}
 }
 
+// SendGetAcceptedHeights calls SendGetAcceptedHeightsF if it was initialized.
+// If it wasn't initialized and this function shouldn't be called and testing
+// was initialized, then testing will fail.
+func (s *SenderTest) SendGetAcceptedHeights(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) {
+	if s.SendGetAcceptedHeightsF != nil {
+		s.SendGetAcceptedHeightsF(ctx, nodeID, requestID, heights)
+	} else if s.CantSendGetAcceptedHeights && s.T != nil {
+		require.FailNow(s.T, "Unexpectedly called SendGetAcceptedHeights")
+	}
+}
+
 // SendAccepted calls SendAcceptedF if it was initialized. If it wasn't
 // initialized and this function shouldn't be called and testing was
 // initialized, then testing will fail.
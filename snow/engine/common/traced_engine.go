@@ -136,6 +136,17 @@ func (e *tracedEngine) GetAccepted(ctx context.Context, nodeID ids.NodeID, reque
 	return e.engine.GetAccepted(ctx, nodeID, requestID, containerIDs)
 }
 
+func (e *tracedEngine) GetAcceptedHeights(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.GetAcceptedHeights", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.Int("numHeights", len(heights)),
+	))
+	defer span.End()
+
+	return e.engine.GetAcceptedHeights(ctx, nodeID, requestID, heights)
+}
+
 func (e *tracedEngine) Accepted(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) error {
 	ctx, span := e.tracer.Start(ctx, "tracedEngine.Accepted", oteltrace.WithAttributes(
 		attribute.Stringer("nodeID", nodeID),
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// repollLatencyPercentile is the query latency percentile used to
+	// determine the number of concurrent repolls.
+	repollLatencyPercentile = .5
+	// pollTimeoutLatencyPercentile is the query latency percentile used to
+	// determine when outstanding polls are expired.
+	pollTimeoutLatencyPercentile = .99
+)
+
+// AdaptivePollsConfig configures the engine to adjust the number of concurrent
+// repolls and the poll timeout based on the observed query latency.
+//
+// The consensus parameters that provide safety (K, AlphaPreference,
+// AlphaConfidence, BetaVirtuous and BetaRogue) are never modified.
+type AdaptivePollsConfig struct {
+	Enabled bool `json:"enabled"`
+	// FastLatency is the median query latency at or below which
+	// [MaxConcurrentRepolls] polls are kept outstanding. As the median query
+	// latency grows above [FastLatency], the number of concurrent repolls
+	// shrinks proportionally, but never below the ConcurrentRepolls
+	// consensus parameter.
+	FastLatency time.Duration `json:"fastLatency"`
+	// MaxConcurrentRepolls is the maximum number of polls to keep outstanding.
+	// It is capped by the BetaRogue consensus parameter.
+	MaxConcurrentRepolls int `json:"maxConcurrentRepolls"`
+	// Polls that have been outstanding for longer than [TimeoutCoefficient]
+	// times the 99th percentile query latency are finished early, treating
+	// any outstanding votes as dropped.
+	TimeoutCoefficient float64 `json:"timeoutCoefficient"`
+	// MinimumTimeout is the minimum amount of time a poll will be outstanding
+	// before it is finished early.
+	MinimumTimeout time.Duration `json:"minimumTimeout"`
+}
+
+// QueryLatencyReporter reports the latency of the queries sent by the engine.
+type QueryLatencyReporter interface {
+	// QueryLatency returns the [percentile] latency, in [0, 1], of the most
+	// recent queries. Returns false if no latencies have been observed.
+	QueryLatency(percentile float64) (time.Duration, bool)
+}
+
+// concurrentRepolls returns the number of polls to keep outstanding.
+func (t *Transitive) concurrentRepolls() int {
+	repolls := t.Params.ConcurrentRepolls
+	if !t.AdaptivePolls.Enabled {
+		return repolls
+	}
+
+	latency, ok := t.QueryLatency.QueryLatency(repollLatencyPercentile)
+	if !ok {
+		return repolls
+	}
+
+	adaptiveRepolls := t.AdaptivePolls.MaxConcurrentRepolls
+	if latency > t.AdaptivePolls.FastLatency {
+		adaptiveRepolls = int(int64(adaptiveRepolls) * int64(t.AdaptivePolls.FastLatency) / int64(latency))
+	}
+	if adaptiveRepolls > t.Params.BetaRogue {
+		adaptiveRepolls = t.Params.BetaRogue
+	}
+	if adaptiveRepolls > repolls {
+		repolls = adaptiveRepolls
+	}
+	return repolls
+}
+
+// expirePolls finishes the polls that have been outstanding for longer than
+// the adaptive poll timeout.
+func (t *Transitive) expirePolls(ctx context.Context) {
+	if !t.AdaptivePolls.Enabled {
+		return
+	}
+
+	latency, ok := t.QueryLatency.QueryLatency(pollTimeoutLatencyPercentile)
+	if !ok {
+		return
+	}
+
+	timeout := time.Duration(t.AdaptivePolls.TimeoutCoefficient * float64(latency))
+	if timeout < t.AdaptivePolls.MinimumTimeout {
+		timeout = t.AdaptivePolls.MinimumTimeout
+	}
+	t.metrics.pollTimeout.Set(float64(timeout))
+
+	results := t.polls.Expire(t.Clock.Time().Add(-timeout))
+	t.recordPolls(ctx, results)
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+type testQueryLatencyReporter struct {
+	latency time.Duration
+	ok      bool
+}
+
+func (r *testQueryLatencyReporter) QueryLatency(float64) (time.Duration, bool) {
+	return r.latency, r.ok
+}
+
+func TestAdaptiveConcurrentRepolls(t *testing.T) {
+	params := snowball.Parameters{
+		BetaRogue:         20,
+		ConcurrentRepolls: 2,
+	}
+	adaptivePolls := AdaptivePollsConfig{
+		Enabled:              true,
+		FastLatency:          100 * time.Millisecond,
+		MaxConcurrentRepolls: 8,
+	}
+
+	tests := []struct {
+		name          string
+		adaptivePolls AdaptivePollsConfig
+		latency       *testQueryLatencyReporter
+		expected      int
+	}{
+		{
+			name:          "disabled",
+			adaptivePolls: AdaptivePollsConfig{},
+			latency:       &testQueryLatencyReporter{},
+			expected:      2,
+		},
+		{
+			name:          "no observed latency",
+			adaptivePolls: adaptivePolls,
+			latency:       &testQueryLatencyReporter{},
+			expected:      2,
+		},
+		{
+			name:          "fast network",
+			adaptivePolls: adaptivePolls,
+			latency: &testQueryLatencyReporter{
+				latency: 50 * time.Millisecond,
+				ok:      true,
+			},
+			expected: 8,
+		},
+		{
+			name:          "twice the fast latency",
+			adaptivePolls: adaptivePolls,
+			latency: &testQueryLatencyReporter{
+				latency: 200 * time.Millisecond,
+				ok:      true,
+			},
+			expected: 4,
+		},
+		{
+			name:          "slow network",
+			adaptivePolls: adaptivePolls,
+			latency: &testQueryLatencyReporter{
+				latency: time.Second,
+				ok:      true,
+			},
+			expected: 2,
+		},
+		{
+			name: "capped by beta rogue",
+			adaptivePolls: AdaptivePollsConfig{
+				Enabled:              true,
+				FastLatency:          100 * time.Millisecond,
+				MaxConcurrentRepolls: 50,
+			},
+			latency: &testQueryLatencyReporter{
+				latency: 50 * time.Millisecond,
+				ok:      true,
+			},
+			expected: 20,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			te := &Transitive{
+				Config: Config{
+					Params:        params,
+					AdaptivePolls: test.adaptivePolls,
+					QueryLatency:  test.latency,
+				},
+			}
+			require.Equal(t, test.expected, te.concurrentRepolls())
+		})
+	}
+}
+
+func TestAdaptivePollsExpire(t *testing.T) {
+	require := require.New(t)
+
+	engCfg := DefaultConfigs()
+	engCfg.AdaptivePolls = AdaptivePollsConfig{
+		Enabled:              true,
+		FastLatency:          time.Second,
+		MaxConcurrentRepolls: 2,
+		TimeoutCoefficient:   1,
+	}
+	engCfg.QueryLatency = &testQueryLatencyReporter{
+		latency: time.Second,
+		ok:      true,
+	}
+	now := time.Unix(1_000_000, 0)
+	engCfg.Clock = &mockable.Clock{}
+	engCfg.Clock.Set(now)
+	_, _, sender, vm, te, gBlk := setup(t, common.DefaultConfigTest(), engCfg)
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return gBlk.ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	vm.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return blk, nil
+	}
+
+	numQueries := 0
+	sender.SendPushQueryF = func(context.Context, set.Set[ids.NodeID], uint32, []byte, uint64) {
+		numQueries++
+	}
+	sender.SendPullQueryF = func(context.Context, set.Set[ids.NodeID], uint32, ids.ID, uint64) {
+		numQueries++
+	}
+	sender.CantSendGossip = false
+
+	// Issuing the block sends a push query and repolls up to the adaptive
+	// number of concurrent repolls.
+	require.NoError(te.Notify(context.Background(), common.PendingTxs))
+	require.Equal(2, numQueries)
+	require.Equal(2, te.polls.Len())
+
+	// The polls haven't been outstanding for longer than the poll timeout
+	// yet.
+	engCfg.Clock.Set(now.Add(time.Second))
+	require.NoError(te.Gossip(context.Background()))
+	require.Equal(2, numQueries)
+	require.Equal(2, te.polls.Len())
+
+	engCfg.Clock.Set(now.Add(time.Second + time.Nanosecond))
+
+	// The outstanding polls are expired without any votes, so the engine
+	// repolls.
+	require.NoError(te.Gossip(context.Background()))
+	require.Equal(4, numQueries)
+	require.Equal(2, te.polls.Len())
+	require.Equal(choices.Processing, blk.Status())
+}
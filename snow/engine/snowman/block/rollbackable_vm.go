@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrRollbackableVMNotImplemented = errors.New("vm does not implement RollbackableVM interface")
+
+// RollbackableVM contains the functionality to rewind the accepted chain to a
+// previously accepted height. This allows recovering from a bad upgrade
+// without resyncing the chain.
+type RollbackableVM interface {
+	// Rollback sets the last accepted block to the block that was accepted at
+	// [height]. The blocks accepted after [height] are no longer considered
+	// accepted, and the height index no longer maps their heights.
+	//
+	// Rollback is only called after the VM is initialized and before the VM is
+	// bootstrapped, so no blocks are processing.
+	Rollback(ctx context.Context, height uint64) error
+}
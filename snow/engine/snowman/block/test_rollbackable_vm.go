@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ RollbackableVM = (*TestRollbackableVM)(nil)
+
+	errRollback = errors.New("unexpectedly called Rollback")
+)
+
+type TestRollbackableVM struct {
+	T *testing.T
+
+	CantRollback bool
+
+	RollbackF func(ctx context.Context, height uint64) error
+}
+
+func (vm *TestRollbackableVM) Rollback(ctx context.Context, height uint64) error {
+	if vm.RollbackF != nil {
+		return vm.RollbackF(ctx, height)
+	}
+	if vm.CantRollback && vm.T != nil {
+		require.FailNow(vm.T, errRollback.Error())
+	}
+	return errRollback
+}
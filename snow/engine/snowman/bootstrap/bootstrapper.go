@@ -25,11 +25,20 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 )
 
-// Parameters for delaying bootstrapping to avoid potential CPU burns
-const bootstrappingDelay = 10 * time.Second
+const (
+	// Parameters for delaying bootstrapping to avoid potential CPU burns
+	bootstrappingDelay = 10 * time.Second
+
+	// maxSpeculativeRanges is the maximum number of ranges below the blocks
+	// that are known to be accepted that are fetched ahead of time. One
+	// request slot is always left for the blocks that are known to be
+	// accepted.
+	maxSpeculativeRanges = common.MaxOutstandingGetAncestorsRequests - 1
+)
 
 var (
-	_ common.BootstrapableEngine = (*bootstrapper)(nil)
+	_ common.BootstrapableEngine       = (*bootstrapper)(nil)
+	_ common.BootstrapProgressReporter = (*bootstrapper)(nil)
 
 	errUnexpectedTimeout = errors.New("unexpected timeout fired")
 )
@@ -51,6 +60,8 @@ type bootstrapper struct {
 	common.Fetcher
 	*metrics
 
+	progress *common.BootstrapProgressTracker
+
 	started bool
 
 	// Greatest height of the blocks passed in ForceAccepted
@@ -78,20 +89,62 @@ type bootstrapper struct {
 	// again.
 	fetchFrom set.Set[ids.NodeID]
 
+	// needToFetch contains the blocks that are known to be accepted and still
+	// need to be requested. Each block is the top of a range that extends down
+	// to the next block that has already been fetched.
+	needToFetch set.Set[ids.ID]
+	// fetchStartTimes contains the time of the request for each block in
+	// [OutstandingRequests].
+	fetchStartTimes map[ids.ID]time.Time
+
+	// heightRequests contains the heights whose block IDs were requested from
+	// peers, keyed by the ID of the GetAcceptedHeights request.
+	heightRequests map[uint32]heightRequest
+	// lowestRequestedHeight is the lowest height whose block ID was requested,
+	// or 0 if no heights have been requested since ForceAccepted was called.
+	lowestRequestedHeight uint64
+	// speculative contains the blocks that peers reported at the requested
+	// heights, and the ancestors of those blocks, which are not yet known to
+	// be accepted. They are fetched in parallel with [needToFetch], but are
+	// only processed once the blocks that are known to be accepted reach them.
+	speculative map[ids.ID]heightRange
+	// buffered contains the blocks fetched in response to requests for
+	// [speculative] blocks. Because a peer may have reported an incorrect
+	// block for a height, these blocks are only added to the jobs queue once
+	// they are the parent of a block that was added to the jobs queue.
+	buffered map[ids.ID]snowman.Block
+
 	// bootstrappedOnce ensures that the [Bootstrapped] callback is only invoked
 	// once, even if bootstrapping is retried.
 	bootstrappedOnce sync.Once
 }
 
+type heightRequest struct {
+	nodeID ids.NodeID
+	height uint64
+}
+
+// heightRange is the range of heights (bottom, height] that is fetched when
+// requesting a speculative block at [height].
+type heightRange struct {
+	height uint64
+	bottom uint64
+}
+
 func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) error) (common.BootstrapableEngine, error) {
 	metrics, err := newMetrics("bs", config.Ctx.Registerer)
 	if err != nil {
 		return nil, err
 	}
+	progress, err := common.NewBootstrapProgressTracker("bs", config.Ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
 
 	b := &bootstrapper{
 		Config:                      config,
 		metrics:                     metrics,
+		progress:                    progress,
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
 		AcceptedStateSummaryHandler: common.NewNoOpAcceptedStateSummaryHandler(config.Ctx.Log),
 		PutHandler:                  common.NewNoOpPutHandler(config.Ctx.Log),
@@ -103,6 +156,10 @@ func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) e
 			OnFinished: onFinished,
 		},
 		executedStateTransitions: math.MaxInt32,
+		fetchStartTimes:          make(map[ids.ID]time.Time),
+		heightRequests:           make(map[uint32]heightRequest),
+		speculative:              make(map[ids.ID]heightRange),
+		buffered:                 make(map[ids.ID]snowman.Block),
 	}
 
 	config.Bootstrapable = b
@@ -132,6 +189,8 @@ func (b *bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 	if err := b.Blocked.SetParser(ctx, b.parser); err != nil {
 		return err
 	}
+	b.Blocked.SetProgressTracker(b.progress)
+	b.progress.SetPhase(common.BootstrapPhaseFrontier)
 
 	// Set the starting height
 	lastAcceptedID, err := b.VM.LastAccepted(ctx)
@@ -165,6 +224,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		)
 		return nil
 	}
+	fetchStartTime, hasFetchStartTime := b.removeFetchStartTime(wantedBlkID)
 
 	lenBlks := len(blks)
 	if lenBlks == 0 {
@@ -176,7 +236,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		b.markUnavailable(nodeID)
 
 		// Send another request for this
-		return b.fetch(ctx, wantedBlkID)
+		return b.fetchFailed(ctx, nodeID, wantedBlkID)
 	}
 
 	// This node has responded - so add it back into the set
@@ -198,7 +258,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Uint32("requestID", requestID),
 			zap.Error(err),
 		)
-		return b.fetch(ctx, wantedBlkID)
+		return b.fetchFailed(ctx, nodeID, wantedBlkID)
 	}
 
 	if len(blocks) == 0 {
@@ -206,7 +266,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
 		)
-		return b.fetch(ctx, wantedBlkID)
+		return b.fetchFailed(ctx, nodeID, wantedBlkID)
 	}
 
 	requestedBlock := blocks[0]
@@ -215,7 +275,17 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("expectedBlkID", wantedBlkID),
 			zap.Stringer("blkID", actualID),
 		)
-		return b.fetch(ctx, wantedBlkID)
+		return b.fetchFailed(ctx, nodeID, wantedBlkID)
+	}
+
+	// Requests sent before a restart aren't timed.
+	if hasFetchStartTime {
+		b.observeFetch(nodeID, len(blocks), time.Since(fetchStartTime))
+	}
+
+	if fetchRange, ok := b.speculative[wantedBlkID]; ok {
+		delete(b.speculative, wantedBlkID)
+		return b.buffer(ctx, nodeID, fetchRange, blocks)
 	}
 
 	blockSet := make(map[ids.ID]snowman.Block, len(blocks))
@@ -234,12 +304,61 @@ func (b *bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 		)
 		return nil
 	}
+	b.removeFetchStartTime(blkID)
 
 	// This node timed out their request, so we can add them back to [fetchFrom]
 	b.fetchFrom.Add(nodeID)
 
 	// Send another request for this
-	return b.fetch(ctx, blkID)
+	return b.fetchFailed(ctx, nodeID, blkID)
+}
+
+// Accepted handles the response to a request for the ID of the block at a
+// height. The block reported by the peer is fetched, along with its ancestors,
+// in parallel with the blocks that are known to be accepted. Responses to the
+// GetAccepted requests of the accepted frontier phase are passed to the
+// embedded [common.Bootstrapper].
+func (b *bootstrapper) Accepted(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) error {
+	request, ok := b.heightRequests[requestID]
+	if !ok || request.nodeID != nodeID {
+		return b.Bootstrapper.Accepted(ctx, nodeID, requestID, containerIDs)
+	}
+	delete(b.heightRequests, requestID)
+
+	// Peers that don't know the block at the requested height, or that don't
+	// support height requests, respond without any IDs.
+	if len(containerIDs) == 0 {
+		return nil
+	}
+
+	blkID := containerIDs[0]
+	if b.isFetched(ctx, blkID) {
+		return nil
+	}
+
+	rangeSize := uint64(b.Config.AncestorsMaxContainersReceived)
+	bottom := b.startingHeight
+	if request.height > bottom+rangeSize {
+		bottom = request.height - rangeSize
+	}
+	b.speculative[blkID] = heightRange{
+		height: request.height,
+		bottom: bottom,
+	}
+	return b.sendGetAncestors(ctx)
+}
+
+// GetAcceptedFailed drops the request for the ID of the block at a height. The
+// blocks in that range are fetched once the blocks that are known to be
+// accepted reach them. Failures of the GetAccepted requests of the accepted
+// frontier phase are passed to the embedded [common.Bootstrapper].
+func (b *bootstrapper) GetAcceptedFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	request, ok := b.heightRequests[requestID]
+	if !ok || request.nodeID != nodeID {
+		return b.Bootstrapper.GetAcceptedFailed(ctx, nodeID, requestID)
+	}
+	delete(b.heightRequests, requestID)
+	return nil
 }
 
 func (b *bootstrapper) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
@@ -283,12 +402,23 @@ func (b *bootstrapper) Timeout(ctx context.Context) error {
 	b.awaitingTimeout = false
 
 	if !b.Config.BootstrapTracker.IsBootstrapped() {
+		b.progress.SetPhase(common.BootstrapPhaseFrontier)
 		return b.Restart(ctx, true)
 	}
 	b.fetchETA.Set(0)
+	b.progress.SetPhase(common.BootstrapPhaseFinished)
 	return b.OnFinished(ctx, b.Config.SharedCfg.RequestID)
 }
 
+// Restart drops the ranges that were waiting to be fetched, as they are found
+// again once the new accepted frontier is passed to ForceAccepted.
+func (b *bootstrapper) Restart(ctx context.Context, reset bool) error {
+	b.needToFetch.Clear()
+	b.fetchStartTimes = make(map[ids.ID]time.Time)
+	b.clearHeightRanges()
+	return b.Bootstrapper.Restart(ctx, reset)
+}
+
 func (*bootstrapper) Gossip(context.Context) error {
 	return nil
 }
@@ -330,11 +460,17 @@ func (b *bootstrapper) GetVM() common.VM {
 	return b.VM
 }
 
+func (b *bootstrapper) BootstrapProgress() common.BootstrapProgress {
+	return b.progress.BootstrapProgress()
+}
+
 func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs []ids.ID) error {
 	pendingContainerIDs := b.Blocked.MissingIDs()
 
 	// Initialize the fetch from set to the currently preferred peers
 	b.fetchFrom = b.StartupTracker.PreferredPeers()
+	b.needToFetch.Clear()
+	b.clearHeightRanges()
 
 	// Append the list of accepted container IDs to pendingContainerIDs to ensure
 	// we iterate over every container that must be traversed.
@@ -351,16 +487,19 @@ func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs [
 		// `database.ErrNotFound`, then the error should be propagated.
 		blk, err := b.VM.GetBlock(ctx, blkID)
 		if err != nil {
-			if err := b.fetch(ctx, blkID); err != nil {
-				return err
-			}
+			b.needToFetch.Add(blkID)
 			continue
 		}
 		toProcess = append(toProcess, blk)
 	}
+	if err := b.sendGetAncestors(ctx); err != nil {
+		return err
+	}
 
 	b.initiallyFetched = b.Blocked.PendingJobs()
 	b.startTime = time.Now()
+	b.progress.Fetched(b.startingHeight, b.tipHeight, b.initiallyFetched)
+	b.progress.SetPhase(common.BootstrapPhaseFetching)
 
 	// Process received blocks
 	for _, blk := range toProcess {
@@ -372,8 +511,13 @@ func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs [
 	return b.checkFinish(ctx)
 }
 
-// Get block [blkID] and its ancestors from a validator
+// Add block [blkID] to the set of blocks that need to be fetched and then
+// request it, along with its ancestors, from a validator.
 func (b *bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
+	// If [blkID] was requested ahead of time, it is now known to be accepted,
+	// so the response can be processed directly.
+	delete(b.speculative, blkID)
+
 	// Make sure we haven't already requested this block
 	if b.OutstandingRequests.Contains(blkID) {
 		return nil
@@ -384,19 +528,216 @@ func (b *bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 		return b.checkFinish(ctx)
 	}
 
-	validatorID, ok := b.fetchFrom.Peek()
-	if !ok {
-		return fmt.Errorf("dropping request for %s as there are no validators", blkID)
+	b.needToFetch.Add(blkID)
+	return b.sendGetAncestors(ctx)
+}
+
+// sendGetAncestors requests the ranges in [needToFetch] until either there are
+// no more ranges to fetch or we are at the maximum number of outstanding
+// requests. Any remaining request slots, other than one kept for the blocks
+// that are known to be accepted, are used to request the [speculative] ranges.
+// Each request is sent to a different peer, if possible, so that ranges are
+// fetched in parallel.
+func (b *bootstrapper) sendGetAncestors(ctx context.Context) error {
+	for b.needToFetch.Len() > 0 && b.OutstandingRequests.Len() < common.MaxOutstandingGetAncestorsRequests {
+		blkID, _ := b.needToFetch.Pop()
+
+		// Make sure we haven't already requested this block
+		if b.OutstandingRequests.Contains(blkID) {
+			continue
+		}
+
+		// Make sure we don't already have this block
+		if _, err := b.VM.GetBlock(ctx, blkID); err == nil {
+			continue
+		}
+
+		validatorID, ok := b.fetchFrom.Peek()
+		if !ok {
+			return fmt.Errorf("dropping request for %s as there are no validators", blkID)
+		}
+		b.sendGetAncestorsTo(ctx, validatorID, blkID)
 	}
 
+	for blkID := range b.speculative {
+		if b.OutstandingRequests.Len() >= maxSpeculativeRanges {
+			break
+		}
+
+		if b.OutstandingRequests.Contains(blkID) {
+			continue
+		}
+
+		validatorID, ok := b.fetchFrom.Peek()
+		if !ok {
+			break
+		}
+		b.sendGetAncestorsTo(ctx, validatorID, blkID)
+	}
+	b.numOutstandingFetches.Set(float64(b.OutstandingRequests.Len()))
+	return nil
+}
+
+func (b *bootstrapper) sendGetAncestorsTo(ctx context.Context, nodeID ids.NodeID, blkID ids.ID) {
 	// We only allow one outbound request at a time from a node
-	b.markUnavailable(validatorID)
+	b.markUnavailable(nodeID)
 
 	b.Config.SharedCfg.RequestID++
 
-	b.OutstandingRequests.Add(validatorID, b.Config.SharedCfg.RequestID, blkID)
-	b.Config.Sender.SendGetAncestors(ctx, validatorID, b.Config.SharedCfg.RequestID, blkID) // request block and ancestors
-	return nil
+	b.OutstandingRequests.Add(nodeID, b.Config.SharedCfg.RequestID, blkID)
+	b.fetchStartTimes[blkID] = time.Now()
+	b.Config.Sender.SendGetAncestors(ctx, nodeID, b.Config.SharedCfg.RequestID, blkID) // request block and ancestors
+}
+
+// requestHeights requests the IDs of the blocks that split the chain below
+// [fetchingHeight] into ranges of [AncestorsMaxContainersReceived] blocks, so
+// that the ranges can be fetched from different peers in parallel. Only the
+// [maxSpeculativeRanges] ranges directly below [fetchingHeight] are requested,
+// which bounds the number of blocks that are buffered.
+func (b *bootstrapper) requestHeights(ctx context.Context, fetchingHeight uint64) {
+	rangeSize := uint64(b.Config.AncestorsMaxContainersReceived)
+	if rangeSize == 0 {
+		return
+	}
+
+	height := fetchingHeight
+	if b.lowestRequestedHeight != 0 && b.lowestRequestedHeight < height {
+		height = b.lowestRequestedHeight
+	}
+	lowestHeight := b.startingHeight
+	if window := maxSpeculativeRanges * rangeSize; fetchingHeight > lowestHeight+window {
+		lowestHeight = fetchingHeight - window
+	}
+	for height > lowestHeight+rangeSize {
+		nodeID, ok := b.fetchFrom.Peek()
+		if !ok {
+			return
+		}
+
+		height -= rangeSize
+		b.lowestRequestedHeight = height
+
+		b.Config.SharedCfg.RequestID++
+		b.heightRequests[b.Config.SharedCfg.RequestID] = heightRequest{
+			nodeID: nodeID,
+			height: height,
+		}
+		b.Config.Sender.SendGetAcceptedHeights(ctx, nodeID, b.Config.SharedCfg.RequestID, []uint64{height})
+	}
+}
+
+// buffer stores the blocks that were fetched in response to a request for a
+// [speculative] block, which should be at the top of [fetchRange]. If the
+// range wasn't fully fetched, the rest of the range is requested from [nodeID]
+// or another peer.
+func (b *bootstrapper) buffer(ctx context.Context, nodeID ids.NodeID, fetchRange heightRange, blocks []snowman.Block) error {
+	blk := blocks[0]
+	if height := blk.Height(); height != fetchRange.height {
+		b.Ctx.Log.Debug("dropping blocks at an unexpected height",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("expectedHeight", fetchRange.height),
+			zap.Uint64("height", height),
+		)
+		b.observeFetchFailure(nodeID)
+		return b.sendGetAncestors(ctx)
+	}
+
+	blockSet := make(map[ids.ID]snowman.Block, len(blocks))
+	for _, block := range blocks[1:] {
+		blockSet[block.ID()] = block
+	}
+
+	maxBuffered := maxSpeculativeRanges * b.Config.AncestorsMaxContainersReceived
+	for len(b.buffered) < maxBuffered {
+		b.buffered[blk.ID()] = blk
+
+		parentHeight := blk.Height() - 1
+		if parentHeight <= fetchRange.bottom {
+			break
+		}
+
+		parentID := blk.Parent()
+		parent, ok := blockSet[parentID]
+		if ok && parent.Height() == parentHeight {
+			blk = parent
+			continue
+		}
+
+		if !b.isFetched(ctx, parentID) {
+			b.speculative[parentID] = heightRange{
+				height: parentHeight,
+				bottom: fetchRange.bottom,
+			}
+		}
+		break
+	}
+
+	// If the blocks that are known to be accepted are waiting for any of the
+	// buffered blocks, they can continue to be processed.
+	for _, blkID := range b.Blocked.MissingIDs() {
+		blk, ok := b.buffered[blkID]
+		if !ok {
+			continue
+		}
+		delete(b.buffered, blkID)
+		if err := b.process(ctx, blk, nil); err != nil {
+			return err
+		}
+	}
+	return b.sendGetAncestors(ctx)
+}
+
+// isFetched returns true if [blkID] has already been fetched or is being
+// fetched.
+func (b *bootstrapper) isFetched(ctx context.Context, blkID ids.ID) bool {
+	if _, ok := b.buffered[blkID]; ok {
+		return true
+	}
+	if _, ok := b.speculative[blkID]; ok {
+		return true
+	}
+	if b.needToFetch.Contains(blkID) || b.OutstandingRequests.Contains(blkID) {
+		return true
+	}
+	_, err := b.VM.GetBlock(ctx, blkID)
+	return err == nil
+}
+
+// clearHeightRanges drops the ranges that were being fetched ahead of the
+// blocks that are known to be accepted. Blocks that are still being requested
+// remain [speculative] so that the responses are never treated as accepted.
+func (b *bootstrapper) clearHeightRanges() {
+	b.heightRequests = make(map[uint32]heightRequest)
+	b.lowestRequestedHeight = 0
+	for blkID := range b.speculative {
+		if !b.OutstandingRequests.Contains(blkID) {
+			delete(b.speculative, blkID)
+		}
+	}
+	b.buffered = make(map[ids.ID]snowman.Block)
+}
+
+// removeFetchStartTime removes and returns the time at which [blkID] was
+// requested. [blkID] must have already been removed from
+// [OutstandingRequests]. If the request was sent before the last restart, false
+// is returned.
+func (b *bootstrapper) removeFetchStartTime(blkID ids.ID) (time.Time, bool) {
+	startTime, ok := b.fetchStartTimes[blkID]
+	delete(b.fetchStartTimes, blkID)
+	b.numOutstandingFetches.Set(float64(b.OutstandingRequests.Len()))
+	return startTime, ok
+}
+
+// fetchFailed re-requests [blkID] after [nodeID] failed to provide it. If
+// [blkID] is not yet known to be accepted, it is dropped instead, as the peer
+// may have reported a block that doesn't exist.
+func (b *bootstrapper) fetchFailed(ctx context.Context, nodeID ids.NodeID, blkID ids.ID) error {
+	b.observeFetchFailure(nodeID)
+	if _, ok := b.speculative[blkID]; ok {
+		delete(b.speculative, blkID)
+		return b.sendGetAncestors(ctx)
+	}
+	return b.fetch(ctx, blkID)
 }
 
 // markUnavailable removes [nodeID] from the set of peers used to fetch
@@ -444,6 +785,12 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		}
 
 		b.Blocked.RemoveMissingID(blkID)
+		// If [blkID] was the top of a range that hasn't been requested yet,
+		// there is no need to request it anymore.
+		b.needToFetch.Remove(blkID)
+		if !b.OutstandingRequests.Contains(blkID) {
+			delete(b.speculative, blkID)
+		}
 
 		status := blk.Status()
 		// The status should never be rejected here - but we check to fail as
@@ -490,8 +837,10 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		// We added a new block to the queue, so track that it was fetched
 		b.numFetched.Inc()
 
-		// Periodically log progress
 		blocksFetchedSoFar := b.Blocked.Jobs.PendingJobs()
+		b.progress.Fetched(b.startingHeight, b.tipHeight, blocksFetchedSoFar)
+
+		// Periodically log progress
 		if blocksFetchedSoFar%common.StatusUpdateFrequency == 0 {
 			totalBlocksToFetch := b.tipHeight - b.startingHeight
 			eta := timer.EstimateETA(
@@ -526,6 +875,15 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 			continue
 		}
 
+		// Then check if the parent was fetched as part of a range below this
+		// block
+		parent, ok = b.buffered[parentID]
+		if ok {
+			delete(b.buffered, parentID)
+			blk = parent
+			continue
+		}
+
 		// If the parent is not available in processing blocks, attempt to get
 		// the block from the vm
 		parent, err = b.VM.GetBlock(ctx, parentID)
@@ -541,6 +899,7 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		if err := b.fetch(ctx, parentID); err != nil {
 			return err
 		}
+		b.requestHeights(ctx, blkHeight-1)
 
 		if err := b.Blocked.Commit(); err != nil {
 			return err
@@ -553,9 +912,15 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 // after which it finishes the bootstrap process
 func (b *bootstrapper) checkFinish(ctx context.Context) error {
 	if numPending := b.Blocked.NumMissingIDs(); numPending != 0 {
-		return nil
+		// Make sure that any ranges that were waiting for a request slot are
+		// requested.
+		return b.sendGetAncestors(ctx)
 	}
 
+	// All the blocks that are known to be accepted have been fetched, so any
+	// remaining buffered blocks will never be processed.
+	b.clearHeightRanges()
+
 	if b.IsBootstrapped() || b.awaitingTimeout {
 		return nil
 	}
@@ -570,6 +935,7 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 		)
 	}
 
+	b.progress.SetPhase(common.BootstrapPhaseExecuting)
 	executedBlocks, err := b.Blocked.ExecuteAll(
 		ctx,
 		b.Config.Ctx,
@@ -588,6 +954,7 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 	// so that the bootstrapping process will terminate even as new blocks are
 	// being issued.
 	if b.Config.RetryBootstrap && executedBlocks > 0 && executedBlocks < previouslyExecuted/2 {
+		b.progress.SetPhase(common.BootstrapPhaseFrontier)
 		return b.Restart(ctx, true)
 	}
 
@@ -614,8 +981,10 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 		// on the latest tip.
 		b.Config.Timer.RegisterTimeout(bootstrappingDelay)
 		b.awaitingTimeout = true
+		b.progress.SetPhase(common.BootstrapPhaseWaiting)
 		return nil
 	}
 	b.fetchETA.Set(0)
+	b.progress.SetPhase(common.BootstrapPhaseFinished)
 	return b.OnFinished(ctx, b.Config.SharedCfg.RequestID)
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
@@ -1440,3 +1441,454 @@ func TestBootstrapNoParseOnNew(t *testing.T) {
 	)
 	require.NoError(err)
 }
+
+// The ranges below each accepted block are fetched from different peers in
+// parallel and the responses can be processed out of order.
+func TestBootstrapperParallelFetching(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+
+	// Add a second peer to fetch from
+	otherPeerID := ids.GenerateTestNodeID()
+	require.NoError(config.Beacons.AddStaker(config.Ctx.SubnetID, otherPeerID, nil, ids.Empty, 1))
+	require.NoError(config.StartupTracker.Connected(context.Background(), otherPeerID, version.CurrentApp))
+
+	blks := make([]*snowman.TestBlock, 5)
+	blks[0] = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: utils.RandomBytes(32),
+	}
+	for i := 1; i < len(blks); i++ {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Unknown,
+			},
+			ParentV: blks[i-1].IDV,
+			HeightV: uint64(i),
+			BytesV:  utils.RandomBytes(32),
+		}
+	}
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID && blk.Status() != choices.Unknown {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.StatusV == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bsIntf, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.IsType(&bootstrapper{}, bsIntf)
+	bs := bsIntf.(*bootstrapper)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	type request struct {
+		nodeID    ids.NodeID
+		requestID uint32
+	}
+	requests := map[ids.ID]request{}
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+
+	// Peers reported accepted blocks at heights 4 and 2, so the ranges (2, 4]
+	// and (0, 2] should be requested from different peers
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[4].ID(), blks[2].ID()}))
+	require.Len(requests, 2)
+	highRequest, ok := requests[blks[4].ID()]
+	require.True(ok)
+	lowRequest, ok := requests[blks[2].ID()]
+	require.True(ok)
+	require.NotEqual(highRequest.nodeID, lowRequest.nodeID)
+	require.Equal(2, bs.OutstandingRequests.Len())
+
+	// Respond to the lower range first
+	require.NoError(bs.Ancestors(context.Background(), lowRequest.nodeID, lowRequest.requestID, [][]byte{blks[2].Bytes(), blks[1].Bytes()}))
+	require.NotEqual(snow.NormalOp, config.Ctx.State.Get().State)
+
+	// The higher range stops once it reaches the lower range, without
+	// requesting any more blocks
+	require.NoError(bs.Ancestors(context.Background(), highRequest.nodeID, highRequest.requestID, [][]byte{blks[4].Bytes(), blks[3].Bytes()}))
+	require.Len(requests, 2)
+
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+
+	progress := bs.BootstrapProgress()
+	require.Equal(common.BootstrapPhaseFinished, progress.Phase)
+	require.Equal(uint64(4), progress.TipHeight)
+	require.Equal(uint64(4), progress.NumToFetch)
+	require.Equal(uint64(4), progress.NumExecuted)
+	require.Equal(uint64(4), progress.NumToExecute)
+
+	require.Equal(float64(2), testutil.ToFloat64(bs.peerFetchedBlocks.WithLabelValues(highRequest.nodeID.String())))
+	require.Equal(float64(2), testutil.ToFloat64(bs.peerFetchedBlocks.WithLabelValues(lowRequest.nodeID.String())))
+	require.Zero(testutil.CollectAndCount(bs.peerFetchFailures))
+}
+
+// Responses to requests sent before a restart are processed but not timed.
+func TestBootstrapperRestartDropsFetchTimes(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: utils.RandomBytes(32),
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 1,
+		BytesV:  utils.RandomBytes(32),
+	}
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blk0.ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == blk0.ID():
+			return blk0, nil
+		case blkID == blk1.ID() && blk1.Status() != choices.Unknown:
+			return blk1, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		require.Equal(blk1.Bytes(), blkBytes)
+		blk1.StatusV = choices.Processing
+		return blk1, nil
+	}
+
+	bsIntf, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.IsType(&bootstrapper{}, bsIntf)
+	bs := bsIntf.(*bootstrapper)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	var requestID uint32
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, nodeID)
+		require.Equal(blk1.ID(), blkID)
+		requestID = reqID
+	}
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blk1.ID()}))
+	require.Len(bs.fetchStartTimes, 1)
+
+	require.NoError(bs.Restart(context.Background(), true))
+	require.Empty(bs.fetchStartTimes)
+	require.Zero(bs.needToFetch.Len())
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, [][]byte{blk1.Bytes()}))
+	require.Equal(choices.Accepted, blk1.Status())
+	require.Zero(testutil.CollectAndCount(bs.peerFetchedBlocks))
+	require.Zero(testutil.CollectAndCount(bs.peerFetchTime))
+}
+
+// The IDs of the blocks at lower heights are requested from peers so that the
+// chain below the accepted frontier is fetched from different peers in
+// parallel.
+func TestBootstrapperFetchesHeightRanges(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+	config.AncestorsMaxContainersReceived = 2
+
+	// Add a second peer to fetch from
+	otherPeerID := ids.GenerateTestNodeID()
+	require.NoError(config.Beacons.AddStaker(config.Ctx.SubnetID, otherPeerID, nil, ids.Empty, 1))
+	require.NoError(config.StartupTracker.Connected(context.Background(), otherPeerID, version.CurrentApp))
+
+	blks := make([]*snowman.TestBlock, 7)
+	blks[0] = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: utils.RandomBytes(32),
+	}
+	for i := 1; i < len(blks); i++ {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Unknown,
+			},
+			ParentV: blks[i-1].IDV,
+			HeightV: uint64(i),
+			BytesV:  utils.RandomBytes(32),
+		}
+	}
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID && blk.Status() != choices.Unknown {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.StatusV == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bsIntf, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.IsType(&bootstrapper{}, bsIntf)
+	bs := bsIntf.(*bootstrapper)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	type request struct {
+		nodeID    ids.NodeID
+		requestID uint32
+	}
+	requests := map[ids.ID]request{}
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	heightRequests := map[uint64]request{}
+	sender.SendGetAcceptedHeightsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) {
+		require.Len(heights, 1)
+		heightRequests[heights[0]] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[6].ID()}))
+	require.Len(requests, 1)
+	require.Empty(heightRequests)
+
+	// Once the height of the tip is known, the block at the top of the next
+	// range is looked up while the range directly below the tip is fetched
+	tipRequest := requests[blks[6].ID()]
+	require.NoError(bs.Ancestors(context.Background(), tipRequest.nodeID, tipRequest.requestID, [][]byte{blks[6].Bytes(), blks[5].Bytes()}))
+	require.Len(requests, 2)
+	highRequest, ok := requests[blks[4].ID()]
+	require.True(ok)
+	require.Len(heightRequests, 1)
+	heightRequest, ok := heightRequests[2]
+	require.True(ok)
+
+	require.NoError(bs.Accepted(context.Background(), heightRequest.nodeID, heightRequest.requestID, []ids.ID{blks[2].ID()}))
+	require.Len(requests, 3)
+	lowRequest, ok := requests[blks[2].ID()]
+	require.True(ok)
+	require.NotEqual(highRequest.nodeID, lowRequest.nodeID)
+
+	// The lower range is buffered until the range above it reaches it
+	require.NoError(bs.Ancestors(context.Background(), lowRequest.nodeID, lowRequest.requestID, [][]byte{blks[2].Bytes(), blks[1].Bytes()}))
+	require.Len(bs.buffered, 2)
+	require.Equal(uint64(2), bs.Blocked.PendingJobs())
+	require.NotEqual(snow.NormalOp, config.Ctx.State.Get().State)
+
+	require.NoError(bs.Ancestors(context.Background(), highRequest.nodeID, highRequest.requestID, [][]byte{blks[4].Bytes(), blks[3].Bytes()}))
+	require.Len(requests, 3)
+	require.Empty(bs.buffered)
+
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
+
+// Blocks reported by peers at the requested heights are never accepted unless
+// they are ancestors of the accepted frontier.
+func TestBootstrapperDropsIncorrectHeightRanges(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+	config.AncestorsMaxContainersReceived = 2
+
+	blks := make([]*snowman.TestBlock, 6)
+	blks[0] = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: utils.RandomBytes(32),
+	}
+	for i := 1; i < len(blks); i++ {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Unknown,
+			},
+			ParentV: blks[i-1].IDV,
+			HeightV: uint64(i),
+			BytesV:  utils.RandomBytes(32),
+		}
+	}
+	// [conflictingBlk] is reported as the block at height 2
+	conflictingBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Unknown,
+		},
+		ParentV: blks[1].IDV,
+		HeightV: 2,
+		BytesV:  utils.RandomBytes(32),
+	}
+	allBlks := append([]*snowman.TestBlock{conflictingBlk}, blks...)
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range allBlks {
+			if blk.ID() == blkID && blk.Status() != choices.Unknown {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range allBlks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.StatusV == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bsIntf, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.IsType(&bootstrapper{}, bsIntf)
+	bs := bsIntf.(*bootstrapper)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	requests := map[ids.ID]uint32{}
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		require.Equal(peerID, nodeID)
+		requests[blkID] = requestID
+	}
+	heightRequests := map[uint64]uint32{}
+	sender.SendGetAcceptedHeightsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) {
+		require.Equal(peerID, nodeID)
+		require.Len(heights, 1)
+		heightRequests[heights[0]] = requestID
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[5].ID()}))
+	require.NoError(bs.Ancestors(context.Background(), peerID, requests[blks[5].ID()], [][]byte{blks[5].Bytes()}))
+	require.Contains(heightRequests, uint64(2))
+
+	require.NoError(bs.Accepted(context.Background(), peerID, heightRequests[2], []ids.ID{conflictingBlk.ID()}))
+	require.Contains(requests, conflictingBlk.ID())
+	require.NoError(bs.Ancestors(context.Background(), peerID, requests[conflictingBlk.ID()], [][]byte{conflictingBlk.Bytes(), blks[1].Bytes()}))
+	require.Len(bs.buffered, 2)
+
+	// The range below the accepted frontier doesn't reach [conflictingBlk], so
+	// the real block at height 2 is fetched
+	require.NoError(bs.Ancestors(context.Background(), peerID, requests[blks[4].ID()], [][]byte{blks[4].Bytes(), blks[3].Bytes()}))
+	require.Contains(requests, blks[2].ID())
+	require.NoError(bs.Ancestors(context.Background(), peerID, requests[blks[2].ID()], [][]byte{blks[2].Bytes()}))
+
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+	require.Equal(choices.Processing, conflictingBlk.Status())
+	require.Empty(bs.buffered)
+}
@@ -4,14 +4,41 @@
 package bootstrap
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 )
 
+const (
+	peerLabel = "peer"
+
+	// maxLabeledPeers is the maximum number of peers that are given their own
+	// label in the per-peer metrics. Any additional peers share the
+	// [otherPeersLabel] label, which keeps the cardinality of the metrics
+	// bounded.
+	maxLabeledPeers = 32
+	otherPeersLabel = "other"
+)
+
 type metrics struct {
 	numFetched, numDropped, numAccepted prometheus.Counter
 	fetchETA                            prometheus.Gauge
+	numOutstandingFetches               prometheus.Gauge
+
+	// The average fetching throughput of a peer can be calculated as
+	// [peerFetchedBlocks] / [peerFetchTime], while [peerThroughput] reports
+	// the throughput of the last response from the peer.
+	peerFetchedBlocks *prometheus.CounterVec
+	peerFetchTime     *prometheus.CounterVec
+	peerThroughput    *prometheus.GaugeVec
+	peerFetchFailures *prometheus.CounterVec
+
+	// peerLabels contains the labels of the peers that have been given their
+	// own label.
+	peerLabels map[ids.NodeID]string
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -36,6 +63,44 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			Name:      "eta_fetching_complete",
 			Help:      "ETA in nanoseconds until fetching phase of bootstrapping finishes",
 		}),
+		numOutstandingFetches: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "outstanding_fetches",
+			Help:      "Number of GetAncestors requests that are currently outstanding",
+		}),
+		peerFetchedBlocks: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_fetched",
+				Help:      "Number of blocks fetched from each peer in timed GetAncestors responses during bootstrapping",
+			},
+			[]string{peerLabel},
+		),
+		peerFetchTime: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_fetch_time",
+				Help:      "Time spent waiting for timed GetAncestors responses from each peer during bootstrapping (in nanoseconds)",
+			},
+			[]string{peerLabel},
+		),
+		peerThroughput: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "peer_throughput",
+				Help:      "Blocks per second fetched in the last timed GetAncestors response from each peer during bootstrapping",
+			},
+			[]string{peerLabel},
+		),
+		peerFetchFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_fetch_failures",
+				Help:      "Number of GetAncestors requests to each peer that failed or returned no valid blocks during bootstrapping",
+			},
+			[]string{peerLabel},
+		),
+		peerLabels: make(map[ids.NodeID]string),
 	}
 
 	err := utils.Err(
@@ -43,6 +108,39 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 		registerer.Register(m.numDropped),
 		registerer.Register(m.numAccepted),
 		registerer.Register(m.fetchETA),
+		registerer.Register(m.numOutstandingFetches),
+		registerer.Register(m.peerFetchedBlocks),
+		registerer.Register(m.peerFetchTime),
+		registerer.Register(m.peerThroughput),
+		registerer.Register(m.peerFetchFailures),
 	)
 	return m, err
 }
+
+// observeFetch records that [numBlocks] blocks were fetched from [nodeID] in
+// [duration].
+func (m *metrics) observeFetch(nodeID ids.NodeID, numBlocks int, duration time.Duration) {
+	label := m.peerLabel(nodeID)
+	m.peerFetchedBlocks.WithLabelValues(label).Add(float64(numBlocks))
+	m.peerFetchTime.WithLabelValues(label).Add(float64(duration))
+	if duration > 0 {
+		m.peerThroughput.WithLabelValues(label).Set(float64(numBlocks) / duration.Seconds())
+	}
+}
+
+// observeFetchFailure records that a request to [nodeID] failed.
+func (m *metrics) observeFetchFailure(nodeID ids.NodeID) {
+	m.peerFetchFailures.WithLabelValues(m.peerLabel(nodeID)).Inc()
+}
+
+func (m *metrics) peerLabel(nodeID ids.NodeID) string {
+	if label, ok := m.peerLabels[nodeID]; ok {
+		return label
+	}
+	if len(m.peerLabels) >= maxLabeledPeers {
+		return otherPeersLabel
+	}
+	label := nodeID.String()
+	m.peerLabels[nodeID] = label
+	return label
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestMetricsBoundPeerLabels(t *testing.T) {
+	require := require.New(t)
+
+	m, err := newMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	for i := 0; i < maxLabeledPeers+2; i++ {
+		m.observeFetch(ids.GenerateTestNodeID(), 10, time.Second)
+	}
+	require.Equal(maxLabeledPeers+1, testutil.CollectAndCount(m.peerFetchedBlocks))
+	require.Equal(float64(20), testutil.ToFloat64(m.peerFetchedBlocks.WithLabelValues(otherPeersLabel)))
+	require.Equal(float64(10), testutil.ToFloat64(m.peerThroughput.WithLabelValues(otherPeersLabel)))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 // Config wraps all the parameters needed for a snowman engine
@@ -23,4 +24,13 @@ type Config struct {
 	Params      snowball.Parameters
 	Consensus   snowman.Consensus
 	PartialSync bool
+	// WeightedPolls weights the response of each sampled validator by its
+	// stake, rather than by the number of times it was sampled.
+	WeightedPolls bool
+
+	// AdaptivePolls optionally adjusts polling based on [QueryLatency].
+	AdaptivePolls AdaptivePollsConfig
+	QueryLatency  QueryLatencyReporter
+	// Clock is used to time outstanding polls. If nil, the wall clock is used.
+	Clock *mockable.Clock
 }
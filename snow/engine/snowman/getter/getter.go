@@ -147,6 +147,29 @@ func (gh *getter) GetAccepted(ctx context.Context, nodeID ids.NodeID, requestID
 	return nil
 }
 
+func (gh *getter) GetAcceptedHeights(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) error {
+	if len(heights) > gh.cfg.AncestorsMaxContainersSent {
+		gh.log.Debug("dropping GetAcceptedHeights message",
+			zap.String("reason", "too many heights requested"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Int("numHeights", len(heights)),
+		)
+		return nil
+	}
+
+	acceptedIDs := make([]ids.ID, 0, len(heights))
+	for _, height := range heights {
+		blkID, err := gh.vm.GetBlockIDAtHeight(ctx, height)
+		if err != nil {
+			break
+		}
+		acceptedIDs = append(acceptedIDs, blkID)
+	}
+	gh.sender.SendAccepted(ctx, nodeID, requestID, acceptedIDs)
+	return nil
+}
+
 func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
 	ancestorsBytes, err := block.GetAncestors(
 		ctx,
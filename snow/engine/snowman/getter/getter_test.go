@@ -178,3 +178,46 @@ func TestFilterAccepted(t *testing.T) {
 	require.Contains(accepted, blkID1)
 	require.NotContains(accepted, blkID2)
 }
+
+func TestGetAcceptedHeights(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	vm, sender, config := testSetup(t, ctrl)
+
+	blkID0 := ids.GenerateTestID()
+	blkID1 := ids.GenerateTestID()
+	blkID3 := ids.GenerateTestID()
+
+	bsIntf, err := New(vm, config)
+	require.NoError(err)
+	require.IsType(&getter{}, bsIntf)
+	bs := bsIntf.(*getter)
+
+	vm.GetBlockIDAtHeightF = func(_ context.Context, height uint64) (ids.ID, error) {
+		switch height {
+		case 0:
+			return blkID0, nil
+		case 1:
+			return blkID1, nil
+		case 3:
+			return blkID3, nil
+		}
+		return ids.Empty, errUnknownBlock
+	}
+
+	var accepted []ids.ID
+	sender.SendAcceptedF = func(_ context.Context, _ ids.NodeID, _ uint32, blkIDs []ids.ID) {
+		accepted = blkIDs
+	}
+
+	// The IDs are reported in order up to the first unknown height
+	require.NoError(bs.GetAcceptedHeights(context.Background(), ids.EmptyNodeID, 0, []uint64{1, 0, 2, 3}))
+	require.Equal([]ids.ID{blkID1, blkID0}, accepted)
+
+	// Requests for too many heights are dropped
+	accepted = nil
+	heights := make([]uint64, config.AncestorsMaxContainersSent+1)
+	require.NoError(bs.GetAcceptedHeights(context.Background(), ids.EmptyNodeID, 0, heights))
+	require.Nil(accepted)
+}
@@ -25,6 +25,8 @@ type metrics struct {
 	numProcessingAncestorFetchesDropped   prometheus.Counter
 	numProcessingAncestorFetchesSucceeded prometheus.Counter
 	numProcessingAncestorFetchesUnneeded  prometheus.Counter
+	concurrentRepolls                     prometheus.Gauge
+	pollTimeout                           prometheus.Gauge
 	getAncestorsBlks                      metric.Averager
 	selectedVoteIndex                     metric.Averager
 }
@@ -101,6 +103,16 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		Name:      "num_processing_ancestor_fetches_unneeded",
 		Help:      "Number of votes that were directly applied to blocks",
 	})
+	m.concurrentRepolls = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "concurrent_repolls",
+		Help:      "Number of polls the engine keeps outstanding",
+	})
+	m.pollTimeout = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "poll_timeout",
+		Help:      "Time (in ns) after which outstanding polls are finished early when adaptive polling is enabled",
+	})
 	m.getAncestorsBlks = metric.NewAveragerWithErrs(
 		namespace,
 		"get_ancestors_blks",
@@ -131,6 +143,8 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		reg.Register(m.numProcessingAncestorFetchesDropped),
 		reg.Register(m.numProcessingAncestorFetchesSucceeded),
 		reg.Register(m.numProcessingAncestorFetchesUnneeded),
+		reg.Register(m.concurrentRepolls),
+		reg.Register(m.pollTimeout),
 	)
 	return errs.Err
 }
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/replay"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const verboseKey = "verbose"
+
+var errDiverged = errors.New("replayed decisions diverged from the recording")
+
+func main() {
+	cmd := &cobra.Command{
+		Use:   "replay [recording]",
+		Short: "Replays a snowman engine recording and compares the consensus decisions",
+		Args:  cobra.ExactArgs(1),
+		RunE:  replayFunc,
+	}
+	cmd.Flags().Bool(verboseKey, false, "Print every replayed decision")
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func replayFunc(c *cobra.Command, args []string) error {
+	verbose, err := c.Flags().GetBool(verboseKey)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entries, err := replay.ReadEntries(f)
+	if err != nil {
+		return err
+	}
+
+	log := logging.NewLogger(
+		"replay",
+		logging.NewWrappedCore(logging.Info, os.Stderr, logging.Plain.ConsoleEncoder()),
+	)
+	result, err := replay.Replay(c.Context(), log, entries)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		for i, decision := range result.Replayed {
+			fmt.Printf("%d: %s\n", i, decision)
+		}
+	}
+	fmt.Printf("recorded decisions: %d\n", len(result.Recorded))
+	fmt.Printf("replayed decisions: %d\n", len(result.Replayed))
+	fmt.Printf("last accepted: %s (height %d)\n", result.Inspection.LastAcceptedID, result.Inspection.LastAcceptedHeight)
+
+	index := result.Divergence()
+	if index == -1 {
+		return nil
+	}
+	if index < len(result.Recorded) {
+		fmt.Printf("recorded decision %d: %s\n", index, result.Recorded[index])
+	}
+	if index < len(result.Replayed) {
+		fmt.Printf("replayed decision %d: %s\n", index, result.Replayed[index])
+	}
+	return fmt.Errorf("%w at decision %d", errDiverged, index)
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+// maxEntrySize is the largest entry that can be read from a recording.
+const maxEntrySize = 64 * 1024 * 1024
+
+// Op identifies the kind of event an [Entry] records.
+type Op string
+
+// Inbound engine messages. These are fed back into the engine during a replay.
+const (
+	OpStart       Op = "start"
+	OpPut         Op = "put"
+	OpGetFailed   Op = "getFailed"
+	OpPullQuery   Op = "pullQuery"
+	OpPushQuery   Op = "pushQuery"
+	OpChits       Op = "chits"
+	OpQueryFailed Op = "queryFailed"
+	OpNotify      Op = "notify"
+	OpGossip      Op = "gossip"
+)
+
+// Outcomes observed while the engine handled an inbound message. These are
+// used to script the VM and the validator set during a replay.
+const (
+	// OpBlock records the first time a block was returned by the VM.
+	OpBlock   Op = "block"
+	OpBuild   Op = "build"
+	OpVerify  Op = "verify"
+	OpOptions Op = "options"
+	OpSample  Op = "sample"
+	OpAccept  Op = "accept"
+	OpReject  Op = "reject"
+	// OpQueryLatency records a query latency reported to the engine for
+	// adaptive polling.
+	OpQueryLatency Op = "queryLatency"
+)
+
+// Source describes how the engine first learned about a block.
+type Source string
+
+const (
+	SourceGet   Source = "get"
+	SourceParse Source = "parse"
+	SourceBuild Source = "build"
+)
+
+var errEntryTooLarge = errors.New("entry too large")
+
+// Entry is a single event in a recording. Exactly one of the optional fields
+// is populated, depending on [Op].
+type Entry struct {
+	Op   Op        `json:"op"`
+	Time time.Time `json:"time"`
+
+	Start   *Start          `json:"start,omitempty"`
+	Message *Message        `json:"message,omitempty"`
+	Notify  *common.Message `json:"notify,omitempty"`
+	Block   *Block          `json:"block,omitempty"`
+	Outcome *Outcome        `json:"outcome,omitempty"`
+	Options *Options        `json:"options,omitempty"`
+	Sample  *Sample         `json:"sample,omitempty"`
+
+	QueryLatency *QueryLatency `json:"queryLatency,omitempty"`
+}
+
+type Start struct {
+	RequestID      uint32                    `json:"requestID"`
+	Params         snowball.Parameters       `json:"params"`
+	WeightedPolls  bool                      `json:"weightedPolls,omitempty"`
+	AdaptivePolls  smeng.AdaptivePollsConfig `json:"adaptivePolls"`
+	LastAcceptedID ids.ID                    `json:"lastAcceptedID"`
+}
+
+// Message is an inbound consensus message. Only the fields that are relevant
+// to the message's [Op] are populated.
+type Message struct {
+	NodeID              ids.NodeID `json:"nodeID"`
+	RequestID           uint32     `json:"requestID"`
+	BlockID             ids.ID     `json:"blockID"`
+	Bytes               []byte     `json:"bytes,omitempty"`
+	RequestedHeight     uint64     `json:"requestedHeight,omitempty"`
+	PreferredID         ids.ID     `json:"preferredID"`
+	PreferredIDAtHeight ids.ID     `json:"preferredIDAtHeight"`
+	AcceptedID          ids.ID     `json:"acceptedID"`
+}
+
+type Block struct {
+	ID        ids.ID         `json:"id"`
+	ParentID  ids.ID         `json:"parentID"`
+	Height    uint64         `json:"height"`
+	Timestamp time.Time      `json:"timestamp"`
+	Bytes     []byte         `json:"bytes"`
+	Status    choices.Status `json:"status"`
+	Source    Source         `json:"source"`
+}
+
+// Outcome is the result of an operation on a block. [Err] is empty if the
+// operation succeeded.
+type Outcome struct {
+	BlockID ids.ID `json:"blockID"`
+	Err     string `json:"err,omitempty"`
+}
+
+type Options struct {
+	BlockID   ids.ID   `json:"blockID"`
+	OptionIDs []ids.ID `json:"optionIDs,omitempty"`
+	NotOracle bool     `json:"notOracle,omitempty"`
+	Err       string   `json:"err,omitempty"`
+}
+
+// Sample is the result of sampling validators for a poll. [Weights] are the
+// weights of the sampled validators and [TotalWeight] is the weight of the
+// subnet when the sample was taken, which weighted polls are based on.
+type Sample struct {
+	NodeIDs     []ids.NodeID `json:"nodeIDs"`
+	Weights     []uint64     `json:"weights,omitempty"`
+	TotalWeight uint64       `json:"totalWeight,omitempty"`
+	Err         string       `json:"err,omitempty"`
+}
+
+type QueryLatency struct {
+	Percentile float64       `json:"percentile"`
+	Latency    time.Duration `json:"latency"`
+	OK         bool          `json:"ok"`
+}
+
+// ReadEntries parses all the entries from a recording.
+func ReadEntries(r io.Reader) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxEntrySize)
+
+	var entries []Entry
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, errEntryTooLarge
+		}
+		return nil, err
+	}
+	return entries, nil
+}
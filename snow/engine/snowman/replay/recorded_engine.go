@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+var _ snowman.Engine = (*recordedEngine)(nil)
+
+// recordedEngine records the inbound messages that influence consensus
+// before passing them to the engine.
+type recordedEngine struct {
+	snowman.Engine
+	config   snowman.Config
+	recorder *Recorder
+}
+
+// RecordEngine wraps [engine] so that the messages it handles are written to
+// [recorder]. [config] must be the config [engine] was created with. Its VM
+// and validators should have been wrapped with [RecordVM] and
+// [RecordValidators], and its QueryLatency with [RecordQueryLatency], using the
+// same recorder. If [config.Clock] is provided, it is set to the time each
+// message is recorded at, so that the polls are timed identically when the
+// recording is replayed. The recorder is closed when the engine is shutdown.
+func RecordEngine(
+	engine snowman.Engine,
+	config snowman.Config,
+	recorder *Recorder,
+) snowman.Engine {
+	return &recordedEngine{
+		Engine:   engine,
+		config:   config,
+		recorder: recorder,
+	}
+}
+
+func (e *recordedEngine) Start(ctx context.Context, startReqID uint32) error {
+	lastAcceptedID, err := e.config.VM.LastAccepted(ctx)
+	if err != nil {
+		return err
+	}
+	e.record(Entry{
+		Op: OpStart,
+		Start: &Start{
+			RequestID:      startReqID,
+			Params:         e.config.Params,
+			WeightedPolls:  e.config.WeightedPolls,
+			AdaptivePolls:  e.config.AdaptivePolls,
+			LastAcceptedID: lastAcceptedID,
+		},
+	})
+	return e.Engine.Start(ctx, startReqID)
+}
+
+func (e *recordedEngine) Put(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkBytes []byte) error {
+	e.record(Entry{
+		Op: OpPut,
+		Message: &Message{
+			NodeID:    nodeID,
+			RequestID: requestID,
+			Bytes:     blkBytes,
+		},
+	})
+	return e.Engine.Put(ctx, nodeID, requestID, blkBytes)
+}
+
+func (e *recordedEngine) GetFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	e.record(Entry{
+		Op: OpGetFailed,
+		Message: &Message{
+			NodeID:    nodeID,
+			RequestID: requestID,
+		},
+	})
+	return e.Engine.GetFailed(ctx, nodeID, requestID)
+}
+
+func (e *recordedEngine) PullQuery(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID, requestedHeight uint64) error {
+	e.record(Entry{
+		Op: OpPullQuery,
+		Message: &Message{
+			NodeID:          nodeID,
+			RequestID:       requestID,
+			BlockID:         blkID,
+			RequestedHeight: requestedHeight,
+		},
+	})
+	return e.Engine.PullQuery(ctx, nodeID, requestID, blkID, requestedHeight)
+}
+
+func (e *recordedEngine) PushQuery(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkBytes []byte, requestedHeight uint64) error {
+	e.record(Entry{
+		Op: OpPushQuery,
+		Message: &Message{
+			NodeID:          nodeID,
+			RequestID:       requestID,
+			Bytes:           blkBytes,
+			RequestedHeight: requestedHeight,
+		},
+	})
+	return e.Engine.PushQuery(ctx, nodeID, requestID, blkBytes, requestedHeight)
+}
+
+func (e *recordedEngine) Chits(ctx context.Context, nodeID ids.NodeID, requestID uint32, preferredID ids.ID, preferredIDAtHeight ids.ID, acceptedID ids.ID) error {
+	e.record(Entry{
+		Op: OpChits,
+		Message: &Message{
+			NodeID:              nodeID,
+			RequestID:           requestID,
+			PreferredID:         preferredID,
+			PreferredIDAtHeight: preferredIDAtHeight,
+			AcceptedID:          acceptedID,
+		},
+	})
+	return e.Engine.Chits(ctx, nodeID, requestID, preferredID, preferredIDAtHeight, acceptedID)
+}
+
+func (e *recordedEngine) QueryFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	e.record(Entry{
+		Op: OpQueryFailed,
+		Message: &Message{
+			NodeID:    nodeID,
+			RequestID: requestID,
+		},
+	})
+	return e.Engine.QueryFailed(ctx, nodeID, requestID)
+}
+
+func (e *recordedEngine) Notify(ctx context.Context, msg common.Message) error {
+	e.record(Entry{
+		Op:     OpNotify,
+		Notify: &msg,
+	})
+	return e.Engine.Notify(ctx, msg)
+}
+
+func (e *recordedEngine) Gossip(ctx context.Context) error {
+	e.record(Entry{
+		Op: OpGossip,
+	})
+	return e.Engine.Gossip(ctx)
+}
+
+func (e *recordedEngine) Shutdown(ctx context.Context) error {
+	err := e.Engine.Shutdown(ctx)
+	if closeErr := e.recorder.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// record [entry] and move the engine's clock to the time it was recorded at.
+func (e *recordedEngine) record(entry Entry) {
+	now := e.recorder.record(entry)
+	if e.config.Clock != nil {
+		e.config.Clock.Set(now)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+var _ snowman.QueryLatencyReporter = (*recordedQueryLatency)(nil)
+
+// recordedQueryLatency records the query latencies that adaptive polling is
+// based on.
+type recordedQueryLatency struct {
+	reporter snowman.QueryLatencyReporter
+	recorder *Recorder
+}
+
+// RecordQueryLatency wraps [reporter] so that the reported latencies are
+// written to [recorder].
+func RecordQueryLatency(reporter snowman.QueryLatencyReporter, recorder *Recorder) snowman.QueryLatencyReporter {
+	return &recordedQueryLatency{
+		reporter: reporter,
+		recorder: recorder,
+	}
+}
+
+func (l *recordedQueryLatency) QueryLatency(percentile float64) (time.Duration, bool) {
+	latency, ok := l.reporter.QueryLatency(percentile)
+	l.recorder.record(Entry{
+		Op: OpQueryLatency,
+		QueryLatency: &QueryLatency{
+			Percentile: percentile,
+			Latency:    latency,
+			OK:         ok,
+		},
+	})
+	return latency, ok
+}
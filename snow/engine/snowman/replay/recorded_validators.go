@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+var _ validators.Manager = (*recordedValidators)(nil)
+
+// recordedValidators records the validators that are sampled for each poll,
+// along with their weights.
+type recordedValidators struct {
+	validators.Manager
+	recorder *Recorder
+}
+
+// RecordValidators wraps [vdrs] so that the results of sampling validators are
+// written to [recorder].
+func RecordValidators(vdrs validators.Manager, recorder *Recorder) validators.Manager {
+	return &recordedValidators{
+		Manager:  vdrs,
+		recorder: recorder,
+	}
+}
+
+func (v *recordedValidators) Sample(subnetID ids.ID, size int) ([]ids.NodeID, error) {
+	nodeIDs, err := v.Manager.Sample(subnetID, size)
+	sample := &Sample{
+		NodeIDs: nodeIDs,
+		Err:     errString(err),
+	}
+	if err == nil {
+		sample.Weights = make([]uint64, len(nodeIDs))
+		for i, nodeID := range nodeIDs {
+			sample.Weights[i] = v.Manager.GetWeight(subnetID, nodeID)
+		}
+		// If the total weight can't be calculated, weighted polls finish
+		// without any votes, which is replayed by a total weight of 0.
+		if totalWeight, err := v.Manager.TotalWeight(subnetID); err == nil {
+			sample.TotalWeight = totalWeight
+		}
+	}
+	v.recorder.record(Entry{
+		Op:     OpSample,
+		Sample: sample,
+	})
+	return nodeIDs, err
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var (
+	_ block.ChainVM       = (*recordedVM)(nil)
+	_ snowman.OracleBlock = (*recordedBlock)(nil)
+)
+
+// recordedVM records the blocks that the engine learns about, along with the
+// outcome of every operation the engine performs on them.
+type recordedVM struct {
+	block.ChainVM
+	recorder *Recorder
+}
+
+// RecordVM wraps [vm] so that the blocks it returns are written to [recorder].
+func RecordVM(vm block.ChainVM, recorder *Recorder) block.ChainVM {
+	return &recordedVM{
+		ChainVM:  vm,
+		recorder: recorder,
+	}
+}
+
+func (vm *recordedVM) BuildBlock(ctx context.Context) (snowman.Block, error) {
+	blk, err := vm.ChainVM.BuildBlock(ctx)
+	if err != nil {
+		vm.recorder.record(Entry{
+			Op: OpBuild,
+			Outcome: &Outcome{
+				Err: err.Error(),
+			},
+		})
+		return nil, err
+	}
+
+	vm.recorder.recordBlock(newBlock(blk, SourceBuild))
+	vm.recorder.record(Entry{
+		Op: OpBuild,
+		Outcome: &Outcome{
+			BlockID: blk.ID(),
+		},
+	})
+	return vm.wrap(blk), nil
+}
+
+func (vm *recordedVM) ParseBlock(ctx context.Context, blkBytes []byte) (snowman.Block, error) {
+	blk, err := vm.ChainVM.ParseBlock(ctx, blkBytes)
+	if err != nil {
+		return nil, err
+	}
+	vm.recorder.recordBlock(newBlock(blk, SourceParse))
+	return vm.wrap(blk), nil
+}
+
+func (vm *recordedVM) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Block, error) {
+	blk, err := vm.ChainVM.GetBlock(ctx, blkID)
+	if err != nil {
+		return nil, err
+	}
+	vm.recorder.recordBlock(newBlock(blk, SourceGet))
+	return vm.wrap(blk), nil
+}
+
+func (vm *recordedVM) wrap(blk snowman.Block) snowman.Block {
+	return &recordedBlock{
+		Block:    blk,
+		recorder: vm.recorder,
+	}
+}
+
+func newBlock(blk snowman.Block, source Source) *Block {
+	return &Block{
+		ID:        blk.ID(),
+		ParentID:  blk.Parent(),
+		Height:    blk.Height(),
+		Timestamp: blk.Timestamp(),
+		Bytes:     blk.Bytes(),
+		Status:    blk.Status(),
+		Source:    source,
+	}
+}
+
+// recordedBlock records the outcome of verifying and deciding a block.
+//
+// recordedBlock always implements [snowman.OracleBlock] so that the options of
+// the underlying block are preserved. If the underlying block isn't an oracle
+// block, [snowman.ErrNotOracle] is returned.
+type recordedBlock struct {
+	snowman.Block
+	recorder *Recorder
+}
+
+func (b *recordedBlock) Verify(ctx context.Context) error {
+	err := b.Block.Verify(ctx)
+	b.recorder.record(Entry{
+		Op: OpVerify,
+		Outcome: &Outcome{
+			BlockID: b.ID(),
+			Err:     errString(err),
+		},
+	})
+	return err
+}
+
+func (b *recordedBlock) Accept(ctx context.Context) error {
+	err := b.Block.Accept(ctx)
+	b.recorder.record(Entry{
+		Op: OpAccept,
+		Outcome: &Outcome{
+			BlockID: b.ID(),
+			Err:     errString(err),
+		},
+	})
+	return err
+}
+
+func (b *recordedBlock) Reject(ctx context.Context) error {
+	err := b.Block.Reject(ctx)
+	b.recorder.record(Entry{
+		Op: OpReject,
+		Outcome: &Outcome{
+			BlockID: b.ID(),
+			Err:     errString(err),
+		},
+	})
+	return err
+}
+
+func (b *recordedBlock) Options(ctx context.Context) ([2]snowman.Block, error) {
+	oracleBlk, ok := b.Block.(snowman.OracleBlock)
+	if !ok {
+		b.recordOptions(nil, snowman.ErrNotOracle)
+		return [2]snowman.Block{}, snowman.ErrNotOracle
+	}
+
+	options, err := oracleBlk.Options(ctx)
+	if err != nil {
+		b.recordOptions(nil, err)
+		return [2]snowman.Block{}, err
+	}
+
+	optionIDs := make([]ids.ID, len(options))
+	for i, option := range options {
+		b.recorder.recordBlock(newBlock(option, SourceGet))
+		optionIDs[i] = option.ID()
+		options[i] = &recordedBlock{
+			Block:    option,
+			recorder: b.recorder,
+		}
+	}
+	b.recordOptions(optionIDs, nil)
+	return options, nil
+}
+
+func (b *recordedBlock) recordOptions(optionIDs []ids.ID, err error) {
+	options := &Options{
+		BlockID:   b.ID(),
+		OptionIDs: optionIDs,
+	}
+	if err == snowman.ErrNotOracle {
+		options.NotOracle = true
+	} else {
+		options.Err = errString(err)
+	}
+	b.recorder.record(Entry{
+		Op:      OpOptions,
+		Options: options,
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// Recorder writes the entries of a recording, one JSON object per line.
+//
+// If writing an entry fails, the error is logged and no further entries are
+// written, as the recording could no longer be replayed.
+type Recorder struct {
+	log   logging.Logger
+	clock mockable.Clock
+
+	lock    sync.Mutex
+	writer  io.Writer
+	encoder *json.Encoder
+	// blockIDs contains the IDs of all the blocks that have been recorded
+	blockIDs set.Set[ids.ID]
+	failed   bool
+}
+
+func NewRecorder(log logging.Logger, writer io.Writer) *Recorder {
+	return &Recorder{
+		log:     log,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// record [entry] and return the time it was recorded at.
+func (r *Recorder) record(entry Entry) time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.recordWithLock(entry)
+}
+
+func (r *Recorder) recordWithLock(entry Entry) time.Time {
+	entry.Time = r.clock.Time()
+	if r.failed {
+		return entry.Time
+	}
+
+	if err := r.encoder.Encode(entry); err != nil {
+		r.log.Warn("stopping engine recording",
+			zap.String("reason", "failed to write entry"),
+			zap.String("op", string(entry.Op)),
+			zap.Error(err),
+		)
+		r.failed = true
+	}
+	return entry.Time
+}
+
+// recordBlock records [blk] if it hasn't been recorded before.
+func (r *Recorder) recordBlock(blk *Block) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.blockIDs.Contains(blk.ID) {
+		return
+	}
+	r.blockIDs.Add(blk.ID)
+	r.recordWithLock(Entry{
+		Op:    OpBlock,
+		Block: blk,
+	})
+}
+
+// Close closes the underlying writer if it is an [io.Closer].
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.failed = true
+	if closer, ok := r.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+var (
+	errNoStart       = errors.New("recording doesn't contain a start entry")
+	errMissingFields = errors.New("entry is missing required fields")
+)
+
+// Decision is a block that was either accepted or rejected.
+type Decision struct {
+	BlockID  ids.ID
+	Accepted bool
+}
+
+func (d Decision) String() string {
+	if d.Accepted {
+		return fmt.Sprintf("accepted %s", d.BlockID)
+	}
+	return fmt.Sprintf("rejected %s", d.BlockID)
+}
+
+// Result compares the decisions of a recording with the decisions made when
+// replaying it.
+type Result struct {
+	// Recorded are the decisions made while recording, in order.
+	Recorded []Decision
+	// Replayed are the decisions made during the replay, in order.
+	Replayed []Decision
+	// Inspection is the state of consensus after the last message was
+	// replayed.
+	Inspection snowman.Inspection
+}
+
+// Divergence returns the index of the first decision that differs between the
+// recording and the replay. If the decisions are identical, -1 is returned.
+func (r *Result) Divergence() int {
+	for i := 0; i < len(r.Recorded) && i < len(r.Replayed); i++ {
+		if r.Recorded[i] != r.Replayed[i] {
+			return i
+		}
+	}
+	if len(r.Recorded) != len(r.Replayed) {
+		if len(r.Recorded) < len(r.Replayed) {
+			return len(r.Recorded)
+		}
+		return len(r.Replayed)
+	}
+	return -1
+}
+
+// Replay feeds the inbound messages of a recording into a fresh snowman
+// engine.
+//
+// The VM, the validator set, including the weights of sampled validators, and
+// the query latencies are replaced with mocks that return the outcomes
+// observed during the recording, so the engine should reproduce the recorded
+// consensus decisions. Replaying stops if the engine is restarted during the
+// recording.
+func Replay(ctx context.Context, log logging.Logger, entries []Entry) (*Result, error) {
+	startIndex := -1
+	for i, entry := range entries {
+		if entry.Op == OpStart {
+			startIndex = i
+			break
+		}
+	}
+	if startIndex == -1 {
+		return nil, errNoStart
+	}
+	entries = entries[startIndex:]
+	start := entries[0].Start
+	if start == nil {
+		return nil, fmt.Errorf("%w: %s", errMissingFields, OpStart)
+	}
+
+	result := &Result{}
+	vm := newVM(start.LastAcceptedID, &result.Replayed)
+	vdrs := newValidators()
+	latencies := &scriptedQueryLatency{}
+	for i, entry := range entries {
+		// Only the outcomes of the first run of the engine are replayed.
+		if i > 0 && entry.Op == OpStart {
+			entries = entries[:i]
+			break
+		}
+		if err := vm.load(entry); err != nil {
+			return nil, err
+		}
+		if err := vdrs.load(entry); err != nil {
+			return nil, err
+		}
+		if err := latencies.load(entry); err != nil {
+			return nil, err
+		}
+		switch entry.Op {
+		case OpAccept, OpReject:
+			if entry.Outcome.Err == "" {
+				result.Recorded = append(result.Recorded, Decision{
+					BlockID:  entry.Outcome.BlockID,
+					Accepted: entry.Op == OpAccept,
+				})
+			}
+		}
+	}
+
+	snowCtx := snow.DefaultConsensusContextTest()
+	snowCtx.Log = log
+	consensus := &snowman.Topological{}
+	// The recorded engine's polls were timed by the recorded time of each
+	// message, so the same times are used rather than the wall clock.
+	clock := &mockable.Clock{}
+	engine, err := smeng.New(smeng.Config{
+		Ctx:           snowCtx,
+		VM:            vm,
+		Sender:        &common.SenderTest{},
+		Validators:    vdrs,
+		Params:        start.Params,
+		Consensus:     consensus,
+		WeightedPolls: start.WeightedPolls,
+		AdaptivePolls: start.AdaptivePolls,
+		QueryLatency:  latencies,
+		Clock:         clock,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		clock.Set(entry.Time)
+		if err := replayEntry(ctx, engine, entry); err != nil {
+			return nil, fmt.Errorf("failed to replay %s at %s: %w", entry.Op, entry.Time, err)
+		}
+	}
+
+	result.Inspection = consensus.Inspect()
+	return result, nil
+}
+
+func replayEntry(ctx context.Context, engine smeng.Engine, entry Entry) error {
+	switch entry.Op {
+	case OpStart:
+		return engine.Start(ctx, entry.Start.RequestID)
+	case OpNotify:
+		if entry.Notify == nil {
+			return errMissingFields
+		}
+		return engine.Notify(ctx, *entry.Notify)
+	case OpGossip:
+		return engine.Gossip(ctx)
+	case OpPut, OpGetFailed, OpPullQuery, OpPushQuery, OpChits, OpQueryFailed:
+	default:
+		// The entry records an outcome rather than an inbound message.
+		return nil
+	}
+
+	msg := entry.Message
+	if msg == nil {
+		return errMissingFields
+	}
+	switch entry.Op {
+	case OpPut:
+		return engine.Put(ctx, msg.NodeID, msg.RequestID, msg.Bytes)
+	case OpGetFailed:
+		return engine.GetFailed(ctx, msg.NodeID, msg.RequestID)
+	case OpPullQuery:
+		return engine.PullQuery(ctx, msg.NodeID, msg.RequestID, msg.BlockID, msg.RequestedHeight)
+	case OpPushQuery:
+		return engine.PushQuery(ctx, msg.NodeID, msg.RequestID, msg.Bytes, msg.RequestedHeight)
+	case OpChits:
+		return engine.Chits(ctx, msg.NodeID, msg.RequestID, msg.PreferredID, msg.PreferredIDAtHeight, msg.AcceptedID)
+	default:
+		return engine.QueryFailed(ctx, msg.NodeID, msg.RequestID)
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestRecordAndReplay(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	snowCtx := snow.DefaultConsensusContextTest()
+
+	vdr := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(snowCtx.SubnetID, vdr, nil, ids.Empty, 1))
+
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: []byte{0},
+	}
+	// [blk0] and [blk1] conflict, [blk2] fails verification.
+	blks := make([]*snowman.TestBlock, 3)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV: genesis.IDV,
+			HeightV: 1,
+			BytesV:  []byte{byte(i + 1)},
+		}
+	}
+	blks[2].VerifyV = errTest
+
+	// parsed contains the blocks that the VM has been asked to parse, which
+	// can then be fetched with GetBlock.
+	parsed := set.Set[ids.ID]{}
+	vm := &block.TestVM{}
+	vm.Default(false)
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return genesis.IDV, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		if blkID == genesis.IDV {
+			return genesis, nil
+		}
+		for _, blk := range blks {
+			if blk.IDV == blkID && parsed.Contains(blkID) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.BytesV, b) {
+				parsed.Add(blk.IDV)
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	var queryRequestIDs []uint32
+	sender := &common.SenderTest{}
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte, _ uint64) {
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          1,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     100,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+
+	recording := &bytes.Buffer{}
+	recorder := NewRecorder(logging.NoLog{}, recording)
+	config := smeng.Config{
+		Ctx:        snowCtx,
+		VM:         RecordVM(vm, recorder),
+		Sender:     sender,
+		Validators: RecordValidators(vdrs, recorder),
+		Params:     params,
+		Consensus:  &snowman.Topological{},
+	}
+	engine, err := smeng.New(config)
+	require.NoError(err)
+	engine = RecordEngine(engine, config, recorder)
+
+	require.NoError(engine.Start(ctx, 0))
+	for i, blk := range blks {
+		require.NoError(engine.PushQuery(ctx, vdr, uint32(i), blk.BytesV, 0))
+	}
+	// Every successful poll issues a new query until [blk0] is accepted.
+	for i := 0; i < len(queryRequestIDs); i++ {
+		require.NoError(engine.Chits(ctx, vdr, queryRequestIDs[i], blks[0].IDV, blks[0].IDV, genesis.IDV))
+	}
+	require.Equal(choices.Accepted, blks[0].StatusV)
+	require.Equal(choices.Rejected, blks[1].StatusV)
+
+	entries, err := ReadEntries(recording)
+	require.NoError(err)
+
+	result, err := Replay(ctx, logging.NoLog{}, entries)
+	require.NoError(err)
+	require.Equal([]Decision{
+		{BlockID: blks[0].IDV, Accepted: true},
+		{BlockID: blks[1].IDV},
+	}, result.Recorded)
+	require.Equal(result.Recorded, result.Replayed)
+	require.Equal(-1, result.Divergence())
+	require.Equal(blks[0].IDV, result.Inspection.LastAcceptedID)
+}
+
+func TestReplayAdaptivePolls(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	snowCtx := snow.DefaultConsensusContextTest()
+
+	vdr := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(snowCtx.SubnetID, vdr, nil, ids.Empty, 1))
+
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: []byte{0},
+	}
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: genesis.IDV,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	parsed := false
+	vm := &block.TestVM{}
+	vm.Default(false)
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return genesis.IDV, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == genesis.IDV:
+			return genesis, nil
+		case blkID == blk.IDV && parsed:
+			return blk, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		if bytes.Equal(blk.BytesV, b) {
+			parsed = true
+			return blk, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	var queryRequestIDs []uint32
+	sender := &common.SenderTest{}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+
+	recording := &bytes.Buffer{}
+	recorder := NewRecorder(logging.NoLog{}, recording)
+	now := time.Unix(1_600_000_000, 0)
+	recorder.clock.Set(now)
+
+	config := smeng.Config{
+		Ctx:        snowCtx,
+		VM:         RecordVM(vm, recorder),
+		Sender:     sender,
+		Validators: RecordValidators(vdrs, recorder),
+		Params: snowball.Parameters{
+			K:                     1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
+			BetaVirtuous:          1,
+			BetaRogue:             2,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     100,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Consensus: &snowman.Topological{},
+		AdaptivePolls: smeng.AdaptivePollsConfig{
+			Enabled:              true,
+			FastLatency:          time.Second,
+			MaxConcurrentRepolls: 1,
+			TimeoutCoefficient:   1,
+			MinimumTimeout:       time.Second,
+		},
+		QueryLatency: RecordQueryLatency(testQueryLatency(100*time.Millisecond), recorder),
+		Clock:        &mockable.Clock{},
+	}
+	engine, err := smeng.New(config)
+	require.NoError(err)
+	engine = RecordEngine(engine, config, recorder)
+
+	require.NoError(engine.Start(ctx, 0))
+	require.NoError(engine.PushQuery(ctx, vdr, 0, blk.BytesV, 0))
+	require.Len(queryRequestIDs, 1)
+
+	// Once the poll has been outstanding for longer than the poll timeout, it
+	// expires and the block is polled again.
+	recorder.clock.Set(now.Add(2 * time.Second))
+	require.NoError(engine.Gossip(ctx))
+	require.Len(queryRequestIDs, 2)
+
+	require.NoError(engine.Chits(ctx, vdr, queryRequestIDs[1], blk.IDV, blk.IDV, genesis.IDV))
+	require.Equal(choices.Accepted, blk.StatusV)
+
+	entries, err := ReadEntries(recording)
+	require.NoError(err)
+
+	// The Chits only reach an outstanding poll if the first poll expired
+	// during the replay as well.
+	result, err := Replay(ctx, logging.NoLog{}, entries)
+	require.NoError(err)
+	require.Equal([]Decision{
+		{BlockID: blk.IDV, Accepted: true},
+	}, result.Recorded)
+	require.Equal(result.Recorded, result.Replayed)
+}
+
+func TestReplayWeightedPolls(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	snowCtx := snow.DefaultConsensusContextTest()
+
+	heavy := ids.GenerateTestNodeID()
+	light := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(snowCtx.SubnetID, heavy, nil, ids.Empty, 99))
+	require.NoError(vdrs.AddStaker(snowCtx.SubnetID, light, nil, ids.Empty, 1))
+
+	genesis := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV: []byte{0},
+	}
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: genesis.IDV,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	parsed := false
+	vm := &block.TestVM{}
+	vm.Default(false)
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return genesis.IDV, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == genesis.IDV:
+			return genesis, nil
+		case blkID == blk.IDV && parsed:
+			return blk, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		if bytes.Equal(blk.BytesV, b) {
+			parsed = true
+			return blk, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	var queryRequestIDs []uint32
+	sender := &common.SenderTest{}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+
+	recording := &bytes.Buffer{}
+	recorder := NewRecorder(logging.NoLog{}, recording)
+
+	// The light validator fills most of the sample, so the block is only
+	// accepted if the vote of the heavy validator is weighted by its stake.
+	sampled := &fixedSampleValidators{
+		Manager: vdrs,
+		sample:  []ids.NodeID{heavy, light, light, light},
+	}
+	config := smeng.Config{
+		Ctx:        snowCtx,
+		VM:         RecordVM(vm, recorder),
+		Sender:     sender,
+		Validators: RecordValidators(sampled, recorder),
+		Params: snowball.Parameters{
+			K:                     4,
+			AlphaPreference:       3,
+			AlphaConfidence:       3,
+			BetaVirtuous:          1,
+			BetaRogue:             2,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     100,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Consensus:     &snowman.Topological{},
+		WeightedPolls: true,
+	}
+	engine, err := smeng.New(config)
+	require.NoError(err)
+	engine = RecordEngine(engine, config, recorder)
+
+	require.NoError(engine.Start(ctx, 0))
+	require.NoError(engine.PushQuery(ctx, heavy, 0, blk.BytesV, 0))
+	require.Len(queryRequestIDs, 1)
+
+	require.NoError(engine.Chits(ctx, heavy, queryRequestIDs[0], blk.IDV, blk.IDV, genesis.IDV))
+	require.NoError(engine.Chits(ctx, light, queryRequestIDs[0], genesis.IDV, genesis.IDV, genesis.IDV))
+	require.Equal(choices.Accepted, blk.StatusV)
+
+	entries, err := ReadEntries(recording)
+	require.NoError(err)
+
+	result, err := Replay(ctx, logging.NoLog{}, entries)
+	require.NoError(err)
+	require.Equal([]Decision{
+		{BlockID: blk.IDV, Accepted: true},
+	}, result.Recorded)
+	require.Equal(result.Recorded, result.Replayed)
+}
+
+func TestReplayNoStart(t *testing.T) {
+	_, err := Replay(context.Background(), logging.NoLog{}, []Entry{
+		{
+			Op:      OpVerify,
+			Outcome: &Outcome{},
+		},
+	})
+	require.ErrorIs(t, err, errNoStart)
+}
+
+func TestReadEntriesTooLarge(t *testing.T) {
+	line := strings.Repeat("a", maxEntrySize+1)
+	_, err := ReadEntries(strings.NewReader(line))
+	require.ErrorIs(t, err, errEntryTooLarge)
+}
+
+func TestResultDivergence(t *testing.T) {
+	a := Decision{BlockID: ids.GenerateTestID(), Accepted: true}
+	b := Decision{BlockID: ids.GenerateTestID()}
+
+	tests := []struct {
+		name     string
+		recorded []Decision
+		replayed []Decision
+		expected int
+	}{
+		{
+			name:     "identical",
+			recorded: []Decision{a, b},
+			replayed: []Decision{a, b},
+			expected: -1,
+		},
+		{
+			name:     "different decision",
+			recorded: []Decision{a, b},
+			replayed: []Decision{a, a},
+			expected: 1,
+		},
+		{
+			name:     "missing decision",
+			recorded: []Decision{a, b},
+			replayed: []Decision{a},
+			expected: 1,
+		},
+		{
+			name:     "extra decision",
+			recorded: nil,
+			replayed: []Decision{b},
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := &Result{
+				Recorded: test.recorded,
+				Replayed: test.replayed,
+			}
+			require.Equal(t, test.expected, result.Divergence())
+		})
+	}
+}
+
+type testQueryLatency time.Duration
+
+func (l testQueryLatency) QueryLatency(float64) (time.Duration, bool) {
+	return time.Duration(l), true
+}
+
+// fixedSampleValidators always samples [sample].
+type fixedSampleValidators struct {
+	validators.Manager
+	sample []ids.NodeID
+}
+
+func (v *fixedSampleValidators) Sample(ids.ID, int) ([]ids.NodeID, error) {
+	return v.sample, nil
+}
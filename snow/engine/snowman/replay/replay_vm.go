@@ -0,0 +1,296 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+var (
+	_ block.ChainVM              = (*vm)(nil)
+	_ snowman.OracleBlock        = (*replayBlock)(nil)
+	_ validators.Manager         = (*scriptedValidators)(nil)
+	_ smeng.QueryLatencyReporter = (*scriptedQueryLatency)(nil)
+
+	errUnknownBlock     = errors.New("unknown block")
+	errNoRecordedBuild  = errors.New("no recorded block build")
+	errNoRecordedSample = errors.New("no recorded validator sample")
+)
+
+// vm returns the blocks and outcomes that were observed by the recorded
+// engine.
+type vm struct {
+	block.TestVM
+
+	lastAcceptedID ids.ID
+	blocks         map[ids.ID]*replayBlock
+	blocksByBytes  map[string]*replayBlock
+	// known contains the blocks that can currently be fetched with GetBlock
+	known set.Set[ids.ID]
+	// builds are the remaining outcomes of BuildBlock
+	builds []Outcome
+	// verifications are the remaining outcomes of Verify for each block
+	verifications map[ids.ID][]string
+	options       map[ids.ID]*Options
+
+	decisions *[]Decision
+}
+
+func newVM(lastAcceptedID ids.ID, decisions *[]Decision) *vm {
+	v := &vm{
+		lastAcceptedID: lastAcceptedID,
+		blocks:         make(map[ids.ID]*replayBlock),
+		blocksByBytes:  make(map[string]*replayBlock),
+		verifications:  make(map[ids.ID][]string),
+		options:        make(map[ids.ID]*Options),
+		decisions:      decisions,
+	}
+	v.TestVM.Default(false)
+	return v
+}
+
+// load the outcome recorded in [entry], if any.
+func (v *vm) load(entry Entry) error {
+	switch entry.Op {
+	case OpBlock:
+		if entry.Block == nil {
+			return fmt.Errorf("%w: %s", errMissingFields, entry.Op)
+		}
+		blk := &replayBlock{
+			TestBlock: snowman.TestBlock{
+				TestDecidable: choices.TestDecidable{
+					IDV:     entry.Block.ID,
+					StatusV: entry.Block.Status,
+				},
+				ParentV:    entry.Block.ParentID,
+				HeightV:    entry.Block.Height,
+				TimestampV: entry.Block.Timestamp,
+				BytesV:     entry.Block.Bytes,
+			},
+			vm: v,
+		}
+		v.blocks[blk.IDV] = blk
+		v.blocksByBytes[string(blk.BytesV)] = blk
+		// Blocks that were first fetched with GetBlock must have already been
+		// known by the VM.
+		if entry.Block.Source == SourceGet {
+			v.known.Add(blk.IDV)
+		}
+	case OpBuild, OpVerify, OpAccept, OpReject:
+		if entry.Outcome == nil {
+			return fmt.Errorf("%w: %s", errMissingFields, entry.Op)
+		}
+		switch entry.Op {
+		case OpBuild:
+			v.builds = append(v.builds, *entry.Outcome)
+		case OpVerify:
+			blkID := entry.Outcome.BlockID
+			v.verifications[blkID] = append(v.verifications[blkID], entry.Outcome.Err)
+		}
+	case OpOptions:
+		if entry.Options == nil {
+			return fmt.Errorf("%w: %s", errMissingFields, entry.Op)
+		}
+		if _, ok := v.options[entry.Options.BlockID]; !ok {
+			v.options[entry.Options.BlockID] = entry.Options
+		}
+	}
+	return nil
+}
+
+func (v *vm) BuildBlock(context.Context) (snowman.Block, error) {
+	if len(v.builds) == 0 {
+		return nil, errNoRecordedBuild
+	}
+	build := v.builds[0]
+	v.builds = v.builds[1:]
+	if build.Err != "" {
+		return nil, errors.New(build.Err)
+	}
+	return v.getBlock(build.BlockID)
+}
+
+func (v *vm) ParseBlock(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+	blk, ok := v.blocksByBytes[string(blkBytes)]
+	if !ok {
+		return nil, errUnknownBlock
+	}
+	v.known.Add(blk.IDV)
+	return blk, nil
+}
+
+func (v *vm) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+	if !v.known.Contains(blkID) {
+		return nil, errUnknownBlock
+	}
+	return v.blocks[blkID], nil
+}
+
+// getBlock returns the recorded block [blkID] and marks it as known.
+func (v *vm) getBlock(blkID ids.ID) (*replayBlock, error) {
+	blk, ok := v.blocks[blkID]
+	if !ok {
+		return nil, errUnknownBlock
+	}
+	v.known.Add(blkID)
+	return blk, nil
+}
+
+func (v *vm) LastAccepted(context.Context) (ids.ID, error) {
+	return v.lastAcceptedID, nil
+}
+
+// replayBlock reproduces the recorded outcomes of operations on a block.
+type replayBlock struct {
+	snowman.TestBlock
+	vm *vm
+}
+
+func (b *replayBlock) Verify(context.Context) error {
+	verifications := b.vm.verifications[b.IDV]
+	if len(verifications) == 0 {
+		return nil
+	}
+	result := verifications[0]
+	b.vm.verifications[b.IDV] = verifications[1:]
+	if result != "" {
+		return errors.New(result)
+	}
+	return nil
+}
+
+func (b *replayBlock) Accept(context.Context) error {
+	b.StatusV = choices.Accepted
+	b.vm.lastAcceptedID = b.IDV
+	*b.vm.decisions = append(*b.vm.decisions, Decision{
+		BlockID:  b.IDV,
+		Accepted: true,
+	})
+	return nil
+}
+
+func (b *replayBlock) Reject(context.Context) error {
+	b.StatusV = choices.Rejected
+	*b.vm.decisions = append(*b.vm.decisions, Decision{
+		BlockID: b.IDV,
+	})
+	return nil
+}
+
+func (b *replayBlock) Options(context.Context) ([2]snowman.Block, error) {
+	options, ok := b.vm.options[b.IDV]
+	switch {
+	case !ok || options.NotOracle:
+		return [2]snowman.Block{}, snowman.ErrNotOracle
+	case options.Err != "":
+		return [2]snowman.Block{}, errors.New(options.Err)
+	case len(options.OptionIDs) != 2:
+		return [2]snowman.Block{}, fmt.Errorf("%w: %s", errMissingFields, OpOptions)
+	}
+
+	var blks [2]snowman.Block
+	for i, optionID := range options.OptionIDs {
+		blk, err := b.vm.getBlock(optionID)
+		if err != nil {
+			return [2]snowman.Block{}, err
+		}
+		blks[i] = blk
+	}
+	return blks, nil
+}
+
+// scriptedValidators returns the recorded validator samples in order. The
+// weights reported for the validators are the weights recorded with the last
+// sample.
+type scriptedValidators struct {
+	validators.Manager
+	samples []Sample
+
+	weights     map[ids.NodeID]uint64
+	totalWeight uint64
+}
+
+func newValidators() *scriptedValidators {
+	return &scriptedValidators{
+		Manager: validators.NewManager(),
+	}
+}
+
+// load the validator sample recorded in [entry], if any.
+func (v *scriptedValidators) load(entry Entry) error {
+	if entry.Op != OpSample {
+		return nil
+	}
+	if entry.Sample == nil {
+		return fmt.Errorf("%w: %s", errMissingFields, entry.Op)
+	}
+	v.samples = append(v.samples, *entry.Sample)
+	return nil
+}
+
+func (v *scriptedValidators) Sample(ids.ID, int) ([]ids.NodeID, error) {
+	if len(v.samples) == 0 {
+		return nil, errNoRecordedSample
+	}
+	sample := v.samples[0]
+	v.samples = v.samples[1:]
+	if sample.Err != "" {
+		return nil, errors.New(sample.Err)
+	}
+
+	v.weights = make(map[ids.NodeID]uint64, len(sample.Weights))
+	for i, weight := range sample.Weights {
+		if i < len(sample.NodeIDs) {
+			v.weights[sample.NodeIDs[i]] = weight
+		}
+	}
+	v.totalWeight = sample.TotalWeight
+	return sample.NodeIDs, nil
+}
+
+func (v *scriptedValidators) GetWeight(_ ids.ID, nodeID ids.NodeID) uint64 {
+	return v.weights[nodeID]
+}
+
+func (v *scriptedValidators) TotalWeight(ids.ID) (uint64, error) {
+	return v.totalWeight, nil
+}
+
+// scriptedQueryLatency returns the recorded query latencies in order.
+type scriptedQueryLatency struct {
+	latencies []QueryLatency
+}
+
+// load the query latency recorded in [entry], if any.
+func (l *scriptedQueryLatency) load(entry Entry) error {
+	if entry.Op != OpQueryLatency {
+		return nil
+	}
+	if entry.QueryLatency == nil {
+		return fmt.Errorf("%w: %s", errMissingFields, entry.Op)
+	}
+	l.latencies = append(l.latencies, *entry.QueryLatency)
+	return nil
+}
+
+func (l *scriptedQueryLatency) QueryLatency(float64) (time.Duration, bool) {
+	if len(l.latencies) == 0 {
+		return 0, false
+	}
+	latency := l.latencies[0]
+	l.latencies = l.latencies[1:]
+	return latency.Latency, latency.OK
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -109,6 +110,10 @@ func newTransitive(config Config) (*Transitive, error) {
 		return nil, err
 	}
 
+	if config.Clock == nil {
+		config.Clock = &mockable.Clock{}
+	}
+
 	acceptedFrontiers := tracker.NewAccepted()
 	config.Validators.RegisterCallbackListener(config.Ctx.SubnetID, acceptedFrontiers)
 
@@ -116,6 +121,19 @@ func newTransitive(config Config) (*Transitive, error) {
 		config.Params.AlphaPreference,
 		config.Params.AlphaConfidence,
 	)
+	if config.WeightedPolls {
+		factory = poll.NewEarlyTermWeightedFactory(
+			config.Params.K,
+			config.Params.AlphaPreference,
+			config.Params.AlphaConfidence,
+			func(nodeID ids.NodeID) uint64 {
+				return config.Validators.GetWeight(config.Ctx.SubnetID, nodeID)
+			},
+			func() (uint64, error) {
+				return config.Validators.TotalWeight(config.Ctx.SubnetID)
+			},
+		)
+	}
 	t := &Transitive{
 		Config:                      config,
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
@@ -134,6 +152,7 @@ func newTransitive(config Config) (*Transitive, error) {
 			config.Ctx.Log,
 			"",
 			config.Ctx.Registerer,
+			config.Clock,
 		),
 	}
 
@@ -266,6 +285,7 @@ func (t *Transitive) PushQuery(ctx context.Context, nodeID ids.NodeID, requestID
 
 func (t *Transitive) Chits(ctx context.Context, nodeID ids.NodeID, requestID uint32, preferredID ids.ID, preferredIDAtHeight ids.ID, acceptedID ids.ID) error {
 	t.acceptedFrontiers.SetLastAccepted(nodeID, acceptedID)
+	t.expirePolls(ctx)
 
 	t.Ctx.Log.Verbo("called Chits for the block",
 		zap.Stringer("nodeID", nodeID),
@@ -325,6 +345,8 @@ func (t *Transitive) QueryFailed(ctx context.Context, nodeID ids.NodeID, request
 		return t.Chits(ctx, nodeID, requestID, lastAccepted, lastAccepted, lastAccepted)
 	}
 
+	t.expirePolls(ctx)
+
 	t.blocked.Register(
 		ctx,
 		&voter{
@@ -342,6 +364,11 @@ func (*Transitive) Timeout(context.Context) error {
 }
 
 func (t *Transitive) Gossip(ctx context.Context) error {
+	t.expirePolls(ctx)
+	if err := t.errs.Err; err != nil {
+		return err
+	}
+
 	blkID, err := t.VM.LastAccepted(ctx)
 	if err != nil {
 		return err
@@ -610,6 +637,41 @@ func (t *Transitive) buildBlocks(ctx context.Context) error {
 	return nil
 }
 
+// recordPolls applies the results of the finished polls to consensus and
+// issues new polls if consensus hasn't quiesced.
+func (t *Transitive) recordPolls(ctx context.Context, results []bag.Bag[ids.ID]) {
+	if len(results) == 0 {
+		return
+	}
+
+	for _, result := range results {
+		result := result
+		t.Ctx.Log.Debug("finishing poll",
+			zap.Stringer("result", &result),
+		)
+		if err := t.Consensus.RecordPoll(ctx, result); err != nil {
+			t.errs.Add(err)
+		}
+	}
+
+	if t.errs.Errored() {
+		return
+	}
+
+	if err := t.VM.SetPreference(ctx, t.Consensus.Preference()); err != nil {
+		t.errs.Add(err)
+		return
+	}
+
+	if t.Consensus.NumProcessing() == 0 {
+		t.Ctx.Log.Debug("Snowman engine can quiesce")
+		return
+	}
+
+	t.Ctx.Log.Debug("Snowman engine can't quiesce")
+	t.repoll(ctx)
+}
+
 // Issue another poll to the network, asking what it prefers given the block we prefer.
 // Helps move consensus along.
 func (t *Transitive) repoll(ctx context.Context) {
@@ -617,7 +679,9 @@ func (t *Transitive) repoll(ctx context.Context) {
 	// propagate the most likely branch as quickly as possible
 	prefID := t.Consensus.Preference()
 
-	for i := t.polls.Len(); i < t.Params.ConcurrentRepolls; i++ {
+	concurrentRepolls := t.concurrentRepolls()
+	t.metrics.concurrentRepolls.Set(float64(concurrentRepolls))
+	for i := t.polls.Len(); i < concurrentRepolls; i++ {
 		t.sendQuery(ctx, prefID, nil, false)
 	}
 }
@@ -65,36 +65,7 @@ func (v *voter) Update(ctx context.Context) {
 		results = v.t.polls.Drop(v.requestID, v.vdr)
 	}
 
-	if len(results) == 0 {
-		return
-	}
-
-	for _, result := range results {
-		result := result
-		v.t.Ctx.Log.Debug("finishing poll",
-			zap.Stringer("result", &result),
-		)
-		if err := v.t.Consensus.RecordPoll(ctx, result); err != nil {
-			v.t.errs.Add(err)
-		}
-	}
-
-	if v.t.errs.Errored() {
-		return
-	}
-
-	if err := v.t.VM.SetPreference(ctx, v.t.Consensus.Preference()); err != nil {
-		v.t.errs.Add(err)
-		return
-	}
-
-	if v.t.Consensus.NumProcessing() == 0 {
-		v.t.Ctx.Log.Debug("Snowman engine can quiesce")
-		return
-	}
-
-	v.t.Ctx.Log.Debug("Snowman engine can't quiesce")
-	v.t.repoll(ctx)
+	v.t.recordPolls(ctx, results)
 }
 
 // getProcessingAncestor finds [initialVote]'s most recent ancestor that is
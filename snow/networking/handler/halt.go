@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	errAlreadyHalted = errors.New("chain is already halted")
+	errNotHalted     = errors.New("chain isn't halted")
+
+	// haltedServedOps are the sync messages that are still handled while the
+	// chain is halted. They only read the chain's accepted state or track
+	// connections.
+	haltedServedOps = set.Of(
+		message.GetStateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp,
+		message.GetAcceptedOp,
+		message.GetAncestorsOp,
+		message.GetOp,
+		message.ConnectedOp,
+		message.ConnectedSubnetOp,
+		message.DisconnectedOp,
+	)
+
+	// haltedDroppedOps are the sync messages that are dropped while the chain
+	// is halted. The sender will time out the request.
+	//
+	// All other sync messages, other than gossiped containers, are responses
+	// to requests sent by this chain. They can't be dropped, because the
+	// request's timeout has already been cleared, so they are deferred until
+	// the chain is resumed.
+	haltedDroppedOps = set.Of(
+		message.PushQueryOp,
+		message.PullQueryOp,
+	)
+)
+
+// maxDeferredResponses is the maximum number of responses that are deferred
+// while the chain is halted. Any further responses are dropped and their
+// requests are failed instead, which only requires deferring a small internal
+// message.
+const maxDeferredResponses = 1024
+
+type deferredMsg struct {
+	ctx context.Context
+	msg Message
+}
+
+// releasedMsg is a deferred message whose inbound throttler reservation was
+// already released when it was deferred.
+type releasedMsg struct {
+	message.InboundMessage
+}
+
+func (releasedMsg) OnFinishedHandling() {}
+
+// Halt stops the engine from processing consensus messages until Resume is
+// called. Requests for accepted containers continue to be served.
+//
+// A message that was already being handled when Halt was called may still be
+// processed.
+func (h *handler) Halt(context.Context) error {
+	h.haltLock.Lock()
+	defer h.haltLock.Unlock()
+
+	if h.halted {
+		return errAlreadyHalted
+	}
+	h.halted = true
+
+	h.ctx.Log.Info("halted chain")
+	return nil
+}
+
+// Resume allows the engine to process consensus messages again. Any responses
+// and VM notifications received while the chain was halted are then
+// processed.
+func (h *handler) Resume(ctx context.Context) error {
+	h.haltLock.Lock()
+	if !h.halted {
+		h.haltLock.Unlock()
+		return errNotHalted
+	}
+	h.halted = false
+	deferredMsgs := h.deferredMsgs
+	deferredChanMsgs := h.deferredChanMsgs
+	h.deferredMsgs = nil
+	h.deferredChanMsgs = nil
+	h.numDeferredResponses = 0
+	h.haltLock.Unlock()
+
+	h.ctx.Log.Info("resuming chain",
+		zap.Int("numDeferredMsgs", len(deferredMsgs)),
+		zap.Int("numDeferredChanMsgs", len(deferredChanMsgs)),
+	)
+
+	for _, msg := range deferredChanMsgs {
+		if err := h.handleChanMsg(msg); err != nil {
+			err = fmt.Errorf("%w while processing deferred chan message: %s", err, msg)
+			h.StopWithError(ctx, err)
+			return err
+		}
+	}
+	for _, deferred := range deferredMsgs {
+		h.syncMessageQueue.Push(deferred.ctx, deferred.msg)
+	}
+	return nil
+}
+
+func (h *handler) Halted() bool {
+	h.haltLock.Lock()
+	defer h.haltLock.Unlock()
+
+	return h.halted
+}
+
+// deferSyncMsg returns true if [msg] shouldn't be handled because the chain is
+// halted. If the message will be handled after the chain is resumed, it is
+// recorded.
+//
+// Deferred messages release their inbound throttler reservation immediately,
+// so that a halted chain doesn't starve its peers' inbound bandwidth.
+func (h *handler) deferSyncMsg(ctx context.Context, msg Message) bool {
+	op := msg.Op()
+	if haltedServedOps.Contains(op) {
+		return false
+	}
+
+	h.haltLock.Lock()
+	defer h.haltLock.Unlock()
+
+	if !h.halted {
+		return false
+	}
+
+	switch _, isFailure := message.FailedToResponseOps[op]; {
+	case haltedDroppedOps.Contains(op):
+		h.dropHaltedMsg(msg, "chain halted")
+		return true
+	case op == message.PutOp && isGossip(msg):
+		// Gossiped containers aren't responses to any outstanding request.
+		h.dropHaltedMsg(msg, "unrequested response")
+		return true
+	case !isFailure && h.numDeferredResponses >= maxDeferredResponses:
+		failedMsg, ok := failedResponse(h.ctx.ChainID, msg)
+		h.dropHaltedMsg(msg, "too many deferred responses")
+		if !ok {
+			return true
+		}
+		// The request's timeout has already been cleared, so the request is
+		// failed to avoid it being outstanding forever.
+		msg = failedMsg
+	case !isFailure:
+		h.numDeferredResponses++
+	}
+
+	msg.OnFinishedHandling()
+	h.deferredMsgs = append(h.deferredMsgs, deferredMsg{
+		ctx: ctx,
+		msg: Message{
+			InboundMessage: releasedMsg{
+				InboundMessage: msg.InboundMessage,
+			},
+			EngineType: msg.EngineType,
+		},
+	})
+	return true
+}
+
+func (h *handler) dropHaltedMsg(msg Message, reason string) {
+	h.ctx.Log.Debug("dropping sync message",
+		zap.String("reason", reason),
+		zap.Stringer("nodeID", msg.NodeID()),
+		zap.Stringer("messageOp", msg.Op()),
+	)
+	msg.OnFinishedHandling()
+}
+
+func isGossip(msg Message) bool {
+	requestID, ok := message.GetRequestID(msg.Message())
+	return ok && requestID == constants.GossipMsgRequestID
+}
+
+// failedResponse returns the internal failure message of the request that
+// [msg] is a response to. Returns false if [msg] isn't a response.
+func failedResponse(chainID ids.ID, msg Message) (Message, bool) {
+	requestID, ok := message.GetRequestID(msg.Message())
+	if !ok {
+		return Message{}, false
+	}
+
+	var (
+		nodeID     = msg.NodeID()
+		engineType = msg.EngineType
+		failedMsg  message.InboundMessage
+	)
+	switch msg.Op() {
+	case message.StateSummaryFrontierOp:
+		failedMsg = message.InternalGetStateSummaryFrontierFailed(nodeID, chainID, requestID)
+	case message.AcceptedStateSummaryOp:
+		failedMsg = message.InternalGetAcceptedStateSummaryFailed(nodeID, chainID, requestID)
+	case message.AcceptedFrontierOp:
+		failedMsg = message.InternalGetAcceptedFrontierFailed(nodeID, chainID, requestID, engineType)
+	case message.AcceptedOp:
+		failedMsg = message.InternalGetAcceptedFailed(nodeID, chainID, requestID, engineType)
+	case message.AncestorsOp:
+		failedMsg = message.InternalGetAncestorsFailed(nodeID, chainID, requestID, engineType)
+	case message.PutOp:
+		failedMsg = message.InternalGetFailed(nodeID, chainID, requestID, engineType)
+	case message.ChitsOp:
+		failedMsg = message.InternalQueryFailed(nodeID, chainID, requestID, engineType)
+	default:
+		return Message{}, false
+	}
+	return Message{
+		InboundMessage: failedMsg,
+		EngineType:     engineType,
+	}, true
+}
+
+// deferChanMsg returns true if [msg] shouldn't be handled because the chain is
+// halted. VM notifications and timeouts are handled once the chain is
+// resumed, while gossip requests are dropped.
+//
+// At most one instance of each VM notification and of the timeout
+// notification is deferred, so the deferred chan messages are bounded.
+func (h *handler) deferChanMsg(msg message.InboundMessage) bool {
+	h.haltLock.Lock()
+	defer h.haltLock.Unlock()
+
+	if !h.halted {
+		return false
+	}
+
+	switch body := msg.Message().(type) {
+	case *message.GossipRequest:
+		msg.OnFinishedHandling()
+		return true
+	case *message.VMMessage:
+		// Only one instance of each notification needs to be handled.
+		for _, deferred := range h.deferredChanMsgs {
+			if vmMsg, ok := deferred.Message().(*message.VMMessage); ok && vmMsg.Notification == body.Notification {
+				msg.OnFinishedHandling()
+				return true
+			}
+		}
+	case *message.Timeout:
+		for _, deferred := range h.deferredChanMsgs {
+			if _, ok := deferred.Message().(*message.Timeout); ok {
+				msg.OnFinishedHandling()
+				return true
+			}
+		}
+	}
+	h.deferredChanMsgs = append(h.deferredChanMsgs, msg)
+	return true
+}
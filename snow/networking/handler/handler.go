@@ -65,6 +65,16 @@ type Handler interface {
 
 	SetOnStopped(onStopped func())
 	Start(ctx context.Context, recoverPanic bool)
+
+	// Halt stops the chain from processing consensus messages until Resume is
+	// called. Requests for accepted containers are still served.
+	Halt(ctx context.Context) error
+	// Resume processes the consensus messages received while the chain was
+	// halted and allows new consensus messages to be processed.
+	Resume(ctx context.Context) error
+	// Halted returns true if the chain is halted.
+	Halted() bool
+
 	Push(ctx context.Context, msg Message)
 	Len() int
 
@@ -126,6 +136,18 @@ type handler struct {
 
 	// Tracks the peers that are currently connected to this subnet
 	peerTracker commontracker.Peers
+
+	haltLock sync.Mutex
+	// halted is true while the chain is halted by an operator.
+	halted bool
+	// deferredMsgs are the sync messages received while halted that will be
+	// handled once the chain is resumed.
+	deferredMsgs []deferredMsg
+	// numDeferredResponses is the number of responses in [deferredMsgs].
+	numDeferredResponses int
+	// deferredChanMsgs are the chan messages received while halted that will
+	// be handled once the chain is resumed.
+	deferredChanMsgs []message.InboundMessage
 }
 
 // Initialize this consensus handler
@@ -441,6 +463,10 @@ func (h *handler) dispatchChans(ctx context.Context) {
 
 // Any returned error is treated as fatal
 func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
+	if h.deferSyncMsg(ctx, msg) {
+		return nil
+	}
+
 	var (
 		nodeID    = msg.NodeID()
 		op        = msg.Op()
@@ -617,6 +643,10 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		return engine.GetAcceptedFrontierFailed(ctx, nodeID, msg.RequestID)
 
 	case *p2p.GetAccepted:
+		if len(msg.Heights) > 0 {
+			return engine.GetAcceptedHeights(ctx, nodeID, msg.RequestId, msg.Heights)
+		}
+
 		containerIDs, err := getIDs(msg.ContainerIds)
 		if err != nil {
 			h.ctx.Log.Debug("message with invalid field",
@@ -893,6 +923,10 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 
 // Any returned error is treated as fatal
 func (h *handler) handleChanMsg(msg message.InboundMessage) error {
+	if h.deferChanMsg(msg) {
+		return nil
+	}
+
 	var (
 		op        = msg.Op()
 		body      = msg.Message()
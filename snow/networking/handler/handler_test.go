@@ -671,3 +671,220 @@ func TestHandlerStartError(t *testing.T) {
 	_, err = handler.AwaitStopped(context.Background())
 	require.NoError(err)
 }
+
+func TestHandlerHaltDefersResponses(t *testing.T) {
+	require := require.New(t)
+
+	getFailed := make(chan struct{}, 1)
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handlerIntf, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+	)
+	require.NoError(err)
+	handler := handlerIntf.(*handler)
+
+	handler.clock.Set(time.Now())
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	bootstrapper.GetFailedF = func(context.Context, ids.NodeID, uint32) error {
+		getFailed <- struct{}{}
+		return nil
+	}
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping, // assumed bootstrap is ongoing
+	})
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	require.ErrorIs(handler.Resume(context.Background()), errNotHalted)
+	require.NoError(handler.Halt(context.Background()))
+	require.ErrorIs(handler.Halt(context.Background()), errAlreadyHalted)
+	require.True(handler.Halted())
+
+	handler.Start(context.Background(), false)
+
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InternalGetFailed(ids.EmptyNodeID, ctx.ChainID, 1, p2p.EngineType_ENGINE_TYPE_SNOWMAN),
+		EngineType:     p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+
+	select {
+	case <-getFailed:
+		require.FailNow("handled response while halted")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(handler.Resume(context.Background()))
+	require.False(handler.Halted())
+
+	select {
+	case <-getFailed:
+	case <-time.After(time.Second):
+		require.FailNow("deferred response wasn't handled after resuming")
+	}
+}
+
+type finishedHandlingMsg struct {
+	message.InboundMessage
+	onFinishedHandling func()
+}
+
+func (m finishedHandlingMsg) OnFinishedHandling() {
+	m.onFinishedHandling()
+}
+
+func TestHandlerHaltBoundsDeferredResponses(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handlerIntf, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+	)
+	require.NoError(err)
+	handler := handlerIntf.(*handler)
+
+	handler.clock.Set(time.Now())
+
+	var (
+		lock          sync.Mutex
+		numChits      int
+		failedQueries []uint32
+	)
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	bootstrapper.ChitsF = func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID, ids.ID) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		numChits++
+		return nil
+	}
+	bootstrapper.QueryFailedF = func(_ context.Context, _ ids.NodeID, requestID uint32) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		failedQueries = append(failedQueries, requestID)
+		return nil
+	}
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping, // assumed bootstrap is ongoing
+	})
+
+	require.NoError(handler.Halt(context.Background()))
+	handler.Start(context.Background(), false)
+
+	var (
+		numResponses = maxDeferredResponses + 1
+		released     = make(chan struct{}, numResponses)
+	)
+	for requestID := 0; requestID < numResponses; requestID++ {
+		handler.Push(context.Background(), Message{
+			InboundMessage: finishedHandlingMsg{
+				InboundMessage: message.InboundChits(ctx.ChainID, uint32(requestID), ids.Empty, ids.Empty, ids.Empty, ids.EmptyNodeID),
+				onFinishedHandling: func() {
+					released <- struct{}{}
+				},
+			},
+			EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		})
+	}
+
+	// Deferring the responses releases them from the inbound throttler.
+	for i := 0; i < numResponses; i++ {
+		select {
+		case <-released:
+		case <-time.After(time.Second):
+			require.FailNow("deferred response wasn't released")
+		}
+	}
+
+	require.NoError(handler.Resume(context.Background()))
+	require.Eventually(func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return numChits+len(failedQueries) == numResponses
+	}, time.Second, 10*time.Millisecond)
+
+	// The response that exceeded the limit was replaced by a failure.
+	lock.Lock()
+	defer lock.Unlock()
+
+	require.Equal(maxDeferredResponses, numChits)
+	require.Equal([]uint32{maxDeferredResponses}, failedQueries)
+	require.Empty(released)
+}
@@ -27,6 +27,7 @@ func (h *handler) HealthCheck(ctx context.Context) (interface{}, error) {
 	intf := map[string]interface{}{
 		"engine":     engineIntf,
 		"networking": networkingIntf,
+		"halted":     h.Halted(),
 	}
 	if engineErr == nil {
 		return intf, networkingErr
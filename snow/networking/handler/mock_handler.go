@@ -83,6 +83,34 @@ func (mr *MockHandlerMockRecorder) GetEngineManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEngineManager", reflect.TypeOf((*MockHandler)(nil).GetEngineManager))
 }
 
+// Halt mocks base method.
+func (m *MockHandler) Halt(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Halt", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Halt indicates an expected call of Halt.
+func (mr *MockHandlerMockRecorder) Halt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Halt", reflect.TypeOf((*MockHandler)(nil).Halt), arg0)
+}
+
+// Halted mocks base method.
+func (m *MockHandler) Halted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Halted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Halted indicates an expected call of Halted.
+func (mr *MockHandlerMockRecorder) Halted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Halted", reflect.TypeOf((*MockHandler)(nil).Halted))
+}
+
 // HealthCheck mocks base method.
 func (m *MockHandler) HealthCheck(arg0 context.Context) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -136,6 +164,20 @@ func (mr *MockHandlerMockRecorder) RegisterTimeout(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTimeout", reflect.TypeOf((*MockHandler)(nil).RegisterTimeout), arg0)
 }
 
+// Resume mocks base method.
+func (m *MockHandler) Resume(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockHandlerMockRecorder) Resume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockHandler)(nil).Resume), arg0)
+}
+
 // SetEngineManager mocks base method.
 func (m *MockHandler) SetEngineManager(arg0 *EngineManager) {
 	m.ctrl.T.Helper()
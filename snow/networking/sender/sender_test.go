@@ -81,6 +81,7 @@ func TestTimeout(t *testing.T) {
 		"dummyNamespace",
 		constants.DefaultNetworkCompressionType,
 		10*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -339,6 +340,7 @@ func TestReliableMessages(t *testing.T) {
 		"dummyNamespace",
 		constants.DefaultNetworkCompressionType,
 		10*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -487,6 +489,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		"dummyNamespace",
 		constants.DefaultNetworkCompressionType,
 		10*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
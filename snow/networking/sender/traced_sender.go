@@ -102,6 +102,17 @@ func (s *tracedSender) SendGetAccepted(ctx context.Context, nodeIDs set.Set[ids.
 	s.sender.SendGetAccepted(ctx, nodeIDs, requestID, containerIDs)
 }
 
+func (s *tracedSender) SendGetAcceptedHeights(ctx context.Context, nodeID ids.NodeID, requestID uint32, heights []uint64) {
+	ctx, span := s.tracer.Start(ctx, "tracedSender.SendGetAcceptedHeights", oteltrace.WithAttributes(
+		attribute.Stringer("recipients", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.Int("numHeights", len(heights)),
+	))
+	defer span.End()
+
+	s.sender.SendGetAcceptedHeights(ctx, nodeID, requestID, heights)
+}
+
 func (s *tracedSender) SendAccepted(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) {
 	ctx, span := s.tracer.Start(ctx, "tracedSender.SendAccepted", oteltrace.WithAttributes(
 		attribute.Stringer("recipients", nodeID),
@@ -0,0 +1,408 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+
+	commontracker "github.com/ava-labs/avalanchego/snow/engine/common/tracker"
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
+
+const (
+	// Gossip isn't driven by the virtual clock, so it is effectively disabled.
+	gossipFrequency = 24 * time.Hour
+	threadPoolSize  = 1
+	closeTimeout    = 10 * time.Second
+
+	maxMessageTimeout          = 10 * time.Second
+	ancestorsMaxContainersSent = 2000
+	maxTimeGetAncestors        = 50 * time.Millisecond
+)
+
+var (
+	_ handler.Handler            = (*trackedHandler)(nil)
+	_ message.InboundMessage     = (*trackedMessage)(nil)
+	_ common.Sender              = (*trackedSender)(nil)
+	_ router.Router              = (*trackedRouter)(nil)
+	_ sender.ExternalSender      = (*externalSender)(nil)
+	_ common.BootstrapableEngine = (*bootstrapper)(nil)
+)
+
+// node is a single simulated node running one snowman chain.
+type node struct {
+	sim    *Simulator
+	nodeID ids.NodeID
+
+	ctx        *snow.ConsensusContext
+	vm         *vm
+	msgCreator message.Creator
+	router     *router.ChainRouter
+	handler    handler.Handler
+	engine     smeng.Engine
+}
+
+func newNode(
+	sim *Simulator,
+	nodeID ids.NodeID,
+	vdrs validators.Manager,
+	genesisBytes []byte,
+) (*node, error) {
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.NodeID = nodeID
+	ctx.Log = sim.config.Log
+
+	msgCreator, err := message.NewCreator(
+		ctx.Log,
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		maxMessageTimeout,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts := newTimeoutManager(sim, sim.config.RequestTimeout)
+	chainRouter := &router.ChainRouter{}
+	err = chainRouter.Initialize(
+		nodeID,
+		ctx.Log,
+		timeouts,
+		closeTimeout,
+		set.Set[ids.ID]{},
+		true,
+		set.Set[ids.ID]{},
+		nil,
+		router.HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := newVM(nodeID, genesisBytes, sim.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{
+		sim:        sim,
+		nodeID:     nodeID,
+		ctx:        ctx,
+		vm:         vm,
+		msgCreator: msgCreator,
+		router:     chainRouter,
+	}
+
+	subnet := subnets.New(nodeID, subnets.Config{})
+	snowmanSender, err := sender.New(
+		ctx,
+		msgCreator,
+		&externalSender{node: n},
+		&trackedRouter{
+			Router: chainRouter,
+			sim:    sim,
+		},
+		timeouts,
+		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		subnet,
+	)
+	if err != nil {
+		return nil, err
+	}
+	snowmanSender = &trackedSender{
+		Sender: snowmanSender,
+		sim:    sim,
+		nodeID: nodeID,
+	}
+
+	getHandler, err := getter.New(n.vm, common.Config{
+		Ctx:                        ctx,
+		Sender:                     snowmanSender,
+		AncestorsMaxContainersSent: ancestorsMaxContainersSent,
+		MaxTimeGetAncestors:        maxTimeGetAncestors,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n.engine, err = smeng.New(smeng.Config{
+		AllGetsServer: getHandler,
+		Ctx:           ctx,
+		VM:            n.vm,
+		Sender:        snowmanSender,
+		Validators:    vdrs,
+		Params:        sim.config.Params,
+		Consensus:     &snowman.Topological{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := handler.New(
+		ctx,
+		vdrs,
+		nil,
+		gossipFrequency,
+		threadPoolSize,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+		subnet,
+		commontracker.NewPeers(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	h.SetEngineManager(&handler.EngineManager{
+		Snowman: &handler.Engine{
+			Bootstrapper: &bootstrapper{Engine: n.engine},
+			Consensus:    n.engine,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+
+	n.handler = &trackedHandler{
+		Handler: h,
+		sim:     sim,
+	}
+	chainRouter.AddChain(context.Background(), n.handler)
+	return n, nil
+}
+
+// connect marks all of [nodeIDs] as connected to this node.
+func (n *node) connect(nodeIDs []ids.NodeID) {
+	for _, nodeID := range nodeIDs {
+		if nodeID != n.nodeID {
+			n.router.Connected(nodeID, version.CurrentApp, constants.PrimaryNetworkID)
+		}
+	}
+}
+
+// start the consensus engine. The engine skips bootstrapping because all the
+// nodes start from the same genesis block.
+func (n *node) start() {
+	n.handler.Start(context.Background(), false)
+}
+
+func (n *node) buildBlock(ctx context.Context) error {
+	n.ctx.Lock.Lock()
+	defer n.ctx.Lock.Unlock()
+
+	return n.engine.Notify(ctx, common.PendingTxs)
+}
+
+// receive a message sent by [nodeID].
+func (n *node) receive(nodeID ids.NodeID, msgBytes []byte) {
+	msg, err := n.msgCreator.Parse(msgBytes, nodeID, nil)
+	if err != nil {
+		n.ctx.Log.Error("failed to parse simulated message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return
+	}
+	n.router.HandleInbound(context.Background(), msg)
+}
+
+func (n *node) shutdown(ctx context.Context) {
+	n.router.Shutdown(ctx)
+}
+
+// trackedHandler notifies the simulator when it starts and finishes handling a
+// message so that the simulator can wait for all the nodes to be idle.
+type trackedHandler struct {
+	handler.Handler
+	sim *Simulator
+}
+
+func (h *trackedHandler) Push(ctx context.Context, msg handler.Message) {
+	h.sim.startProcessing()
+	msg.InboundMessage = &trackedMessage{
+		InboundMessage: msg.InboundMessage,
+		sim:            h.sim,
+	}
+	h.Handler.Push(ctx, msg)
+}
+
+type trackedMessage struct {
+	message.InboundMessage
+	sim *Simulator
+}
+
+func (m *trackedMessage) OnFinishedHandling() {
+	m.InboundMessage.OnFinishedHandling()
+	m.sim.finishProcessing()
+}
+
+// trackedSender marks the queries and chits that a node sends to itself as
+// being processed. The sender hands these messages to the router in a new
+// goroutine, so they would otherwise not be tracked until they are pushed into
+// the handler.
+type trackedSender struct {
+	common.Sender
+	sim    *Simulator
+	nodeID ids.NodeID
+}
+
+func (s *trackedSender) SendPushQuery(
+	ctx context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	container []byte,
+	requestedHeight uint64,
+) {
+	if nodeIDs.Contains(s.nodeID) {
+		s.sim.startProcessing()
+	}
+	s.Sender.SendPushQuery(ctx, nodeIDs, requestID, container, requestedHeight)
+}
+
+func (s *trackedSender) SendPullQuery(
+	ctx context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	containerID ids.ID,
+	requestedHeight uint64,
+) {
+	if nodeIDs.Contains(s.nodeID) {
+		s.sim.startProcessing()
+	}
+	s.Sender.SendPullQuery(ctx, nodeIDs, requestID, containerID, requestedHeight)
+}
+
+func (s *trackedSender) SendChits(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	preferredID ids.ID,
+	preferredIDAtHeight ids.ID,
+	acceptedID ids.ID,
+) {
+	if nodeID == s.nodeID {
+		s.sim.startProcessing()
+	}
+	s.Sender.SendChits(ctx, nodeID, requestID, preferredID, preferredIDAtHeight, acceptedID)
+}
+
+// trackedRouter marks the messages tracked by [trackedSender] as no longer
+// being processed once they have been handed to the handler.
+type trackedRouter struct {
+	router.Router
+	sim *Simulator
+}
+
+func (r *trackedRouter) HandleInbound(ctx context.Context, msg message.InboundMessage) {
+	r.Router.HandleInbound(ctx, msg)
+
+	switch msg.Op() {
+	case message.PushQueryOp, message.PullQueryOp, message.ChitsOp:
+		r.sim.finishProcessing()
+	}
+}
+
+// externalSender delivers messages over the simulated links.
+type externalSender struct {
+	node *node
+}
+
+func (s *externalSender) Send(
+	msg message.OutboundMessage,
+	nodeIDs set.Set[ids.NodeID],
+	_ ids.ID,
+	_ subnets.Allower,
+) set.Set[ids.NodeID] {
+	// Sort the nodeIDs so that messages are scheduled in a consistent order.
+	sortedNodeIDs := nodeIDs.List()
+	utils.Sort(sortedNodeIDs)
+
+	var (
+		from     = s.node.nodeID
+		msgBytes = msg.Bytes()
+		sentTo   = set.NewSet[ids.NodeID](len(sortedNodeIDs))
+	)
+	for _, nodeID := range sortedNodeIDs {
+		to, ok := s.node.sim.nodes[nodeID]
+		if !ok || nodeID == from {
+			continue
+		}
+
+		s.node.sim.send(from, nodeID, func() {
+			to.receive(from, msgBytes)
+		})
+		sentTo.Add(nodeID)
+	}
+	return sentTo
+}
+
+func (s *externalSender) Gossip(
+	msg message.OutboundMessage,
+	subnetID ids.ID,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+	allower subnets.Allower,
+) set.Set[ids.NodeID] {
+	// Every simulated node is a validator.
+	nodeIDs := s.node.sim.sample(
+		s.node.nodeID,
+		numValidatorsToSend+numNonValidatorsToSend+numPeersToSend,
+	)
+	return s.Send(msg, nodeIDs, subnetID, allower)
+}
+
+// bootstrapper allows the consensus engine to be used as the starting gear of
+// the handler.
+type bootstrapper struct {
+	smeng.Engine
+}
+
+func (*bootstrapper) ForceAccepted(context.Context, []ids.ID) error {
+	return nil
+}
+
+func (*bootstrapper) Clear(context.Context) error {
+	return nil
+}
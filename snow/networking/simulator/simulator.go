@@ -0,0 +1,484 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs multiple nodes' chain routers, handlers and snowman
+// engines in a single process over simulated links.
+//
+// Messages are serialized by each node's message creator and handed directly
+// to the receiving node's chain router. The network layer (network.Network and
+// peer.Peer) is not part of the simulation, so handshakes, message throttling,
+// peer gossip and disconnects are not exercised. A partition only drops the
+// messages sent across it; the nodes on either side remain connected.
+//
+// Time is simulated: messages and request timeouts are scheduled on a virtual
+// clock and are processed one at a time, waiting for all the nodes to finish
+// handling the previous event before the next event is processed. Link
+// latency, message loss and engine sampling are driven by the configured
+// seed, which makes runs reproducible.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	errNoNodes       = errors.New("at least one node is required")
+	errInvalidLoss   = errors.New("loss rate must be in [0, 1]")
+	errUnknownNode   = errors.New("unknown node")
+	errClosed        = errors.New("simulator closed")
+	errDuplicateNode = errors.New("node is in multiple partitions")
+
+	// start is the virtual time that every simulation starts at.
+	start = time.Unix(1_600_000_000, 0)
+)
+
+// LinkConfig describes the behavior of a directed link between two nodes.
+type LinkConfig struct {
+	// Latency is the minimum amount of time it takes for a message to be
+	// delivered over the link.
+	Latency time.Duration `json:"latency"`
+	// Jitter is the maximum amount of additional, uniformly random, time it
+	// takes for a message to be delivered over the link.
+	Jitter time.Duration `json:"jitter"`
+	// LossRate is the probability that a message sent over the link is
+	// dropped. Must be in [0, 1].
+	LossRate float64 `json:"lossRate"`
+}
+
+func (c LinkConfig) verify() error {
+	if c.LossRate < 0 || c.LossRate > 1 {
+		return fmt.Errorf("%w: %f", errInvalidLoss, c.LossRate)
+	}
+	return nil
+}
+
+type Config struct {
+	// NumNodes is the number of equally weighted validators to simulate.
+	NumNodes int `json:"numNodes"`
+	// Params are the consensus parameters used by every node.
+	Params snowball.Parameters `json:"params"`
+	// Link is the behavior of every link that isn't explicitly overridden.
+	Link LinkConfig `json:"link"`
+	// RequestTimeout is the amount of time a node waits for a response before
+	// marking the request as failed.
+	RequestTimeout time.Duration `json:"requestTimeout"`
+	// Seed initializes the randomness of the links and of the consensus
+	// sampling.
+	Seed int64 `json:"seed"`
+	// Log is used by every node. Defaults to not logging.
+	Log logging.Logger `json:"-"`
+}
+
+type link struct {
+	from, to ids.NodeID
+}
+
+// Simulator runs a set of nodes over simulated links.
+//
+// Simulator isn't safe for concurrent use.
+type Simulator struct {
+	config Config
+	rng    *rand.Rand
+
+	// nodeIDs is the ordered list of simulated nodes.
+	nodeIDs []ids.NodeID
+	nodes   map[ids.NodeID]*node
+
+	// lock protects all the fields below.
+	lock sync.Mutex
+	// processing is signalled whenever [numProcessing] drops to 0.
+	processing    *sync.Cond
+	numProcessing int
+
+	now     time.Time
+	events  heap.Queue[*event]
+	nextSeq uint64
+	closed  bool
+
+	links map[link]LinkConfig
+	// groups maps a node to the partition it is in. Nodes are only able to
+	// communicate with nodes in the same partition.
+	groups map[ids.NodeID]int
+
+	numDelivered int
+	numDropped   int
+}
+
+// New creates [config.NumNodes] nodes that are all connected to each other
+// and that have accepted the same genesis block.
+func New(config Config) (*Simulator, error) {
+	if config.NumNodes <= 0 {
+		return nil, errNoNodes
+	}
+	if err := config.Params.Verify(); err != nil {
+		return nil, err
+	}
+	if err := config.Link.verify(); err != nil {
+		return nil, err
+	}
+	if config.Log == nil {
+		config.Log = logging.NoLog{}
+	}
+
+	s := &Simulator{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)), // #nosec G404
+		nodes:  make(map[ids.NodeID]*node, config.NumNodes),
+		now:    start,
+		events: heap.NewQueue[*event](func(a, b *event) bool {
+			return a.Less(b)
+		}),
+		links:  make(map[link]LinkConfig),
+		groups: make(map[ids.NodeID]int),
+	}
+	s.processing = sync.NewCond(&s.lock)
+
+	s.nodeIDs = make([]ids.NodeID, config.NumNodes)
+	for i := range s.nodeIDs {
+		s.nodeIDs[i] = ids.NodeID(hashing.ComputeHash160Array([]byte{byte(i >> 8), byte(i)}))
+	}
+
+	vdrs := &validatorManager{
+		Manager: validators.NewManager(),
+		sim:     s,
+	}
+	for _, nodeID := range s.nodeIDs {
+		if err := vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	genesisBytes := newGenesis(start)
+	for _, nodeID := range s.nodeIDs {
+		n, err := newNode(s, nodeID, vdrs, genesisBytes)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("failed to create node %s: %w", nodeID, err)
+		}
+		s.nodes[nodeID] = n
+	}
+	for _, nodeID := range s.nodeIDs {
+		s.nodes[nodeID].connect(s.nodeIDs)
+	}
+	for _, nodeID := range s.nodeIDs {
+		s.nodes[nodeID].start()
+	}
+	s.waitIdle()
+	return s, nil
+}
+
+// NodeIDs returns the IDs of the simulated nodes.
+func (s *Simulator) NodeIDs() []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, len(s.nodeIDs))
+	copy(nodeIDs, s.nodeIDs)
+	return nodeIDs
+}
+
+// Now returns the current virtual time.
+func (s *Simulator) Now() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.now
+}
+
+// SetLink overrides the behavior of messages sent from [from] to [to].
+func (s *Simulator) SetLink(from, to ids.NodeID, config LinkConfig) error {
+	if err := config.verify(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.links[link{from: from, to: to}] = config
+	return nil
+}
+
+// Partition splits the nodes into [partitions]. Nodes that aren't in any of
+// the provided partitions form an additional partition. Messages that are in
+// flight across partitions are dropped.
+func (s *Simulator) Partition(partitions ...[]ids.NodeID) error {
+	groups := make(map[ids.NodeID]int)
+	for i, partition := range partitions {
+		for _, nodeID := range partition {
+			if _, ok := s.nodes[nodeID]; !ok {
+				return fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+			}
+			if _, ok := groups[nodeID]; ok {
+				return fmt.Errorf("%w: %s", errDuplicateNode, nodeID)
+			}
+			groups[nodeID] = i + 1
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.groups = groups
+	return nil
+}
+
+// Heal removes all partitions.
+func (s *Simulator) Heal() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.groups = make(map[ids.NodeID]int)
+}
+
+// BuildBlock notifies [nodeID]'s engine that its VM has a block ready to be
+// built. The block is built at the current virtual time.
+func (s *Simulator) BuildBlock(nodeID ids.NodeID) error {
+	n, ok := s.nodes[nodeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+	}
+
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return errClosed
+	}
+	s.lock.Unlock()
+
+	err := n.buildBlock(context.Background())
+	s.waitIdle()
+	return err
+}
+
+// LastAccepted returns the ID and height of the last block accepted by
+// [nodeID].
+func (s *Simulator) LastAccepted(nodeID ids.NodeID) (ids.ID, uint64, error) {
+	n, ok := s.nodes[nodeID]
+	if !ok {
+		return ids.Empty, 0, fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+	}
+	blkID, height := n.vm.lastAccepted()
+	return blkID, height, nil
+}
+
+// NumDelivered returns the number of messages that have been delivered.
+func (s *Simulator) NumDelivered() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.numDelivered
+}
+
+// NumDropped returns the number of messages that have been dropped due to
+// message loss or partitions.
+func (s *Simulator) NumDropped() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.numDropped
+}
+
+// Run processes all the events scheduled in the next [duration] of virtual
+// time.
+func (s *Simulator) Run(duration time.Duration) {
+	s.RunUntil(func() bool { return false }, duration)
+}
+
+// RunUntil processes events until [done] returns true or [maxDuration] of
+// virtual time has passed. [done] is checked after every event.
+//
+// Returns true if [done] returned true.
+func (s *Simulator) RunUntil(done func() bool, maxDuration time.Duration) bool {
+	s.lock.Lock()
+	end := s.now.Add(maxDuration)
+	s.lock.Unlock()
+
+	for {
+		if done() {
+			return true
+		}
+
+		s.lock.Lock()
+		e, ok := s.events.Peek()
+		if s.closed || !ok || e.time.After(end) {
+			if s.now.Before(end) {
+				s.now = end
+			}
+			s.lock.Unlock()
+			return done()
+		}
+		_, _ = s.events.Pop()
+		s.now = e.time
+		cancelled := e.cancelled
+		s.lock.Unlock()
+
+		if !cancelled {
+			e.fire()
+			s.waitIdle()
+		}
+	}
+}
+
+// Close stops all the nodes. Events that haven't been processed yet are
+// dropped.
+func (s *Simulator) Close() {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	s.closed = true
+	s.lock.Unlock()
+
+	for _, n := range s.nodes {
+		n.shutdown(context.Background())
+	}
+}
+
+// schedule [fire] to be executed after [delay] of virtual time. Ties are
+// broken by [nodeID] and then by the order the events were scheduled in.
+//
+// Assumes [s.lock] is held.
+func (s *Simulator) schedule(delay time.Duration, nodeID ids.NodeID, fire func()) *event {
+	e := &event{
+		time:   s.now.Add(delay),
+		nodeID: nodeID,
+		seq:    s.nextSeq,
+		fire:   fire,
+	}
+	s.nextSeq++
+	s.events.Push(e)
+	return e
+}
+
+// send schedules the delivery of a message from [from] to [to]. If the message
+// is lost, [deliver] is never called.
+func (s *Simulator) send(from, to ids.NodeID, deliver func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed || !s.canReach(from, to) {
+		s.numDropped++
+		return
+	}
+
+	config, ok := s.links[link{from: from, to: to}]
+	if !ok {
+		config = s.config.Link
+	}
+	if s.rng.Float64() < config.LossRate {
+		s.numDropped++
+		return
+	}
+
+	delay := config.Latency
+	if config.Jitter > 0 {
+		delay += time.Duration(s.rng.Int63n(int64(config.Jitter)))
+	}
+	s.schedule(delay, to, func() {
+		s.lock.Lock()
+		if !s.canReach(from, to) {
+			s.numDropped++
+			s.lock.Unlock()
+			return
+		}
+		s.numDelivered++
+		s.lock.Unlock()
+
+		deliver()
+	})
+}
+
+// seed returns a new seed derived from [config.Seed].
+func (s *Simulator) seed() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.rng.Int63()
+}
+
+// sample returns up to [size] nodes other than [exclude].
+func (s *Simulator) sample(exclude ids.NodeID, size int) set.Set[ids.NodeID] {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nodeIDs := set.NewSet[ids.NodeID](size)
+	for _, i := range s.rng.Perm(len(s.nodeIDs)) {
+		if nodeIDs.Len() >= size {
+			break
+		}
+		if nodeID := s.nodeIDs[i]; nodeID != exclude {
+			nodeIDs.Add(nodeID)
+		}
+	}
+	return nodeIDs
+}
+
+// canReach assumes [s.lock] is held.
+func (s *Simulator) canReach(from, to ids.NodeID) bool {
+	return s.groups[from] == s.groups[to]
+}
+
+// startProcessing marks that a node has started handling a message.
+func (s *Simulator) startProcessing() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.numProcessing++
+}
+
+// finishProcessing marks that a node has finished handling a message.
+func (s *Simulator) finishProcessing() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.numProcessing--
+	if s.numProcessing == 0 {
+		s.processing.Broadcast()
+	}
+}
+
+// waitIdle blocks until no node is handling a message.
+func (s *Simulator) waitIdle() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for s.numProcessing > 0 {
+		s.processing.Wait()
+	}
+}
+
+type event struct {
+	time      time.Time
+	nodeID    ids.NodeID
+	seq       uint64
+	cancelled bool
+	fire      func()
+}
+
+func (e *event) Less(other *event) bool {
+	switch {
+	case e.time.Before(other.time):
+		return true
+	case other.time.Before(e.time):
+		return false
+	}
+	switch {
+	case e.nodeID.Less(other.nodeID):
+		return true
+	case other.nodeID.Less(e.nodeID):
+		return false
+	}
+	return e.seq < other.seq
+}
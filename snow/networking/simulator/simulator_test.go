@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+var testParams = snowball.Parameters{
+	K:                     5,
+	AlphaPreference:       4,
+	AlphaConfidence:       4,
+	BetaVirtuous:          3,
+	BetaRogue:             5,
+	ConcurrentRepolls:     2,
+	OptimalProcessing:     10,
+	MaxOutstandingItems:   256,
+	MaxItemProcessingTime: 30 * time.Second,
+}
+
+func newTestSimulator(t *testing.T, link LinkConfig) *Simulator {
+	s, err := New(Config{
+		NumNodes:       5,
+		Params:         testParams,
+		Link:           link,
+		RequestTimeout: time.Second,
+		Seed:           1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// allAccepted returns a function that reports whether every node has accepted
+// a block at [height].
+func allAccepted(t *testing.T, s *Simulator, height uint64) func() bool {
+	return func() bool {
+		for _, nodeID := range s.NodeIDs() {
+			_, lastHeight, err := s.LastAccepted(nodeID)
+			require.NoError(t, err)
+			if lastHeight < height {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "no nodes",
+			config: Config{
+				Params: testParams,
+			},
+			expectedErr: errNoNodes,
+		},
+		{
+			name: "invalid params",
+			config: Config{
+				NumNodes: 1,
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+		{
+			name: "invalid loss rate",
+			config: Config{
+				NumNodes: 1,
+				Params:   testParams,
+				Link: LinkConfig{
+					LossRate: 2,
+				},
+			},
+			expectedErr: errInvalidLoss,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(test.config)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSimulatorAcceptsBlock(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, LinkConfig{
+		Latency: 50 * time.Millisecond,
+		Jitter:  50 * time.Millisecond,
+	})
+	nodeIDs := s.NodeIDs()
+
+	require.NoError(s.BuildBlock(nodeIDs[0]))
+	require.True(s.RunUntil(allAccepted(t, s, 1), time.Minute))
+
+	blkID, _, err := s.LastAccepted(nodeIDs[0])
+	require.NoError(err)
+	for _, nodeID := range nodeIDs[1:] {
+		otherBlkID, _, err := s.LastAccepted(nodeID)
+		require.NoError(err)
+		require.Equal(blkID, otherBlkID)
+	}
+	require.Positive(s.NumDelivered())
+	require.Zero(s.NumDropped())
+}
+
+func TestSimulatorPartitionLiveness(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, LinkConfig{
+		Latency: 50 * time.Millisecond,
+	})
+	nodeIDs := s.NodeIDs()
+
+	// Neither side of the partition has enough nodes to reach alpha.
+	require.NoError(s.Partition(nodeIDs[:3], nodeIDs[3:]))
+	require.NoError(s.BuildBlock(nodeIDs[0]))
+	s.Run(time.Minute)
+
+	for _, nodeID := range nodeIDs {
+		_, height, err := s.LastAccepted(nodeID)
+		require.NoError(err)
+		require.Zero(height)
+	}
+	require.Positive(s.NumDropped())
+
+	// Once the partition heals, the processing block should be accepted.
+	s.Heal()
+	require.True(s.RunUntil(allAccepted(t, s, 1), time.Minute))
+}
+
+func TestSimulatorLossyLinks(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, LinkConfig{
+		Latency:  20 * time.Millisecond,
+		Jitter:   80 * time.Millisecond,
+		LossRate: .1,
+	})
+	nodeIDs := s.NodeIDs()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(s.BuildBlock(nodeIDs[i]))
+		require.True(s.RunUntil(allAccepted(t, s, uint64(i+1)), time.Minute))
+	}
+}
+
+func TestSimulatorIsolatedNode(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, LinkConfig{
+		Latency: 10 * time.Millisecond,
+	})
+	nodeIDs := s.NodeIDs()
+
+	// Make all messages sent to the last node be dropped.
+	isolatedNodeID := nodeIDs[len(nodeIDs)-1]
+	for _, nodeID := range nodeIDs[:len(nodeIDs)-1] {
+		require.NoError(s.SetLink(nodeID, isolatedNodeID, LinkConfig{
+			LossRate: 1,
+		}))
+	}
+
+	require.NoError(s.BuildBlock(nodeIDs[0]))
+	require.True(s.RunUntil(
+		func() bool {
+			for _, nodeID := range nodeIDs[:len(nodeIDs)-1] {
+				_, height, err := s.LastAccepted(nodeID)
+				require.NoError(err)
+				if height < 1 {
+					return false
+				}
+			}
+			return true
+		},
+		time.Minute,
+	))
+
+	_, height, err := s.LastAccepted(isolatedNodeID)
+	require.NoError(err)
+	require.Zero(height)
+}
+
+func TestPartitionErrors(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, LinkConfig{})
+	nodeIDs := s.NodeIDs()
+
+	err := s.Partition([]ids.NodeID{ids.GenerateTestNodeID()})
+	require.ErrorIs(err, errUnknownNode)
+
+	err = s.Partition(nodeIDs[:2], nodeIDs[1:])
+	require.ErrorIs(err, errDuplicateNode)
+}
+
+func TestSimulatorDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	type result struct {
+		now          time.Time
+		numDelivered int
+		numDropped   int
+		lastAccepted []ids.ID
+	}
+	run := func() result {
+		s := newTestSimulator(t, LinkConfig{
+			Latency:  20 * time.Millisecond,
+			Jitter:   80 * time.Millisecond,
+			LossRate: .1,
+		})
+		defer s.Close()
+
+		nodeIDs := s.NodeIDs()
+		for i, nodeID := range nodeIDs {
+			require.NoError(s.BuildBlock(nodeID))
+			require.True(s.RunUntil(allAccepted(t, s, uint64(i+1)), time.Minute))
+		}
+
+		r := result{
+			now:          s.Now(),
+			numDelivered: s.NumDelivered(),
+			numDropped:   s.NumDropped(),
+			lastAccepted: make([]ids.ID, len(nodeIDs)),
+		}
+		for i, nodeID := range nodeIDs {
+			blkID, _, err := s.LastAccepted(nodeID)
+			require.NoError(err)
+			r.lastAccepted[i] = blkID
+		}
+		return r
+	}
+
+	require.Equal(run(), run())
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+)
+
+var _ timeout.Manager = (*timeoutManager)(nil)
+
+// timeoutManager schedules request timeouts on the simulator's virtual clock.
+// Nodes are never benched.
+type timeoutManager struct {
+	sim     *Simulator
+	timeout time.Duration
+
+	// requests is protected by the simulator's lock.
+	requests map[ids.RequestID]*event
+}
+
+func newTimeoutManager(sim *Simulator, timeout time.Duration) *timeoutManager {
+	return &timeoutManager{
+		sim:      sim,
+		timeout:  timeout,
+		requests: make(map[ids.RequestID]*event),
+	}
+}
+
+func (*timeoutManager) Dispatch() {}
+
+func (m *timeoutManager) TimeoutDuration() time.Duration {
+	return m.timeout
+}
+
+func (*timeoutManager) IsBenched(ids.NodeID, ids.ID) bool {
+	return false
+}
+
+func (*timeoutManager) RegisterChain(*snow.ConsensusContext) error {
+	return nil
+}
+
+func (m *timeoutManager) RegisterRequest(
+	nodeID ids.NodeID,
+	_ ids.ID,
+	_ bool,
+	requestID ids.RequestID,
+	timeoutHandler func(),
+) {
+	m.sim.lock.Lock()
+	defer m.sim.lock.Unlock()
+
+	m.requests[requestID] = m.sim.schedule(m.timeout, nodeID, func() {
+		m.sim.lock.Lock()
+		delete(m.requests, requestID)
+		m.sim.lock.Unlock()
+
+		timeoutHandler()
+	})
+}
+
+func (*timeoutManager) RegisterRequestToUnreachableValidator() {}
+
+func (m *timeoutManager) RegisterResponse(
+	_ ids.NodeID,
+	_ ids.ID,
+	requestID ids.RequestID,
+	_ message.Op,
+	_ time.Duration,
+) {
+	m.RemoveRequest(requestID)
+}
+
+func (m *timeoutManager) RemoveRequest(requestID ids.RequestID) {
+	m.sim.lock.Lock()
+	defer m.sim.lock.Unlock()
+
+	if e, ok := m.requests[requestID]; ok {
+		e.cancelled = true
+		delete(m.requests, requestID)
+	}
+}
+
+// QueryLatency is not tracked by the simulator, so adaptive polling always
+// falls back to the static consensus parameters.
+func (*timeoutManager) QueryLatency(ids.ID, float64) (time.Duration, bool) {
+	return 0, false
+}
+
+func (*timeoutManager) Stop() {}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+var _ validators.Manager = (*validatorManager)(nil)
+
+// validatorManager samples validators using the randomness of the simulator,
+// rather than the process-wide source used by [validators.Manager], so that
+// simulations are reproducible from their seed.
+type validatorManager struct {
+	validators.Manager
+	sim *Simulator
+}
+
+func (m *validatorManager) Sample(subnetID ids.ID, size int) ([]ids.NodeID, error) {
+	vdrs := m.Manager.GetMap(subnetID)
+	nodeIDs := make([]ids.NodeID, 0, len(vdrs))
+	for nodeID := range vdrs {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	// Map iteration is random, so the validators must be sorted for the
+	// sample to only depend on the seed.
+	utils.Sort(nodeIDs)
+
+	weights := make([]uint64, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		weights[i] = vdrs[nodeID].Weight
+	}
+
+	s := sampler.NewDeterministicWeightedWithoutReplacement()
+	if err := s.Initialize(weights); err != nil {
+		return nil, err
+	}
+	s.Seed(m.sim.seed())
+	indices, err := s.Sample(size)
+	if err != nil {
+		return nil, err
+	}
+
+	sampled := make([]ids.NodeID, size)
+	for i, index := range indices {
+		sampled[i] = nodeIDs[index]
+	}
+	return sampled, nil
+}
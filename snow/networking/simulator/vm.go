@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// blockLen is the length of a serialized block:
+// parentID + height + timestamp + builder + nonce
+const blockLen = ids.IDLen + wrappers.LongLen + wrappers.LongLen + ids.NodeIDLen + wrappers.LongLen
+
+var (
+	_ block.ChainVM = (*vm)(nil)
+	_ snowman.Block = (*simBlock)(nil)
+
+	errInvalidBlockLen = errors.New("invalid block length")
+	errUnknownBlock    = errors.New("unknown block")
+)
+
+// vm is an in-memory chain of blocks that are always valid.
+//
+// Other than [lastAccepted], vm is only accessed by the consensus engine,
+// which holds the context lock.
+type vm struct {
+	block.TestVM
+
+	nodeID   ids.NodeID
+	now      func() time.Time
+	numBuilt uint64
+
+	blocks      map[ids.ID]*simBlock
+	preferredID ids.ID
+
+	lock           sync.RWMutex
+	lastAcceptedID ids.ID
+	lastHeight     uint64
+}
+
+// newGenesis returns the serialized genesis block that is shared by all the
+// nodes.
+func newGenesis(timestamp time.Time) []byte {
+	return marshalBlock(ids.Empty, 0, timestamp, ids.EmptyNodeID, 0)
+}
+
+func newVM(nodeID ids.NodeID, genesisBytes []byte, now func() time.Time) (*vm, error) {
+	v := &vm{
+		nodeID: nodeID,
+		now:    now,
+		blocks: make(map[ids.ID]*simBlock),
+	}
+	v.TestVM.Default(false)
+
+	genesis, err := v.ParseBlock(context.Background(), genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+	genesis.(*simBlock).StatusV = choices.Accepted
+	v.preferredID = genesis.ID()
+	v.lastAcceptedID = genesis.ID()
+	return v, nil
+}
+
+func (v *vm) BuildBlock(ctx context.Context) (snowman.Block, error) {
+	parent, ok := v.blocks[v.preferredID]
+	if !ok {
+		return nil, errUnknownBlock
+	}
+
+	v.numBuilt++
+	blkBytes := marshalBlock(
+		parent.ID(),
+		parent.Height()+1,
+		v.now(),
+		v.nodeID,
+		v.numBuilt,
+	)
+	return v.ParseBlock(ctx, blkBytes)
+}
+
+func (v *vm) ParseBlock(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+	if len(blkBytes) != blockLen {
+		return nil, errInvalidBlockLen
+	}
+
+	blkID := hashing.ComputeHash256Array(blkBytes)
+	if blk, ok := v.blocks[blkID]; ok {
+		return blk, nil
+	}
+
+	p := wrappers.Packer{Bytes: blkBytes}
+	parentID, err := ids.ToID(p.UnpackFixedBytes(ids.IDLen))
+	if err != nil {
+		return nil, err
+	}
+	height := p.UnpackLong()
+	timestamp := time.Unix(0, int64(p.UnpackLong()))
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	blk := &simBlock{
+		TestBlock: snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     blkID,
+				StatusV: choices.Processing,
+			},
+			ParentV:    parentID,
+			HeightV:    height,
+			TimestampV: timestamp,
+			BytesV:     blkBytes,
+		},
+		vm: v,
+	}
+	v.blocks[blkID] = blk
+	return blk, nil
+}
+
+func (v *vm) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+	blk, ok := v.blocks[blkID]
+	if !ok {
+		return nil, errUnknownBlock
+	}
+	return blk, nil
+}
+
+func (v *vm) SetPreference(_ context.Context, blkID ids.ID) error {
+	v.preferredID = blkID
+	return nil
+}
+
+func (v *vm) LastAccepted(context.Context) (ids.ID, error) {
+	blkID, _ := v.lastAccepted()
+	return blkID, nil
+}
+
+// lastAccepted returns the ID and height of the last accepted block.
+func (v *vm) lastAccepted() (ids.ID, uint64) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return v.lastAcceptedID, v.lastHeight
+}
+
+func (v *vm) accept(blk *simBlock) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.lastAcceptedID = blk.ID()
+	v.lastHeight = blk.Height()
+}
+
+// simBlock is a block that is always valid.
+type simBlock struct {
+	snowman.TestBlock
+
+	vm *vm
+}
+
+func (b *simBlock) Accept(ctx context.Context) error {
+	if err := b.TestBlock.Accept(ctx); err != nil {
+		return err
+	}
+	b.vm.accept(b)
+	return nil
+}
+
+func marshalBlock(
+	parentID ids.ID,
+	height uint64,
+	timestamp time.Time,
+	builder ids.NodeID,
+	nonce uint64,
+) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, blockLen)}
+	p.PackFixedBytes(parentID[:])
+	p.PackLong(height)
+	p.PackLong(uint64(timestamp.UnixNano()))
+	p.PackFixedBytes(builder[:])
+	p.PackLong(nonce)
+	return p.Bytes
+}
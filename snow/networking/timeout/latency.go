@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// latencyWindowSize is the number of most recent query latencies that are used
+// to calculate latency percentiles.
+const latencyWindowSize = 256
+
+// latencyWindow tracks the most recently observed latencies.
+type latencyWindow struct {
+	lock    sync.Mutex
+	samples []time.Duration
+	// next is the index in [samples] that the next latency will be written to
+	// once [samples] is full.
+	next int
+}
+
+func (w *latencyWindow) Observe(latency time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, latency)
+		return
+	}
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % latencyWindowSize
+}
+
+// Percentile returns the [percentile] latency of the window, where
+// [percentile] is in [0, 1]. Returns false if no latencies have been observed.
+func (w *latencyWindow) Percentile(percentile float64) (time.Duration, bool) {
+	w.lock.Lock()
+	samples := slices.Clone(w.samples)
+	w.lock.Unlock()
+
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	slices.Sort(samples)
+	switch {
+	case percentile <= 0:
+		return samples[0], true
+	case percentile >= 1:
+		return samples[len(samples)-1], true
+	}
+	// Use the nearest-rank method.
+	index := int(math.Ceil(percentile*float64(len(samples)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return samples[index], true
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyWindowPercentile(t *testing.T) {
+	require := require.New(t)
+
+	w := &latencyWindow{}
+	_, ok := w.Percentile(.5)
+	require.False(ok)
+
+	for i := 1; i <= 100; i++ {
+		w.Observe(time.Duration(i) * time.Millisecond)
+	}
+
+	tests := []struct {
+		percentile float64
+		expected   time.Duration
+	}{
+		{
+			percentile: 0,
+			expected:   time.Millisecond,
+		},
+		{
+			percentile: .5,
+			expected:   50 * time.Millisecond,
+		},
+		{
+			percentile: .99,
+			expected:   99 * time.Millisecond,
+		},
+		{
+			percentile: 1,
+			expected:   100 * time.Millisecond,
+		},
+	}
+	for _, test := range tests {
+		latency, ok := w.Percentile(test.percentile)
+		require.True(ok)
+		require.Equal(test.expected, latency)
+	}
+}
+
+func TestLatencyWindowEvictsOldest(t *testing.T) {
+	require := require.New(t)
+
+	w := &latencyWindow{}
+	w.Observe(time.Hour)
+	for i := 0; i < latencyWindowSize; i++ {
+		w.Observe(time.Millisecond)
+	}
+
+	latency, ok := w.Percentile(1)
+	require.True(ok)
+	require.Equal(time.Millisecond, latency)
+}
@@ -63,6 +63,10 @@ type Manager interface {
 	// Mark that we no longer expect a response to this request we sent.
 	// Does not modify the timeout.
 	RemoveRequest(requestID ids.RequestID)
+	// QueryLatency returns the [percentile] latency, in [0, 1], of the most
+	// recent query responses received for [chainID]. Returns false if no
+	// query responses have been received for [chainID].
+	QueryLatency(chainID ids.ID, percentile float64) (time.Duration, bool)
 
 	// Stops the manager.
 	Stop()
@@ -83,8 +87,9 @@ func NewManager(
 		return nil, fmt.Errorf("couldn't create timeout manager: %w", err)
 	}
 	return &manager{
-		benchlistMgr: benchlistMgr,
-		tm:           tm,
+		benchlistMgr:   benchlistMgr,
+		tm:             tm,
+		queryLatencies: make(map[ids.ID]*latencyWindow),
 	}, nil
 }
 
@@ -93,6 +98,10 @@ type manager struct {
 	benchlistMgr benchlist.Manager
 	metrics      metrics
 	stopOnce     sync.Once
+
+	queryLatenciesLock sync.RWMutex
+	// chainID -> the most recent query latencies of the chain
+	queryLatencies map[ids.ID]*latencyWindow
 }
 
 func (m *manager) Dispatch() {
@@ -116,6 +125,10 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	if err := m.benchlistMgr.RegisterChain(ctx); err != nil {
 		return fmt.Errorf("couldn't register chain %s with benchlist manager: %w", ctx.ChainID, err)
 	}
+
+	m.queryLatenciesLock.Lock()
+	m.queryLatencies[ctx.ChainID] = &latencyWindow{}
+	m.queryLatenciesLock.Unlock()
 	return nil
 }
 
@@ -152,12 +165,32 @@ func (m *manager) RegisterResponse(
 	m.metrics.Observe(nodeID, chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
 	m.tm.Remove(requestID)
+
+	if op != message.ChitsOp {
+		return
+	}
+	m.queryLatenciesLock.RLock()
+	window, ok := m.queryLatencies[chainID]
+	m.queryLatenciesLock.RUnlock()
+	if ok {
+		window.Observe(latency)
+	}
 }
 
 func (m *manager) RemoveRequest(requestID ids.RequestID) {
 	m.tm.Remove(requestID)
 }
 
+func (m *manager) QueryLatency(chainID ids.ID, percentile float64) (time.Duration, bool) {
+	m.queryLatenciesLock.RLock()
+	window, ok := m.queryLatencies[chainID]
+	m.queryLatenciesLock.RUnlock()
+	if !ok {
+		return 0, false
+	}
+	return window.Percentile(percentile)
+}
+
 func (m *manager) RegisterRequestToUnreachableValidator() {
 	m.tm.ObserveLatency(m.TimeoutDuration())
 }
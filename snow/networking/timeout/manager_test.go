@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -48,3 +50,39 @@ func TestManagerFire(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestManagerQueryLatency(t *testing.T) {
+	require := require.New(t)
+
+	manager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutCoefficient: 1.25,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(manager.RegisterChain(ctx))
+
+	_, ok := manager.QueryLatency(ctx.ChainID, .5)
+	require.False(ok)
+
+	nodeID := ids.GenerateTestNodeID()
+	manager.RegisterResponse(nodeID, ctx.ChainID, ids.RequestID{}, message.ChitsOp, time.Millisecond)
+	// Only query responses are included in the query latency.
+	manager.RegisterResponse(nodeID, ctx.ChainID, ids.RequestID{}, message.AncestorsOp, time.Second)
+
+	latency, ok := manager.QueryLatency(ctx.ChainID, 1)
+	require.True(ok)
+	require.Equal(time.Millisecond, latency)
+
+	_, ok = manager.QueryLatency(ids.GenerateTestID(), 1)
+	require.False(ok)
+}
@@ -66,6 +66,21 @@ func (mr *MockManagerMockRecorder) IsBenched(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBenched", reflect.TypeOf((*MockManager)(nil).IsBenched), arg0, arg1)
 }
 
+// QueryLatency mocks base method.
+func (m *MockManager) QueryLatency(arg0 ids.ID, arg1 float64) (time.Duration, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLatency", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// QueryLatency indicates an expected call of QueryLatency.
+func (mr *MockManagerMockRecorder) QueryLatency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLatency", reflect.TypeOf((*MockManager)(nil).QueryLatency), arg0, arg1)
+}
+
 // RegisterChain mocks base method.
 func (m *MockManager) RegisterChain(arg0 *snow.ConsensusContext) error {
 	m.ctrl.T.Helper()
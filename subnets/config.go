@@ -39,6 +39,11 @@ type Config struct {
 	// ValidatorOnly is enabled.
 	AllowedNodes        set.Set[ids.NodeID] `json:"allowedNodes"        yaml:"allowedNodes"`
 	ConsensusParameters snowball.Parameters `json:"consensusParameters" yaml:"consensusParameters"`
+	// WeightedPolls indicates that this Subnet's snowman chains should weight
+	// the response of each sampled validator by its stake, adjusted for the
+	// likelihood of the validator being sampled, rather than by the number of
+	// times the validator was sampled.
+	WeightedPolls bool `json:"weightedPolls" yaml:"weightedPolls"`
 
 	// ProposerMinBlockDelay is the minimum delay this node will enforce when
 	// building a snowman++ block.
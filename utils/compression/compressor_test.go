@@ -24,8 +24,10 @@ var (
 		TypeNone: func(int64) (Compressor, error) { //nolint:unparam // an error is needed to be returned to compile
 			return NewNoCompressor(), nil
 		},
-		TypeGzip: NewGzipCompressor,
-		TypeZstd: NewZstdCompressor,
+		TypeGzip:   NewGzipCompressor,
+		TypeZstd:   NewZstdCompressor,
+		TypeLZ4:    NewLZ4Compressor,
+		TypeSnappy: NewSnappyCompressor,
 	}
 
 	//go:embed gzip_zip_bomb.bin
@@ -34,9 +36,17 @@ var (
 	//go:embed zstd_zip_bomb.bin
 	zstdZipBomb []byte
 
+	//go:embed lz4_zip_bomb.bin
+	lz4ZipBomb []byte
+
+	//go:embed snappy_zip_bomb.bin
+	snappyZipBomb []byte
+
 	zipBombs = map[Type][]byte{
-		TypeGzip: gzipZipBomb,
-		TypeZstd: zstdZipBomb,
+		TypeGzip:   gzipZipBomb,
+		TypeZstd:   zstdZipBomb,
+		TypeLZ4:    lz4ZipBomb,
+		TypeSnappy: snappyZipBomb,
 	}
 )
 
@@ -162,6 +172,14 @@ func FuzzZstdCompressor(f *testing.F) {
 	fuzzHelper(f, TypeZstd)
 }
 
+func FuzzLZ4Compressor(f *testing.F) {
+	fuzzHelper(f, TypeLZ4)
+}
+
+func FuzzSnappyCompressor(f *testing.F) {
+	fuzzHelper(f, TypeSnappy)
+}
+
 func fuzzHelper(f *testing.F, compressionType Type) {
 	var (
 		compressor Compressor
@@ -174,6 +192,12 @@ func fuzzHelper(f *testing.F, compressionType Type) {
 	case TypeZstd:
 		compressor, err = NewZstdCompressor(maxMessageSize)
 		require.NoError(f, err)
+	case TypeLZ4:
+		compressor, err = NewLZ4Compressor(maxMessageSize)
+		require.NoError(f, err)
+	case TypeSnappy:
+		compressor, err = NewSnappyCompressor(maxMessageSize)
+		require.NoError(f, err)
 	default:
 		require.FailNow(f, "Unknown compression type")
 	}
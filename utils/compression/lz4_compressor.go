@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+var _ Compressor = (*lz4Compressor)(nil)
+
+type lz4Compressor struct {
+	maxSize       int64
+	lz4WriterPool sync.Pool
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (l *lz4Compressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > l.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), l.maxSize)
+	}
+
+	var writeBuffer bytes.Buffer
+	lz4Writer := l.lz4WriterPool.Get().(*lz4.Writer)
+	lz4Writer.Reset(&writeBuffer)
+	defer l.lz4WriterPool.Put(lz4Writer)
+
+	if _, err := lz4Writer.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := lz4Writer.Close(); err != nil {
+		return nil, err
+	}
+	return writeBuffer.Bytes(), nil
+}
+
+// Decompress decompresses [msg].
+func (l *lz4Compressor) Decompress(msg []byte) ([]byte, error) {
+	lz4Reader := lz4.NewReader(bytes.NewReader(msg))
+
+	// We allow [io.LimitReader] to read up to [l.maxSize + 1] bytes, so that if
+	// the decompressed payload is greater than the maximum size, this function
+	// will return the appropriate error instead of an incomplete byte slice.
+	limitedReader := io.LimitReader(lz4Reader, l.maxSize+1)
+
+	decompressed, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > l.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), l.maxSize)
+	}
+	return decompressed, nil
+}
+
+// NewLZ4Compressor returns a new lz4 Compressor that compresses messages using
+// the lz4 frame format.
+func NewLZ4Compressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// "Decompress" creates "io.LimitReader" with max size + 1:
+		// if the max size + 1 overflows, "io.LimitReader" reads nothing
+		// returning 0 byte for the decompress call
+		// require max size < math.MaxInt64 to prevent int64 overflows
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &lz4Compressor{
+		maxSize: maxSize,
+		lz4WriterPool: sync.Pool{
+			New: func() interface{} {
+				return lz4.NewWriter(nil)
+			},
+		},
+	}, nil
+}
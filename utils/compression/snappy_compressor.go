@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/snappy"
+)
+
+var _ Compressor = (*snappyCompressor)(nil)
+
+type snappyCompressor struct {
+	maxSize int64
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (s *snappyCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > s.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), s.maxSize)
+	}
+	return snappy.Encode(nil, msg), nil
+}
+
+// Decompress decompresses [msg].
+func (s *snappyCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The snappy block format is prefixed with the length of the decompressed
+	// payload, which allows rejecting oversized payloads before allocating any
+	// memory for them.
+	decompressedLen, err := snappy.DecodedLen(msg)
+	if err != nil {
+		return nil, err
+	}
+	if int64(decompressedLen) > s.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, decompressedLen, s.maxSize)
+	}
+	return snappy.Decode(nil, msg)
+}
+
+// NewSnappyCompressor returns a new snappy Compressor that compresses messages
+// using the snappy block format.
+func NewSnappyCompressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// Keep the same restriction as the other compressors so that they can
+		// be used interchangeably.
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &snappyCompressor{
+		maxSize: maxSize,
+	}, nil
+}
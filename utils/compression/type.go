@@ -16,6 +16,8 @@ const (
 	TypeNone Type = iota + 1
 	TypeGzip
 	TypeZstd
+	TypeLZ4
+	TypeSnappy
 )
 
 func (t Type) String() string {
@@ -26,6 +28,10 @@ func (t Type) String() string {
 		return "gzip"
 	case TypeZstd:
 		return "zstd"
+	case TypeLZ4:
+		return "lz4"
+	case TypeSnappy:
+		return "snappy"
 	default:
 		return "unknown"
 	}
@@ -39,6 +45,10 @@ func TypeFromString(s string) (Type, error) {
 		return TypeGzip, nil
 	case TypeZstd.String():
 		return TypeZstd, nil
+	case TypeLZ4.String():
+		return TypeLZ4, nil
+	case TypeSnappy.String():
+		return TypeSnappy, nil
 	default:
 		return TypeNone, errUnknownCompressionType
 	}
@@ -5,14 +5,26 @@ package compression
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"runtime"
 
 	"github.com/DataDog/zstd"
 )
 
-var _ Compressor = (*zstdCompressor)(nil)
+// zstdDictionaryMagic prefixes every zstd dictionary that isn't a raw content
+// dictionary.
+const zstdDictionaryMagic = 0xEC30A437
+
+var (
+	_ Compressor = (*zstdCompressor)(nil)
+	_ Compressor = (*zstdDictionaryCompressor)(nil)
+
+	ErrInvalidZstdDictionary = errors.New("invalid zstd dictionary")
+)
 
 func NewZstdCompressor(maxSize int64) (Compressor, error) {
 	if maxSize == math.MaxInt64 {
@@ -56,3 +68,76 @@ func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
 	}
 	return decompressed, nil
 }
+
+// NewZstdDictionaryCompressor returns a zstd Compressor that compresses
+// messages using [dictionary]. Messages compressed by this Compressor can only
+// be decompressed by a Compressor using the same dictionary.
+func NewZstdDictionaryCompressor(maxSize int64, dictionary []byte) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// See NewZstdCompressor
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+	if _, err := ZstdDictionaryID(dictionary); err != nil {
+		return nil, err
+	}
+
+	// The bulk processor digests the dictionary once so that it doesn't need
+	// to be loaded for every message that is compressed.
+	processor, err := zstd.NewBulkProcessor(dictionary, zstd.DefaultCompression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZstdDictionary, err)
+	}
+	return &zstdDictionaryCompressor{
+		maxSize:    maxSize,
+		dictionary: dictionary,
+		processor:  processor,
+	}, nil
+}
+
+type zstdDictionaryCompressor struct {
+	maxSize    int64
+	dictionary []byte
+	processor  *zstd.BulkProcessor
+}
+
+func (z *zstdDictionaryCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), z.maxSize)
+	}
+	compressed, err := z.processor.Compress(nil, msg)
+	// The processor frees the digested dictionary in a finalizer, so it must
+	// not be collected while it is being used by cgo.
+	runtime.KeepAlive(z.processor)
+	return compressed, err
+}
+
+func (z *zstdDictionaryCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The bulk processor trusts the content size included in the frame header,
+	// so the streaming reader is used to enforce [z.maxSize].
+	reader := zstd.NewReaderDict(bytes.NewReader(msg), z.dictionary)
+	defer reader.Close()
+
+	limitReader := io.LimitReader(reader, z.maxSize+1)
+	decompressed, err := io.ReadAll(limitReader)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > z.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), z.maxSize)
+	}
+	return decompressed, nil
+}
+
+// ZstdDictionaryID returns the ID encoded in the header of [dictionary].
+//
+// Raw content dictionaries don't have an ID and are therefore not supported.
+func ZstdDictionaryID(dictionary []byte) (uint32, error) {
+	if len(dictionary) < 8 || binary.LittleEndian.Uint32(dictionary) != zstdDictionaryMagic {
+		return 0, fmt.Errorf("%w: missing header", ErrInvalidZstdDictionary)
+	}
+	id := binary.LittleEndian.Uint32(dictionary[4:])
+	if id == 0 {
+		return 0, fmt.Errorf("%w: missing ID", ErrInvalidZstdDictionary)
+	}
+	return id, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// newZstdDictionarySamples returns payloads that share a common prefix but
+// otherwise differ, similar to blocks of the same chain.
+func newZstdDictionarySamples(numSamples int) [][]byte {
+	prefix := utils.RandomBytes(units.KiB)
+	samples := make([][]byte, numSamples)
+	for i := range samples {
+		sample := make([]byte, 0, len(prefix)+8+256)
+		sample = append(sample, prefix...)
+		sample = binary.BigEndian.AppendUint64(sample, uint64(i))
+		sample = append(sample, utils.RandomBytes(256)...)
+		samples[i] = sample
+	}
+	return samples
+}
+
+func TestZstdDictionaryCompressor(t *testing.T) {
+	require := require.New(t)
+
+	samples := newZstdDictionarySamples(1000)
+	dictionary, err := TrainZstdDictionary(samples[:900], 16*units.KiB)
+	require.NoError(err)
+
+	id, err := ZstdDictionaryID(dictionary)
+	require.NoError(err)
+	require.NotZero(id)
+
+	compressor, err := NewZstdDictionaryCompressor(maxMessageSize, dictionary)
+	require.NoError(err)
+	zstdCompressor, err := NewZstdCompressor(maxMessageSize)
+	require.NoError(err)
+
+	for _, sample := range samples[900:] {
+		compressed, err := compressor.Compress(sample)
+		require.NoError(err)
+
+		// The dictionary should allow payloads similar to the samples to be
+		// compressed better than without it.
+		compressedWithoutDictionary, err := zstdCompressor.Compress(sample)
+		require.NoError(err)
+		require.Less(len(compressed), len(compressedWithoutDictionary))
+
+		decompressed, err := compressor.Decompress(compressed)
+		require.NoError(err)
+		require.Equal(sample, decompressed)
+
+		// The message can't be decompressed without the dictionary.
+		_, err = zstdCompressor.Decompress(compressed)
+		require.Error(err) //nolint:forbidigo // the error is returned by cgo
+	}
+}
+
+func TestZstdDictionaryCompressorSizeLimiting(t *testing.T) {
+	require := require.New(t)
+
+	dictionary, err := TrainZstdDictionary(newZstdDictionarySamples(100), 16*units.KiB)
+	require.NoError(err)
+
+	compressor, err := NewZstdDictionaryCompressor(maxMessageSize, dictionary)
+	require.NoError(err)
+
+	data := make([]byte, maxMessageSize+1)
+	_, err = compressor.Compress(data)
+	require.ErrorIs(err, ErrMsgTooLarge)
+
+	compressor2, err := NewZstdDictionaryCompressor(2*maxMessageSize, dictionary)
+	require.NoError(err)
+
+	dataCompressed, err := compressor2.Compress(data)
+	require.NoError(err)
+
+	_, err = compressor.Decompress(dataCompressed)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+}
+
+func TestZstdDictionaryID(t *testing.T) {
+	tests := []struct {
+		name        string
+		dictionary  []byte
+		expectedID  uint32
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			dictionary:  nil,
+			expectedErr: ErrInvalidZstdDictionary,
+		},
+		{
+			name:        "raw content",
+			dictionary:  utils.RandomBytes(units.KiB),
+			expectedErr: ErrInvalidZstdDictionary,
+		},
+		{
+			name:        "missing ID",
+			dictionary:  []byte{0x37, 0xa4, 0x30, 0xec, 0, 0, 0, 0},
+			expectedErr: ErrInvalidZstdDictionary,
+		},
+		{
+			name:       "valid",
+			dictionary: []byte{0x37, 0xa4, 0x30, 0xec, 1, 2, 0, 0},
+			expectedID: 0x0201,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			id, err := ZstdDictionaryID(test.dictionary)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedID, id)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+/*
+#include <stddef.h>
+
+// These symbols are provided by the zstd library that is bundled with
+// github.com/DataDog/zstd.
+size_t ZDICT_trainFromBuffer(void* dictBuffer, size_t dictBufferCapacity, const void* samplesBuffer, const size_t* samplesSizes, unsigned nbSamples);
+unsigned ZDICT_isError(size_t errorCode);
+const char* ZDICT_getErrorName(size_t errorCode);
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+var errNoSamples = errors.New("no samples provided")
+
+// TrainZstdDictionary builds a zstd dictionary of at most [maxSize] bytes from
+// [samples]. The samples should be representative of the payloads that will be
+// compressed with the dictionary. A random ID is assigned to the dictionary,
+// which can be retrieved with ZstdDictionaryID.
+func TrainZstdDictionary(samples [][]byte, maxSize int) ([]byte, error) {
+	var (
+		samplesLen int
+		sizes      = make([]C.size_t, 0, len(samples))
+	)
+	for _, sample := range samples {
+		if len(sample) == 0 {
+			continue
+		}
+		samplesLen += len(sample)
+		sizes = append(sizes, C.size_t(len(sample)))
+	}
+	if samplesLen == 0 {
+		return nil, errNoSamples
+	}
+
+	// zstd expects the samples to be concatenated into a single buffer.
+	samplesBuffer := make([]byte, 0, samplesLen)
+	for _, sample := range samples {
+		samplesBuffer = append(samplesBuffer, sample...)
+	}
+
+	dictionary := make([]byte, maxSize)
+	size := C.ZDICT_trainFromBuffer(
+		unsafe.Pointer(&dictionary[0]),
+		C.size_t(len(dictionary)),
+		unsafe.Pointer(&samplesBuffer[0]),
+		&sizes[0],
+		C.uint(len(sizes)),
+	)
+	if C.ZDICT_isError(size) != 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidZstdDictionary, C.GoString(C.ZDICT_getErrorName(size)))
+	}
+	return dictionary[:size], nil
+}
@@ -32,6 +32,8 @@ const (
 	DefaultNetworkPeerListNonValidatorGossipSize = 0
 	DefaultNetworkPeerListPeersGossipSize        = 10
 	DefaultNetworkPeerListGossipFreq             = time.Minute
+	DefaultNetworkPeerListMaxPeersPerIPPrefix    = 0
+	DefaultNetworkPeerListMinSubnetPeers         = 5
 
 	// Inbound Connection Throttling
 	DefaultInboundConnUpgradeThrottlerCooldown = 10 * time.Second
@@ -55,6 +57,8 @@ const (
 	DefaultNetworkRequireValidatorToConnect = false
 	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
+	DefaultNetworkPeerEventsSize            = 1024
+	DefaultNetworkQUICEnabled               = false
 
 	DefaultNetworkTCPProxyEnabled = false
 
@@ -116,3 +120,12 @@ const (
 	DefaultNetworkInitialReconnectDelay = time.Second
 	DefaultNetworkMaxReconnectDelay     = time.Minute
 )
+
+// DefaultNetworkCompressionTypes are advertised to peers from the cheapest to
+// the most expensive to compute.
+var DefaultNetworkCompressionTypes = []compression.Type{
+	compression.TypeLZ4,
+	compression.TypeSnappy,
+	compression.TypeZstd,
+	compression.TypeGzip,
+}
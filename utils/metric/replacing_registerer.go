@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metric
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var _ prometheus.Registerer = (*replacingRegisterer)(nil)
+
+type replacingRegisterer struct {
+	prometheus.Registerer
+}
+
+// NewReplacingRegisterer returns a registerer that replaces any collector
+// previously registered to [registerer] with the same descriptors, rather than
+// failing to register the collector. This allows a component to be recreated
+// while keeping its metrics registered.
+func NewReplacingRegisterer(registerer prometheus.Registerer) prometheus.Registerer {
+	return &replacingRegisterer{
+		Registerer: registerer,
+	}
+}
+
+func (r *replacingRegisterer) Register(c prometheus.Collector) error {
+	r.Registerer.Unregister(c)
+	return r.Registerer.Register(c)
+}
+
+func (r *replacingRegisterer) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -504,7 +504,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := mempool.New("mempool", registerer, toEngine)
+	mempool, err := mempool.New("mempool", registerer, toEngine, &mockable.Clock{})
 	require.NoError(err)
 	// add a tx to the mempool
 	tx := transactions[0]
@@ -526,7 +526,7 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 
 	baseDB := versiondb.New(memdb.New())
 
-	state, err := states.New(baseDB, parser, registerer, trackChecksums)
+	state, err := states.New(baseDB, nil, parser, registerer, trackChecksums)
 	require.NoError(err)
 
 	clk := &mockable.Clock{}
@@ -5,6 +5,7 @@ package executor
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
@@ -24,6 +25,10 @@ var (
 
 	ErrChainNotSynced       = errors.New("chain not synced")
 	ErrConflictingParentTxs = errors.New("block contains a transaction that conflicts with a transaction in a parent block")
+
+	errRollbackWithProcessing    = errors.New("cannot roll back with blocks processing")
+	errRollbackAboveLastAccepted = errors.New("cannot roll back above the last accepted block")
+	errRollbackAtomicTx          = errors.New("cannot roll back an atomic tx")
 )
 
 type Manager interface {
@@ -46,6 +51,12 @@ type Manager interface {
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
+
+	// Rollback reverts the accepted chain to the block accepted at [height].
+	//
+	// Blocks that issued atomic txs can't be rolled back, as the shared
+	// memory operations they performed aren't reverted.
+	Rollback(height uint64) error
 }
 
 func NewManager(
@@ -205,6 +216,50 @@ func (m *manager) VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
 	}
 }
 
+func (m *manager) Rollback(height uint64) error {
+	if len(m.blkIDToState) != 0 {
+		return fmt.Errorf("%w: %d", errRollbackWithProcessing, len(m.blkIDToState))
+	}
+
+	blk, err := m.state.GetBlock(m.lastAccepted)
+	if err != nil {
+		return err
+	}
+	lastAcceptedHeight := blk.Height()
+	if height > lastAcceptedHeight {
+		return fmt.Errorf("%w: %d > %d", errRollbackAboveLastAccepted, height, lastAcceptedHeight)
+	}
+	if height == lastAcceptedHeight {
+		return nil
+	}
+
+	for blk.Height() > height {
+		for _, tx := range blk.Txs() {
+			switch tx.Unsigned.(type) {
+			case *txs.ImportTx, *txs.ExportTx:
+				return fmt.Errorf("%w %s in block %s at height %d",
+					errRollbackAtomicTx,
+					tx.ID(),
+					blk.ID(),
+					blk.Height(),
+				)
+			}
+		}
+
+		blk, err = m.state.GetBlock(blk.Parent())
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := m.state.Rollback(height); err != nil {
+		return err
+	}
+	m.lastAccepted = m.state.GetLastAccepted()
+	m.preferred = m.lastAccepted
+	return nil
+}
+
 func (m *manager) free(blkID ids.ID) {
 	delete(m.blkIDToState, blkID)
 }
@@ -35,6 +35,13 @@ type Client interface {
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
 	// used instead to determine if the tx was accepted.
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
+	// GetMempool returns up to [pageSize] of the txs pending in the mempool,
+	// ordered by txID, starting after the tx with ID [cursor]. A [pageSize] of
+	// 0 uses the maximum page size.
+	GetMempool(ctx context.Context, cursor ids.ID, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error)
+	// GetDroppedTxs returns the most recently dropped txs and the reasons they
+	// were dropped
+	GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]DroppedTx, error)
 	// ConfirmTx attempts to confirm [txID] by repeatedly checking its status.
 	// Note: ConfirmTx will block until either the context is done or the client
 	//       returns a decided status.
@@ -277,6 +284,21 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) GetMempool(ctx context.Context, cursor ids.ID, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempool", &GetMempoolArgs{
+		Cursor:   cursor,
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]DroppedTx, error) {
+	res := &GetDroppedTxsReply{}
+	err := c.requester.SendRequest(ctx, "avm.getDroppedTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
 func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error) {
 	res := &GetTxStatusReply{}
 	err := c.requester.SendRequest(ctx, "avm.getTxStatus", &api.JSONTxID{
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"reflect"
+	"time"
 
 	stdjson "encoding/json"
 
@@ -327,6 +329,120 @@ func (s *Service) GetTxStatus(_ *http.Request, args *api.JSONTxID, reply *GetTxS
 	return nil
 }
 
+// GetMempoolArgs are the arguments for calling GetMempool
+type GetMempoolArgs struct {
+	// Cursor is the ID of the tx to start after. If empty, txs are returned
+	// starting from the lowest txID.
+	Cursor ids.ID `json:"cursor"`
+	// PageSize is the maximum number of txs to return
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// MempoolTx describes a tx that is pending in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Type is the name of the unsigned tx type, e.g. "BaseTx"
+	Type string      `json:"type"`
+	Size json.Uint64 `json:"size"`
+	// Added is the unix time the tx was added to the mempool
+	Added json.Uint64 `json:"added"`
+	// Age is the number of seconds the tx has been in the mempool
+	Age json.Uint64 `json:"age"`
+}
+
+// GetMempoolReply is the response from calling GetMempool
+type GetMempoolReply struct {
+	Txs []MempoolTx `json:"txs"`
+	// NumTxs is the total number of txs in the mempool
+	NumTxs json.Uint64 `json:"numTxs"`
+	// Cursor to provide to fetch the next page of txs
+	Cursor ids.ID `json:"cursor"`
+}
+
+// GetMempool returns the txs currently pending in the mempool, ordered by
+// txID
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, reply *GetMempoolReply) error {
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempool"),
+		zap.Stringer("cursor", args.Cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	pendingTxs := s.vm.mempool.PendingTxs(args.Cursor, int(pageSize))
+	now := s.vm.clock.Time()
+	reply.Txs = make([]MempoolTx, 0, len(pendingTxs))
+	for _, pendingTx := range pendingTxs {
+		reply.Txs = append(reply.Txs, MempoolTx{
+			TxID:  pendingTx.Tx.ID(),
+			Type:  reflect.TypeOf(pendingTx.Tx.Unsigned).Elem().Name(),
+			Size:  json.Uint64(len(pendingTx.Tx.Bytes())),
+			Added: json.Uint64(pendingTx.Added.Unix()),
+			Age:   json.Uint64(now.Sub(pendingTx.Added) / time.Second),
+		})
+	}
+	reply.NumTxs = json.Uint64(s.vm.mempool.NumTxs())
+	reply.Cursor = args.Cursor
+	if len(pendingTxs) > 0 {
+		reply.Cursor = pendingTxs[len(pendingTxs)-1].Tx.ID()
+	}
+	return nil
+}
+
+// DroppedTx describes a tx that was recently dropped from the mempool
+type DroppedTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+	// Dropped is the unix time the tx was dropped
+	Dropped json.Uint64 `json:"dropped"`
+}
+
+// GetDroppedTxsReply is the response from calling GetDroppedTxs
+type GetDroppedTxsReply struct {
+	Txs []DroppedTx `json:"txs"`
+}
+
+// GetDroppedTxs returns the most recently dropped txs along with the reason
+// they were dropped, in the order they were dropped
+func (s *Service) GetDroppedTxs(_ *http.Request, _ *struct{}, reply *GetDroppedTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getDroppedTxs"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	droppedTxs := s.vm.mempool.DroppedTxs()
+	reply.Txs = make([]DroppedTx, len(droppedTxs))
+	for i, droppedTx := range droppedTxs {
+		reply.Txs[i] = DroppedTx{
+			TxID:    droppedTx.TxID,
+			Reason:  droppedTx.Reason.Error(),
+			Dropped: json.Uint64(droppedTx.Dropped.Unix()),
+		}
+	}
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
@@ -24,11 +24,14 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
 
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
@@ -56,7 +59,11 @@ var (
 	timestampKey     = []byte{0x01}
 	lastAcceptedKey  = []byte{0x02}
 
-	errStatusWithoutTx = errors.New("unexpected status without transactions")
+	errStatusWithoutTx      = errors.New("unexpected status without transactions")
+	errRollbackWhilePruning = errors.New("cannot roll back while the state is being pruned")
+	// The rollback request is dropped, rather than failing, if the journal is
+	// disabled.
+	errRollbackJournalDisabled = fmt.Errorf("%w: rollback journal is disabled", snowmanblock.ErrRollbackableVMNotImplemented)
 
 	_ State = (*state)(nil)
 )
@@ -105,6 +112,13 @@ type State interface {
 	// Commit changes to the base database.
 	Commit() error
 
+	// Rollback reverts the database to the state that was committed when the
+	// block at [height] was accepted, and reloads the state from it. Fails if
+	// the rollback journal is disabled.
+	//
+	// Invariant: There are no uncommitted changes.
+	Rollback(height uint64) error
+
 	// Returns a batch of unwritten changes that, when written, will commit all
 	// pending changes to the base database.
 	CommitBatch() (database.Batch, error)
@@ -146,16 +160,22 @@ type State interface {
  *   '-- lastAcceptedKey -> lastAccepted
  */
 type state struct {
-	parser block.Parser
-	db     *versiondb.Database
+	parser  block.Parser
+	metrics prometheus.Registerer
+	db      *versiondb.Database
+	// journal records the writes of each accepted block so that they can be
+	// rolled back. It is nil if the rollback journal is disabled.
+	journal *chain.Journal
 
 	modifiedUTXOs map[ids.ID]*avax.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     avax.UTXOState
 
 	statusesPruned bool
-	statusCache    cache.Cacher[ids.ID, *choices.Status] // cache of id -> choices.Status. If the entry is nil, it is not in the database
-	statusDB       database.Database
+	// pruning is true while Prune is removing state from disk.
+	pruning     bool
+	statusCache cache.Cacher[ids.ID, *choices.Status] // cache of id -> choices.Status. If the entry is nil, it is not in the database
+	statusDB    database.Database
 
 	addedTxs map[ids.ID]*txs.Tx            // map of txID -> *txs.Tx
 	txCache  cache.Cacher[ids.ID, *txs.Tx] // cache of txID -> *txs.Tx. If the entry is nil, it is not in the database
@@ -178,12 +198,25 @@ type state struct {
 	txChecksum    ids.ID
 }
 
+// New returns the state stored in [db]. If [journal] is non-nil, [db] must be
+// a database over [journal].
 func New(
 	db *versiondb.Database,
+	journal *chain.Journal,
 	parser block.Parser,
 	metrics prometheus.Registerer,
 	trackChecksums bool,
 ) (State, error) {
+	return newState(db, journal, parser, metrics, trackChecksums)
+}
+
+func newState(
+	db *versiondb.Database,
+	journal *chain.Journal,
+	parser block.Parser,
+	metrics prometheus.Registerer,
+	trackChecksums bool,
+) (*state, error) {
 	utxoDB := prefixdb.New(utxoPrefix, db)
 	statusDB := prefixdb.New(statusPrefix, db)
 	txDB := prefixdb.New(txPrefix, db)
@@ -233,8 +266,10 @@ func New(
 	}
 
 	s := &state{
-		parser: parser,
-		db:     db,
+		parser:  parser,
+		metrics: metrics,
+		db:      db,
+		journal: journal,
 
 		modifiedUTXOs: make(map[ids.ID]*avax.UTXO),
 		utxoDB:        utxoDB,
@@ -441,8 +476,15 @@ func (s *state) GetBlock(blkID ids.ID) (block.Block, error) {
 
 func (s *state) AddBlock(block block.Block) {
 	blkID := block.ID()
-	s.addedBlockIDs[block.Height()] = blkID
+	height := block.Height()
+	s.addedBlockIDs[height] = blkID
 	s.addedBlocks[blkID] = block
+
+	// The writes that are committed along with the block are journaled at its
+	// height.
+	if s.journal != nil {
+		s.journal.SetHeight(height)
+	}
 }
 
 func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time) error {
@@ -452,6 +494,11 @@ func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.
 	} else if err != nil {
 		return err
 	}
+	return s.loadChainState(lastAccepted)
+}
+
+func (s *state) loadChainState(lastAccepted ids.ID) error {
+	var err error
 	s.lastAccepted = lastAccepted
 	s.persistedLastAccepted = lastAccepted
 	s.timestamp, err = database.GetTimestamp(s.singletonDB, timestampKey)
@@ -510,6 +557,43 @@ func (s *state) Commit() error {
 	return batch.Write()
 }
 
+func (s *state) Rollback(height uint64) error {
+	if s.journal == nil {
+		return errRollbackJournalDisabled
+	}
+	if s.pruning {
+		return errRollbackWhilePruning
+	}
+
+	s.Abort()
+	if err := s.journal.Rollback(height); err != nil {
+		return err
+	}
+
+	// The state is reloaded into a new instance, so the caches are recreated
+	// and their metrics replace the metrics of the previous caches.
+	reloaded, err := newState(
+		s.db,
+		s.journal,
+		s.parser,
+		metric.NewReplacingRegisterer(s.metrics),
+		s.trackChecksum,
+	)
+	if err != nil {
+		return err
+	}
+	lastAccepted, err := database.GetID(reloaded.singletonDB, lastAcceptedKey)
+	if err != nil {
+		return err
+	}
+	if err := reloaded.loadChainState(lastAccepted); err != nil {
+		return err
+	}
+
+	*s = *reloaded
+	return nil
+}
+
 func (s *state) Abort() {
 	s.db.Abort()
 }
@@ -660,6 +744,7 @@ func (s *state) Prune(lock sync.Locker, log logging.Logger) error {
 	oldTxCache := s.txCache
 	s.statusCache = &cache.Empty[ids.ID, *choices.Status]{}
 	s.txCache = &cache.Empty[ids.ID, *txs.Tx]{}
+	s.pruning = true
 	lock.Unlock()
 
 	startTime := time.Now()
@@ -756,6 +841,7 @@ func (s *state) Prune(lock sync.Locker, log logging.Logger) error {
 	// surfacing any stale data.
 	oldTxCache.Flush()
 	s.statusesPruned = true
+	s.pruning = false
 	s.txCache = oldTxCache
 
 	log.Info("finished state pruning",
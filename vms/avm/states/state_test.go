@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -101,7 +102,7 @@ func TestState(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err := New(vdb, nil, parser, prometheus.NewRegistry(), trackChecksums)
 	require.NoError(err)
 
 	s.AddUTXO(populatedUTXO)
@@ -109,7 +110,7 @@ func TestState(t *testing.T) {
 	s.AddBlock(populatedBlk)
 	require.NoError(s.Commit())
 
-	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err = New(vdb, nil, parser, prometheus.NewRegistry(), trackChecksums)
 	require.NoError(err)
 
 	ChainUTXOTest(t, s)
@@ -122,7 +123,7 @@ func TestDiff(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err := New(vdb, nil, parser, prometheus.NewRegistry(), trackChecksums)
 	require.NoError(err)
 
 	s.AddUTXO(populatedUTXO)
@@ -282,7 +283,7 @@ func TestInitializeChainState(t *testing.T) {
 
 	db := memdb.New()
 	vdb := versiondb.New(db)
-	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	s, err := New(vdb, nil, parser, prometheus.NewRegistry(), trackChecksums)
 	require.NoError(err)
 
 	stopVertexID := ids.GenerateTestID()
@@ -315,3 +316,65 @@ func TestInitializeChainState(t *testing.T) {
 	require.NoError(err)
 	require.Equal(genesis.ID(), lastAccepted.Parent())
 }
+
+func TestStateRollback(t *testing.T) {
+	require := require.New(t)
+
+	db := chain.NewJournal(memdb.New(), chain.DefaultJournalDepth)
+	vdb := versiondb.New(db)
+	registerer := prometheus.NewRegistry()
+	s, err := New(vdb, db, parser, registerer, trackChecksums)
+	require.NoError(err)
+
+	stopVertexID := ids.GenerateTestID()
+	genesisTimestamp := version.DefaultUpgradeTime
+	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp))
+
+	genesisID := s.GetLastAccepted()
+	childBlock, err := block.NewStandardBlock(
+		genesisID,
+		1,
+		genesisTimestamp.Add(time.Second),
+		nil,
+		parser.Codec(),
+	)
+	require.NoError(err)
+
+	s.AddUTXO(populatedUTXO)
+	s.AddTx(populatedTx)
+	s.AddBlock(childBlock)
+	s.SetLastAccepted(childBlock.ID())
+	s.SetTimestamp(childBlock.Timestamp())
+	require.NoError(s.Commit())
+
+	require.NoError(s.Rollback(0))
+
+	require.Equal(genesisID, s.GetLastAccepted())
+	require.Equal(genesisTimestamp.UnixNano(), s.GetTimestamp().UnixNano())
+
+	_, err = s.GetUTXO(populatedUTXOID)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetTx(populatedTxID)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetBlock(childBlock.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = s.GetBlockIDAtHeight(1)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The rolled back state is persisted.
+	s, err = New(vdb, db, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp))
+	require.Equal(genesisID, s.GetLastAccepted())
+}
+
+func TestStateRollbackJournalDisabled(t *testing.T) {
+	require := require.New(t)
+
+	vdb := versiondb.New(memdb.New())
+	s, err := New(vdb, nil, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+
+	err = s.Rollback(0)
+	require.ErrorIs(err, errRollbackJournalDisabled)
+}
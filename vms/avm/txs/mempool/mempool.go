@@ -6,14 +6,17 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/btree"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 )
@@ -30,6 +33,8 @@ const (
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	unissuedTxIDsTreeDegree = 2
 )
 
 var (
@@ -60,28 +65,60 @@ type Mempool interface {
 	// unissued. This allows previously dropped txs to be possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
+
+	// NumTxs returns the number of txs currently in the mempool.
+	NumTxs() int
+	// PendingTxs returns up to [limit] of the txs currently in the mempool
+	// whose IDs are greater than [startAfter], ordered by txID.
+	PendingTxs(startAfter ids.ID, limit int) []PendingTx
+	// DroppedTxs returns the cached dropped txs, from least to most recently
+	// marked or queried.
+	DroppedTxs() []DroppedTx
+}
+
+// PendingTx is a tx in the mempool along with when it was added.
+type PendingTx struct {
+	Tx    *txs.Tx
+	Added time.Time
+}
+
+// DroppedTx is a tx that was dropped from the mempool along with the reason it
+// was dropped.
+type DroppedTx struct {
+	TxID    ids.ID
+	Reason  error
+	Dropped time.Time
 }
 
 type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
-	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
-	numTxs      prometheus.Gauge
+	unissuedTxs linkedhashmap.LinkedHashmap[ids.ID, PendingTx]
+	// IDs of the unissued txs, ordered so that they can be paged through
+	// without copying the entire mempool.
+	unissuedTxIDs *btree.BTreeG[ids.ID]
+	numTxs        prometheus.Gauge
 
 	toEngine chan<- common.Message
 
 	// Key: Tx ID
-	// Value: Verification error
-	droppedTxIDs *cache.LRU[ids.ID, error]
+	// Value: Verification error and the time the tx was dropped
+	//
+	// Holds at most [droppedTxIDsCacheSize] entries, evicting the least
+	// recently used entry first.
+	droppedTxIDs linkedhashmap.LinkedHashmap[ids.ID, DroppedTx]
 
 	consumedUTXOs set.Set[ids.ID]
+
+	clk *mockable.Clock
 }
 
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
+	clk *mockable.Clock,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -105,11 +142,13 @@ func New(
 	return &mempool{
 		bytesAvailableMetric: bytesAvailableMetric,
 		bytesAvailable:       maxMempoolSize,
-		unissuedTxs:          linkedhashmap.New[ids.ID, *txs.Tx](),
+		unissuedTxs:          linkedhashmap.New[ids.ID, PendingTx](),
+		unissuedTxIDs:        btree.NewG(unissuedTxIDsTreeDegree, ids.ID.Less),
 		numTxs:               numTxsMetric,
 		toEngine:             toEngine,
-		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		droppedTxIDs:         linkedhashmap.New[ids.ID, DroppedTx](),
 		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
+		clk:                  clk,
 	}, nil
 }
 
@@ -146,14 +185,18 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	m.bytesAvailable -= txSize
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
-	m.unissuedTxs.Put(txID, tx)
+	m.unissuedTxs.Put(txID, PendingTx{
+		Tx:    tx,
+		Added: m.clk.Time(),
+	})
+	m.unissuedTxIDs.ReplaceOrInsert(txID)
 	m.numTxs.Inc()
 
 	// Mark these UTXOs as consumed in the mempool
 	m.consumedUTXOs.Union(inputs)
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxIDs.Delete(txID)
 	return nil
 }
 
@@ -162,8 +205,8 @@ func (m *mempool) Has(txID ids.ID) bool {
 }
 
 func (m *mempool) Get(txID ids.ID) *txs.Tx {
-	unissuedTxs, _ := m.unissuedTxs.Get(txID)
-	return unissuedTxs
+	pendingTx, _ := m.unissuedTxs.Get(txID)
+	return pendingTx.Tx
 }
 
 func (m *mempool) Remove(txsToRemove []*txs.Tx) {
@@ -179,6 +222,7 @@ func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 		m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
 		m.unissuedTxs.Delete(txID)
+		m.unissuedTxIDs.Delete(txID)
 		m.numTxs.Dec()
 
 		inputs := tx.Unsigned.InputIDs()
@@ -189,7 +233,7 @@ func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 func (m *mempool) Peek(maxTxSize int) *txs.Tx {
 	txIter := m.unissuedTxs.NewIterator()
 	for txIter.Next() {
-		tx := txIter.Value()
+		tx := txIter.Value().Tx
 		txSize := len(tx.Bytes())
 		if txSize <= maxTxSize {
 			return tx
@@ -210,10 +254,50 @@ func (m *mempool) RequestBuildBlock() {
 }
 
 func (m *mempool) MarkDropped(txID ids.ID, reason error) {
-	m.droppedTxIDs.Put(txID, reason)
+	m.droppedTxIDs.Put(txID, DroppedTx{
+		TxID:    txID,
+		Reason:  reason,
+		Dropped: m.clk.Time(),
+	})
+	if m.droppedTxIDs.Len() > droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) error {
-	err, _ := m.droppedTxIDs.Get(txID)
-	return err
+	droppedTx, ok := m.droppedTxIDs.Get(txID)
+	if !ok {
+		return nil
+	}
+	m.droppedTxIDs.Put(txID, droppedTx) // Mark [txID] as MRU.
+	return droppedTx.Reason
+}
+
+func (m *mempool) NumTxs() int {
+	return m.unissuedTxs.Len()
+}
+
+func (m *mempool) PendingTxs(startAfter ids.ID, limit int) []PendingTx {
+	var pendingTxs []PendingTx
+	m.unissuedTxIDs.AscendGreaterOrEqual(startAfter, func(txID ids.ID) bool {
+		if len(pendingTxs) >= limit {
+			return false
+		}
+		if txID != startAfter {
+			pendingTx, _ := m.unissuedTxs.Get(txID)
+			pendingTxs = append(pendingTxs, pendingTx)
+		}
+		return true
+	})
+	return pendingTxs
+}
+
+func (m *mempool) DroppedTxs() []DroppedTx {
+	droppedTxs := make([]DroppedTx, 0, m.droppedTxIDs.Len())
+	txIter := m.droppedTxIDs.NewIterator()
+	for txIter.Next() {
+		droppedTxs = append(droppedTxs, txIter.Value())
+	}
+	return droppedTxs
 }
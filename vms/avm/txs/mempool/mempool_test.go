@@ -4,17 +4,22 @@
 package mempool
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -33,7 +38,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mempoolIntf, err := New("mempool", registerer, nil)
+	mempoolIntf, err := New("mempool", registerer, nil, &mockable.Clock{})
 	require.NoError(err)
 
 	mempool := mempoolIntf.(*mempool)
@@ -58,7 +63,7 @@ func TestTxsInMempool(t *testing.T) {
 
 	registerer := prometheus.NewRegistry()
 	toEngine := make(chan common.Message, 100)
-	mempool, err := New("mempool", registerer, toEngine)
+	mempool, err := New("mempool", registerer, toEngine, &mockable.Clock{})
 	require.NoError(err)
 
 	testTxs := createTestTxs(2)
@@ -115,6 +120,68 @@ func TestTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestPendingAndDroppedTxs(t *testing.T) {
+	require := require.New(t)
+
+	clk := &mockable.Clock{}
+	now := time.Unix(1607133207, 0)
+	clk.Set(now)
+
+	registerer := prometheus.NewRegistry()
+	mempool, err := New("mempool", registerer, nil, clk)
+	require.NoError(err)
+
+	testTxs := createTestTxs(3)
+	pendingTxs := make([]PendingTx, 0, len(testTxs))
+	for _, tx := range testTxs {
+		require.NoError(mempool.Add(tx))
+		pendingTxs = append(pendingTxs, PendingTx{
+			Tx:    tx,
+			Added: now,
+		})
+	}
+	slices.SortFunc(pendingTxs, func(a, b PendingTx) bool {
+		return a.Tx.ID().Less(b.Tx.ID())
+	})
+
+	// Paging through the mempool should return every tx exactly once, ordered
+	// by txID.
+	require.Equal(3, mempool.NumTxs())
+	require.Equal(pendingTxs[:2], mempool.PendingTxs(ids.Empty, 2))
+	require.Equal(pendingTxs[2:], mempool.PendingTxs(pendingTxs[1].Tx.ID(), 2))
+	require.Empty(mempool.PendingTxs(pendingTxs[2].Tx.ID(), 2))
+
+	// Removing the last returned tx must not cause the next page to skip or
+	// repeat any txs.
+	mempool.Remove([]*txs.Tx{pendingTxs[1].Tx})
+	require.Equal(2, mempool.NumTxs())
+	require.Equal(pendingTxs[2:], mempool.PendingTxs(pendingTxs[1].Tx.ID(), 2))
+
+	errTest := errors.New("test error")
+	for i := 0; i < droppedTxIDsCacheSize+1; i++ {
+		mempool.MarkDropped(ids.ID{byte(i)}, errTest)
+	}
+	droppedTxs := mempool.DroppedTxs()
+	require.Len(droppedTxs, droppedTxIDsCacheSize)
+	require.Equal(ids.ID{1}, droppedTxs[0].TxID)
+	require.Equal(ids.ID{droppedTxIDsCacheSize}, droppedTxs[droppedTxIDsCacheSize-1].TxID)
+	require.Nil(mempool.GetDropReason(ids.ID{0}))
+
+	// Querying a dropped tx should mark it as the most recently used, so it is
+	// evicted last.
+	require.ErrorIs(mempool.GetDropReason(ids.ID{1}), errTest)
+	mempool.MarkDropped(ids.ID{droppedTxIDsCacheSize + 1}, errTest)
+	require.ErrorIs(mempool.GetDropReason(ids.ID{1}), errTest)
+	require.Nil(mempool.GetDropReason(ids.ID{2}))
+
+	// Re-adding a dropped tx should clear its drop reason
+	removedTx := pendingTxs[1].Tx
+	mempool.MarkDropped(removedTx.ID(), errTest)
+	require.NoError(mempool.Add(removedTx))
+	require.Nil(mempool.GetDropReason(removedTx.ID()))
+	require.Len(mempool.DroppedTxs(), droppedTxIDsCacheSize-1)
+}
+
 func createTestTxs(count int) []*txs.Tx {
 	testTxs := make([]*txs.Tx, 0, count)
 	addr := keys[0].PublicKey().Address()
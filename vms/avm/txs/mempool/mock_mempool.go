@@ -52,6 +52,20 @@ func (mr *MockMempoolMockRecorder) Add(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMempool)(nil).Add), arg0)
 }
 
+// DroppedTxs mocks base method.
+func (m *MockMempool) DroppedTxs() []DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedTxs")
+	ret0, _ := ret[0].([]DroppedTx)
+	return ret0
+}
+
+// DroppedTxs indicates an expected call of DroppedTxs.
+func (mr *MockMempoolMockRecorder) DroppedTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedTxs", reflect.TypeOf((*MockMempool)(nil).DroppedTxs))
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) *txs.Tx {
 	m.ctrl.T.Helper()
@@ -106,6 +120,20 @@ func (mr *MockMempoolMockRecorder) MarkDropped(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDropped", reflect.TypeOf((*MockMempool)(nil).MarkDropped), arg0, arg1)
 }
 
+// NumTxs mocks base method.
+func (m *MockMempool) NumTxs() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NumTxs")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// NumTxs indicates an expected call of NumTxs.
+func (mr *MockMempoolMockRecorder) NumTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumTxs", reflect.TypeOf((*MockMempool)(nil).NumTxs))
+}
+
 // Peek mocks base method.
 func (m *MockMempool) Peek(arg0 int) *txs.Tx {
 	m.ctrl.T.Helper()
@@ -120,6 +148,20 @@ func (mr *MockMempoolMockRecorder) Peek(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockMempool)(nil).Peek), arg0)
 }
 
+// PendingTxs mocks base method.
+func (m *MockMempool) PendingTxs(arg0 ids.ID, arg1 int) []PendingTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingTxs", arg0, arg1)
+	ret0, _ := ret[0].([]PendingTx)
+	return ret0
+}
+
+// PendingTxs indicates an expected call of PendingTxs.
+func (mr *MockMempoolMockRecorder) PendingTxs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingTxs", reflect.TypeOf((*MockMempool)(nil).PendingTxs), arg0, arg1)
+}
+
 // Remove mocks base method.
 func (m *MockMempool) Remove(arg0 []*txs.Tx) {
 	m.ctrl.T.Helper()
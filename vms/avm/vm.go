@@ -43,10 +43,12 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/avm/utxo"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/avalanchego/vms/components/index"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	blockbuilder "github.com/ava-labs/avalanchego/vms/avm/block/builder"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/avm/block/executor"
 	extensions "github.com/ava-labs/avalanchego/vms/avm/fxs"
@@ -62,6 +64,7 @@ var (
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 
 	_ vertex.LinearizableVMWithEngine = (*VM)(nil)
+	_ snowmanblock.RollbackableVM     = (*VM)(nil)
 )
 
 type VM struct {
@@ -118,6 +121,7 @@ type VM struct {
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
 	network      network.Network
+	mempool      mempool.Mempool
 }
 
 func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
@@ -138,6 +142,10 @@ type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
 	ChecksumsEnabled     bool `json:"checksums-enabled"`
+	// RollbackJournalEnabled journals the state changes of the most recently
+	// accepted blocks so that the chain can be rolled back to one of them.
+	// Journaling slows down accepting blocks and uses additional disk space.
+	RollbackJournalEnabled bool `json:"rollback-journal-enabled"`
 }
 
 func (vm *VM) Initialize(
@@ -183,7 +191,19 @@ func (vm *VM) Initialize(
 	vm.ctx = ctx
 	vm.appSender = appSender
 	vm.baseDB = db
-	vm.db = versiondb.New(db)
+
+	// If the journal is disabled, any entries left from when it was enabled
+	// are outdated.
+	var journal *chain.Journal
+	if avmConfig.RollbackJournalEnabled {
+		journal = chain.NewJournal(db, chain.DefaultJournalDepth)
+		vm.db = versiondb.New(journal)
+	} else {
+		if err := chain.ClearJournal(db); err != nil {
+			return fmt.Errorf("failed to clear rollback journal: %w", err)
+		}
+		vm.db = versiondb.New(db)
+	}
 	vm.assetToFxCache = &cache.LRU[ids.ID, set.Bits64]{Size: assetToFxCacheSize}
 
 	vm.pubsub = pubsub.New(ctx.Log)
@@ -222,6 +242,7 @@ func (vm *VM) Initialize(
 
 	state, err := states.New(
 		vm.db,
+		journal,
 		vm.parser,
 		vm.registerer,
 		avmConfig.ChecksumsEnabled,
@@ -390,6 +411,13 @@ func (*VM) VerifyHeightIndex(context.Context) error {
 	return nil
 }
 
+// Rollback reverts the accepted chain to the block accepted at [height].
+//
+// vm.ctx.Lock should be held
+func (vm *VM) Rollback(_ context.Context, height uint64) error {
+	return vm.chainManager.Rollback(height)
+}
+
 /*
  ******************************************************************************
  *********************************** DAG VM ***********************************
@@ -403,10 +431,11 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		return err
 	}
 
-	mempool, err := mempool.New("mempool", vm.registerer, toEngine)
+	mempool, err := mempool.New("mempool", vm.registerer, toEngine, &vm.clock)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	vm.mempool = mempool
 
 	vm.chainManager = blockexecutor.NewManager(
 		mempool,
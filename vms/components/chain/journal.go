@@ -0,0 +1,318 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// DefaultJournalDepth is the number of most recently accepted heights that can
+// be reverted by default.
+const DefaultJournalDepth = 1024
+
+// clearJournalWriteSize is the size of the batches that journal entries are
+// deleted in when the journal is cleared.
+const clearJournalWriteSize = 8 * units.KiB
+
+const (
+	absentValue byte = iota
+	presentValue
+)
+
+var (
+	_ database.Database = (*Journal)(nil)
+	_ database.Batch    = (*journalBatch)(nil)
+
+	// Journal entries are stored in the journaled database, under prefixes
+	// that are hashed like the prefixes of prefixdb so that they don't
+	// collide with the keys of prefixed databases.
+	entryPrefix    = hashing.ComputeHash256([]byte("journalEntry"))
+	startHeightKey = hashing.ComputeHash256([]byte("journalStartHeight"))
+
+	ErrNotJournaled = errors.New("height is not journaled")
+
+	errMalformedEntry = errors.New("malformed journal entry")
+)
+
+// Journal wraps a database and records, for every height, the values that the
+// writes made at that height overwrote. This allows the database to be
+// reverted to the state it had when a recently accepted height was committed.
+//
+// Writes are attributed to the height provided to SetHeight. Writes made
+// before SetHeight is first called aren't journaled.
+type Journal struct {
+	database.Database
+
+	// depth is the number of heights that are kept in the journal.
+	depth uint64
+
+	lock       sync.RWMutex
+	journaling bool
+	height     uint64
+}
+
+// NewJournal returns a journal of the writes to [db] that keeps the entries of
+// the last [depth] heights.
+func NewJournal(db database.Database, depth uint64) *Journal {
+	return &Journal{
+		Database: db,
+		depth:    depth,
+	}
+}
+
+// SetHeight attributes the following writes to [height].
+func (j *Journal) SetHeight(height uint64) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.journaling = true
+	j.height = height
+}
+
+func (j *Journal) Put(key, value []byte) error {
+	batch := j.NewBatch()
+	if err := batch.Put(key, value); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (j *Journal) Delete(key []byte) error {
+	batch := j.NewBatch()
+	if err := batch.Delete(key); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// NewBatch returns a batch that journals the values its writes overwrite. The
+// journal entries are written to the same underlying batch as the writes, so
+// they are committed atomically with the writes, including when the batch is
+// replayed rather than written.
+//
+// The writes of the batch are attributed to the height of the journal when the
+// first write is made to the batch, or to the batch after it is reset.
+func (j *Journal) NewBatch() database.Batch {
+	return &journalBatch{
+		Batch:   j.Database.NewBatch(),
+		journal: j,
+	}
+}
+
+// Rollback reverts every write that was journaled at a height above [height].
+//
+// Returns [ErrNotJournaled] if some of the writes made above [height] may not
+// have been journaled.
+func (j *Journal) Rollback(height uint64) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	startHeight, err := database.GetUInt64(j.Database, startHeightKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w: %d", ErrNotJournaled, height)
+	}
+	if err != nil {
+		return err
+	}
+	if height < startHeight {
+		return fmt.Errorf("%w: %d < %d", ErrNotJournaled, height, startHeight)
+	}
+
+	var (
+		batch    = j.Database.NewBatch()
+		restored set.Set[string]
+		it       = j.Database.NewIteratorWithStartAndPrefix(
+			entryKey(height+1, nil),
+			entryPrefix,
+		)
+	)
+	defer it.Release()
+
+	// Entries are iterated in increasing height, so the first entry of a key
+	// holds the value the key had at [height].
+	for it.Next() {
+		entry := it.Key()
+		key := entry[len(entryPrefix)+wrappers.LongLen:]
+		if !restored.Contains(string(key)) {
+			restored.Add(string(key))
+			if err := restore(batch, slices.Clone(key), it.Value()); err != nil {
+				return err
+			}
+		}
+		if err := batch.Delete(slices.Clone(entry)); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	j.journaling = true
+	j.height = height
+	return nil
+}
+
+// ClearJournal deletes the journal entries stored in [db]. It must be called
+// when [db] is no longer journaled, so that a journal created over [db] later
+// doesn't revert writes based on outdated entries.
+func ClearJournal(db database.Database) error {
+	// The start height is deleted first so that the remaining entries are
+	// never used if clearing them is interrupted.
+	if err := db.Delete(startHeightKey); err != nil {
+		return err
+	}
+	return database.ClearPrefix(db, entryPrefix, clearJournalWriteSize)
+}
+
+func restore(w database.KeyValueWriterDeleter, key, entry []byte) error {
+	if len(entry) == 0 {
+		return fmt.Errorf("%w of key %x", errMalformedEntry, key)
+	}
+	if entry[0] == absentValue {
+		return w.Delete(key)
+	}
+	return w.Put(key, slices.Clone(entry[1:]))
+}
+
+func entryKey(height uint64, key []byte) []byte {
+	entry := make([]byte, 0, len(entryPrefix)+wrappers.LongLen+len(key))
+	entry = append(entry, entryPrefix...)
+	entry = binary.BigEndian.AppendUint64(entry, height)
+	return append(entry, key...)
+}
+
+type journalBatch struct {
+	database.Batch
+	journal *Journal
+
+	// initialized is true once the height of the batch has been set and the
+	// entries that are too old to be kept have been deleted in this batch.
+	initialized bool
+	journaling  bool
+	height      uint64
+
+	// journaled contains the keys whose previous values were journaled in
+	// this batch.
+	journaled set.Set[string]
+}
+
+func (b *journalBatch) Put(key, value []byte) error {
+	if err := b.record(key); err != nil {
+		return err
+	}
+	return b.Batch.Put(key, value)
+}
+
+func (b *journalBatch) Delete(key []byte) error {
+	if err := b.record(key); err != nil {
+		return err
+	}
+	return b.Batch.Delete(key)
+}
+
+func (b *journalBatch) Reset() {
+	b.Batch.Reset()
+	b.initialized = false
+	b.journaled = nil
+}
+
+// record journals the value of [key] before the first write to [key] at this
+// batch's height.
+func (b *journalBatch) record(key []byte) error {
+	if err := b.initialize(); err != nil {
+		return err
+	}
+	if !b.journaling || b.journaled.Contains(string(key)) {
+		return nil
+	}
+	b.journaled.Add(string(key))
+
+	db := b.journal.Database
+	entry := entryKey(b.height, key)
+	// If [key] was already written by an earlier batch at this height, its
+	// value before this height is already journaled.
+	if has, err := db.Has(entry); err != nil || has {
+		return err
+	}
+
+	value, err := db.Get(key)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return b.Batch.Put(entry, []byte{absentValue})
+	case err != nil:
+		return err
+	default:
+		return b.Batch.Put(entry, append([]byte{presentValue}, value...))
+	}
+}
+
+// initialize sets the height of the batch. If the batch is journaled, the
+// entries of the heights that are no longer kept are deleted and the lowest
+// height that can be rolled back to is recorded.
+func (b *journalBatch) initialize() error {
+	if b.initialized {
+		return nil
+	}
+	b.initialized = true
+
+	b.journal.lock.RLock()
+	b.journaling = b.journal.journaling
+	b.height = b.journal.height
+	b.journal.lock.RUnlock()
+	if !b.journaling {
+		return nil
+	}
+
+	db := b.journal.Database
+	startHeight, err := database.GetUInt64(db, startHeightKey)
+	if errors.Is(err, database.ErrNotFound) {
+		// The writes made before this height weren't journaled.
+		return database.PutUInt64(b.Batch, startHeightKey, b.height)
+	}
+	if err != nil {
+		return err
+	}
+	if b.height < b.journal.depth {
+		return nil
+	}
+
+	oldestHeight := b.height - b.journal.depth
+	if startHeight >= oldestHeight {
+		return nil
+	}
+
+	// The entries below [startHeight] have already been deleted, so they are
+	// skipped.
+	it := db.NewIteratorWithStartAndPrefix(entryKey(startHeight, nil), entryPrefix)
+	defer it.Release()
+
+	for it.Next() {
+		entry := it.Key()
+		height := binary.BigEndian.Uint64(entry[len(entryPrefix):])
+		if height > oldestHeight {
+			break
+		}
+		if err := b.Batch.Delete(slices.Clone(entry)); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return database.PutUInt64(b.Batch, startHeightKey, oldestHeight)
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestJournalInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, NewJournal(memdb.New(), DefaultJournalDepth))
+	}
+}
+
+func TestJournalRollback(t *testing.T) {
+	require := require.New(t)
+
+	db := NewJournal(memdb.New(), DefaultJournalDepth)
+
+	// Writes made before a height is set aren't journaled.
+	require.NoError(db.Put([]byte("a"), []byte("0")))
+
+	db.SetHeight(1)
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+	require.NoError(db.Put([]byte("b"), []byte("1")))
+
+	db.SetHeight(2)
+	require.NoError(db.Put([]byte("a"), []byte("2")))
+	require.NoError(db.Delete([]byte("b")))
+	require.NoError(db.Put([]byte("c"), []byte("2")))
+
+	db.SetHeight(3)
+	require.NoError(db.Put([]byte("a"), []byte("3")))
+
+	require.NoError(db.Rollback(1))
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("1"), value)
+
+	value, err = db.Get([]byte("b"))
+	require.NoError(err)
+	require.Equal([]byte("1"), value)
+
+	_, err = db.Get([]byte("c"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The writes of height 1 may have overwritten writes that weren't
+	// journaled.
+	err = db.Rollback(0)
+	require.ErrorIs(err, ErrNotJournaled)
+
+	// Writes made after the rollback are journaled at the rolled back height.
+	require.NoError(db.Put([]byte("a"), []byte("4")))
+	db.SetHeight(2)
+	require.NoError(db.Put([]byte("a"), []byte("5")))
+	require.NoError(db.Rollback(1))
+
+	value, err = db.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("4"), value)
+}
+
+func TestJournalRollbackCommittedBatches(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		journal = NewJournal(memdb.New(), DefaultJournalDepth)
+		db      = versiondb.New(journal)
+	)
+
+	journal.SetHeight(0)
+	require.NoError(db.Put([]byte("a"), []byte("0")))
+	require.NoError(db.Commit())
+
+	// Both commits at height 1 are reverted to the value the key had at
+	// height 0.
+	journal.SetHeight(1)
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+	require.NoError(db.Commit())
+	require.NoError(db.Put([]byte("a"), []byte("2")))
+	batch, err := db.CommitBatch()
+	require.NoError(err)
+
+	// Replaying the batch writes the journal entries along with the writes.
+	require.NoError(batch.Replay(journal.Database))
+	db.Abort()
+
+	require.NoError(journal.Rollback(0))
+
+	value, err := db.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("0"), value)
+}
+
+func TestJournalDepth(t *testing.T) {
+	require := require.New(t)
+
+	db := NewJournal(memdb.New(), 2)
+	for height := uint64(1); height <= 5; height++ {
+		db.SetHeight(height)
+		require.NoError(db.Put([]byte("a"), database.PackUInt64(height)))
+	}
+
+	err := db.Rollback(2)
+	require.ErrorIs(err, ErrNotJournaled)
+
+	require.NoError(db.Rollback(3))
+
+	value, err := database.GetUInt64(db, []byte("a"))
+	require.NoError(err)
+	require.Equal(uint64(3), value)
+}
+
+func TestClearJournal(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := NewJournal(baseDB, DefaultJournalDepth)
+	for height := uint64(1); height <= 3; height++ {
+		db.SetHeight(height)
+		require.NoError(db.Put([]byte("a"), database.PackUInt64(height)))
+	}
+
+	require.NoError(ClearJournal(baseDB))
+
+	// Only the journaled database is left.
+	count, err := database.Count(baseDB)
+	require.NoError(err)
+	require.Equal(1, count)
+
+	value, err := database.GetUInt64(baseDB, []byte("a"))
+	require.NoError(err)
+	require.Equal(uint64(3), value)
+
+	// A new journal only reverts the writes it journaled.
+	db = NewJournal(baseDB, DefaultJournalDepth)
+	err = db.Rollback(1)
+	require.ErrorIs(err, ErrNotJournaled)
+}
+
+// BenchmarkJournalCommit measures the cost of journaling the writes that are
+// committed when a block is accepted.
+func BenchmarkJournalCommit(b *testing.B) {
+	for _, keys := range []int{16, 256} {
+		b.Run(fmt.Sprintf("keys=%d/journal=false", keys), func(b *testing.B) {
+			db := newBenchmarkDB(b)
+			benchmarkCommit(b, db, nil, keys)
+		})
+		b.Run(fmt.Sprintf("keys=%d/journal=true", keys), func(b *testing.B) {
+			journal := NewJournal(newBenchmarkDB(b), DefaultJournalDepth)
+			benchmarkCommit(b, journal, journal, keys)
+		})
+	}
+}
+
+func newBenchmarkDB(b *testing.B) database.Database {
+	require := require.New(b)
+
+	db, err := leveldb.New(
+		b.TempDir(),
+		nil,
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	b.Cleanup(func() {
+		require.NoError(db.Close())
+	})
+	return db
+}
+
+func benchmarkCommit(b *testing.B, db database.Database, journal *Journal, keys int) {
+	require := require.New(b)
+
+	vdb := versiondb.New(db)
+	value := make([]byte, 32)
+	b.ResetTimer()
+	for height := uint64(0); height < uint64(b.N); height++ {
+		if journal != nil {
+			journal.SetHeight(height)
+		}
+		// Half of the keys are overwritten and half of them are new.
+		for i := 0; i < keys; i++ {
+			key := database.PackUInt64(height*uint64(keys)/2 + uint64(i))
+			require.NoError(vdb.Put(key, value))
+		}
+		require.NoError(vdb.Commit())
+	}
+}
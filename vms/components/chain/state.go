@@ -49,6 +49,9 @@ type State struct {
 	// getStatus returns the status of the block
 	getStatus func(context.Context, snowman.Block) (choices.Status, error)
 
+	// If nil, [Rollback] returns [block.ErrRollbackableVMNotImplemented].
+	rollback func(context.Context, uint64) (snowman.Block, error)
+
 	// verifiedBlocks is a map of blocks that have been verified and are
 	// therefore currently in consensus.
 	verifiedBlocks map[ids.ID]*BlockWrapper
@@ -76,6 +79,9 @@ type Config struct {
 	BuildBlock            func(context.Context) (snowman.Block, error)
 	BuildBlockWithContext func(context.Context, *block.Context) (snowman.Block, error)
 	GetBlockIDAtHeight    func(context.Context, uint64) (ids.ID, error)
+	// Rollback rewinds the VM's persisted last accepted block and height index
+	// to the provided height and returns the new last accepted block.
+	Rollback func(context.Context, uint64) (snowman.Block, error)
 }
 
 // Block is an interface wrapping the normal snowman.Block interface to be used in
@@ -130,6 +136,7 @@ func (s *State) initialize(config *Config) {
 	s.buildBlock = config.BuildBlock
 	s.buildBlockWithContext = config.BuildBlockWithContext
 	s.unmarshalBlock = config.UnmarshalBlock
+	s.rollback = config.Rollback
 	s.batchedUnmarshalBlock = config.BatchedUnmarshalBlock
 	if config.GetBlockIDAtHeight == nil {
 		s.getStatus = func(_ context.Context, blk snowman.Block) (choices.Status, error) {
@@ -222,7 +229,11 @@ func NewMeteredState(
 	return c, nil
 }
 
-var errSetAcceptedWithProcessing = errors.New("cannot set last accepted block with blocks processing")
+var (
+	errSetAcceptedWithProcessing = errors.New("cannot set last accepted block with blocks processing")
+	errRollbackWithProcessing    = errors.New("cannot roll back with blocks processing")
+	errRollbackAboveLastAccepted = errors.New("cannot roll back above the last accepted block")
+)
 
 // SetLastAcceptedBlock sets the last accepted block to [lastAcceptedBlock].
 // This should be called with an internal block - not a wrapped block returned
@@ -252,6 +263,35 @@ func (s *State) SetLastAcceptedBlock(lastAcceptedBlock snowman.Block) error {
 	return nil
 }
 
+// Rollback rewinds the last accepted block to the block accepted at [height].
+//
+// Every cache is flushed, as cached blocks may still report having been
+// accepted.
+func (s *State) Rollback(ctx context.Context, height uint64) error {
+	if s.rollback == nil {
+		return block.ErrRollbackableVMNotImplemented
+	}
+	if len(s.verifiedBlocks) != 0 {
+		return fmt.Errorf("%w: %d", errRollbackWithProcessing, len(s.verifiedBlocks))
+	}
+
+	lastAcceptedHeight := s.lastAcceptedBlock.Height()
+	if height > lastAcceptedHeight {
+		return fmt.Errorf("%w: %d > %d", errRollbackAboveLastAccepted, height, lastAcceptedHeight)
+	}
+	if height == lastAcceptedHeight {
+		return nil
+	}
+
+	lastAcceptedBlock, err := s.rollback(ctx, height)
+	if err != nil {
+		return err
+	}
+
+	s.Flush()
+	return s.SetLastAcceptedBlock(lastAcceptedBlock)
+}
+
 // Flush each block cache
 func (s *State) Flush() {
 	s.decidedBlocks.Flush()
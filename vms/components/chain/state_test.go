@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/metric"
 )
@@ -728,6 +729,76 @@ func TestSetLastAcceptedBlockWithProcessingBlocksErrors(t *testing.T) {
 	require.ErrorIs(err, errSetAcceptedWithProcessing)
 }
 
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	testBlks := NewTestBlocks(4)
+	for _, blk := range testBlks {
+		blk.SetStatus(choices.Accepted)
+	}
+	lastAcceptedBlk := testBlks[3]
+
+	getBlock, parseBlock, getCanonicalBlockID := createInternalBlockFuncs(t, testBlks)
+	rollback := func(_ context.Context, height uint64) (snowman.Block, error) {
+		for _, blk := range testBlks[height+1:] {
+			blk.SetStatus(choices.Processing)
+		}
+		return testBlks[height], nil
+	}
+
+	chainState := NewState(&Config{
+		DecidedCacheSize:    2,
+		MissingCacheSize:    2,
+		UnverifiedCacheSize: 2,
+		BytesToIDCacheSize:  2,
+		LastAcceptedBlock:   lastAcceptedBlk,
+		GetBlock:            getBlock,
+		UnmarshalBlock:      parseBlock,
+		BuildBlock:          cantBuildBlock,
+		GetBlockIDAtHeight:  getCanonicalBlockID,
+		Rollback:            rollback,
+	})
+
+	// Cache the last accepted block as accepted
+	parsedBlk, err := chainState.ParseBlock(context.Background(), lastAcceptedBlk.Bytes())
+	require.NoError(err)
+	checkAcceptedBlock(t, chainState, parsedBlk, true)
+
+	err = chainState.Rollback(context.Background(), 4)
+	require.ErrorIs(err, errRollbackAboveLastAccepted)
+
+	require.NoError(chainState.Rollback(context.Background(), 1))
+	lastAcceptedID, err := chainState.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(testBlks[1].ID(), lastAcceptedID)
+
+	// The rolled back blocks are no longer reported as accepted
+	parsedBlk, err = chainState.ParseBlock(context.Background(), lastAcceptedBlk.Bytes())
+	require.NoError(err)
+	checkProcessingBlock(t, chainState, parsedBlk)
+}
+
+func TestRollbackNotImplemented(t *testing.T) {
+	require := require.New(t)
+
+	testBlks := NewTestBlocks(2)
+	for _, blk := range testBlks {
+		blk.SetStatus(choices.Accepted)
+	}
+
+	getBlock, parseBlock, getCanonicalBlockID := createInternalBlockFuncs(t, testBlks)
+	chainState := NewState(&Config{
+		LastAcceptedBlock:  testBlks[1],
+		GetBlock:           getBlock,
+		UnmarshalBlock:     parseBlock,
+		BuildBlock:         cantBuildBlock,
+		GetBlockIDAtHeight: getCanonicalBlockID,
+	})
+
+	err := chainState.Rollback(context.Background(), 0)
+	require.ErrorIs(err, block.ErrRollbackableVMNotImplemented)
+}
+
 func TestStateParseTransitivelyAcceptedBlock(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gas
+
+import (
+	"math"
+	"math/big"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// PriceDenominator is the denominator of the price. A price equal to
+// PriceDenominator charges the static fee of a tx, which is also the lowest
+// fee a tx can be charged.
+const PriceDenominator = 1_000_000
+
+// MaxBlockGasMultiplier is the multiple of [Config.TargetBlockGas] that a block
+// can consume, like the elasticity multiplier of EIP-1559.
+const MaxBlockGasMultiplier = 2
+
+var (
+	bigPriceDenominator = new(big.Int).SetUint64(PriceDenominator)
+	bigMaxUint64        = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+// Config describes how the price reacts to the amount of gas consumed by
+// blocks, similarly to EIP-1559.
+//
+// The zero value never changes the price, so the static fees are always
+// charged unchanged.
+type Config struct {
+	// TargetBlockGas is the amount of gas a block is expected to consume. The
+	// price increases after a block that consumes more gas than the target and
+	// decreases after a block that consumes less.
+	TargetBlockGas uint64 `json:"targetBlockGas"`
+
+	// MaxPrice is the highest the price can reach. Values lower than
+	// [PriceDenominator] are treated as [PriceDenominator].
+	MaxPrice uint64 `json:"maxPrice"`
+
+	// ChangeDenominator bounds the change of the price after a single block to
+	// 1/ChangeDenominator of the price.
+	ChangeDenominator uint64 `json:"changeDenominator"`
+}
+
+// Price returns [price] bounded by [PriceDenominator] and [MaxPrice].
+func (c Config) Price(price uint64) uint64 {
+	switch {
+	case price < PriceDenominator:
+		return PriceDenominator
+	case price > c.MaxPrice:
+		return safemath.Max(c.MaxPrice, PriceDenominator)
+	default:
+		return price
+	}
+}
+
+// MaxBlockGas returns the most gas that a block should consume. If
+// [TargetBlockGas] is 0, the gas of a block isn't limited.
+func (c Config) MaxBlockGas() uint64 {
+	if c.TargetBlockGas == 0 {
+		return math.MaxUint64
+	}
+	gas, err := safemath.Mul64(c.TargetBlockGas, MaxBlockGasMultiplier)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return gas
+}
+
+// NextPrice returns the price after a block that consumed [gas] was accepted
+// on top of a chain with the provided [price].
+func (c Config) NextPrice(price uint64, gas uint64) uint64 {
+	price = c.Price(price)
+	if c.TargetBlockGas == 0 || c.ChangeDenominator == 0 || gas == c.TargetBlockGas {
+		return price
+	}
+
+	// delta = price * |gas - target| / target / changeDenominator
+	var gasDelta uint64
+	if gas > c.TargetBlockGas {
+		gasDelta = gas - c.TargetBlockGas
+	} else {
+		gasDelta = c.TargetBlockGas - gas
+	}
+	delta := new(big.Int).SetUint64(price)
+	delta.Mul(delta, new(big.Int).SetUint64(gasDelta))
+	delta.Div(delta, new(big.Int).SetUint64(c.TargetBlockGas))
+	delta.Div(delta, new(big.Int).SetUint64(c.ChangeDenominator))
+
+	if gas < c.TargetBlockGas {
+		// delta <= price / changeDenominator, so this can't underflow.
+		return c.Price(price - delta.Uint64())
+	}
+
+	// Like EIP-1559, the price always increases after a block that consumed
+	// more gas than the target.
+	if delta.Sign() == 0 {
+		delta.SetUint64(1)
+	}
+	if !delta.IsUint64() {
+		return c.Price(math.MaxUint64)
+	}
+	nextPrice, err := safemath.Add64(price, delta.Uint64())
+	if err != nil {
+		return c.Price(math.MaxUint64)
+	}
+	return c.Price(nextPrice)
+}
+
+// Fee returns [staticFee] scaled by [price], rounded up.
+func (c Config) Fee(staticFee uint64, price uint64) (uint64, error) {
+	fee := new(big.Int).SetUint64(staticFee)
+	fee.Mul(fee, new(big.Int).SetUint64(c.Price(price)))
+	fee.Add(fee, big.NewInt(PriceDenominator-1))
+	fee.Div(fee, bigPriceDenominator)
+	if fee.Cmp(bigMaxUint64) > 0 {
+		return 0, safemath.ErrOverflow
+	}
+	return fee.Uint64(), nil
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var testConfig = Config{
+	TargetBlockGas:    1_000,
+	MaxPrice:          10 * PriceDenominator,
+	ChangeDenominator: 8,
+}
+
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		price         uint64
+		expectedPrice uint64
+	}{
+		{
+			name:          "zero config",
+			config:        Config{},
+			price:         2 * PriceDenominator,
+			expectedPrice: PriceDenominator,
+		},
+		{
+			name:          "below min",
+			config:        testConfig,
+			price:         0,
+			expectedPrice: PriceDenominator,
+		},
+		{
+			name:          "above max",
+			config:        testConfig,
+			price:         math.MaxUint64,
+			expectedPrice: testConfig.MaxPrice,
+		},
+		{
+			name:          "in range",
+			config:        testConfig,
+			price:         2 * PriceDenominator,
+			expectedPrice: 2 * PriceDenominator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedPrice, test.config.Price(test.price))
+		})
+	}
+}
+
+func TestMaxBlockGas(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedGas uint64
+	}{
+		{
+			name:        "zero config",
+			config:      Config{},
+			expectedGas: math.MaxUint64,
+		},
+		{
+			name:        "multiple of target",
+			config:      testConfig,
+			expectedGas: MaxBlockGasMultiplier * testConfig.TargetBlockGas,
+		},
+		{
+			name: "overflow",
+			config: Config{
+				TargetBlockGas: math.MaxUint64,
+			},
+			expectedGas: math.MaxUint64,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedGas, test.config.MaxBlockGas())
+		})
+	}
+}
+
+func TestNextPrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		price         uint64
+		gas           uint64
+		expectedPrice uint64
+	}{
+		{
+			name:          "zero config",
+			config:        Config{},
+			price:         PriceDenominator,
+			gas:           math.MaxUint64,
+			expectedPrice: PriceDenominator,
+		},
+		{
+			name:          "at target",
+			config:        testConfig,
+			price:         2 * PriceDenominator,
+			gas:           testConfig.TargetBlockGas,
+			expectedPrice: 2 * PriceDenominator,
+		},
+		{
+			name:          "double target",
+			config:        testConfig,
+			price:         8 * PriceDenominator,
+			gas:           2 * testConfig.TargetBlockGas,
+			expectedPrice: 9 * PriceDenominator,
+		},
+		{
+			name:          "empty block",
+			config:        testConfig,
+			price:         8 * PriceDenominator,
+			gas:           0,
+			expectedPrice: 7 * PriceDenominator,
+		},
+		{
+			name:          "empty block at min price",
+			config:        testConfig,
+			price:         PriceDenominator,
+			gas:           0,
+			expectedPrice: PriceDenominator,
+		},
+		{
+			name:          "always increases above target",
+			config:        testConfig,
+			price:         PriceDenominator,
+			gas:           testConfig.TargetBlockGas + 1,
+			expectedPrice: PriceDenominator + 125,
+		},
+		{
+			name: "minimal increase",
+			config: Config{
+				TargetBlockGas:    math.MaxUint64 - 1,
+				MaxPrice:          math.MaxUint64,
+				ChangeDenominator: 8,
+			},
+			price:         PriceDenominator,
+			gas:           math.MaxUint64,
+			expectedPrice: PriceDenominator + 1,
+		},
+		{
+			name:          "capped at max",
+			config:        testConfig,
+			price:         testConfig.MaxPrice,
+			gas:           math.MaxUint64,
+			expectedPrice: testConfig.MaxPrice,
+		},
+		{
+			name: "overflow",
+			config: Config{
+				TargetBlockGas:    1,
+				MaxPrice:          math.MaxUint64,
+				ChangeDenominator: 1,
+			},
+			price:         math.MaxUint64 / 2,
+			gas:           3,
+			expectedPrice: math.MaxUint64,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedPrice, test.config.NextPrice(test.price, test.gas))
+		})
+	}
+}
+
+func TestFee(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		staticFee   uint64
+		price       uint64
+		expectedFee uint64
+		expectedErr error
+	}{
+		{
+			name:        "zero config",
+			config:      Config{},
+			staticFee:   100,
+			price:       10 * PriceDenominator,
+			expectedFee: 100,
+		},
+		{
+			name:        "scaled",
+			config:      testConfig,
+			staticFee:   100,
+			price:       3 * PriceDenominator / 2,
+			expectedFee: 150,
+		},
+		{
+			name:        "rounded up",
+			config:      testConfig,
+			staticFee:   1,
+			price:       PriceDenominator + 1,
+			expectedFee: 2,
+		},
+		{
+			name: "overflow",
+			config: Config{
+				MaxPrice: math.MaxUint64,
+			},
+			staticFee:   math.MaxUint64,
+			price:       2 * PriceDenominator,
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			fee, err := test.config.Fee(test.staticFee, test.price)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedFee, fee)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) Rollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackableVMNotImplemented
+	}
+	return vm.rollbackVM.Rollback(ctx, height)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"go.uber.org/zap"
@@ -18,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
@@ -39,6 +41,23 @@ var (
 	ErrChainNotSynced  = errors.New("chain not synced")
 )
 
+// TxVerificationError is returned by VerifyTx when a tx is invalid on top of
+// the preferred block.
+type TxVerificationError struct {
+	TxID ids.ID
+	// ParentID is the preferred block the tx was verified on top of.
+	ParentID ids.ID
+	Err      error
+}
+
+func (e *TxVerificationError) Error() string {
+	return fmt.Sprintf("tx %s failed verification on top of %s: %s", e.TxID, e.ParentID, e.Err)
+}
+
+func (e *TxVerificationError) Unwrap() error {
+	return e.Err
+}
+
 type Builder interface {
 	mempool.Mempool
 	mempool.BlockTimer
@@ -53,6 +72,11 @@ type Builder interface {
 	// AddUnverifiedTx verifier the tx before adding it to mempool
 	AddUnverifiedTx(tx *txs.Tx) error
 
+	// VerifyTx verifies the tx against the preferred state without adding it
+	// to the mempool. If the tx is invalid, a *TxVerificationError is
+	// returned.
+	VerifyTx(tx *txs.Tx) error
+
 	// BuildBlock is called on timer clock to attempt to create
 	// next block
 	BuildBlock(context.Context) (snowman.Block, error)
@@ -136,13 +160,7 @@ func (b *builder) AddUnverifiedTx(tx *txs.Tx) error {
 		return nil
 	}
 
-	verifier := txexecutor.MempoolTxVerifier{
-		Backend:       b.txExecutorBackend,
-		ParentID:      b.preferredBlockID, // We want to build off of the preferred block
-		StateVersions: b.blkManager,
-		Tx:            tx,
-	}
-	if err := tx.Unsigned.Visit(&verifier); err != nil {
+	if err := b.verifyTx(tx); err != nil {
 		b.MarkDropped(txID, err)
 		return err
 	}
@@ -157,6 +175,31 @@ func (b *builder) AddUnverifiedTx(tx *txs.Tx) error {
 	return b.GossipTx(tx)
 }
 
+func (b *builder) VerifyTx(tx *txs.Tx) error {
+	if !b.txExecutorBackend.Bootstrapped.Get() {
+		return ErrChainNotSynced
+	}
+
+	if err := b.verifyTx(tx); err != nil {
+		return &TxVerificationError{
+			TxID:     tx.ID(),
+			ParentID: b.preferredBlockID,
+			Err:      err,
+		}
+	}
+	return nil
+}
+
+func (b *builder) verifyTx(tx *txs.Tx) error {
+	verifier := txexecutor.MempoolTxVerifier{
+		Backend:       b.txExecutorBackend,
+		ParentID:      b.preferredBlockID, // We want to build off of the preferred block
+		StateVersions: b.blkManager,
+		Tx:            tx,
+	}
+	return tx.Unsigned.Visit(&verifier)
+}
+
 // BuildBlock builds a block to be added to consensus.
 // This method removes the transactions from the returned
 // blocks from the mempool.
@@ -381,10 +424,31 @@ func buildBlock(
 		timestamp,
 		parentID,
 		height,
-		builder.Mempool.PeekTxs(targetBlockSize),
+		builder.Mempool.PeekTxs(
+			targetBlockSize,
+			maxBlockGas(builder.txExecutorBackend.Config, timestamp),
+		),
 	)
 }
 
+// maxBlockGas returns the maximum amount of gas consumed by the transactions
+// placed into a StandardBlock with the provided [timestamp].
+//
+// Prior to the E upgrade, blocks are only limited by [targetBlockSize].
+// Afterwards, blocks are also limited to [gas.MaxBlockGasMultiplier] times the
+// target block gas of the dynamic fee config. Because the gas of a tx also
+// charges for its UTXO reads and writes, signatures and staker changes, a
+// block holds fewer txs than the size limit alone allows. For example, with the
+// target block gas of 64 KiB used by Mainnet and Fuji, a block holds 149 txs
+// that each spend one UTXO to send AVAX, compared to 345 such txs prior to the
+// E upgrade.
+func maxBlockGas(cfg *config.Config, timestamp time.Time) uint64 {
+	if !cfg.IsEActivated(timestamp) {
+		return math.MaxUint64
+	}
+	return cfg.DynamicFeeConfig.MaxBlockGas()
+}
+
 // getNextStakerToReward returns the next staker txID to remove from the staking
 // set with a RewardValidatorTx rather than an AdvanceTimeTx. [chainTimestamp]
 // is the timestamp of the chain at the time this validator would be getting
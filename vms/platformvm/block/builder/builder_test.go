@@ -6,6 +6,7 @@ package builder
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -18,11 +19,15 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -66,6 +71,38 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 	require.False(env.mempool.Has(txID))
 }
 
+func TestBlockBuilderVerifyTx(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	tx := getValidTx(env.txBuilder, t)
+	require.NoError(env.Builder.VerifyTx(tx))
+
+	// Verifying the tx doesn't add it to the mempool.
+	require.False(env.mempool.Has(tx.ID()))
+
+	// Without its credentials, the tx isn't authorized by the subnet.
+	unsignedTx := &txs.Tx{Unsigned: tx.Unsigned}
+	require.NoError(unsignedTx.Initialize(txs.Codec))
+
+	err := env.Builder.VerifyTx(unsignedTx)
+	var verificationErr *TxVerificationError
+	require.ErrorAs(err, &verificationErr)
+	require.Equal(unsignedTx.ID(), verificationErr.TxID)
+	preferred, err := env.Builder.Preferred()
+	require.NoError(err)
+	require.Equal(preferred.ID(), verificationErr.ParentID)
+
+	env.isBootstrapped.Set(false)
+	err = env.Builder.VerifyTx(tx)
+	require.ErrorIs(err, ErrChainNotSynced)
+}
+
 func TestPreviouslyDroppedTxsCanBeReAddedToMempool(t *testing.T) {
 	require := require.New(t)
 
@@ -403,9 +440,14 @@ func TestBuildBlock(t *testing.T) {
 				// There are txs.
 				mempool.EXPECT().HasStakerTx().Return(false)
 				mempool.EXPECT().HasTxs().Return(true)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return(transactions)
+				mempool.EXPECT().PeekTxs(targetBlockSize, uint64(math.MaxUint64)).Return(transactions)
 				return &builder{
 					Mempool: mempool,
+					txExecutorBackend: &txexecutor.Backend{
+						Config: &config.Config{
+							ETime: mockable.MaxTime,
+						},
+					},
 				}
 			},
 			timestamp:        parentTimestamp,
@@ -498,13 +540,16 @@ func TestBuildBlock(t *testing.T) {
 				// There are no txs.
 				mempool.EXPECT().HasStakerTx().Return(false)
 				mempool.EXPECT().HasTxs().Return(false)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return(nil)
+				mempool.EXPECT().PeekTxs(targetBlockSize, uint64(math.MaxUint64)).Return(nil)
 
 				clk := &mockable.Clock{}
 				clk.Set(now)
 				return &builder{
 					Mempool: mempool,
 					txExecutorBackend: &txexecutor.Backend{
+						Config: &config.Config{
+							ETime: mockable.MaxTime,
+						},
 						Clk: clk,
 					},
 				}
@@ -553,13 +598,16 @@ func TestBuildBlock(t *testing.T) {
 				// There is a tx.
 				mempool.EXPECT().HasStakerTx().Return(false)
 				mempool.EXPECT().HasTxs().Return(true)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return([]*txs.Tx{transactions[0]})
+				mempool.EXPECT().PeekTxs(targetBlockSize, uint64(math.MaxUint64)).Return([]*txs.Tx{transactions[0]})
 
 				clk := &mockable.Clock{}
 				clk.Set(now)
 				return &builder{
 					Mempool: mempool,
 					txExecutorBackend: &txexecutor.Backend{
+						Config: &config.Config{
+							ETime: mockable.MaxTime,
+						},
 						Clk: clk,
 					},
 				}
@@ -607,13 +655,16 @@ func TestBuildBlock(t *testing.T) {
 				// There is a staker tx.
 				mempool.EXPECT().HasStakerTx().Return(false)
 				mempool.EXPECT().HasTxs().Return(true)
-				mempool.EXPECT().PeekTxs(targetBlockSize).Return([]*txs.Tx{transactions[0]})
+				mempool.EXPECT().PeekTxs(targetBlockSize, uint64(math.MaxUint64)).Return([]*txs.Tx{transactions[0]})
 
 				clk := &mockable.Clock{}
 				clk.Set(now)
 				return &builder{
 					Mempool: mempool,
 					txExecutorBackend: &txexecutor.Backend{
+						Config: &config.Config{
+							ETime: mockable.MaxTime,
+						},
 						Clk: clk,
 					},
 				}
@@ -676,3 +727,90 @@ func TestBuildBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxBlockGas(t *testing.T) {
+	eTime := time.Unix(1_000, 0)
+	feeConfig := gas.Config{
+		TargetBlockGas: 64 * units.KiB,
+	}
+
+	tests := []struct {
+		name        string
+		config      *config.Config
+		timestamp   time.Time
+		expectedGas uint64
+	}{
+		{
+			name: "pre-E",
+			config: &config.Config{
+				ETime:            eTime,
+				DynamicFeeConfig: feeConfig,
+			},
+			timestamp:   eTime.Add(-time.Second),
+			expectedGas: math.MaxUint64,
+		},
+		{
+			name: "post-E",
+			config: &config.Config{
+				ETime:            eTime,
+				DynamicFeeConfig: feeConfig,
+			},
+			timestamp:   eTime,
+			expectedGas: gas.MaxBlockGasMultiplier * 64 * units.KiB,
+		},
+		{
+			name: "post-E without target",
+			config: &config.Config{
+				ETime: eTime,
+			},
+			timestamp:   eTime,
+			expectedGas: math.MaxUint64,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedGas, maxBlockGas(test.config, test.timestamp))
+		})
+	}
+}
+
+// Shows how many txs that each spend a single UTXO to send AVAX fit into a block
+// before and after the E upgrade.
+func TestMaxBlockGasCapacity(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	addr := preFundedKeys[0].PublicKey().Address()
+	tx, err := env.txBuilder.NewBaseTx(
+		1,
+		secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		},
+		[]*secp256k1.PrivateKey{preFundedKeys[0]},
+		addr,
+	)
+	require.NoError(err)
+	require.IsType(&txs.BaseTx{}, tx.Unsigned)
+	baseTx := tx.Unsigned.(*txs.BaseTx)
+	require.Len(baseTx.Ins, 1)
+	require.Len(baseTx.Outs, 2)
+
+	txGas, err := fee.Gas([]*txs.Tx{tx})
+	require.NoError(err)
+
+	cfg := &config.Config{
+		DynamicFeeConfig: gas.Config{
+			TargetBlockGas: 64 * units.KiB,
+		},
+	}
+	preETxs := targetBlockSize / len(tx.Bytes())
+	postETxs := maxBlockGas(cfg, time.Time{}) / txGas
+	require.Equal(345, preETxs)
+	require.Equal(uint64(149), postETxs)
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -188,8 +189,13 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 
 	metrics := metrics.Noop
 
+	var chainState state.Chain = res.state
+	if ctrl != nil {
+		chainState = res.mockedState
+	}
+
 	var err error
-	res.mempool, err = mempool.NewMempool("mempool", registerer, res)
+	res.mempool, err = mempool.NewMempool("mempool", registerer, res, fee.NewTipCalculator(res.config, res.ctx.AVAXAssetID, res.clk, chainState), res.clk)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
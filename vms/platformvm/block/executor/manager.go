@@ -4,17 +4,28 @@
 package executor
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validators"
 )
 
-var _ Manager = (*manager)(nil)
+var (
+	_ Manager = (*manager)(nil)
+
+	errRollbackWithProcessing    = errors.New("cannot roll back with blocks processing")
+	errRollbackAboveLastAccepted = errors.New("cannot roll back above the last accepted block")
+	errRollbackToProposalBlock   = errors.New("cannot roll back to a proposal block")
+	errRollbackAtomicTx          = errors.New("cannot roll back an atomic tx")
+)
 
 type Manager interface {
 	state.Versions
@@ -24,6 +35,12 @@ type Manager interface {
 	GetBlock(blkID ids.ID) (snowman.Block, error)
 	GetStatelessBlock(blkID ids.ID) (block.Block, error)
 	NewBlock(block.Block) snowman.Block
+
+	// Rollback reverts the accepted chain to the block accepted at [height].
+	//
+	// Blocks that issued atomic txs can't be rolled back, as the shared
+	// memory operations they performed aren't reverted.
+	Rollback(height uint64) error
 }
 
 func NewManager(
@@ -85,3 +102,51 @@ func (m *manager) NewBlock(blk block.Block) snowman.Block {
 		Block:   blk,
 	}
 }
+
+func (m *manager) Rollback(height uint64) error {
+	if len(m.blkIDToState) != 0 {
+		return fmt.Errorf("%w: %d", errRollbackWithProcessing, len(m.blkIDToState))
+	}
+
+	blk, err := m.state.GetStatelessBlock(m.lastAccepted)
+	if err != nil {
+		return err
+	}
+	lastAcceptedHeight := blk.Height()
+	if height > lastAcceptedHeight {
+		return fmt.Errorf("%w: %d > %d", errRollbackAboveLastAccepted, height, lastAcceptedHeight)
+	}
+	if height == lastAcceptedHeight {
+		return nil
+	}
+
+	for blk.Height() > height {
+		for _, tx := range blk.Txs() {
+			switch tx.Unsigned.(type) {
+			case *txs.ImportTx, *txs.ExportTx:
+				return fmt.Errorf("%w %s in block %s at height %d",
+					errRollbackAtomicTx,
+					tx.ID(),
+					blk.ID(),
+					blk.Height(),
+				)
+			}
+		}
+
+		blk, err = m.state.GetStatelessBlock(blk.Parent())
+		if err != nil {
+			return err
+		}
+	}
+	switch blk.(type) {
+	case *block.ApricotProposalBlock, *block.BanffProposalBlock:
+		// The engine requires the last accepted block to be a decision block.
+		return fmt.Errorf("%w: %s", errRollbackToProposalBlock, blk.ID())
+	}
+
+	if err := m.state.Rollback(height); err != nil {
+		return err
+	}
+	m.lastAccepted = m.state.GetLastAccepted()
+	return nil
+}
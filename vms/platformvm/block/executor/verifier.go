@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 )
 
 var (
@@ -373,6 +374,14 @@ func (v *verifier) proposalBlock(
 	onCommitState.AddTx(b.Tx, status.Committed)
 	onAbortState.AddTx(b.Tx, status.Aborted)
 
+	blkTxs := []*txs.Tx{b.Tx}
+	if err := v.updateFeePrice(onCommitState, blkTxs); err != nil {
+		return err
+	}
+	if err := v.updateFeePrice(onAbortState, blkTxs); err != nil {
+		return err
+	}
+
 	blkID := b.ID()
 	v.blkIDToState[blkID] = &blockState{
 		proposalBlockState: proposalBlockState{
@@ -445,6 +454,10 @@ func (v *verifier) standardBlock(
 		return err
 	}
 
+	if err := v.updateFeePrice(onAcceptState, b.Transactions); err != nil {
+		return err
+	}
+
 	if numFuncs := len(funcs); numFuncs == 1 {
 		blkState.onAcceptFunc = funcs[0]
 	} else if numFuncs > 1 {
@@ -462,6 +475,22 @@ func (v *verifier) standardBlock(
 	return nil
 }
 
+// updateFeePrice sets the fee price of [chainState] to the price that the
+// following block must pay, given that [blkTxs] were included on top of it.
+func (v *verifier) updateFeePrice(chainState state.Diff, blkTxs []*txs.Tx) error {
+	price, err := fee.NextPrice(
+		v.txExecutorBackend.Config,
+		chainState.GetTimestamp(),
+		chainState.GetFeePrice(),
+		blkTxs,
+	)
+	if err != nil {
+		return err
+	}
+	chainState.SetFeePrice(price)
+	return nil
+}
+
 // verifyUniqueInputs verifies that the inputs of the given block are not
 // duplicated in any of the parent blocks pinned in memory.
 func (v *verifier) verifyUniqueInputs(block block.Block, inputs set.Set[ids.ID]) error {
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
@@ -41,6 +42,7 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	timestamp := time.Now()
 	// One call for each of onCommitState and onAbortState.
 	parentOnAcceptState.EXPECT().GetTimestamp().Return(timestamp).Times(2)
+	parentOnAcceptState.EXPECT().GetFeePrice().Return(uint64(0)).Times(2)
 
 	backend := &backend{
 		lastAccepted: parentID,
@@ -72,6 +74,9 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 
 	blkTx := txs.NewMockUnsignedTx(ctrl)
 	blkTx.EXPECT().Visit(gomock.AssignableToTypeOf(&executor.ProposalTxExecutor{})).Return(nil).Times(1)
+	// The complexity of [blkTx] is calculated when updating the fee price of
+	// both the commit and abort states.
+	blkTx.EXPECT().Visit(gomock.Any()).Return(nil).Times(2)
 
 	// We can't serialize [blkTx] because it isn't
 	// registered with the blocks.Codec.
@@ -263,6 +268,8 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 			return nil
 		},
 	).Times(1)
+	// The complexity of [blkTx] is calculated when updating the fee price.
+	blkTx.EXPECT().Visit(gomock.Any()).Return(nil).Times(1)
 
 	// We can't serialize [blkTx] because it isn't
 	// registered with the blocks.Codec.
@@ -285,6 +292,7 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 	// Set expectations for dependencies.
 	timestamp := time.Now()
 	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
+	parentState.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
 	mempool.EXPECT().Remove(apricotBlk.Txs()).Times(1)
 
@@ -551,6 +559,7 @@ func TestBanffAbortBlockTimestampChecks(t *testing.T) {
 			parentTime := defaultGenesisTime
 			s.EXPECT().GetLastAccepted().Return(parentID).Times(2)
 			s.EXPECT().GetTimestamp().Return(parentTime).Times(2)
+			s.EXPECT().GetFeePrice().Return(uint64(0)).Times(2)
 
 			onCommitState, err := state.NewDiff(parentID, backend)
 			require.NoError(err)
@@ -644,6 +653,7 @@ func TestBanffCommitBlockTimestampChecks(t *testing.T) {
 			parentTime := defaultGenesisTime
 			s.EXPECT().GetLastAccepted().Return(parentID).Times(2)
 			s.EXPECT().GetTimestamp().Return(parentTime).Times(2)
+			s.EXPECT().GetFeePrice().Return(uint64(0)).Times(2)
 
 			onCommitState, err := state.NewDiff(parentID, backend)
 			require.NoError(err)
@@ -758,6 +768,7 @@ func TestVerifierVisitStandardBlockWithDuplicateInputs(t *testing.T) {
 	timestamp := time.Now()
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
 	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
+	parentState.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 	parentStatelessBlk.EXPECT().Parent().Return(grandParentID).Times(1)
 
 	err = verifier.ApricotStandardBlock(blk)
@@ -1053,3 +1064,52 @@ func TestVerifierVisitBanffAbortBlockUnexpectedParentState(t *testing.T) {
 	err = verifier.BanffAbortBlock(blk)
 	require.ErrorIs(err, state.ErrMissingParentState)
 }
+
+func TestVerifierUpdateFeePrice(t *testing.T) {
+	eTime := time.Unix(1_000, 0)
+	cfg := &config.Config{
+		ETime: eTime,
+		DynamicFeeConfig: gas.Config{
+			TargetBlockGas:    1,
+			MaxPrice:          10 * gas.PriceDenominator,
+			ChangeDenominator: 8,
+		},
+	}
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{}}
+	tx.SetBytes(nil, []byte{0, 1, 2})
+
+	tests := []struct {
+		name          string
+		timestamp     time.Time
+		expectedPrice uint64
+	}{
+		{
+			name:          "pre E",
+			timestamp:     eTime.Add(-time.Second),
+			expectedPrice: 0,
+		},
+		{
+			name:          "post E",
+			timestamp:     eTime,
+			expectedPrice: 5 * gas.PriceDenominator / 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			chainState := state.NewMockDiff(ctrl)
+			chainState.EXPECT().GetTimestamp().Return(test.timestamp)
+			chainState.EXPECT().GetFeePrice().Return(uint64(0))
+			chainState.EXPECT().SetFeePrice(test.expectedPrice)
+
+			verifier := &verifier{
+				txExecutorBackend: &executor.Backend{
+					Config: cfg,
+				},
+			}
+			verifier.updateFeePrice(chainState, []*txs.Tx{tx})
+		})
+	}
+}
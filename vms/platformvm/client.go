@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
 )
@@ -80,6 +81,18 @@ type Client interface {
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]interface{}, []interface{}, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system along with the P-chain height
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
+	// EstimateReward returns the reward that would be received for staking
+	// [amount] on [subnetID] for [duration]. If [delegationFeeRate], a
+	// percentage, is non-zero, the reward of a delegator paying that fee is
+	// estimated.
+	EstimateReward(
+		ctx context.Context,
+		subnetID ids.ID,
+		amount uint64,
+		duration time.Duration,
+		delegationFeeRate float32,
+		options ...rpc.Option,
+	) (*EstimateRewardReply, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -204,6 +217,18 @@ type Client interface {
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	// VerifyTx verifies the transaction against the preferred state without
+	// issuing it
+	VerifyTx(ctx context.Context, tx []byte, options ...rpc.Option) (*VerifyTxReply, error)
+	// GetTxComplexity returns the execution cost of the transaction
+	GetTxComplexity(ctx context.Context, tx []byte, options ...rpc.Option) (*GetTxComplexityReply, error)
+	// GetMempool returns up to [pageSize] of the txs pending in the mempool,
+	// ordered by txID, starting after the tx with ID [cursor]. A [pageSize] of
+	// 0 uses the maximum page size.
+	GetMempool(ctx context.Context, cursor ids.ID, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error)
+	// GetDroppedTxs returns the most recently dropped txs and the reasons they
+	// were dropped
+	GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]DroppedTx, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error)
 	// AwaitTxDecided polls [GetTxStatus] until a status is returned that
@@ -249,8 +274,21 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetStakingLedger returns up to [pageSize] of the staking records of
+	// [addr], starting at index [cursor]. A [pageSize] of 0 uses the maximum
+	// page size.
+	GetStakingLedger(
+		ctx context.Context,
+		addr ids.ShortID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) (*GetStakingLedgerReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetFeeState returns the current fee price and the parameters that
+	// determine how it changes.
+	GetFeeState(ctx context.Context, options ...rpc.Option) (*GetFeeStateReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
 	// subnet at the specified height.
 	GetValidatorsAt(
@@ -259,6 +297,16 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetValidatorSetDiff returns the changes to the validator set of a
+	// provided subnet between [startHeight] and [endHeight]. The range may
+	// span at most 1024 heights.
+	GetValidatorSetDiff(
+		ctx context.Context,
+		subnetID ids.ID,
+		startHeight uint64,
+		endHeight uint64,
+		options ...rpc.Option,
+	) ([]ValidatorDiff, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
@@ -390,6 +438,8 @@ type ClientSubnet struct {
 	// signatures from [Threshold] of these keys to be valid.
 	ControlKeys []ids.ShortID
 	Threshold   uint32
+	// Metadata set by the subnet owner, or nil if it was never set.
+	Metadata *txs.SubnetMetadata
 }
 
 func (c *client) GetSubnets(ctx context.Context, ids []ids.ID, options ...rpc.Option) ([]ClientSubnet, error) {
@@ -411,6 +461,7 @@ func (c *client) GetSubnets(ctx context.Context, ids []ids.ID, options ...rpc.Op
 			ID:          apiSubnet.ID,
 			ControlKeys: controlKeys,
 			Threshold:   uint32(apiSubnet.Threshold),
+			Metadata:    apiSubnet.Metadata,
 		}
 	}
 	return subnets, nil
@@ -463,6 +514,24 @@ func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options
 	return uint64(res.Supply), uint64(res.Height), err
 }
 
+func (c *client) EstimateReward(
+	ctx context.Context,
+	subnetID ids.ID,
+	amount uint64,
+	duration time.Duration,
+	delegationFeeRate float32,
+	options ...rpc.Option,
+) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateReward", &EstimateRewardArgs{
+		Amount:            json.Uint64(amount),
+		Duration:          json.Uint64(duration / time.Second),
+		DelegationFeeRate: json.Float32(delegationFeeRate),
+		SubnetID:          subnetID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "platform.sampleValidators", &SampleValidatorsArgs{
@@ -730,6 +799,49 @@ func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option)
 	return formatting.Decode(res.Encoding, res.Tx)
 }
 
+func (c *client) VerifyTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*VerifyTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &VerifyTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.verifyTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetTxComplexity(ctx context.Context, txBytes []byte, options ...rpc.Option) (*GetTxComplexityReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetTxComplexityReply{}
+	err = c.requester.SendRequest(ctx, "platform.getTxComplexity", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempool(ctx context.Context, cursor ids.ID, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", &GetMempoolArgs{
+		Cursor:   cursor,
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]DroppedTx, error) {
+	res := &GetDroppedTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDroppedTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
 func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error) {
 	res := &GetTxStatusResponse{}
 	err := c.requester.SendRequest(
@@ -849,12 +961,34 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetStakingLedger(
+	ctx context.Context,
+	addr ids.ShortID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) (*GetStakingLedgerReply, error) {
+	res := &GetStakingLedgerReply{}
+	err := c.requester.SendRequest(ctx, "platform.getStakingLedger", &GetStakingLedgerArgs{
+		Address:  addr.String(),
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
 	return res.Timestamp, err
 }
 
+func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (*GetFeeStateReply, error) {
+	res := &GetFeeStateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeState", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -869,6 +1003,22 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorSetDiff(
+	ctx context.Context,
+	subnetID ids.ID,
+	startHeight uint64,
+	endHeight uint64,
+	options ...rpc.Option,
+) ([]ValidatorDiff, error) {
+	res := &GetValidatorSetDiffReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorSetDiff", &GetValidatorSetDiffArgs{
+		SubnetID:    subnetID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res.Diffs, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -107,6 +108,13 @@ type Config struct {
 	// Time of the D network upgrade
 	DTime time.Time
 
+	// Time of the E network upgrade
+	ETime time.Time
+
+	// DynamicFeeConfig describes how fees react to the usage of the chain
+	// after the E network upgrade
+	DynamicFeeConfig gas.Config
+
 	// UseCurrentHeight forces [GetMinimumHeight] to return the current height
 	// of the P-Chain instead of the oldest block in the [recentlyAccepted]
 	// window.
@@ -138,6 +146,11 @@ func (c *Config) IsDActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.DTime)
 }
 
+// TODO: Rename
+func (c *Config) IsEActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.ETime)
+}
+
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
 	if c.IsApricotPhase3Activated(timestamp) {
 		return c.CreateBlockchainTxFee
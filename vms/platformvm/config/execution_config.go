@@ -19,6 +19,8 @@ var DefaultExecutionConfig = ExecutionConfig{
 	BlockIDCacheSize:             8192,
 	FxOwnerCacheSize:             4 * units.MiB,
 	ChecksumsEnabled:             false,
+	StakingLedgerEnabled:         false,
+	RollbackJournalEnabled:       false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -32,6 +34,13 @@ type ExecutionConfig struct {
 	BlockIDCacheSize             int  `json:"block-id-cache-size"`
 	FxOwnerCacheSize             int  `json:"fx-owner-cache-size"`
 	ChecksumsEnabled             bool `json:"checksums-enabled"`
+	// StakingLedgerEnabled records, per reward address, the outcome of every
+	// staking period that ends while it is enabled.
+	StakingLedgerEnabled bool `json:"staking-ledger-enabled"`
+	// RollbackJournalEnabled journals the state changes of the most recently
+	// accepted blocks so that the chain can be rolled back to one of them.
+	// Journaling slows down accepting blocks and uses additional disk space.
+	RollbackJournalEnabled bool `json:"rollback-journal-enabled"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
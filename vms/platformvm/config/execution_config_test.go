@@ -47,7 +47,9 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"chain-db-cache-size": 7,
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
-			"checksums-enabled": true
+			"checksums-enabled": true,
+			"staking-ledger-enabled": true,
+			"rollback-journal-enabled": true
 		}`)
 		ec, err := GetExecutionConfig(b)
 		require.NoError(err)
@@ -61,6 +63,8 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
 			ChecksumsEnabled:             true,
+			StakingLedgerEnabled:         true,
+			RollbackJournalEnabled:       true,
 		}
 		require.Equal(expected, ec)
 	})
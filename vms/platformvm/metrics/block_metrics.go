@@ -10,12 +10,14 @@ import (
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
 var _ block.Visitor = (*blockMetrics)(nil)
 
 type blockMetrics struct {
-	txMetrics *txMetrics
+	txMetrics         *txMetrics
+	complexityMetrics *complexityMetrics
 
 	numAbortBlocks,
 	numAtomicBlocks,
@@ -30,8 +32,11 @@ func newBlockMetrics(
 ) (*blockMetrics, error) {
 	txMetrics, err := newTxMetrics(namespace, registerer)
 	errs := wrappers.Errs{Err: err}
+	complexityMetrics, err := newComplexityMetrics(namespace, registerer)
+	errs.Add(err)
 	m := &blockMetrics{
 		txMetrics:         txMetrics,
+		complexityMetrics: complexityMetrics,
 		numAbortBlocks:    newBlockMetric(namespace, "abort", registerer, &errs),
 		numAtomicBlocks:   newBlockMetric(namespace, "atomic", registerer, &errs),
 		numCommitBlocks:   newBlockMetric(namespace, "commit", registerer, &errs),
@@ -69,17 +74,17 @@ func (m *blockMetrics) BanffCommitBlock(*block.BanffCommitBlock) error {
 func (m *blockMetrics) BanffProposalBlock(b *block.BanffProposalBlock) error {
 	m.numProposalBlocks.Inc()
 	for _, tx := range b.Transactions {
-		if err := tx.Unsigned.Visit(m.txMetrics); err != nil {
+		if err := m.markAccepted(tx); err != nil {
 			return err
 		}
 	}
-	return b.Tx.Unsigned.Visit(m.txMetrics)
+	return m.markAccepted(b.Tx)
 }
 
 func (m *blockMetrics) BanffStandardBlock(b *block.BanffStandardBlock) error {
 	m.numStandardBlocks.Inc()
 	for _, tx := range b.Transactions {
-		if err := tx.Unsigned.Visit(m.txMetrics); err != nil {
+		if err := m.markAccepted(tx); err != nil {
 			return err
 		}
 	}
@@ -98,13 +103,13 @@ func (m *blockMetrics) ApricotCommitBlock(*block.ApricotCommitBlock) error {
 
 func (m *blockMetrics) ApricotProposalBlock(b *block.ApricotProposalBlock) error {
 	m.numProposalBlocks.Inc()
-	return b.Tx.Unsigned.Visit(m.txMetrics)
+	return m.markAccepted(b.Tx)
 }
 
 func (m *blockMetrics) ApricotStandardBlock(b *block.ApricotStandardBlock) error {
 	m.numStandardBlocks.Inc()
 	for _, tx := range b.Transactions {
-		if err := tx.Unsigned.Visit(m.txMetrics); err != nil {
+		if err := m.markAccepted(tx); err != nil {
 			return err
 		}
 	}
@@ -113,5 +118,10 @@ func (m *blockMetrics) ApricotStandardBlock(b *block.ApricotStandardBlock) error
 
 func (m *blockMetrics) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 	m.numAtomicBlocks.Inc()
-	return b.Tx.Unsigned.Visit(m.txMetrics)
+	return m.markAccepted(b.Tx)
+}
+
+func (m *blockMetrics) markAccepted(tx *txs.Tx) error {
+	m.complexityMetrics.markAccepted(tx)
+	return tx.Unsigned.Visit(m.txMetrics)
 }
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/complexity"
+)
+
+type complexityMetrics struct {
+	bytes,
+	utxoReads,
+	utxoWrites,
+	signatures,
+	stakerChanges prometheus.Counter
+}
+
+func newComplexityMetrics(
+	namespace string,
+	registerer prometheus.Registerer,
+) (*complexityMetrics, error) {
+	errs := wrappers.Errs{}
+	m := &complexityMetrics{
+		bytes:         newComplexityMetric(namespace, "bytes", "bytes", registerer, &errs),
+		utxoReads:     newComplexityMetric(namespace, "utxo_reads", "UTXO reads", registerer, &errs),
+		utxoWrites:    newComplexityMetric(namespace, "utxo_writes", "UTXO writes", registerer, &errs),
+		signatures:    newComplexityMetric(namespace, "signatures", "signature verifications", registerer, &errs),
+		stakerChanges: newComplexityMetric(namespace, "staker_changes", "staker set changes", registerer, &errs),
+	}
+	return m, errs.Err
+}
+
+func newComplexityMetric(
+	namespace string,
+	name string,
+	description string,
+	registerer prometheus.Registerer,
+	errs *wrappers.Errs,
+) prometheus.Counter {
+	complexityMetric := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("tx_complexity_%s", name),
+		Help:      fmt.Sprintf("Total number of %s of accepted txs", description),
+	})
+	errs.Add(registerer.Register(complexityMetric))
+	return complexityMetric
+}
+
+func (m *complexityMetrics) markAccepted(tx *txs.Tx) {
+	dimensions := complexity.Calculate(tx)
+	m.bytes.Add(float64(dimensions.Bytes))
+	m.utxoReads.Add(float64(dimensions.UTXOReads))
+	m.utxoWrites.Add(float64(dimensions.UTXOWrites))
+	m.signatures.Add(float64(dimensions.Signatures))
+	m.stakerChanges.Add(float64(dimensions.StakerChanges))
+}
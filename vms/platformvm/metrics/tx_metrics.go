@@ -29,7 +29,9 @@ type txMetrics struct {
 	numAddPermissionlessValidatorTxs,
 	numAddPermissionlessDelegatorTxs,
 	numTransferSubnetOwnershipTxs,
-	numBaseTxs prometheus.Counter
+	numBaseTxs,
+	numSetSubnetMetadataTxs,
+	numExitSubnetValidatorTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -53,6 +55,8 @@ func newTxMetrics(
 		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
 		numTransferSubnetOwnershipTxs:    newTxMetric(namespace, "transfer_subnet_ownership", registerer, &errs),
 		numBaseTxs:                       newTxMetric(namespace, "base", registerer, &errs),
+		numSetSubnetMetadataTxs:          newTxMetric(namespace, "set_subnet_metadata", registerer, &errs),
+		numExitSubnetValidatorTxs:        newTxMetric(namespace, "exit_subnet_validator", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -146,3 +150,13 @@ func (m *txMetrics) BaseTx(*txs.BaseTx) error {
 	m.numBaseTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) SetSubnetMetadataTx(*txs.SetSubnetMetadataTx) error {
+	m.numSetSubnetMetadataTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) ExitSubnetValidatorTx(*txs.ExitSubnetValidatorTx) error {
+	m.numExitSubnetValidatorTxs.Inc()
+	return nil
+}
@@ -4,11 +4,13 @@
 package platformvm
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"reflect"
 	"time"
 
 	stdjson "encoding/json"
@@ -31,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -40,11 +43,13 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/complexity"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
 )
 
 const (
@@ -54,6 +59,21 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of txs that can be returned by a single call to GetMempool
+	maxGetMempoolPageSize = 1024
+
+	// Max number of records that can be returned by a single call to
+	// GetStakingLedger
+	maxGetStakingLedgerPageSize = 1024
+
+	// Max number of heights that a single call to GetValidatorSetDiff can
+	// span
+	maxGetValidatorSetDiffHeights = 1024
+
+	// Number of heights of validator diffs that GetValidatorSetDiff applies
+	// each time it grabs the context lock
+	validatorSetDiffBatchSize = 64
+
 	// Minimum amount of delay to allow a transaction to be issued through the
 	// API
 	minAddStakerDelay = 2 * executor.SyncBound
@@ -80,6 +100,9 @@ var (
 	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime        = errors.New("start time must be before end time")
 	errStartTimeInThePast       = errors.New("start time in the past")
+	errInvalidStakeDuration     = errors.New("argument 'duration' is outside the allowed stake duration range")
+	errZeroSupply               = errors.New("current supply is 0")
+	errInvalidHeightRange       = errors.New("invalid height range")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -527,6 +550,9 @@ type APISubnet struct {
 	// signatures from [Threshold] of these keys to be valid.
 	ControlKeys []string    `json:"controlKeys"`
 	Threshold   json.Uint32 `json:"threshold"`
+
+	// Metadata set by the subnet owner, if any. Ignored by createSubnet.
+	Metadata *txs.SubnetMetadata `json:"metadata,omitempty"`
 }
 
 // GetSubnetsArgs are the arguments to GetSubnet
@@ -595,7 +621,7 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 			ControlKeys: []string{},
 			Threshold:   json.Uint32(0),
 		}
-		return nil
+		return s.addSubnetMetadata(response.Subnets)
 	}
 
 	subnetSet := set.NewSet[ids.ID](len(args.IDs))
@@ -653,6 +679,26 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 			Threshold:   json.Uint32(owner.Threshold),
 		})
 	}
+	return s.addSubnetMetadata(response.Subnets)
+}
+
+// addSubnetMetadata populates the metadata of the provided subnets.
+func (s *Service) addSubnetMetadata(subnets []APISubnet) error {
+	for i := range subnets {
+		subnet := &subnets[i]
+		if subnet.ID == constants.PrimaryNetworkID {
+			continue
+		}
+
+		metadata, err := s.vm.state.GetSubnetMetadata(subnet.ID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("problem getting subnet metadata: %w", err)
+		}
+		subnet.Metadata = metadata
+	}
 	return nil
 }
 
@@ -1130,6 +1176,110 @@ func (s *Service) GetCurrentSupply(r *http.Request, args *GetCurrentSupplyArgs,
 	return nil
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// Amount of nAVAX, or of the subnet's staking asset, to be staked
+	Amount json.Uint64 `json:"amount"`
+	// Duration is the number of seconds to stake for
+	Duration json.Uint64 `json:"duration"`
+	// DelegationFeeRate is the percentage of the reward paid to the validator
+	// when estimating the reward of a delegator. It should be 0 when
+	// estimating the reward of a validator.
+	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+	// SubnetID of the subnet to stake on. If omitted, defaults to the primary
+	// network.
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// EstimateRewardReply are the results from calling EstimateReward
+type EstimateRewardReply struct {
+	// PotentialReward is the total reward that would be minted for the stake
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// Reward is the part of [PotentialReward] the staker would receive after
+	// paying the delegation fee
+	Reward json.Uint64 `json:"reward"`
+	// DelegationFee is the part of [PotentialReward] paid to the validator
+	DelegationFee json.Uint64 `json:"delegationFee"`
+	// CurrentSupply is the supply the estimate was calculated with
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// EffectiveAPR is the annualized percentage return of [Reward]
+	EffectiveAPR json.Float64 `json:"effectiveAPR"`
+}
+
+// EstimateReward returns the reward that would be received for staking
+// [Amount] for [Duration], assuming the staker meets the uptime requirement
+// and the current supply doesn't change
+func (s *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateReward"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	switch {
+	case args.Amount == 0:
+		return errNoAmount
+	case args.DelegationFeeRate < 0 || args.DelegationFeeRate > 100:
+		return errInvalidDelegationRate
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	var (
+		rewards          reward.Calculator
+		minStakeDuration time.Duration
+		maxStakeDuration time.Duration
+	)
+	if args.SubnetID == constants.PrimaryNetworkID {
+		rewards = reward.NewCalculator(s.vm.RewardConfig)
+		minStakeDuration = s.vm.MinStakeDuration
+		maxStakeDuration = s.vm.MaxStakeDuration
+	} else {
+		transformSubnet, err := executor.GetTransformSubnetTx(s.vm.state, args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get reward config of subnet %s: %w", args.SubnetID, err)
+		}
+		rewards = reward.NewCalculator(reward.Config{
+			MaxConsumptionRate: transformSubnet.MaxConsumptionRate,
+			MinConsumptionRate: transformSubnet.MinConsumptionRate,
+			MintingPeriod:      s.vm.RewardConfig.MintingPeriod,
+			SupplyCap:          transformSubnet.MaximumSupply,
+		})
+		minStakeDuration = time.Duration(transformSubnet.MinStakeDuration) * time.Second
+		maxStakeDuration = time.Duration(transformSubnet.MaxStakeDuration) * time.Second
+	}
+	// Compare in seconds before converting so that a huge [Duration] can't
+	// overflow into the valid range.
+	if uint64(args.Duration) < uint64(minStakeDuration/time.Second) ||
+		uint64(args.Duration) > uint64(maxStakeDuration/time.Second) {
+		return fmt.Errorf("%w: [%s, %s]", errInvalidStakeDuration, minStakeDuration, maxStakeDuration)
+	}
+	duration := time.Duration(args.Duration) * time.Second
+
+	currentSupply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("fetching current supply failed: %w", err)
+	}
+	if currentSupply == 0 {
+		return errZeroSupply
+	}
+
+	potentialReward := rewards.Calculate(duration, uint64(args.Amount), currentSupply)
+	delegationFee, stakerReward := reward.Split(potentialReward, uint32(10000*args.DelegationFeeRate))
+
+	reply.PotentialReward = json.Uint64(potentialReward)
+	reply.Reward = json.Uint64(stakerReward)
+	reply.DelegationFee = json.Uint64(delegationFee)
+	reply.CurrentSupply = json.Uint64(currentSupply)
+
+	const year = 365 * 24 * time.Hour
+	reply.EffectiveAPR = json.Float64(
+		100 * float64(stakerReward) / float64(args.Amount) * float64(year) / float64(duration),
+	)
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -2184,6 +2334,237 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 	return nil
 }
 
+// VerifyTxError describes why a tx is invalid
+type VerifyTxError struct {
+	// ParentID is the preferred block the tx was verified on top of
+	ParentID ids.ID `json:"parentID"`
+	Message  string `json:"message"`
+}
+
+// VerifyTxReply is the response from calling VerifyTx
+type VerifyTxReply struct {
+	TxID  ids.ID `json:"txID"`
+	Valid bool   `json:"valid"`
+	// Reason the tx is invalid.
+	// Only non-nil if Valid is false
+	Error *VerifyTxError `json:"error,omitempty"`
+	// ConsumedUTXOs are the UTXOs, including imported UTXOs, spent by the tx
+	ConsumedUTXOs []avax.UTXOID `json:"consumedUTXOs"`
+	// Fee is the amount of AVAX burned by the tx.
+	// Nil if the tx produces more AVAX than it consumes.
+	Fee *json.Uint64 `json:"fee,omitempty"`
+}
+
+// VerifyTx verifies a tx against the preferred state, as if it were issued,
+// without adding it to the mempool.
+func (s *Service) VerifyTx(_ *http.Request, args *api.FormattedTx, response *VerifyTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "verifyTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+
+	s.vm.ctx.Lock.Lock()
+	err = s.vm.Builder.VerifyTx(tx)
+	s.vm.ctx.Lock.Unlock()
+
+	var verificationErr *blockbuilder.TxVerificationError
+	switch {
+	case err == nil:
+		response.Valid = true
+	case errors.As(err, &verificationErr):
+		response.Error = &VerifyTxError{
+			ParentID: verificationErr.ParentID,
+			Message:  verificationErr.Err.Error(),
+		}
+	default:
+		return err
+	}
+
+	// The UTXOs and the fee of an invalid tx are reported when they can be
+	// determined, to help explain why the tx is invalid.
+	ins, _, err := txs.Flow(tx.Unsigned)
+	if err != nil {
+		return err
+	}
+	response.ConsumedUTXOs = make([]avax.UTXOID, len(ins))
+	for i, in := range ins {
+		response.ConsumedUTXOs[i] = in.UTXOID
+	}
+	if fee, err := txs.Burned(tx.Unsigned, s.vm.ctx.AVAXAssetID); err == nil {
+		response.Fee = (*json.Uint64)(&fee)
+	}
+	return nil
+}
+
+// GetTxComplexityReply is the response from calling GetTxComplexity
+type GetTxComplexityReply struct {
+	TxID ids.ID `json:"txID"`
+	// Bytes is the length of the signed tx
+	Bytes json.Uint64 `json:"bytes"`
+	// UTXOReads is the number of UTXOs consumed by the tx
+	UTXOReads json.Uint64 `json:"utxoReads"`
+	// UTXOWrites is the number of UTXOs produced by the tx
+	UTXOWrites json.Uint64 `json:"utxoWrites"`
+	// Signatures is the number of signatures verified by the tx
+	Signatures json.Uint64 `json:"signatures"`
+	// StakerChanges is the number of stakers added or removed by the tx
+	StakerChanges json.Uint64 `json:"stakerChanges"`
+}
+
+// GetTxComplexity returns the execution cost of a tx, broken down by
+// dimension. The tx isn't verified.
+func (s *Service) GetTxComplexity(_ *http.Request, args *api.FormattedTx, response *GetTxComplexityReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTxComplexity"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	dimensions := complexity.Calculate(tx)
+	response.TxID = tx.ID()
+	response.Bytes = json.Uint64(dimensions.Bytes)
+	response.UTXOReads = json.Uint64(dimensions.UTXOReads)
+	response.UTXOWrites = json.Uint64(dimensions.UTXOWrites)
+	response.Signatures = json.Uint64(dimensions.Signatures)
+	response.StakerChanges = json.Uint64(dimensions.StakerChanges)
+	return nil
+}
+
+// GetMempoolArgs are the arguments for calling GetMempool
+type GetMempoolArgs struct {
+	// Cursor is the ID of the tx to start after. If empty, txs are returned
+	// starting from the lowest txID.
+	Cursor ids.ID `json:"cursor"`
+	// PageSize is the maximum number of txs to return
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// MempoolTx describes a tx that is pending in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Type is the name of the unsigned tx type, e.g. "AddValidatorTx"
+	Type string `json:"type"`
+	// Staker is true if the tx is a staker tx, rather than a decision tx
+	Staker bool        `json:"staker"`
+	Size   json.Uint64 `json:"size"`
+	// Tip is the amount of AVAX the tx burns above its fee at the current fee
+	// price
+	Tip json.Uint64 `json:"tip"`
+	// Added is the unix time the tx was added to the mempool
+	Added json.Uint64 `json:"added"`
+	// Age is the number of seconds the tx has been in the mempool
+	Age json.Uint64 `json:"age"`
+}
+
+// GetMempoolReply is the response from calling GetMempool
+type GetMempoolReply struct {
+	Txs []MempoolTx `json:"txs"`
+	// NumTxs is the total number of txs in the mempool
+	NumTxs json.Uint64 `json:"numTxs"`
+	// Cursor to provide to fetch the next page of txs
+	Cursor ids.ID `json:"cursor"`
+}
+
+// GetMempool returns the txs currently pending in the mempool, ordered by
+// txID
+//
+// Note: Txs are issued in a different order, as txs paying a higher tip per
+// byte are prioritized.
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, response *GetMempoolReply) error {
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempool"),
+		zap.Stringer("cursor", args.Cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > maxGetMempoolPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxGetMempoolPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxGetMempoolPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	pendingTxs := s.vm.Builder.PendingTxs(args.Cursor, int(pageSize))
+	now := s.vm.clock.Time()
+	response.Txs = make([]MempoolTx, 0, len(pendingTxs))
+	for _, pendingTx := range pendingTxs {
+		response.Txs = append(response.Txs, MempoolTx{
+			TxID:   pendingTx.Tx.ID(),
+			Type:   reflect.TypeOf(pendingTx.Tx.Unsigned).Elem().Name(),
+			Staker: pendingTx.Staker,
+			Size:   json.Uint64(len(pendingTx.Tx.Bytes())),
+			Tip:    json.Uint64(pendingTx.Tip),
+			Added:  json.Uint64(pendingTx.Added.Unix()),
+			Age:    json.Uint64(now.Sub(pendingTx.Added) / time.Second),
+		})
+	}
+	response.NumTxs = json.Uint64(s.vm.Builder.NumTxs())
+	response.Cursor = args.Cursor
+	if len(pendingTxs) > 0 {
+		response.Cursor = pendingTxs[len(pendingTxs)-1].Tx.ID()
+	}
+	return nil
+}
+
+// DroppedTx describes a tx that was recently dropped from the mempool
+type DroppedTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+	// Dropped is the unix time the tx was dropped
+	Dropped json.Uint64 `json:"dropped"`
+}
+
+// GetDroppedTxsReply is the response from calling GetDroppedTxs
+type GetDroppedTxsReply struct {
+	Txs []DroppedTx `json:"txs"`
+}
+
+// GetDroppedTxs returns the most recently dropped txs along with the reason
+// they were dropped, in the order they were dropped
+func (s *Service) GetDroppedTxs(_ *http.Request, _ *struct{}, response *GetDroppedTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getDroppedTxs"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	droppedTxs := s.vm.Builder.DroppedTxs()
+	response.Txs = make([]DroppedTx, len(droppedTxs))
+	for i, droppedTx := range droppedTxs {
+		response.Txs[i] = DroppedTx{
+			TxID:    droppedTx.TxID,
+			Reason:  droppedTx.Reason.Error(),
+			Dropped: json.Uint64(droppedTx.Dropped.Unix()),
+		}
+	}
+	return nil
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -2578,6 +2959,102 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetStakingLedgerArgs are the arguments for calling GetStakingLedger
+type GetStakingLedgerArgs struct {
+	// Address is the reward address to fetch the staking records of
+	Address string `json:"address"`
+	// Cursor is the index of the first record to return
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize is the maximum number of records to return
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// StakingRecord describes the outcome of a staking period for a reward address
+type StakingRecord struct {
+	// TxID is the ID of the tx that added the staker
+	TxID      ids.ID      `json:"txID"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	SubnetID  ids.ID      `json:"subnetID"`
+	Delegator bool        `json:"delegator"`
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	Weight    json.Uint64 `json:"weight"`
+	// Rewarded is true if the staker was rewarded for its staking period
+	Rewarded bool `json:"rewarded"`
+	// Reward is the amount paid to the address when the staker was removed
+	Reward json.Uint64 `json:"reward"`
+	// Height is the height of the block that removed the staker
+	Height json.Uint64 `json:"height"`
+}
+
+// GetStakingLedgerReply is the response from calling GetStakingLedger
+type GetStakingLedgerReply struct {
+	Records []StakingRecord `json:"records"`
+	// Cursor to provide to fetch the next page of records
+	Cursor json.Uint64 `json:"cursor"`
+	// StartHeight is the height from which the ledger contains every record.
+	// Staking periods that ended before this height may be missing.
+	StartHeight json.Uint64 `json:"startHeight"`
+}
+
+// GetStakingLedger returns the staking periods that [args.Address] could be
+// rewarded for, in the order they ended.
+//
+// Note: The staking ledger must be enabled in the execution config.
+func (s *Service) GetStakingLedger(_ *http.Request, args *GetStakingLedgerArgs, response *GetStakingLedgerReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStakingLedger"),
+		logging.UserString("address", args.Address),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > maxGetStakingLedgerPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxGetStakingLedgerPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxGetStakingLedgerPageSize
+	}
+
+	addr, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	startHeight, err := s.vm.state.GetStakingLedgerHeight()
+	if err != nil {
+		return fmt.Errorf("couldn't get staking ledger height: %w", err)
+	}
+	records, err := s.vm.state.GetStakingRecords(addr, cursor, int(pageSize))
+	if err != nil {
+		return fmt.Errorf("couldn't get staking records: %w", err)
+	}
+
+	response.Records = make([]StakingRecord, len(records))
+	for i, record := range records {
+		response.Records[i] = StakingRecord{
+			TxID:      record.TxID,
+			NodeID:    record.NodeID,
+			SubnetID:  record.SubnetID,
+			Delegator: record.Delegator,
+			StartTime: json.Uint64(record.StartTime),
+			EndTime:   json.Uint64(record.EndTime),
+			Weight:    json.Uint64(record.Weight),
+			Rewarded:  record.Rewarded,
+			Reward:    json.Uint64(record.Reward),
+			Height:    json.Uint64(record.Height),
+		}
+	}
+	response.Cursor = json.Uint64(cursor + uint64(len(records)))
+	response.StartHeight = json.Uint64(startHeight)
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -2598,6 +3075,41 @@ func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestamp
 	return nil
 }
 
+// GetFeeStateReply is the response from GetFeeState
+type GetFeeStateReply struct {
+	// Dynamic is true once fees are scaled by Price. Before then, the static
+	// fees are charged.
+	Dynamic bool `json:"dynamic"`
+	// Price that txs issued on top of the current chain are charged. The fee
+	// of a tx is its static fee multiplied by Price / [gas.PriceDenominator].
+	Price json.Uint64 `json:"price"`
+	// Parameters that determine how the price changes between blocks
+	Config gas.Config `json:"config"`
+	// Current timestamp
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetFeeState returns the current fee price and the parameters that determine
+// how it changes.
+func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getFeeState"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.Timestamp = s.vm.state.GetTimestamp()
+	reply.Config = s.vm.Config.DynamicFeeConfig
+	reply.Price = json.Uint64(gas.PriceDenominator)
+	reply.Dynamic = s.vm.Config.IsEActivated(reply.Timestamp)
+	if reply.Dynamic {
+		reply.Price = json.Uint64(reply.Config.Price(s.vm.state.GetFeePrice()))
+	}
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   json.Uint64 `json:"height"`
@@ -2691,6 +3203,196 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// GetValidatorSetDiffArgs are the arguments for calling GetValidatorSetDiff
+type GetValidatorSetDiffArgs struct {
+	SubnetID    ids.ID      `json:"subnetID"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	EndHeight   json.Uint64 `json:"endHeight"`
+}
+
+// ValidatorDiff describes how a validator changed between two heights
+type ValidatorDiff struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Status is one of "added", "removed" or "modified"
+	Status         string      `json:"status"`
+	PreviousWeight json.Uint64 `json:"previousWeight"`
+	Weight         json.Uint64 `json:"weight"`
+	// PreviousPublicKey and PublicKey are hex encoded compressed BLS public
+	// keys, or nil if the validator didn't have a key registered
+	PreviousPublicKey *string `json:"previousPublicKey"`
+	PublicKey         *string `json:"publicKey"`
+}
+
+// GetValidatorSetDiffReply is the response from GetValidatorSetDiff
+type GetValidatorSetDiffReply struct {
+	// Diffs are sorted by NodeID
+	Diffs []ValidatorDiff `json:"diffs"`
+}
+
+// GetValidatorSetDiff returns the changes to the validator set of a provided
+// subnet between [args.StartHeight] and [args.EndHeight].
+//
+// The validator set at [args.StartHeight] is calculated by reverting the
+// weight and public key diffs stored for each height in
+// (args.StartHeight, args.EndHeight] from the validator set at
+// [args.EndHeight].
+func (s *Service) GetValidatorSetDiff(r *http.Request, args *GetValidatorSetDiffArgs, reply *GetValidatorSetDiffReply) error {
+	startHeight := uint64(args.StartHeight)
+	endHeight := uint64(args.EndHeight)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorSetDiff"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("startHeight", startHeight),
+		zap.Uint64("endHeight", endHeight),
+	)
+
+	if startHeight > endHeight {
+		return fmt.Errorf("%w: startHeight (%d) > endHeight (%d)", errInvalidHeightRange, startHeight, endHeight)
+	}
+	if endHeight-startHeight > maxGetValidatorSetDiffHeights {
+		return fmt.Errorf("%w: range (%d) > maximum allowed (%d)",
+			errInvalidHeightRange,
+			endHeight-startHeight,
+			maxGetValidatorSetDiffHeights,
+		)
+	}
+
+	ctx := r.Context()
+	s.vm.ctx.Lock.Lock()
+	endSet, err := s.vm.GetValidatorSet(ctx, endHeight, args.SubnetID)
+	s.vm.ctx.Lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to get validator set at %d: %w", endHeight, err)
+	}
+
+	// [endSet] may be cached, so it must not be modified.
+	startSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(endSet))
+	for nodeID, vdr := range endSet {
+		vdrCopy := *vdr
+		startSet[nodeID] = &vdrCopy
+	}
+
+	// The diffs are applied in batches so that the context lock isn't held
+	// for the entire range. The diffs of accepted heights never change, so
+	// releasing the lock between batches doesn't affect the result.
+	for batchStart := endHeight; batchStart > startHeight; {
+		batchEnd := startHeight + 1
+		if batchStart-startHeight > validatorSetDiffBatchSize {
+			batchEnd = batchStart - validatorSetDiffBatchSize + 1
+		}
+		if err := s.applyValidatorDiffs(ctx, startSet, batchStart, batchEnd, args.SubnetID); err != nil {
+			return fmt.Errorf("failed to apply validator diffs in [%d, %d]: %w", batchEnd, batchStart, err)
+		}
+		batchStart = batchEnd - 1
+	}
+
+	reply.Diffs, err = diffValidatorSets(startSet, endSet)
+	return err
+}
+
+// applyValidatorDiffs reverts the weight and public key diffs of
+// [subnetID] from [startHeight] down to and including [endHeight] on
+// [validatorSet].
+func (s *Service) applyValidatorDiffs(
+	ctx context.Context,
+	validatorSet map[ids.NodeID]*validators.GetValidatorOutput,
+	startHeight uint64,
+	endHeight uint64,
+	subnetID ids.ID,
+) error {
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	err := s.vm.state.ApplyValidatorWeightDiffs(
+		ctx,
+		validatorSet,
+		startHeight,
+		endHeight,
+		subnetID,
+	)
+	if err != nil {
+		return err
+	}
+	return s.vm.state.ApplyValidatorPublicKeyDiffs(
+		ctx,
+		validatorSet,
+		startHeight,
+		endHeight,
+	)
+}
+
+// diffValidatorSets returns the changes required to go from [start] to [end],
+// sorted by NodeID.
+func diffValidatorSets(
+	start map[ids.NodeID]*validators.GetValidatorOutput,
+	end map[ids.NodeID]*validators.GetValidatorOutput,
+) ([]ValidatorDiff, error) {
+	nodeIDs := set.NewSet[ids.NodeID](len(start) + len(end))
+	for nodeID := range start {
+		nodeIDs.Add(nodeID)
+	}
+	for nodeID := range end {
+		nodeIDs.Add(nodeID)
+	}
+	nodeIDList := nodeIDs.List()
+	utils.Sort(nodeIDList)
+
+	diffs := []ValidatorDiff{}
+	for _, nodeID := range nodeIDList {
+		prev, hadPrev := start[nodeID]
+		next, hasNext := end[nodeID]
+
+		diff := ValidatorDiff{
+			NodeID: nodeID,
+		}
+		var prevPK, nextPK []byte
+		if hadPrev {
+			diff.PreviousWeight = json.Uint64(prev.Weight)
+			if prev.PublicKey != nil {
+				prevPK = bls.PublicKeyToBytes(prev.PublicKey)
+			}
+		}
+		if hasNext {
+			diff.Weight = json.Uint64(next.Weight)
+			if next.PublicKey != nil {
+				nextPK = bls.PublicKeyToBytes(next.PublicKey)
+			}
+		}
+
+		switch {
+		case !hadPrev:
+			diff.Status = "added"
+		case !hasNext:
+			diff.Status = "removed"
+		case prev.Weight != next.Weight || !bytes.Equal(prevPK, nextPK):
+			diff.Status = "modified"
+		default:
+			continue
+		}
+
+		var err error
+		diff.PreviousPublicKey, err = encodePublicKey(prevPK)
+		if err != nil {
+			return nil, err
+		}
+		diff.PublicKey, err = encodePublicKey(nextPK)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+func encodePublicKey(pkBytes []byte) (*string, error) {
+	if pkBytes == nil {
+		return nil, nil
+	}
+	pk, err := formatting.Encode(formatting.HexNC, pkBytes)
+	return &pk, err
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -29,14 +30,19 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -261,6 +267,295 @@ func TestGetTxStatus(t *testing.T) {
 	require.Zero(resp.Reason)
 }
 
+func TestVerifyTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	// Ensure the tx consumes UTXOs to pay a fee
+	service.vm.Config.CreateAssetTxFee = 100 * defaultTxFee
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	var (
+		arg = &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}
+		resp VerifyTxReply
+	)
+	require.NoError(service.VerifyTx(nil, arg, &resp))
+	require.Equal(tx.ID(), resp.TxID)
+	require.True(resp.Valid)
+	require.Nil(resp.Error)
+	require.NotNil(resp.Fee)
+	require.Equal(json.Uint64(service.vm.Config.GetCreateSubnetTxFee(service.vm.clock.Time())), *resp.Fee)
+	require.NotEmpty(resp.ConsumedUTXOs)
+	require.Len(resp.ConsumedUTXOs, len(tx.Unsigned.InputIDs()))
+
+	// Verifying the tx doesn't issue it
+	service.vm.ctx.Lock.Lock()
+	require.False(service.vm.Builder.Has(tx.ID()))
+	service.vm.ctx.Lock.Unlock()
+
+	// Once the tx's UTXOs are consumed, the tx is no longer valid
+	service.vm.ctx.Lock.Lock()
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+	block, err := service.vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(block.Verify(context.Background()))
+	require.NoError(block.Accept(context.Background()))
+	require.NoError(service.vm.SetPreference(context.Background(), block.ID()))
+	service.vm.ctx.Lock.Unlock()
+
+	resp = VerifyTxReply{}
+	require.NoError(service.VerifyTx(nil, arg, &resp))
+	require.False(resp.Valid)
+	require.NotNil(resp.Error)
+	require.Equal(block.ID(), resp.Error.ParentID)
+	require.NotEmpty(resp.Error.Message)
+}
+
+func TestVerifyTxProducesMoreThanConsumed(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	// Produce more AVAX than the tx consumes
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+	utx.Outs = append(utx.Outs, &avax.TransferableOutput{
+		Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: math.MaxUint64,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+			},
+		},
+	})
+	require.NoError(tx.Initialize(txs.Codec))
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	var (
+		arg = &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}
+		resp VerifyTxReply
+	)
+	require.NoError(service.VerifyTx(nil, arg, &resp))
+	require.Equal(tx.ID(), resp.TxID)
+	require.False(resp.Valid)
+	require.NotNil(resp.Error)
+	require.NotEmpty(resp.Error.Message)
+	require.Len(resp.ConsumedUTXOs, len(tx.Unsigned.InputIDs()))
+	require.Nil(resp.Fee)
+}
+
+func TestGetTxComplexity(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	var (
+		arg = &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}
+		resp GetTxComplexityReply
+	)
+	require.NoError(service.GetTxComplexity(nil, arg, &resp))
+
+	utx := tx.Unsigned.(*txs.CreateSubnetTx)
+	require.Equal(tx.ID(), resp.TxID)
+	require.Equal(json.Uint64(len(tx.Bytes())), resp.Bytes)
+	require.Equal(json.Uint64(len(utx.Ins)), resp.UTXOReads)
+	require.Equal(json.Uint64(len(utx.Outs)), resp.UTXOWrites)
+	// Every input is owned by a single key
+	require.Equal(json.Uint64(len(utx.Ins)), resp.Signatures)
+	require.Zero(resp.StakerChanges)
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	added := service.vm.clock.Time()
+	service.vm.clock.Set(added)
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+
+	// The age of the tx is measured with the VM's clock
+	service.vm.clock.Set(added.Add(10 * time.Second))
+	service.vm.ctx.Lock.Unlock()
+
+	var resp GetMempoolReply
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{}, &resp))
+	require.Equal(json.Uint64(1), resp.NumTxs)
+	require.Equal(tx.ID(), resp.Cursor)
+	require.Len(resp.Txs, 1)
+	require.Equal(tx.ID(), resp.Txs[0].TxID)
+	require.Equal("CreateSubnetTx", resp.Txs[0].Type)
+	require.False(resp.Txs[0].Staker)
+	require.Equal(json.Uint64(len(tx.Bytes())), resp.Txs[0].Size)
+	require.Equal(json.Uint64(added.Unix()), resp.Txs[0].Added)
+	require.Equal(json.Uint64(10), resp.Txs[0].Age)
+
+	// Paging past the end returns no txs
+	resp = GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{Cursor: tx.ID()}, &resp))
+	require.Equal(json.Uint64(1), resp.NumTxs)
+	require.Equal(tx.ID(), resp.Cursor)
+	require.Empty(resp.Txs)
+
+	require.ErrorContains(
+		service.GetMempool(nil, &GetMempoolArgs{PageSize: maxGetMempoolPageSize + 1}, &resp),
+		"pageSize > maximum allowed",
+	)
+
+	errTest := errors.New("test error")
+	service.vm.ctx.Lock.Lock()
+	service.vm.Builder.MarkDropped(tx.ID(), errTest)
+	service.vm.ctx.Lock.Unlock()
+
+	var droppedResp GetDroppedTxsReply
+	require.NoError(service.GetDroppedTxs(nil, nil, &droppedResp))
+	require.Len(droppedResp.Txs, 1)
+	require.Equal(tx.ID(), droppedResp.Txs[0].TxID)
+	require.Equal(errTest.Error(), droppedResp.Txs[0].Reason)
+}
+
+func TestGetStakingLedger(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	addr := keys[0].PublicKey().Address()
+	addrStr, err := service.addrManager.FormatLocalAddress(addr)
+	require.NoError(err)
+
+	// The staking ledger is disabled by default
+	var resp GetStakingLedgerReply
+	err = service.GetStakingLedger(nil, &GetStakingLedgerArgs{Address: addrStr}, &resp)
+	require.ErrorIs(err, state.ErrStakingLedgerDisabled)
+
+	require.ErrorContains(
+		service.GetStakingLedger(nil, &GetStakingLedgerArgs{
+			Address:  addrStr,
+			PageSize: maxGetStakingLedgerPageSize + 1,
+		}, &resp),
+		"pageSize > maximum allowed",
+	)
+
+	record := &state.StakingRecord{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    ids.GenerateTestNodeID(),
+		SubnetID:  constants.PrimaryNetworkID,
+		StartTime: 1,
+		EndTime:   2,
+		Weight:    3,
+		Rewarded:  true,
+		Reward:    4,
+		Height:    5,
+	}
+
+	ctrl := gomock.NewController(t)
+	mockState := state.NewMockState(ctrl)
+	mockState.EXPECT().GetStakingLedgerHeight().Return(uint64(1), nil)
+	mockState.EXPECT().GetStakingRecords(addr, uint64(2), 10).Return([]*state.StakingRecord{record}, nil)
+
+	realState := service.vm.state
+	service.vm.state = mockState
+	defer func() {
+		service.vm.state = realState
+	}()
+
+	resp = GetStakingLedgerReply{}
+	require.NoError(service.GetStakingLedger(nil, &GetStakingLedgerArgs{
+		Address:  addrStr,
+		Cursor:   2,
+		PageSize: 10,
+	}, &resp))
+	require.Equal(GetStakingLedgerReply{
+		Records: []StakingRecord{{
+			TxID:      record.TxID,
+			NodeID:    record.NodeID,
+			SubnetID:  record.SubnetID,
+			StartTime: 1,
+			EndTime:   2,
+			Weight:    3,
+			Rewarded:  true,
+			Reward:    4,
+			Height:    5,
+		}},
+		Cursor:      3,
+		StartHeight: 1,
+	}, resp)
+}
+
 // Test issuing and then retrieving a transaction
 func TestGetTx(t *testing.T) {
 	type test struct {
@@ -744,6 +1039,109 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetFeeState(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+
+	feeConfig := gas.Config{
+		TargetBlockGas:    units.KiB,
+		MaxPrice:          10 * gas.PriceDenominator,
+		ChangeDenominator: 8,
+	}
+	service.vm.Config.DynamicFeeConfig = feeConfig
+	service.vm.Config.ETime = mockable.MaxTime
+	service.vm.state.SetFeePrice(2 * gas.PriceDenominator)
+	timestamp := service.vm.state.GetTimestamp()
+
+	service.vm.ctx.Lock.Unlock()
+
+	// Before the E upgrade, the static fees are charged.
+	reply := GetFeeStateReply{}
+	require.NoError(service.GetFeeState(nil, nil, &reply))
+	require.Equal(GetFeeStateReply{
+		Price:     gas.PriceDenominator,
+		Config:    feeConfig,
+		Timestamp: timestamp,
+	}, reply)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.Config.ETime = timestamp
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.GetFeeState(nil, nil, &reply))
+	require.Equal(GetFeeStateReply{
+		Dynamic:   true,
+		Price:     2 * gas.PriceDenominator,
+		Config:    feeConfig,
+		Timestamp: timestamp,
+	}, reply)
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.ctx.Lock.Lock()
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	args := EstimateRewardArgs{
+		Amount:   json.Uint64(service.vm.MinValidatorStake),
+		Duration: json.Uint64(defaultMinStakingDuration / time.Second),
+	}
+	expectedReward := reward.NewCalculator(service.vm.RewardConfig).Calculate(
+		defaultMinStakingDuration,
+		service.vm.MinValidatorStake,
+		currentSupply,
+	)
+
+	reply := EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &args, &reply))
+	require.Equal(json.Uint64(expectedReward), reply.PotentialReward)
+	require.Equal(json.Uint64(expectedReward), reply.Reward)
+	require.Zero(reply.DelegationFee)
+	require.Equal(json.Uint64(currentSupply), reply.CurrentSupply)
+	require.Positive(float64(reply.EffectiveAPR))
+
+	// A delegator pays part of the reward to the validator
+	args.DelegationFeeRate = 10
+	delegatorReply := EstimateRewardReply{}
+	require.NoError(service.EstimateReward(nil, &args, &delegatorReply))
+	require.Equal(reply.PotentialReward, delegatorReply.PotentialReward)
+	require.Equal(delegatorReply.PotentialReward, delegatorReply.Reward+delegatorReply.DelegationFee)
+	require.Equal(json.Uint64(expectedReward/10), delegatorReply.DelegationFee)
+	require.Less(delegatorReply.EffectiveAPR, reply.EffectiveAPR)
+
+	args.DelegationFeeRate = 0
+	args.Duration = json.Uint64(service.vm.MaxStakeDuration/time.Second) + 1
+	err = service.EstimateReward(nil, &args, &reply)
+	require.ErrorIs(err, errInvalidStakeDuration)
+
+	// Converting this duration to nanoseconds wraps around to the minimum
+	// staking duration, so it must be rejected before being converted.
+	args.Duration = json.Uint64(defaultMinStakingDuration/time.Second) + 1<<55
+	err = service.EstimateReward(nil, &args, &reply)
+	require.ErrorIs(err, errInvalidStakeDuration)
+
+	args.Duration = json.Uint64(defaultMinStakingDuration / time.Second)
+	args.SubnetID = ids.GenerateTestID()
+	err = service.EstimateReward(nil, &args, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -861,6 +1259,152 @@ func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
 	require.Equal(reply, &parsedReply)
 }
 
+func TestDiffValidatorSets(t *testing.T) {
+	require := require.New(t)
+
+	sk0, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk0 := bls.PublicFromSecretKey(sk0)
+	pk0Str, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(pk0))
+	require.NoError(err)
+
+	sk1, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk1 := bls.PublicFromSecretKey(sk1)
+	pk1Str, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(pk1))
+	require.NoError(err)
+
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	utils.Sort(nodeIDs)
+
+	start := map[ids.NodeID]*validators.GetValidatorOutput{
+		// Unchanged
+		nodeIDs[0]: {NodeID: nodeIDs[0], Weight: 1},
+		// Removed
+		nodeIDs[1]: {NodeID: nodeIDs[1], PublicKey: pk0, Weight: 2},
+		// Weight changed
+		nodeIDs[2]: {NodeID: nodeIDs[2], Weight: 3},
+		// Public key changed
+		nodeIDs[3]: {NodeID: nodeIDs[3], PublicKey: pk0, Weight: 4},
+	}
+	end := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeIDs[0]: {NodeID: nodeIDs[0], Weight: 1},
+		nodeIDs[2]: {NodeID: nodeIDs[2], Weight: 5},
+		nodeIDs[3]: {NodeID: nodeIDs[3], PublicKey: pk1, Weight: 4},
+		// Added
+		nodeIDs[4]: {NodeID: nodeIDs[4], PublicKey: pk1, Weight: 6},
+	}
+
+	diffs, err := diffValidatorSets(start, end)
+	require.NoError(err)
+	require.Equal([]ValidatorDiff{
+		{
+			NodeID:            nodeIDs[1],
+			Status:            "removed",
+			PreviousWeight:    2,
+			PreviousPublicKey: &pk0Str,
+		},
+		{
+			NodeID:         nodeIDs[2],
+			Status:         "modified",
+			PreviousWeight: 3,
+			Weight:         5,
+		},
+		{
+			NodeID:            nodeIDs[3],
+			Status:            "modified",
+			PreviousWeight:    4,
+			Weight:            4,
+			PreviousPublicKey: &pk0Str,
+			PublicKey:         &pk1Str,
+		},
+		{
+			NodeID:    nodeIDs[4],
+			Status:    "added",
+			Weight:    6,
+			PublicKey: &pk1Str,
+		},
+	}, diffs)
+
+	diffs, err = diffValidatorSets(start, start)
+	require.NoError(err)
+	require.Empty(diffs)
+}
+
+func TestGetValidatorSetDiff(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	defer func() {
+		service.vm.ctx.Lock.Lock()
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	r := &http.Request{}
+
+	var reply GetValidatorSetDiffReply
+	err := service.GetValidatorSetDiff(r, &GetValidatorSetDiffArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: 1,
+		EndHeight:   0,
+	}, &reply)
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	err = service.GetValidatorSetDiff(r, &GetValidatorSetDiffArgs{
+		SubnetID:  constants.PrimaryNetworkID,
+		EndHeight: maxGetValidatorSetDiffHeights + 1,
+	}, &reply)
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	require.NoError(service.GetValidatorSetDiff(r, &GetValidatorSetDiffArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &reply))
+	require.Empty(reply.Diffs)
+
+	// Remove a genesis validator by rewarding it
+	service.vm.ctx.Lock.Lock()
+	startHeight, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+
+	service.vm.clock.Set(defaultValidateEndTime)
+	blk, err := service.vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	options, err := blk.(snowman.OracleBlock).Options(context.Background())
+	require.NoError(err)
+	commit := options[0]
+	require.NoError(commit.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(commit.Accept(context.Background()))
+
+	endHeight, err := service.vm.GetCurrentHeight(context.Background())
+	require.NoError(err)
+	startSet, err := service.vm.GetValidatorSet(context.Background(), startHeight, constants.PrimaryNetworkID)
+	require.NoError(err)
+	endSet, err := service.vm.GetValidatorSet(context.Background(), endHeight, constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	expectedDiffs, err := diffValidatorSets(startSet, endSet)
+	require.NoError(err)
+	require.Len(expectedDiffs, 1)
+	require.Equal("removed", expectedDiffs[0].Status)
+
+	reply = GetValidatorSetDiffReply{}
+	require.NoError(service.GetValidatorSetDiff(r, &GetValidatorSetDiffArgs{
+		SubnetID:    constants.PrimaryNetworkID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, &reply))
+	require.Equal(expectedDiffs, reply.Diffs)
+}
+
 func TestServiceGetBlockByHeight(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -33,6 +33,7 @@ type diff struct {
 	stateVersions Versions
 
 	timestamp time.Time
+	feePrice  uint64
 
 	// Subnet ID --> supply of native asset of the subnet
 	currentSupply map[ids.ID]uint64
@@ -45,6 +46,8 @@ type diff struct {
 	addedSubnets []*txs.Tx
 	// Subnet ID --> Owner of the subnet
 	subnetOwners map[ids.ID]fx.Owner
+	// Subnet ID --> Metadata of the subnet
+	subnetMetadata map[ids.ID]*txs.SubnetMetadata
 	// Subnet ID --> Tx that transforms the subnet
 	transformedSubnets map[ids.ID]*txs.Tx
 	cachedSubnets      []*txs.Tx
@@ -69,10 +72,12 @@ func NewDiff(
 		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, parentID)
 	}
 	return &diff{
-		parentID:      parentID,
-		stateVersions: stateVersions,
-		timestamp:     parentState.GetTimestamp(),
-		subnetOwners:  make(map[ids.ID]fx.Owner),
+		parentID:       parentID,
+		stateVersions:  stateVersions,
+		timestamp:      parentState.GetTimestamp(),
+		feePrice:       parentState.GetFeePrice(),
+		subnetOwners:   make(map[ids.ID]fx.Owner),
+		subnetMetadata: make(map[ids.ID]*txs.SubnetMetadata),
 	}, nil
 }
 
@@ -84,6 +89,14 @@ func (d *diff) SetTimestamp(timestamp time.Time) {
 	d.timestamp = timestamp
 }
 
+func (d *diff) GetFeePrice() uint64 {
+	return d.feePrice
+}
+
+func (d *diff) SetFeePrice(price uint64) {
+	d.feePrice = price
+}
+
 func (d *diff) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
 	supply, ok := d.currentSupply[subnetID]
 	if ok {
@@ -314,6 +327,24 @@ func (d *diff) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	d.subnetOwners[subnetID] = owner
 }
 
+func (d *diff) GetSubnetMetadata(subnetID ids.ID) (*txs.SubnetMetadata, error) {
+	metadata, exists := d.subnetMetadata[subnetID]
+	if exists {
+		return metadata, nil
+	}
+
+	// If the subnet metadata was not set in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, ErrMissingParentState
+	}
+	return parentState.GetSubnetMetadata(subnetID)
+}
+
+func (d *diff) SetSubnetMetadata(subnetID ids.ID, metadata *txs.SubnetMetadata) {
+	d.subnetMetadata[subnetID] = metadata
+}
+
 func (d *diff) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	tx, exists := d.transformedSubnets[subnetID]
 	if exists {
@@ -481,6 +512,7 @@ func (d *diff) DeleteUTXO(utxoID ids.ID) {
 
 func (d *diff) Apply(baseState State) error {
 	baseState.SetTimestamp(d.timestamp)
+	baseState.SetFeePrice(d.feePrice)
 	for subnetID, supply := range d.currentSupply {
 		baseState.SetCurrentSupply(subnetID, supply)
 	}
@@ -560,5 +592,8 @@ func (d *diff) Apply(baseState State) error {
 	for subnetID, owner := range d.subnetOwners {
 		baseState.SetSubnetOwner(subnetID, owner)
 	}
+	for subnetID, metadata := range d.subnetMetadata {
+		baseState.SetSubnetMetadata(subnetID, metadata)
+	}
 	return nil
 }
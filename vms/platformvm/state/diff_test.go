@@ -82,6 +82,7 @@ func TestDiffCurrentValidator(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	states.EXPECT().GetState(lastAcceptedID).Return(state, true).AnyTimes()
@@ -119,6 +120,7 @@ func TestDiffPendingValidator(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	states.EXPECT().GetState(lastAcceptedID).Return(state, true).AnyTimes()
@@ -161,6 +163,7 @@ func TestDiffCurrentDelegator(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -210,6 +213,7 @@ func TestDiffPendingDelegator(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -253,6 +257,7 @@ func TestDiffSubnet(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -291,6 +296,7 @@ func TestDiffChain(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -330,6 +336,7 @@ func TestDiffTx(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -380,6 +387,7 @@ func TestDiffRewardUTXO(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -425,6 +433,7 @@ func TestDiffUTXO(t *testing.T) {
 	state := NewMockState(ctrl)
 	// Called in NewDiff
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	state.EXPECT().GetFeePrice().Return(uint64(0)).Times(1)
 
 	states := NewMockVersions(ctrl)
 	lastAcceptedID := ids.GenerateTestID()
@@ -488,6 +497,7 @@ func assertChainsEqual(t *testing.T, expected, actual Chain) {
 	}
 
 	require.Equal(expected.GetTimestamp(), actual.GetTimestamp())
+	require.Equal(expected.GetFeePrice(), actual.GetFeePrice())
 
 	expectedCurrentSupply, err := expected.GetCurrentSupply(constants.PrimaryNetworkID)
 	require.NoError(err)
@@ -264,6 +264,20 @@ func (mr *MockChainMockRecorder) GetDelegateeReward(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockChain)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetFeePrice mocks base method.
+func (m *MockChain) GetFeePrice() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeePrice")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetFeePrice indicates an expected call of GetFeePrice.
+func (mr *MockChainMockRecorder) GetFeePrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeePrice", reflect.TypeOf((*MockChain)(nil).GetFeePrice))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -324,6 +338,21 @@ func (mr *MockChainMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockChain)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetSubnetMetadata mocks base method.
+func (m *MockChain) GetSubnetMetadata(arg0 ids.ID) (*txs.SubnetMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetMetadata", arg0)
+	ret0, _ := ret[0].(*txs.SubnetMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetMetadata indicates an expected call of GetSubnetMetadata.
+func (mr *MockChainMockRecorder) GetSubnetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetMetadata", reflect.TypeOf((*MockChain)(nil).GetSubnetMetadata), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockChain) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -488,6 +517,30 @@ func (mr *MockChainMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockChain)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetFeePrice mocks base method.
+func (m *MockChain) SetFeePrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeePrice", arg0)
+}
+
+// SetFeePrice indicates an expected call of SetFeePrice.
+func (mr *MockChainMockRecorder) SetFeePrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeePrice", reflect.TypeOf((*MockChain)(nil).SetFeePrice), arg0)
+}
+
+// SetSubnetMetadata mocks base method.
+func (m *MockChain) SetSubnetMetadata(arg0 ids.ID, arg1 *txs.SubnetMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetMetadata", arg0, arg1)
+}
+
+// SetSubnetMetadata indicates an expected call of SetSubnetMetadata.
+func (mr *MockChainMockRecorder) SetSubnetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetMetadata", reflect.TypeOf((*MockChain)(nil).SetSubnetMetadata), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockChain) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
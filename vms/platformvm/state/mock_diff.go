@@ -278,6 +278,20 @@ func (mr *MockDiffMockRecorder) GetDelegateeReward(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetFeePrice mocks base method.
+func (m *MockDiff) GetFeePrice() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeePrice")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetFeePrice indicates an expected call of GetFeePrice.
+func (mr *MockDiffMockRecorder) GetFeePrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeePrice", reflect.TypeOf((*MockDiff)(nil).GetFeePrice))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -338,6 +352,21 @@ func (mr *MockDiffMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockDiff)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetSubnetMetadata mocks base method.
+func (m *MockDiff) GetSubnetMetadata(arg0 ids.ID) (*txs.SubnetMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetMetadata", arg0)
+	ret0, _ := ret[0].(*txs.SubnetMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetMetadata indicates an expected call of GetSubnetMetadata.
+func (mr *MockDiffMockRecorder) GetSubnetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetMetadata", reflect.TypeOf((*MockDiff)(nil).GetSubnetMetadata), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockDiff) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -502,6 +531,30 @@ func (mr *MockDiffMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetFeePrice mocks base method.
+func (m *MockDiff) SetFeePrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeePrice", arg0)
+}
+
+// SetFeePrice indicates an expected call of SetFeePrice.
+func (mr *MockDiffMockRecorder) SetFeePrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeePrice", reflect.TypeOf((*MockDiff)(nil).SetFeePrice), arg0)
+}
+
+// SetSubnetMetadata mocks base method.
+func (m *MockDiff) SetSubnetMetadata(arg0 ids.ID, arg1 *txs.SubnetMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetMetadata", arg0, arg1)
+}
+
+// SetSubnetMetadata indicates an expected call of SetSubnetMetadata.
+func (mr *MockDiffMockRecorder) SetSubnetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetMetadata", reflect.TypeOf((*MockDiff)(nil).SetSubnetMetadata), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockDiff) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -408,6 +408,20 @@ func (mr *MockStateMockRecorder) GetDelegateeReward(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockState)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetFeePrice mocks base method.
+func (m *MockState) GetFeePrice() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeePrice")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetFeePrice indicates an expected call of GetFeePrice.
+func (mr *MockStateMockRecorder) GetFeePrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeePrice", reflect.TypeOf((*MockState)(nil).GetFeePrice))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -497,6 +511,36 @@ func (mr *MockStateMockRecorder) GetStartTime(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartTime", reflect.TypeOf((*MockState)(nil).GetStartTime), arg0, arg1)
 }
 
+// GetStakingLedgerHeight mocks base method.
+func (m *MockState) GetStakingLedgerHeight() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakingLedgerHeight")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakingLedgerHeight indicates an expected call of GetStakingLedgerHeight.
+func (mr *MockStateMockRecorder) GetStakingLedgerHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingLedgerHeight", reflect.TypeOf((*MockState)(nil).GetStakingLedgerHeight))
+}
+
+// GetStakingRecords mocks base method.
+func (m *MockState) GetStakingRecords(arg0 ids.ShortID, arg1 uint64, arg2 int) ([]*StakingRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakingRecords", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*StakingRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakingRecords indicates an expected call of GetStakingRecords.
+func (mr *MockStateMockRecorder) GetStakingRecords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakingRecords", reflect.TypeOf((*MockState)(nil).GetStakingRecords), arg0, arg1, arg2)
+}
+
 // GetStatelessBlock mocks base method.
 func (m *MockState) GetStatelessBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -512,6 +556,21 @@ func (mr *MockStateMockRecorder) GetStatelessBlock(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatelessBlock", reflect.TypeOf((*MockState)(nil).GetStatelessBlock), arg0)
 }
 
+// GetSubnetMetadata mocks base method.
+func (m *MockState) GetSubnetMetadata(arg0 ids.ID) (*txs.SubnetMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetMetadata", arg0)
+	ret0, _ := ret[0].(*txs.SubnetMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetMetadata indicates an expected call of GetSubnetMetadata.
+func (mr *MockStateMockRecorder) GetSubnetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetMetadata", reflect.TypeOf((*MockState)(nil).GetSubnetMetadata), arg0)
+}
+
 // GetSubnetOwner mocks base method.
 func (m *MockState) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
 	m.ctrl.T.Helper()
@@ -680,6 +739,20 @@ func (mr *MockStateMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockState)(nil).PutPendingValidator), arg0)
 }
 
+// Rollback mocks base method.
+func (m *MockState) Rollback(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockStateMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockState)(nil).Rollback), arg0)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockState) SetCurrentSupply(arg0 ids.ID, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -706,6 +779,18 @@ func (mr *MockStateMockRecorder) SetDelegateeReward(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockState)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetFeePrice mocks base method.
+func (m *MockState) SetFeePrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeePrice", arg0)
+}
+
+// SetFeePrice indicates an expected call of SetFeePrice.
+func (mr *MockStateMockRecorder) SetFeePrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeePrice", reflect.TypeOf((*MockState)(nil).SetFeePrice), arg0)
+}
+
 // SetHeight mocks base method.
 func (m *MockState) SetHeight(arg0 uint64) {
 	m.ctrl.T.Helper()
@@ -730,6 +815,18 @@ func (mr *MockStateMockRecorder) SetLastAccepted(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), arg0)
 }
 
+// SetSubnetMetadata mocks base method.
+func (m *MockState) SetSubnetMetadata(arg0 ids.ID, arg1 *txs.SubnetMetadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSubnetMetadata", arg0, arg1)
+}
+
+// SetSubnetMetadata indicates an expected call of SetSubnetMetadata.
+func (mr *MockStateMockRecorder) SetSubnetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubnetMetadata", reflect.TypeOf((*MockState)(nil).SetSubnetMetadata), arg0, arg1)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockState) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
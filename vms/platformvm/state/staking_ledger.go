@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	ErrStakingLedgerDisabled = errors.New("staking ledger is disabled")
+
+	stakingLedgerPrefix    = []byte("stakingLedger")
+	stakingLedgerCountKey  = []byte("count")
+	stakingLedgerHeightKey = []byte("staking ledger height")
+)
+
+// StakingRecord is the outcome of a staking period, as seen by one of the
+// addresses that could be rewarded for it.
+type StakingRecord struct {
+	// TxID is the ID of the tx that added the staker.
+	TxID      ids.ID     `v0:"true"`
+	NodeID    ids.NodeID `v0:"true"`
+	SubnetID  ids.ID     `v0:"true"`
+	Delegator bool       `v0:"true"`
+	StartTime uint64     `v0:"true"`
+	EndTime   uint64     `v0:"true"`
+	Weight    uint64     `v0:"true"`
+	// Rewarded is true if the staker was rewarded for its staking period.
+	Rewarded bool `v0:"true"`
+	// Reward is the amount paid to this address when the staker was removed.
+	// This may be non-zero even if the staker was not rewarded, as delegation
+	// fees are paid to validators regardless of their own uptime.
+	Reward uint64 `v0:"true"`
+	// Height is the height of the block that removed the staker.
+	Height uint64 `v0:"true"`
+}
+
+// syncStakingLedger records the height from which the staking ledger is
+// complete. If the ledger was disabled, the height is removed so that the
+// ledger is marked as incomplete the next time it is enabled.
+func (s *state) syncStakingLedger() error {
+	hasHeight, err := s.singletonDB.Has(stakingLedgerHeightKey)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case s.stakingLedgerEnabled && !hasHeight:
+		lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+		if err != nil {
+			return err
+		}
+		height := lastAccepted.Height() + 1
+		if err := database.PutUInt64(s.singletonDB, stakingLedgerHeightKey, height); err != nil {
+			return err
+		}
+	case !s.stakingLedgerEnabled && hasHeight:
+		if err := s.singletonDB.Delete(stakingLedgerHeightKey); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	return s.Commit()
+}
+
+func (s *state) GetStakingLedgerHeight() (uint64, error) {
+	if !s.stakingLedgerEnabled {
+		return 0, ErrStakingLedgerDisabled
+	}
+	return database.GetUInt64(s.singletonDB, stakingLedgerHeightKey)
+}
+
+func (s *state) GetStakingRecords(addr ids.ShortID, start uint64, limit int) ([]*StakingRecord, error) {
+	if !s.stakingLedgerEnabled {
+		return nil, ErrStakingLedgerDisabled
+	}
+
+	addrDB := prefixdb.New(addr[:], s.stakingLedgerDB)
+	count, err := getStakingRecordCount(addrDB)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*StakingRecord
+	for i := start; i < count && len(records) < limit; i++ {
+		recordBytes, err := addrDB.Get(database.PackUInt64(i))
+		if err != nil {
+			return nil, err
+		}
+		record := &StakingRecord{}
+		if _, err := metadataCodec.Unmarshal(recordBytes, record); err != nil {
+			return nil, fmt.Errorf("failed to parse staking record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// writeStakingLedger records every staker removed from the current staker set.
+//
+// Invariant: Must be called before writeCurrentStakers and writeRewardUTXOs.
+func (s *state) writeStakingLedger(height uint64) error {
+	if !s.stakingLedgerEnabled {
+		return nil
+	}
+
+	var (
+		validators []*Staker
+		delegators []*Staker
+	)
+	for _, validatorDiffs := range s.currentStakers.validatorDiffs {
+		for _, validatorDiff := range validatorDiffs {
+			if validatorDiff.validatorStatus == deleted {
+				validators = append(validators, validatorDiff.validator)
+			}
+			for _, delegator := range validatorDiff.deletedDelegators {
+				delegators = append(delegators, delegator)
+			}
+		}
+	}
+	if len(validators) == 0 && len(delegators) == 0 {
+		return nil
+	}
+
+	// Stakers are only rewarded when a commit block is accepted.
+	rewarded := false
+	for _, blk := range s.addedBlocks {
+		switch blk.(type) {
+		case *block.BanffCommitBlock, *block.ApricotCommitBlock:
+			rewarded = true
+		}
+	}
+
+	// Sort the stakers so that the records are written in a deterministic
+	// order.
+	slices.SortFunc(validators, lessTxID)
+	slices.SortFunc(delegators, lessTxID)
+	for _, staker := range validators {
+		if err := s.writeStakingRecords(staker, false, rewarded, height); err != nil {
+			return err
+		}
+	}
+	for _, staker := range delegators {
+		if err := s.writeStakingRecords(staker, true, rewarded, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) writeStakingRecords(staker *Staker, delegator bool, rewarded bool, height uint64) error {
+	tx, _, err := s.GetTx(staker.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get staker tx %s: %w", staker.TxID, err)
+	}
+
+	var owners []fx.Owner
+	switch utx := tx.Unsigned.(type) {
+	case txs.ValidatorTx:
+		owners = []fx.Owner{utx.ValidationRewardsOwner(), utx.DelegationRewardsOwner()}
+	case txs.DelegatorTx:
+		owners = []fx.Owner{utx.RewardsOwner()}
+	}
+
+	addrs := set.Set[ids.ShortID]{}
+	for _, owner := range owners {
+		if owner, ok := owner.(*secp256k1fx.OutputOwners); ok {
+			addrs.Add(owner.Addrs...)
+		}
+	}
+
+	utxos, err := s.GetRewardUTXOs(staker.TxID)
+	if err != nil {
+		return fmt.Errorf("failed to get reward UTXOs of %s: %w", staker.TxID, err)
+	}
+	rewards := make(map[ids.ShortID]uint64)
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		for _, addr := range out.Addrs {
+			reward, err := safemath.Add64(rewards[addr], out.Amt)
+			if err != nil {
+				return err
+			}
+			rewards[addr] = reward
+			addrs.Add(addr)
+		}
+	}
+
+	addrList := addrs.List()
+	utils.Sort(addrList)
+	for _, addr := range addrList {
+		record := &StakingRecord{
+			TxID:      staker.TxID,
+			NodeID:    staker.NodeID,
+			SubnetID:  staker.SubnetID,
+			Delegator: delegator,
+			StartTime: uint64(staker.StartTime.Unix()),
+			EndTime:   uint64(staker.EndTime.Unix()),
+			Weight:    staker.Weight,
+			Rewarded:  rewarded,
+			Reward:    rewards[addr],
+			Height:    height,
+		}
+		if err := s.writeStakingRecord(addr, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) writeStakingRecord(addr ids.ShortID, record *StakingRecord) error {
+	addrDB := prefixdb.New(addr[:], s.stakingLedgerDB)
+	count, err := getStakingRecordCount(addrDB)
+	if err != nil {
+		return err
+	}
+
+	recordBytes, err := metadataCodec.Marshal(v0, record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize staking record: %w", err)
+	}
+	if err := addrDB.Put(database.PackUInt64(count), recordBytes); err != nil {
+		return fmt.Errorf("failed to write staking record: %w", err)
+	}
+	return database.PutUInt64(addrDB, stakingLedgerCountKey, count+1)
+}
+
+func getStakingRecordCount(db database.KeyValueReader) (uint64, error) {
+	count, err := database.GetUInt64(db, stakingLedgerCountKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return count, err
+}
+
+func lessTxID(a, b *Staker) bool {
+	return a.TxID.Less(b.TxID)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestStakingLedger(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+	s.(*state).stakingLedgerEnabled = true
+
+	var (
+		validationAddr = ids.GenerateTestShortID()
+		delegationAddr = ids.GenerateTestShortID()
+		nodeID         = ids.GenerateTestNodeID()
+		startTime      = initialTime.Add(time.Second)
+		endTime        = startTime.Add(24 * time.Hour)
+	)
+
+	validatorTx := &txs.Tx{Unsigned: &txs.AddValidatorTx{
+		Validator: txs.Validator{
+			NodeID: nodeID,
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(endTime.Unix()),
+			Wght:   2 * units.Avax,
+		},
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{validationAddr},
+		},
+		DelegationShares: reward.PercentDenominator,
+	}}
+	require.NoError(validatorTx.Initialize(txs.Codec))
+	validator, err := NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(txs.Staker), 10)
+	require.NoError(err)
+
+	delegatorTx := &txs.Tx{Unsigned: &txs.AddDelegatorTx{
+		Validator: txs.Validator{
+			NodeID: nodeID,
+			Start:  uint64(startTime.Unix()),
+			End:    uint64(endTime.Unix()),
+			Wght:   units.Avax,
+		},
+		DelegationRewardsOwner: &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{delegationAddr},
+		},
+	}}
+	require.NoError(delegatorTx.Initialize(txs.Codec))
+	delegator, err := NewCurrentStaker(delegatorTx.ID(), delegatorTx.Unsigned.(txs.Staker), 5)
+	require.NoError(err)
+
+	s.AddTx(validatorTx, status.Committed)
+	s.AddTx(delegatorTx, status.Committed)
+	s.PutCurrentValidator(validator)
+	s.PutCurrentDelegator(delegator)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// The delegator isn't rewarded
+	abortBlk, err := block.NewBanffAbortBlock(endTime, ids.GenerateTestID(), 2)
+	require.NoError(err)
+	s.AddStatelessBlock(abortBlk)
+	s.DeleteCurrentDelegator(delegator)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	// The validator is rewarded
+	commitBlk, err := block.NewBanffCommitBlock(endTime, ids.GenerateTestID(), 3)
+	require.NoError(err)
+	s.AddStatelessBlock(commitBlk)
+	s.DeleteCurrentValidator(validator)
+	s.AddRewardUTXO(validatorTx.ID(), &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: validatorTx.ID()},
+		Asset:  avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: validator.PotentialReward,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{validationAddr},
+			},
+		},
+	})
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	validatorRecord := &StakingRecord{
+		TxID:      validatorTx.ID(),
+		NodeID:    nodeID,
+		SubnetID:  validator.SubnetID,
+		Delegator: false,
+		StartTime: uint64(startTime.Unix()),
+		EndTime:   uint64(endTime.Unix()),
+		Weight:    2 * units.Avax,
+		Rewarded:  true,
+		Reward:    10,
+		Height:    3,
+	}
+	delegatorRecord := &StakingRecord{
+		TxID:      delegatorTx.ID(),
+		NodeID:    nodeID,
+		SubnetID:  delegator.SubnetID,
+		Delegator: true,
+		StartTime: uint64(startTime.Unix()),
+		EndTime:   uint64(endTime.Unix()),
+		Weight:    units.Avax,
+		Rewarded:  false,
+		Reward:    0,
+		Height:    2,
+	}
+
+	records, err := s.GetStakingRecords(validationAddr, 0, 10)
+	require.NoError(err)
+	require.Equal([]*StakingRecord{validatorRecord}, records)
+
+	records, err = s.GetStakingRecords(delegationAddr, 0, 10)
+	require.NoError(err)
+	require.Equal([]*StakingRecord{delegatorRecord}, records)
+
+	records, err = s.GetStakingRecords(delegationAddr, 1, 10)
+	require.NoError(err)
+	require.Empty(records)
+
+	records, err = s.GetStakingRecords(ids.GenerateTestShortID(), 0, 10)
+	require.NoError(err)
+	require.Empty(records)
+
+	s.(*state).stakingLedgerEnabled = false
+	_, err = s.GetStakingRecords(validationAddr, 0, 10)
+	require.ErrorIs(err, ErrStakingLedgerDisabled)
+}
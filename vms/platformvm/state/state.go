@@ -33,9 +33,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -45,6 +47,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
@@ -60,6 +63,9 @@ var (
 
 	errValidatorSetAlreadyPopulated = errors.New("validator set already populated")
 	errIsNotSubnet                  = errors.New("is not a subnet")
+	// The rollback request is dropped, rather than failing, if the journal is
+	// disabled.
+	errRollbackJournalDisabled = fmt.Errorf("%w: rollback journal is disabled", snowmanblock.ErrRollbackableVMNotImplemented)
 
 	blockIDPrefix                       = []byte("blockID")
 	blockPrefix                         = []byte("block")
@@ -79,12 +85,14 @@ var (
 	utxoPrefix                          = []byte("utxo")
 	subnetPrefix                        = []byte("subnet")
 	subnetOwnerPrefix                   = []byte("subnetOwner")
+	subnetMetadataPrefix                = []byte("subnetMetadata")
 	transformedSubnetPrefix             = []byte("transformedSubnet")
 	supplyPrefix                        = []byte("supply")
 	chainPrefix                         = []byte("chain")
 	singletonPrefix                     = []byte("singleton")
 
 	timestampKey      = []byte("timestamp")
+	feePriceKey       = []byte("fee price")
 	currentSupplyKey  = []byte("current supply")
 	lastAcceptedKey   = []byte("last accepted")
 	heightsIndexedKey = []byte("heights indexed")
@@ -103,6 +111,11 @@ type Chain interface {
 	GetTimestamp() time.Time
 	SetTimestamp(tm time.Time)
 
+	// GetFeePrice returns the price used to scale the fees of the txs
+	// included in the next block. See [gas.Config].
+	GetFeePrice() uint64
+	SetFeePrice(price uint64)
+
 	GetCurrentSupply(subnetID ids.ID) (uint64, error)
 	SetCurrentSupply(subnetID ids.ID, cs uint64)
 
@@ -115,6 +128,9 @@ type Chain interface {
 	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
 	SetSubnetOwner(subnetID ids.ID, owner fx.Owner)
 
+	GetSubnetMetadata(subnetID ids.ID) (*txs.SubnetMetadata, error)
+	SetSubnetMetadata(subnetID ids.ID, metadata *txs.SubnetMetadata)
+
 	GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error)
 	AddSubnetTransformation(transformSubnetTx *txs.Tx)
 
@@ -135,6 +151,14 @@ type State interface {
 
 	GetStatelessBlock(blockID ids.ID) (block.Block, error)
 
+	// GetStakingLedgerHeight returns the height from which the staking ledger
+	// contains every staking record.
+	GetStakingLedgerHeight() (uint64, error)
+
+	// GetStakingRecords returns up to [limit] staking records of [addr],
+	// starting from the [start]th record.
+	GetStakingRecords(addr ids.ShortID, start uint64, limit int) ([]*StakingRecord, error)
+
 	// Invariant: [block] is an accepted block.
 	AddStatelessBlock(block block.Block)
 
@@ -183,6 +207,13 @@ type State interface {
 
 	SetHeight(height uint64)
 
+	// Rollback reverts the database to the state that was committed when the
+	// block at [height] was accepted, and reloads the state from it. Fails if
+	// the rollback journal is disabled.
+	//
+	// Invariant: There are no uncommitted changes.
+	Rollback(height uint64) error
+
 	// Discard uncommitted changes to the database.
 	Abort()
 
@@ -275,28 +306,41 @@ type stateBlk struct {
  * |   '-- txID -> nil
  * |-. subnetOwners
  * | '-. subnetID -> owner
+ * |-. subnetMetadata
+ * | '-. subnetID -> metadata
  * |-. chains
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. stakingLedger
+ * | '-. address
+ * |   |-- count -> number of records
+ * |   '-- index -> staking record
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- prunedKey -> nil
  *   |-- timestampKey -> timestamp
+ *   |-- feePriceKey -> feePrice
  *   |-- currentSupplyKey -> currentSupply
  *   |-- lastAcceptedKey -> lastAccepted
- *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   |-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   '-- stakingLedgerHeightKey -> staking ledger start height
  */
 type state struct {
 	validatorState
 
 	cfg          *config.Config
+	execCfg      *config.ExecutionConfig
 	ctx          *snow.Context
 	metrics      metrics.Metrics
+	metricsReg   prometheus.Registerer
 	rewards      reward.Calculator
 	bootstrapped *utils.Atomic[bool]
 
-	baseDB *versiondb.Database
+	// journal records the writes of each accepted height so that they can be
+	// rolled back. It is nil if the rollback journal is disabled.
+	journal *chain.Journal
+	baseDB  *versiondb.Database
 
 	currentStakers *baseStakers
 	pendingStakers *baseStakers
@@ -358,6 +402,10 @@ type state struct {
 	subnetOwnerCache cache.Cacher[ids.ID, fxOwnerAndSize] // cache of subnetID -> owner if the entry is nil, it is not in the database
 	subnetOwnerDB    database.Database
 
+	subnetMetadata      map[ids.ID]*txs.SubnetMetadata            // map of subnetID -> metadata
+	subnetMetadataCache cache.Cacher[ids.ID, *txs.SubnetMetadata] // cache of subnetID -> metadata if the entry is nil, it is not in the database
+	subnetMetadataDB    database.Database
+
 	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
 	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
@@ -371,8 +419,12 @@ type state struct {
 	chainDBCache cache.Cacher[ids.ID, linkeddb.LinkedDB] // cache of subnetID -> linkedDB
 	chainDB      database.Database
 
+	stakingLedgerEnabled bool
+	stakingLedgerDB      database.Database
+
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
+	feePrice, persistedFeePrice           uint64
 	currentSupply, persistedCurrentSupply uint64
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
@@ -463,8 +515,19 @@ func New(
 	rewards reward.Calculator,
 	bootstrapped *utils.Atomic[bool],
 ) (State, error) {
+	// If the journal is disabled, any entries left from when it was enabled
+	// are outdated.
+	var journal *chain.Journal
+	if execCfg.RollbackJournalEnabled {
+		journal = chain.NewJournal(db, chain.DefaultJournalDepth)
+		db = journal
+	} else if err := chain.ClearJournal(db); err != nil {
+		return nil, fmt.Errorf("failed to clear rollback journal: %w", err)
+	}
+
 	s, err := newState(
 		db,
+		journal,
 		metrics,
 		cfg,
 		execCfg,
@@ -505,11 +568,17 @@ func New(
 		}
 	}
 
+	if err := s.syncStakingLedger(); err != nil {
+		return nil, fmt.Errorf("failed to sync staking ledger: %w", err)
+	}
+
+	s.registerValidatorLoggers()
 	return s, nil
 }
 
 func newState(
 	db database.Database,
+	journal *chain.Journal,
 	metrics metrics.Metrics,
 	cfg *config.Config,
 	execCfg *config.ExecutionConfig,
@@ -596,6 +665,15 @@ func newState(
 		return nil, err
 	}
 
+	subnetMetadataCache, err := metercacher.New[ids.ID, *txs.SubnetMetadata](
+		"subnet_metadata_cache",
+		metricsReg,
+		&cache.LRU[ids.ID, *txs.SubnetMetadata]{Size: execCfg.ChainCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	transformedSubnetCache, err := metercacher.New(
 		"transformed_subnet_cache",
 		metricsReg,
@@ -636,10 +714,13 @@ func newState(
 		validatorState: newValidatorState(),
 
 		cfg:          cfg,
+		execCfg:      execCfg,
 		ctx:          ctx,
 		metrics:      metrics,
+		metricsReg:   metricsReg,
 		rewards:      rewards,
 		bootstrapped: bootstrapped,
+		journal:      journal,
 		baseDB:       baseDB,
 
 		addedBlockIDs: make(map[uint64]ids.ID),
@@ -696,6 +777,10 @@ func newState(
 		subnetOwnerDB:    subnetOwnerDB,
 		subnetOwnerCache: subnetOwnerCache,
 
+		subnetMetadata:      make(map[ids.ID]*txs.SubnetMetadata),
+		subnetMetadataCache: subnetMetadataCache,
+		subnetMetadataDB:    prefixdb.New(subnetMetadataPrefix, baseDB),
+
 		transformedSubnets:     make(map[ids.ID]*txs.Tx),
 		transformedSubnetCache: transformedSubnetCache,
 		transformedSubnetDB:    prefixdb.New(transformedSubnetPrefix, baseDB),
@@ -709,6 +794,9 @@ func newState(
 		chainCache:   chainCache,
 		chainDBCache: chainDBCache,
 
+		stakingLedgerEnabled: execCfg.StakingLedgerEnabled,
+		stakingLedgerDB:      prefixdb.New(stakingLedgerPrefix, baseDB),
+
 		singletonDB: prefixdb.New(singletonPrefix, baseDB),
 	}, nil
 }
@@ -894,6 +982,39 @@ func (s *state) SetSubnetOwner(subnetID ids.ID, owner fx.Owner) {
 	s.subnetOwners[subnetID] = owner
 }
 
+func (s *state) GetSubnetMetadata(subnetID ids.ID) (*txs.SubnetMetadata, error) {
+	if metadata, exists := s.subnetMetadata[subnetID]; exists {
+		return metadata, nil
+	}
+
+	if metadata, cached := s.subnetMetadataCache.Get(subnetID); cached {
+		if metadata == nil {
+			return nil, database.ErrNotFound
+		}
+		return metadata, nil
+	}
+
+	metadataBytes, err := s.subnetMetadataDB.Get(subnetID[:])
+	if err == database.ErrNotFound {
+		s.subnetMetadataCache.Put(subnetID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &txs.SubnetMetadata{}
+	if _, err := txs.GenesisCodec.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, err
+	}
+	s.subnetMetadataCache.Put(subnetID, metadata)
+	return metadata, nil
+}
+
+func (s *state) SetSubnetMetadata(subnetID ids.ID, metadata *txs.SubnetMetadata) {
+	s.subnetMetadata[subnetID] = metadata
+}
+
 func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 	if tx, exists := s.transformedSubnets[subnetID]; exists {
 		return tx, nil
@@ -1092,6 +1213,14 @@ func (s *state) SetTimestamp(tm time.Time) {
 	s.timestamp = tm
 }
 
+func (s *state) GetFeePrice() uint64 {
+	return s.feePrice
+}
+
+func (s *state) SetFeePrice(price uint64) {
+	s.feePrice = price
+}
+
 func (s *state) GetLastAccepted() ids.ID {
 	return s.lastAccepted
 }
@@ -1420,6 +1549,18 @@ func (s *state) loadMetadata() error {
 	s.persistedTimestamp = timestamp
 	s.SetTimestamp(timestamp)
 
+	// The fee price is only written after the E upgrade.
+	feePrice, err := database.GetUInt64(s.singletonDB, feePriceKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		feePrice = 0
+	default:
+		return err
+	}
+	s.persistedFeePrice = feePrice
+	s.SetFeePrice(feePrice)
+
 	currentSupply, err := database.GetUInt64(s.singletonDB, currentSupplyKey)
 	if err != nil {
 		return err
@@ -1698,9 +1839,6 @@ func (s *state) initValidatorSets() error {
 		return err
 	}
 
-	vl := validators.NewLogger(s.ctx.Log, s.bootstrapped, constants.PrimaryNetworkID, s.ctx.NodeID)
-	s.cfg.Validators.RegisterCallbackListener(constants.PrimaryNetworkID, vl)
-
 	s.metrics.SetLocalStake(s.cfg.Validators.GetWeight(constants.PrimaryNetworkID, s.ctx.NodeID))
 	totalWeight, err := s.cfg.Validators.TotalWeight(constants.PrimaryNetworkID)
 	if err != nil {
@@ -1717,15 +1855,39 @@ func (s *state) initValidatorSets() error {
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
+// registerValidatorLoggers logs the changes of the validator sets that the
+// local node is part of.
+func (s *state) registerValidatorLoggers() {
+	vl := validators.NewLogger(s.ctx.Log, s.bootstrapped, constants.PrimaryNetworkID, s.ctx.NodeID)
+	s.cfg.Validators.RegisterCallbackListener(constants.PrimaryNetworkID, vl)
+
+	for subnetID := range s.cfg.TrackedSubnets {
 		vl := validators.NewLogger(s.ctx.Log, s.bootstrapped, subnetID, s.ctx.NodeID)
 		s.cfg.Validators.RegisterCallbackListener(subnetID, vl)
 	}
+}
+
+// clearValidatorSets removes the validators added by initValidatorSets and by
+// the writes of the accepted blocks.
+func (s *state) clearValidatorSets() error {
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, s.cfg.TrackedSubnets.List()...)
+	for _, subnetID := range subnetIDs {
+		for nodeID, vdr := range s.cfg.Validators.GetMap(subnetID) {
+			if err := s.cfg.Validators.RemoveWeight(subnetID, nodeID, vdr.Weight); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
 func (s *state) write(updateValidators bool, height uint64) error {
 	return utils.Err(
+		s.writeStakingLedger(height), // Must be called before writeCurrentStakers and writeRewardUTXOs
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height),
 		s.writePendingStakers(),
@@ -1735,6 +1897,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeUTXOs(),
 		s.writeSubnets(),
 		s.writeSubnetOwners(),
+		s.writeSubnetMetadata(),
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
@@ -1759,9 +1922,11 @@ func (s *state) Close() error {
 		s.rewardUTXODB.Close(),
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
+		s.subnetMetadataDB.Close(),
 		s.transformedSubnetDB.Close(),
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
+		s.stakingLedgerDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
@@ -1840,6 +2005,49 @@ func (s *state) SetHeight(height uint64) {
 
 	s.indexedHeights.UpperBound = height
 	s.currentHeight = height
+	if s.journal != nil {
+		s.journal.SetHeight(height)
+	}
+}
+
+func (s *state) Rollback(height uint64) error {
+	if s.journal == nil {
+		return errRollbackJournalDisabled
+	}
+
+	s.Abort()
+	if err := s.journal.Rollback(height); err != nil {
+		return err
+	}
+
+	// The state is reloaded into a new instance, so the caches are recreated
+	// and their metrics replace the metrics of the previous caches.
+	reloaded, err := newState(
+		s.journal,
+		s.journal,
+		s.metrics,
+		s.cfg,
+		s.execCfg,
+		s.ctx,
+		metric.NewReplacingRegisterer(s.metricsReg),
+		s.rewards,
+		s.bootstrapped,
+	)
+	if err != nil {
+		return err
+	}
+	if err := s.clearValidatorSets(); err != nil {
+		return fmt.Errorf("failed to clear validator sets: %w", err)
+	}
+	if err := reloaded.load(); err != nil {
+		return fmt.Errorf("failed to reload the database state: %w", err)
+	}
+	if err := reloaded.syncStakingLedger(); err != nil {
+		return fmt.Errorf("failed to sync staking ledger: %w", err)
+	}
+
+	*s = *reloaded
+	return nil
 }
 
 func (s *state) Commit() error {
@@ -2350,6 +2558,24 @@ func (s *state) writeSubnetOwners() error {
 	return nil
 }
 
+func (s *state) writeSubnetMetadata() error {
+	for subnetID, metadata := range s.subnetMetadata {
+		subnetID := subnetID
+		delete(s.subnetMetadata, subnetID)
+
+		metadataBytes, err := txs.GenesisCodec.Marshal(txs.Version, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subnet metadata: %w", err)
+		}
+
+		s.subnetMetadataCache.Put(subnetID, metadata)
+		if err := s.subnetMetadataDB.Put(subnetID[:], metadataBytes); err != nil {
+			return fmt.Errorf("failed to write subnet metadata: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeTransformedSubnets() error {
 	for subnetID, tx := range s.transformedSubnets {
 		txID := tx.ID()
@@ -2400,6 +2626,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedTimestamp = s.timestamp
 	}
+	if s.persistedFeePrice != s.feePrice {
+		if err := database.PutUInt64(s.singletonDB, feePriceKey, s.feePrice); err != nil {
+			return fmt.Errorf("failed to write fee price: %w", err)
+		}
+		s.persistedFeePrice = s.feePrice
+	}
 	if s.persistedCurrentSupply != s.currentSupply {
 		if err := database.PutUInt64(s.singletonDB, currentSupplyKey, s.currentSupply); err != nil {
 			return fmt.Errorf("failed to write current supply: %w", err)
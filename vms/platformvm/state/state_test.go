@@ -64,6 +64,14 @@ func TestStateInitialization(t *testing.T) {
 	require.False(shouldInit)
 }
 
+func TestStateRollbackJournalDisabled(t *testing.T) {
+	require := require.New(t)
+	s, _ := newUninitializedState(require)
+
+	err := s.Rollback(0)
+	require.ErrorIs(err, errRollbackJournalDisabled)
+}
+
 func TestStateSyncGenesis(t *testing.T) {
 	require := require.New(t)
 	state, _ := newInitializedState(require)
@@ -165,6 +173,7 @@ func newStateFromDB(require *require.Assertions, db database.Database) State {
 	execCfg, _ := config.GetExecutionConfig(nil)
 	state, err := newState(
 		db,
+		nil,
 		metrics.Noop,
 		&config.Config{
 			Validators: validators.NewManager(),
@@ -696,3 +705,54 @@ func TestStateSubnetOwner(t *testing.T) {
 	require.NoError(err)
 	require.Equal(owner2, owner)
 }
+
+func TestStateSubnetMetadata(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newInitializedState(require)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		metadata1 = &txs.SubnetMetadata{
+			Name: "subnet",
+		}
+		metadata2 = &txs.SubnetMetadata{
+			Name:    "renamed subnet",
+			Website: "https://example.com",
+			Contact: "admin@example.com",
+		}
+	)
+
+	metadata, err := s.GetSubnetMetadata(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Nil(metadata)
+
+	s.SetSubnetMetadata(subnetID, metadata1)
+	metadata, err = s.GetSubnetMetadata(subnetID)
+	require.NoError(err)
+	require.Equal(metadata1, metadata)
+
+	s.SetSubnetMetadata(subnetID, metadata2)
+	require.NoError(s.Commit())
+
+	// Read the metadata back from disk rather than from the cache.
+	s.(*state).subnetMetadataCache.Flush()
+	metadata, err = s.GetSubnetMetadata(subnetID)
+	require.NoError(err)
+	require.Equal(metadata2, metadata)
+}
+
+func TestStateFeePrice(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+	require.Zero(s.GetFeePrice())
+
+	s.SetFeePrice(12345)
+	require.Equal(uint64(12345), s.GetFeePrice())
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).loadMetadata())
+	require.Equal(uint64(12345), s.GetFeePrice())
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -270,21 +271,26 @@ func (b *builder) NewImportTx(
 
 	importedAVAX := importedAmounts[b.ctx.AVAXAssetID]
 
+	txFee, err := b.scaleFee(b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+
 	ins := []*avax.TransferableInput{}
 	outs := []*avax.TransferableOutput{}
 	switch {
-	case importedAVAX < b.cfg.TxFee: // imported amount goes toward paying tx fee
+	case importedAVAX < txFee: // imported amount goes toward paying tx fee
 		var baseSigners [][]*secp256k1.PrivateKey
-		ins, outs, _, baseSigners, err = b.Spend(b.state, keys, 0, b.cfg.TxFee-importedAVAX, changeAddr)
+		ins, outs, _, baseSigners, err = b.Spend(b.state, keys, 0, txFee-importedAVAX, changeAddr)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 		}
 		signers = append(baseSigners, signers...)
 		delete(importedAmounts, b.ctx.AVAXAssetID)
-	case importedAVAX == b.cfg.TxFee:
+	case importedAVAX == txFee:
 		delete(importedAmounts, b.ctx.AVAXAssetID)
 	default:
-		importedAmounts[b.ctx.AVAXAssetID] -= b.cfg.TxFee
+		importedAmounts[b.ctx.AVAXAssetID] -= txFee
 	}
 
 	for assetID, amount := range importedAmounts {
@@ -329,9 +335,13 @@ func (b *builder) NewExportTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
+	txFee, err := b.scaleFee(b.cfg.TxFee)
 	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
+		return nil, err
+	}
+	toBurn, err := math.Add64(amount, txFee)
+	if err != nil {
+		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, txFee)
 	}
 	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
 	if err != nil {
@@ -376,7 +386,10 @@ func (b *builder) NewCreateChainTx(
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
-	createBlockchainTxFee := b.cfg.GetCreateBlockchainTxFee(timestamp)
+	createBlockchainTxFee, err := b.scaleFee(b.cfg.GetCreateBlockchainTxFee(timestamp))
+	if err != nil {
+		return nil, err
+	}
 	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createBlockchainTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
@@ -420,7 +433,10 @@ func (b *builder) NewCreateSubnetTx(
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
 	timestamp := b.state.GetTimestamp()
-	createSubnetTxFee := b.cfg.GetCreateSubnetTxFee(timestamp)
+	createSubnetTxFee, err := b.scaleFee(b.cfg.GetCreateSubnetTxFee(timestamp))
+	if err != nil {
+		return nil, err
+	}
 	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, createSubnetTxFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
@@ -459,7 +475,11 @@ func (b *builder) NewAddValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
+	txFee, err := b.scaleFee(b.cfg.AddPrimaryNetworkValidatorFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unstakedOuts, stakedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -501,7 +521,11 @@ func (b *builder) NewAddDelegatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	txFee, err := b.scaleFee(b.cfg.AddPrimaryNetworkDelegatorFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(b.state, keys, stakeAmount, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -542,7 +566,11 @@ func (b *builder) NewAddSubnetValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	txFee, err := b.scaleFee(b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -585,7 +613,11 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	txFee, err := b.scaleFee(b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -641,7 +673,11 @@ func (b *builder) NewTransferSubnetOwnershipTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, b.cfg.TxFee, changeAddr)
+	txFee, err := b.scaleFee(b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, txFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
@@ -679,9 +715,13 @@ func (b *builder) NewBaseTx(
 	keys []*secp256k1.PrivateKey,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	toBurn, err := math.Add64(amount, b.cfg.TxFee)
+	txFee, err := b.scaleFee(b.cfg.TxFee)
+	if err != nil {
+		return nil, err
+	}
+	toBurn, err := math.Add64(amount, txFee)
 	if err != nil {
-		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, b.cfg.TxFee)
+		return nil, fmt.Errorf("amount (%d) + tx fee(%d) overflows", amount, txFee)
 	}
 	ins, outs, _, signers, err := b.Spend(b.state, keys, 0, toBurn, changeAddr)
 	if err != nil {
@@ -712,3 +752,14 @@ func (b *builder) NewBaseTx(
 	}
 	return tx, tx.SyntacticVerify(b.ctx)
 }
+
+// scaleFee returns the amount of AVAX a tx with [staticFee] must burn to be
+// accepted on top of the last accepted state.
+func (b *builder) scaleFee(staticFee uint64) (uint64, error) {
+	return fee.Scale(
+		b.cfg,
+		b.state.GetTimestamp(),
+		b.state.GetFeePrice(),
+		staticFee,
+	)
+}
@@ -107,5 +107,7 @@ func RegisterDUnsignedTxsTypes(targetCodec linearcodec.Codec) error {
 	return utils.Err(
 		targetCodec.RegisterType(&TransferSubnetOwnershipTx{}),
 		targetCodec.RegisterType(&BaseTx{}),
+		targetCodec.RegisterType(&SetSubnetMetadataTx{}),
+		targetCodec.RegisterType(&ExitSubnetValidatorTx{}),
 	)
 }
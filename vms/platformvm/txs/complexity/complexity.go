@@ -0,0 +1,238 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package complexity
+
+import (
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ txs.Visitor = (*calculator)(nil)
+
+// Dimensions describes the cost of executing a tx.
+type Dimensions struct {
+	// Bytes is the length of the signed tx.
+	Bytes uint64 `json:"bytes"`
+	// UTXOReads is the number of UTXOs consumed by the tx, including imported
+	// UTXOs.
+	UTXOReads uint64 `json:"utxoReads"`
+	// UTXOWrites is the number of UTXOs produced by the tx, including exported
+	// and staked UTXOs.
+	UTXOWrites uint64 `json:"utxoWrites"`
+	// Signatures is the number of signatures verified by the tx.
+	Signatures uint64 `json:"signatures"`
+	// StakerChanges is the number of stakers added to or removed from the
+	// staker set by the tx.
+	StakerChanges uint64 `json:"stakerChanges"`
+}
+
+// Add returns the sum of [d] and [o].
+func (d Dimensions) Add(o Dimensions) (Dimensions, error) {
+	var (
+		sum Dimensions
+		err error
+	)
+	if sum.Bytes, err = safemath.Add64(d.Bytes, o.Bytes); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.UTXOReads, err = safemath.Add64(d.UTXOReads, o.UTXOReads); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.UTXOWrites, err = safemath.Add64(d.UTXOWrites, o.UTXOWrites); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.Signatures, err = safemath.Add64(d.Signatures, o.Signatures); err != nil {
+		return Dimensions{}, err
+	}
+	if sum.StakerChanges, err = safemath.Add64(d.StakerChanges, o.StakerChanges); err != nil {
+		return Dimensions{}, err
+	}
+	return sum, nil
+}
+
+// Gas returns the amount of gas consumed by [d], where each dimension is
+// charged the corresponding amount in [weights].
+func (d Dimensions) Gas(weights Dimensions) (uint64, error) {
+	var gas uint64
+	for _, dimension := range [...]struct {
+		amount uint64
+		weight uint64
+	}{
+		{amount: d.Bytes, weight: weights.Bytes},
+		{amount: d.UTXOReads, weight: weights.UTXOReads},
+		{amount: d.UTXOWrites, weight: weights.UTXOWrites},
+		{amount: d.Signatures, weight: weights.Signatures},
+		{amount: d.StakerChanges, weight: weights.StakerChanges},
+	} {
+		dimensionGas, err := safemath.Mul64(dimension.amount, dimension.weight)
+		if err != nil {
+			return 0, err
+		}
+		gas, err = safemath.Add64(gas, dimensionGas)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return gas, nil
+}
+
+// Calculate returns the complexity of [tx].
+//
+// Only the work that can be derived from the tx itself is reported. For
+// example, the UTXOs produced when a staker is rewarded and the stakers
+// removed when the chain time is advanced depend on the state and aren't
+// included.
+func Calculate(tx *txs.Tx) Dimensions {
+	c := &calculator{
+		dimensions: Dimensions{
+			Bytes: uint64(len(tx.Bytes())),
+		},
+	}
+	// calculator never returns an error
+	_ = tx.Unsigned.Visit(c)
+	return c.dimensions
+}
+
+type calculator struct {
+	dimensions Dimensions
+}
+
+func (c *calculator) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.dimensions.UTXOWrites += uint64(len(tx.StakeOuts))
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.subnetAuth(tx.SubnetAuth)
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.dimensions.UTXOWrites += uint64(len(tx.StakeOuts))
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) CreateChainTx(tx *txs.CreateChainTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.subnetAuth(tx.SubnetAuth)
+	return nil
+}
+
+func (c *calculator) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	c.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (c *calculator) ImportTx(tx *txs.ImportTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.inputs(tx.ImportedInputs)
+	return nil
+}
+
+func (c *calculator) ExportTx(tx *txs.ExportTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.dimensions.UTXOWrites += uint64(len(tx.ExportedOutputs))
+	return nil
+}
+
+func (*calculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+func (c *calculator) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.subnetAuth(tx.SubnetAuth)
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.subnetAuth(tx.SubnetAuth)
+	return nil
+}
+
+func (c *calculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.dimensions.UTXOWrites += uint64(len(tx.StakeOuts))
+	if _, ok := tx.Signer.(*signer.ProofOfPossession); ok {
+		c.dimensions.Signatures++
+	}
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.dimensions.UTXOWrites += uint64(len(tx.StakeOuts))
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.subnetAuth(tx.SubnetAuth)
+	return nil
+}
+
+func (c *calculator) BaseTx(tx *txs.BaseTx) error {
+	c.baseTx(tx)
+	return nil
+}
+
+func (c *calculator) SetSubnetMetadataTx(tx *txs.SetSubnetMetadataTx) error {
+	c.baseTx(&tx.BaseTx)
+	c.subnetAuth(tx.SubnetAuth)
+	return nil
+}
+
+func (c *calculator) ExitSubnetValidatorTx(tx *txs.ExitSubnetValidatorTx) error {
+	c.baseTx(&tx.BaseTx)
+	// The exit signature is signed by either the staking key or the BLS key of
+	// the validator.
+	c.dimensions.Signatures++
+	c.dimensions.StakerChanges = 1
+	return nil
+}
+
+func (c *calculator) baseTx(tx *txs.BaseTx) {
+	c.inputs(tx.Ins)
+	c.dimensions.UTXOWrites += uint64(len(tx.Outs))
+}
+
+func (c *calculator) inputs(ins []*avax.TransferableInput) {
+	c.dimensions.UTXOReads += uint64(len(ins))
+	for _, in := range ins {
+		input := in.In
+		if lockIn, ok := input.(*stakeable.LockIn); ok {
+			input = lockIn.TransferableIn
+		}
+		if transferInput, ok := input.(*secp256k1fx.TransferInput); ok {
+			c.dimensions.Signatures += uint64(len(transferInput.SigIndices))
+		}
+	}
+}
+
+func (c *calculator) subnetAuth(subnetAuth verify.Verifiable) {
+	if input, ok := subnetAuth.(*secp256k1fx.Input); ok {
+		c.dimensions.Signatures += uint64(len(input.SigIndices))
+	}
+}
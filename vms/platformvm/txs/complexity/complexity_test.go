@@ -0,0 +1,265 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package complexity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestCalculate(t *testing.T) {
+	baseTx := txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{
+				{
+					In: &secp256k1fx.TransferInput{
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0, 1},
+						},
+					},
+				},
+				{
+					In: &stakeable.LockIn{
+						TransferableIn: &secp256k1fx.TransferInput{
+							Input: secp256k1fx.Input{
+								SigIndices: []uint32{0},
+							},
+						},
+					},
+				},
+			},
+			Outs: []*avax.TransferableOutput{
+				{Out: &secp256k1fx.TransferOutput{}},
+			},
+		},
+	}
+	subnetAuth := &secp256k1fx.Input{
+		SigIndices: []uint32{0, 1, 2},
+	}
+	stakeOuts := []*avax.TransferableOutput{
+		{Out: &secp256k1fx.TransferOutput{}},
+		{Out: &secp256k1fx.TransferOutput{}},
+	}
+	baseDimensions := Dimensions{
+		Bytes:      10,
+		UTXOReads:  2,
+		UTXOWrites: 1,
+		Signatures: 3,
+	}
+
+	tests := []struct {
+		name               string
+		unsignedTx         txs.UnsignedTx
+		expectedDimensions Dimensions
+	}{
+		{
+			name:               "BaseTx",
+			unsignedTx:         &baseTx,
+			expectedDimensions: baseDimensions,
+		},
+		{
+			name:       "AdvanceTimeTx",
+			unsignedTx: &txs.AdvanceTimeTx{},
+			expectedDimensions: Dimensions{
+				Bytes: 10,
+			},
+		},
+		{
+			name:       "RewardValidatorTx",
+			unsignedTx: &txs.RewardValidatorTx{},
+			expectedDimensions: Dimensions{
+				Bytes:         10,
+				StakerChanges: 1,
+			},
+		},
+		{
+			name: "ImportTx",
+			unsignedTx: &txs.ImportTx{
+				BaseTx: baseTx,
+				ImportedInputs: []*avax.TransferableInput{
+					{
+						In: &secp256k1fx.TransferInput{
+							Input: secp256k1fx.Input{
+								SigIndices: []uint32{0, 1},
+							},
+						},
+					},
+				},
+			},
+			expectedDimensions: Dimensions{
+				Bytes:      10,
+				UTXOReads:  3,
+				UTXOWrites: 1,
+				Signatures: 5,
+			},
+		},
+		{
+			name: "ExportTx",
+			unsignedTx: &txs.ExportTx{
+				BaseTx: baseTx,
+				ExportedOutputs: []*avax.TransferableOutput{
+					{Out: &secp256k1fx.TransferOutput{}},
+				},
+			},
+			expectedDimensions: Dimensions{
+				Bytes:      10,
+				UTXOReads:  2,
+				UTXOWrites: 2,
+				Signatures: 3,
+			},
+		},
+		{
+			name: "CreateChainTx",
+			unsignedTx: &txs.CreateChainTx{
+				BaseTx:     baseTx,
+				SubnetAuth: subnetAuth,
+			},
+			expectedDimensions: Dimensions{
+				Bytes:      10,
+				UTXOReads:  2,
+				UTXOWrites: 1,
+				Signatures: 6,
+			},
+		},
+		{
+			name: "AddSubnetValidatorTx",
+			unsignedTx: &txs.AddSubnetValidatorTx{
+				BaseTx:     baseTx,
+				SubnetAuth: subnetAuth,
+			},
+			expectedDimensions: Dimensions{
+				Bytes:         10,
+				UTXOReads:     2,
+				UTXOWrites:    1,
+				Signatures:    6,
+				StakerChanges: 1,
+			},
+		},
+		{
+			name: "AddPermissionlessValidatorTx with signer",
+			unsignedTx: &txs.AddPermissionlessValidatorTx{
+				BaseTx:    baseTx,
+				Signer:    &signer.ProofOfPossession{},
+				StakeOuts: stakeOuts,
+			},
+			expectedDimensions: Dimensions{
+				Bytes:         10,
+				UTXOReads:     2,
+				UTXOWrites:    3,
+				Signatures:    4,
+				StakerChanges: 1,
+			},
+		},
+		{
+			name: "AddPermissionlessValidatorTx without signer",
+			unsignedTx: &txs.AddPermissionlessValidatorTx{
+				BaseTx:    baseTx,
+				Signer:    &signer.Empty{},
+				StakeOuts: stakeOuts,
+			},
+			expectedDimensions: Dimensions{
+				Bytes:         10,
+				UTXOReads:     2,
+				UTXOWrites:    3,
+				Signatures:    3,
+				StakerChanges: 1,
+			},
+		},
+		{
+			name: "AddPermissionlessDelegatorTx",
+			unsignedTx: &txs.AddPermissionlessDelegatorTx{
+				BaseTx:    baseTx,
+				StakeOuts: stakeOuts,
+			},
+			expectedDimensions: Dimensions{
+				Bytes:         10,
+				UTXOReads:     2,
+				UTXOWrites:    3,
+				Signatures:    3,
+				StakerChanges: 1,
+			},
+		},
+		{
+			name: "ExitSubnetValidatorTx",
+			unsignedTx: &txs.ExitSubnetValidatorTx{
+				BaseTx: baseTx,
+			},
+			expectedDimensions: Dimensions{
+				Bytes:         10,
+				UTXOReads:     2,
+				UTXOWrites:    1,
+				Signatures:    4,
+				StakerChanges: 1,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := &txs.Tx{Unsigned: test.unsignedTx}
+			tx.SetBytes(nil, make([]byte, 10))
+			require.Equal(t, test.expectedDimensions, Calculate(tx))
+		})
+	}
+}
+
+func TestDimensionsAdd(t *testing.T) {
+	require := require.New(t)
+
+	d := Dimensions{
+		Bytes:         1,
+		UTXOReads:     2,
+		UTXOWrites:    3,
+		Signatures:    4,
+		StakerChanges: 5,
+	}
+	sum, err := d.Add(d)
+	require.NoError(err)
+	require.Equal(Dimensions{
+		Bytes:         2,
+		UTXOReads:     4,
+		UTXOWrites:    6,
+		Signatures:    8,
+		StakerChanges: 10,
+	}, sum)
+
+	_, err = d.Add(Dimensions{StakerChanges: math.MaxUint64})
+	require.ErrorIs(err, safemath.ErrOverflow)
+}
+
+func TestDimensionsGas(t *testing.T) {
+	require := require.New(t)
+
+	d := Dimensions{
+		Bytes:         1,
+		UTXOReads:     2,
+		UTXOWrites:    3,
+		Signatures:    4,
+		StakerChanges: 5,
+	}
+	gas, err := d.Gas(Dimensions{
+		Bytes:         1,
+		UTXOReads:     10,
+		UTXOWrites:    100,
+		Signatures:    1_000,
+		StakerChanges: 10_000,
+	})
+	require.NoError(err)
+	require.Equal(uint64(54_321), gas)
+
+	_, err = d.Gas(Dimensions{StakerChanges: math.MaxUint64})
+	require.ErrorIs(err, safemath.ErrOverflow)
+
+	_, err = Dimensions{Bytes: math.MaxUint64, UTXOReads: 1}.Gas(Dimensions{Bytes: 1, UTXOReads: 1})
+	require.ErrorIs(err, safemath.ErrOverflow)
+}
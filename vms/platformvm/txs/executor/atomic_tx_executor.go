@@ -80,6 +80,14 @@ func (*AtomicTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*AtomicTxExecutor) SetSubnetMetadataTx(*txs.SetSubnetMetadataTx) error {
+	return ErrWrongTxType
+}
+
+func (*AtomicTxExecutor) ExitSubnetValidatorTx(*txs.ExitSubnetValidatorTx) error {
+	return ErrWrongTxType
+}
+
 func (e *AtomicTxExecutor) ImportTx(tx *txs.ImportTx) error {
 	return e.atomicTx(tx)
 }
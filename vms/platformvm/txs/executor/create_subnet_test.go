@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
@@ -87,3 +88,84 @@ func TestCreateSubnetTxAP3FeeChange(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSubnetTxDynamicFee(t *testing.T) {
+	eTime := defaultGenesisTime.Add(time.Hour)
+	tests := []struct {
+		name        string
+		time        time.Time
+		price       uint64
+		fee         uint64
+		expectedErr error
+	}{
+		{
+			name:        "pre-fork - price ignored",
+			time:        defaultGenesisTime,
+			price:       2 * gas.PriceDenominator,
+			fee:         100 * defaultTxFee,
+			expectedErr: nil,
+		},
+		{
+			name:        "post-fork - incorrectly priced",
+			time:        eTime,
+			price:       2 * gas.PriceDenominator,
+			fee:         200*defaultTxFee - 1*units.NanoAvax,
+			expectedErr: utxo.ErrInsufficientUnlockedFunds,
+		},
+		{
+			name:        "post-fork - correctly priced",
+			time:        eTime,
+			price:       2 * gas.PriceDenominator,
+			fee:         200 * defaultTxFee,
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			env := newEnvironment(t, true /*=postBanff*/, true /*=postCortina*/)
+			env.config.ApricotPhase3Time = defaultGenesisTime
+			env.config.ETime = eTime
+			env.config.DynamicFeeConfig = gas.Config{
+				TargetBlockGas:    units.KiB,
+				MaxPrice:          10 * gas.PriceDenominator,
+				ChangeDenominator: 8,
+			}
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownEnvironment(env))
+			}()
+
+			ins, outs, _, signers, err := env.utxosHandler.Spend(env.state, preFundedKeys, 0, test.fee, ids.ShortEmpty)
+			require.NoError(err)
+
+			// Create the tx
+			utx := &txs.CreateSubnetTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    env.ctx.NetworkID,
+					BlockchainID: env.ctx.ChainID,
+					Ins:          ins,
+					Outs:         outs,
+				}},
+				Owner: &secp256k1fx.OutputOwners{},
+			}
+			tx := &txs.Tx{Unsigned: utx}
+			require.NoError(tx.Sign(txs.Codec, signers))
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			stateDiff.SetTimestamp(test.time)
+			stateDiff.SetFeePrice(test.price)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
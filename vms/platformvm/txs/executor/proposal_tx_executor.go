@@ -105,6 +105,14 @@ func (*ProposalTxExecutor) BaseTx(*txs.BaseTx) error {
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) SetSubnetMetadataTx(*txs.SetSubnetMetadataTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) ExitSubnetValidatorTx(*txs.ExitSubnetValidatorTx) error {
+	return ErrWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
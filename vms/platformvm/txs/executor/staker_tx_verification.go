@@ -10,7 +10,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -38,6 +40,7 @@ var (
 	ErrDelegateToPermissionedValidator = errors.New("delegation to permissioned validator")
 	ErrWrongStakedAssetID              = errors.New("incorrect staked assetID")
 	ErrDUpgradeNotActive               = errors.New("attempting to use a D-upgrade feature prior to activation")
+	ErrMissingPublicKey                = errors.New("validator has no BLS public key")
 )
 
 // verifySubnetValidatorPrimaryNetworkRequirements verifies the primary
@@ -152,6 +155,10 @@ func verifyAddValidatorTx(
 	}
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -159,7 +166,7 @@ func verifyAddValidatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddPrimaryNetworkValidatorFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -242,6 +249,10 @@ func verifyAddSubnetValidatorTx(
 	}
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -249,7 +260,7 @@ func verifyAddSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddSubnetValidatorFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -316,6 +327,10 @@ func verifyRemoveSubnetValidatorTx(
 	}
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, false, err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -323,7 +338,7 @@ func verifyRemoveSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, false, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -332,6 +347,127 @@ func verifyRemoveSubnetValidatorTx(
 	return vdr, isCurrentValidator, nil
 }
 
+// verifyExitSubnetValidatorTx carries out the validation for an
+// ExitSubnetValidatorTx. The returned boolean indicates whether the validator
+// is currently validating the subnet.
+func verifyExitSubnetValidatorTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.ExitSubnetValidatorTx,
+) (*state.Staker, bool, error) {
+	if !backend.Config.IsDActivated(chainState.GetTimestamp()) {
+		return nil, false, ErrDUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, false, err
+	}
+
+	isCurrentValidator := true
+	vdr, err := chainState.GetCurrentValidator(tx.Subnet, tx.NodeID)
+	if err == database.ErrNotFound {
+		vdr, err = chainState.GetPendingValidator(tx.Subnet, tx.NodeID)
+		isCurrentValidator = false
+	}
+	if err != nil {
+		// It isn't a current or pending validator.
+		return nil, false, fmt.Errorf(
+			"%s %w of %s: %w",
+			tx.NodeID,
+			ErrNotValidator,
+			tx.Subnet,
+			err,
+		)
+	}
+
+	if !vdr.Priority.IsPermissionedValidator() {
+		return nil, false, ErrRemovePermissionlessValidator
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return vdr, isCurrentValidator, nil
+	}
+
+	if err := verifyExitSignature(backend, chainState, tx, vdr.TxID); err != nil {
+		return nil, false, err
+	}
+
+	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		sTx.Creds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return nil, false, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return vdr, isCurrentValidator, nil
+}
+
+// verifyExitSignature verifies that [tx.Signature] was produced by the
+// staking key in [tx.StakingCertificate] or, if no certificate was provided,
+// by the BLS key of the primary network validator [tx.NodeID].
+func verifyExitSignature(
+	backend *Backend,
+	chainState state.Chain,
+	tx *txs.ExitSubnetValidatorTx,
+	validatorTxID ids.ID,
+) error {
+	msg := txs.ExitSubnetValidatorMessage(
+		backend.Ctx.NetworkID,
+		backend.Ctx.ChainID,
+		tx.Subnet,
+		tx.NodeID,
+		validatorTxID,
+	)
+
+	// The certificate was checked to belong to [tx.NodeID] during syntactic
+	// verification.
+	if len(tx.StakingCertificate) != 0 {
+		cert, err := staking.ParseCertificate(tx.StakingCertificate)
+		if err != nil {
+			return fmt.Errorf("failed to parse staking certificate: %w", err)
+		}
+		if err := staking.CheckSignature(cert, msg, tx.Signature); err != nil {
+			return fmt.Errorf("%w: %w", txs.ErrInvalidExitSignature, err)
+		}
+		return nil
+	}
+
+	primaryNetworkValidator, err := GetValidator(chainState, constants.PrimaryNetworkID, tx.NodeID)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to fetch the primary network validator for %s: %w",
+			tx.NodeID,
+			err,
+		)
+	}
+	if primaryNetworkValidator.PublicKey == nil {
+		return fmt.Errorf("%w: %s", ErrMissingPublicKey, tx.NodeID)
+	}
+
+	sig, err := bls.SignatureFromBytes(tx.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", txs.ErrInvalidExitSignature, err)
+	}
+	if !bls.Verify(primaryNetworkValidator.PublicKey, sig, msg) {
+		return txs.ErrInvalidExitSignature
+	}
+	return nil
+}
+
 // verifyAddDelegatorTx carries out the validation for an AddDelegatorTx.
 // It returns the tx outputs that should be returned if this delegator is not
 // added to the staking set.
@@ -425,6 +561,10 @@ func verifyAddDelegatorTx(
 	}
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return nil, err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -432,7 +572,7 @@ func verifyAddDelegatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.AddPrimaryNetworkDelegatorFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
@@ -533,15 +673,10 @@ func verifyAddPermissionlessValidatorTx(
 		)
 	}
 
-	var txFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
 		if err := verifySubnetValidatorPrimaryNetworkRequirements(chainState, tx.Validator); err != nil {
 			return err
 		}
-
-		txFee = backend.Config.AddSubnetValidatorFee
-	} else {
-		txFee = backend.Config.AddPrimaryNetworkValidatorFee
 	}
 
 	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
@@ -549,6 +684,10 @@ func verifyAddPermissionlessValidatorTx(
 	copy(outs[len(tx.Outs):], tx.StakeOuts)
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -675,7 +814,6 @@ func verifyAddPermissionlessDelegatorTx(
 	copy(outs, tx.Outs)
 	copy(outs[len(tx.Outs):], tx.StakeOuts)
 
-	var txFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
 		// Invariant: Delegators must only be able to reference validator
 		//            transactions that implement [txs.ValidatorTx]. All
@@ -686,13 +824,13 @@ func verifyAddPermissionlessDelegatorTx(
 		if validator.Priority.IsPermissionedValidator() {
 			return ErrDelegateToPermissionedValidator
 		}
-
-		txFee = backend.Config.AddSubnetDelegatorFee
-	} else {
-		txFee = backend.Config.AddPrimaryNetworkDelegatorFee
 	}
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -747,6 +885,61 @@ func verifyTransferSubnetOwnershipTx(
 	}
 
 	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: txFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
+	}
+
+	return nil
+}
+
+// Returns an error if the given tx is invalid.
+// The transaction is valid if:
+// * [sTx]'s creds authorize it to spend the stated inputs.
+// * [sTx]'s creds authorize it to modify [tx.Subnet].
+// * The flow checker passes.
+func verifySetSubnetMetadataTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.SetSubnetMetadataTx,
+) error {
+	if !backend.Config.IsDActivated(chainState.GetTimestamp()) {
+		return ErrDUpgradeNotActive
+	}
+
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return err
+	}
+
+	if !backend.Bootstrapped.Get() {
+		// Not bootstrapped yet -- don't need to do full verification.
+		return nil
+	}
+
+	baseTxCreds, err := verifySubnetAuthorization(backend, chainState, sTx, tx.Subnet, tx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+
+	// Verify the flowcheck
+	txFee, err := calculateFee(backend, chainState, tx)
+	if err != nil {
+		return err
+	}
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
 		chainState,
@@ -754,7 +947,7 @@ func verifyTransferSubnetOwnershipTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.AVAXAssetID: backend.Config.TxFee,
+			backend.Ctx.AVAXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %w", ErrFlowCheckFailed, err)
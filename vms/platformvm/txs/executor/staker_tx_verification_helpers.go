@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 )
 
 type addValidatorRules struct {
@@ -262,3 +263,14 @@ func GetTransformSubnetTx(chain state.Chain, subnetID ids.ID) (*txs.TransformSub
 
 	return transformSubnet, nil
 }
+
+// calculateFee returns the amount of AVAX [tx] must burn to be accepted on top
+// of [chainState].
+func calculateFee(backend *Backend, chainState state.Chain, tx txs.UnsignedTx) (uint64, error) {
+	return fee.Fee(
+		backend.Config,
+		chainState.GetTimestamp(),
+		chainState.GetFeePrice(),
+		tx,
+	)
+}
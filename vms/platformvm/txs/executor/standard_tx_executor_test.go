@@ -4,6 +4,8 @@
 package executor
 
 import (
+	"crypto"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"math"
@@ -17,8 +19,10 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -1088,6 +1092,8 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
 				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
+				env.state.EXPECT().GetTimestamp().Return(time.Time{})
+				env.state.EXPECT().GetFeePrice().Return(uint64(0))
 				env.flowChecker.EXPECT().VerifySpend(
 					env.unsignedTx, env.state, env.unsignedTx.Ins, env.unsignedTx.Outs, env.tx.Creds[:len(env.tx.Creds)-1], gomock.Any(),
 				).Return(nil).Times(1)
@@ -1277,6 +1283,8 @@ func TestStandardExecutorRemoveSubnetValidatorTx(t *testing.T) {
 				subnetOwner := fx.NewMockOwner(ctrl)
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil)
+				env.state.EXPECT().GetTimestamp().Return(time.Time{})
+				env.state.EXPECT().GetFeePrice().Return(uint64(0))
 				env.flowChecker.EXPECT().VerifySpend(
 					gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 				).Return(errTest)
@@ -1420,6 +1428,265 @@ func newValidTransformSubnetTxVerifyEnv(t *testing.T, ctrl *gomock.Controller) t
 	}
 }
 
+func newExitSubnetValidatorTx(
+	t *testing.T,
+	nodeID ids.NodeID,
+	stakingCertificate []byte,
+	sign func(subnetID ids.ID) []byte,
+) (*txs.ExitSubnetValidatorTx, *txs.Tx) {
+	t.Helper()
+
+	subnetID := ids.GenerateTestID()
+	unsignedTx := &txs.ExitSubnetValidatorTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{
+						ID: ids.GenerateTestID(),
+					},
+					In: &secp256k1fx.TransferInput{
+						Amt: 1,
+						Input: secp256k1fx.Input{
+							SigIndices: []uint32{0},
+						},
+					},
+				}},
+				Memo: []byte("hi"),
+			},
+		},
+		NodeID:             nodeID,
+		Subnet:             subnetID,
+		StakingCertificate: stakingCertificate,
+		Signature:          sign(subnetID),
+	}
+	tx := &txs.Tx{
+		Unsigned: unsignedTx,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{
+				Sigs: make([][65]byte, 1),
+			},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return unsignedTx, tx
+}
+
+func TestStandardExecutorExitSubnetValidatorTx(t *testing.T) {
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+	cert := staking.CertificateFromX509(tlsCert.Leaf)
+	certNodeID := ids.NodeIDFromCert(cert)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	var (
+		dTime         = time.Now()
+		validatorTxID = ids.GenerateTestID()
+		blsNodeID     = ids.GenerateTestNodeID()
+	)
+
+	// The executor's context has the zero network and chain IDs.
+	signStaking := func(subnetID ids.ID) []byte {
+		msg := txs.ExitSubnetValidatorMessage(0, ids.Empty, subnetID, certNodeID, validatorTxID)
+		sig, err := tlsCert.PrivateKey.(crypto.Signer).Sign(rand.Reader, hashing.ComputeHash256(msg), crypto.SHA256)
+		require.NoError(t, err)
+		return sig
+	}
+	signBLS := func(subnetID ids.ID) []byte {
+		msg := txs.ExitSubnetValidatorMessage(0, ids.Empty, subnetID, blsNodeID, validatorTxID)
+		return bls.SignatureToBytes(bls.Sign(sk, msg))
+	}
+	// Signatures over a different subnet are invalid.
+	signStakingWrongSubnet := func(ids.ID) []byte {
+		return signStaking(ids.GenerateTestID())
+	}
+	signBLSWrongSubnet := func(ids.ID) []byte {
+		return signBLS(ids.GenerateTestID())
+	}
+
+	newStaker := func(nodeID ids.NodeID) *state.Staker {
+		return &state.Staker{
+			TxID:     validatorTxID,
+			NodeID:   nodeID,
+			Priority: txs.SubnetPermissionedValidatorCurrentPriority,
+		}
+	}
+	expectExecution := func(utx *txs.ExitSubnetValidatorTx, tx *txs.Tx, s *state.MockDiff, flowChecker *utxo.MockVerifier) {
+		s.EXPECT().GetTimestamp().Return(time.Time{})
+		s.EXPECT().GetFeePrice().Return(uint64(0))
+		flowChecker.EXPECT().VerifySpend(
+			utx, s, utx.Ins, utx.Outs, tx.Creds, gomock.Any(),
+		).Return(nil)
+		s.EXPECT().DeleteUTXO(gomock.Any()).Times(len(utx.Ins))
+		s.EXPECT().AddUTXO(gomock.Any()).Times(len(utx.Outs))
+	}
+
+	type test struct {
+		name        string
+		nodeID      ids.NodeID
+		cert        []byte
+		sign        func(ids.ID) []byte
+		timestamp   time.Time
+		setup       func(*txs.ExitSubnetValidatorTx, *txs.Tx, *state.MockDiff, *utxo.MockVerifier)
+		expectedErr error
+	}
+
+	tests := []test{
+		{
+			name:      "staking key signature",
+			nodeID:    certNodeID,
+			cert:      cert.Raw,
+			sign:      signStaking,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, tx *txs.Tx, s *state.MockDiff, flowChecker *utxo.MockVerifier) {
+				staker := newStaker(certNodeID)
+				s.EXPECT().GetCurrentValidator(utx.Subnet, certNodeID).Return(staker, nil)
+				expectExecution(utx, tx, s, flowChecker)
+				s.EXPECT().DeleteCurrentValidator(staker)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:      "BLS signature of pending validator",
+			nodeID:    blsNodeID,
+			sign:      signBLS,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, tx *txs.Tx, s *state.MockDiff, flowChecker *utxo.MockVerifier) {
+				staker := newStaker(blsNodeID)
+				staker.Priority = txs.SubnetPermissionedValidatorPendingPriority
+				s.EXPECT().GetCurrentValidator(utx.Subnet, blsNodeID).Return(nil, database.ErrNotFound)
+				s.EXPECT().GetPendingValidator(utx.Subnet, blsNodeID).Return(staker, nil)
+				s.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, blsNodeID).Return(&state.Staker{
+					NodeID:    blsNodeID,
+					PublicKey: pk,
+				}, nil)
+				expectExecution(utx, tx, s, flowChecker)
+				s.EXPECT().DeletePendingValidator(staker)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "D upgrade not active",
+			nodeID:      certNodeID,
+			cert:        cert.Raw,
+			sign:        signStaking,
+			timestamp:   dTime.Add(-time.Second),
+			setup:       func(*txs.ExitSubnetValidatorTx, *txs.Tx, *state.MockDiff, *utxo.MockVerifier) {},
+			expectedErr: ErrDUpgradeNotActive,
+		},
+		{
+			name:      "tx fails syntactic verification",
+			nodeID:    certNodeID,
+			cert:      cert.Raw,
+			sign:      signStaking,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, _ *txs.Tx, _ *state.MockDiff, _ *utxo.MockVerifier) {
+				// Setting the subnet ID to the Primary Network ID makes the tx
+				// fail syntactic verification
+				utx.Subnet = constants.PrimaryNetworkID
+			},
+			expectedErr: txs.ErrExitPrimaryNetworkValidator,
+		},
+		{
+			name:      "node isn't a validator of the subnet",
+			nodeID:    certNodeID,
+			cert:      cert.Raw,
+			sign:      signStaking,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, _ *txs.Tx, s *state.MockDiff, _ *utxo.MockVerifier) {
+				s.EXPECT().GetCurrentValidator(utx.Subnet, certNodeID).Return(nil, database.ErrNotFound)
+				s.EXPECT().GetPendingValidator(utx.Subnet, certNodeID).Return(nil, database.ErrNotFound)
+			},
+			expectedErr: ErrNotValidator,
+		},
+		{
+			name:      "validator is permissionless",
+			nodeID:    certNodeID,
+			cert:      cert.Raw,
+			sign:      signStaking,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, _ *txs.Tx, s *state.MockDiff, _ *utxo.MockVerifier) {
+				staker := newStaker(certNodeID)
+				staker.Priority = txs.SubnetPermissionlessValidatorCurrentPriority
+				s.EXPECT().GetCurrentValidator(utx.Subnet, certNodeID).Return(staker, nil)
+			},
+			expectedErr: ErrRemovePermissionlessValidator,
+		},
+		{
+			name:      "invalid staking key signature",
+			nodeID:    certNodeID,
+			cert:      cert.Raw,
+			sign:      signStakingWrongSubnet,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, _ *txs.Tx, s *state.MockDiff, _ *utxo.MockVerifier) {
+				s.EXPECT().GetCurrentValidator(utx.Subnet, certNodeID).Return(newStaker(certNodeID), nil)
+			},
+			expectedErr: txs.ErrInvalidExitSignature,
+		},
+		{
+			name:      "invalid BLS signature",
+			nodeID:    blsNodeID,
+			sign:      signBLSWrongSubnet,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, _ *txs.Tx, s *state.MockDiff, _ *utxo.MockVerifier) {
+				s.EXPECT().GetCurrentValidator(utx.Subnet, blsNodeID).Return(newStaker(blsNodeID), nil)
+				s.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, blsNodeID).Return(&state.Staker{
+					NodeID:    blsNodeID,
+					PublicKey: pk,
+				}, nil)
+			},
+			expectedErr: txs.ErrInvalidExitSignature,
+		},
+		{
+			name:      "primary network validator has no BLS key",
+			nodeID:    blsNodeID,
+			sign:      signBLS,
+			timestamp: dTime,
+			setup: func(utx *txs.ExitSubnetValidatorTx, _ *txs.Tx, s *state.MockDiff, _ *utxo.MockVerifier) {
+				s.EXPECT().GetCurrentValidator(utx.Subnet, blsNodeID).Return(newStaker(blsNodeID), nil)
+				s.EXPECT().GetCurrentValidator(constants.PrimaryNetworkID, blsNodeID).Return(&state.Staker{
+					NodeID: blsNodeID,
+				}, nil)
+			},
+			expectedErr: ErrMissingPublicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			utx, tx := newExitSubnetValidatorTx(t, tt.nodeID, tt.cert, tt.sign)
+			s := state.NewMockDiff(ctrl)
+			flowChecker := utxo.NewMockVerifier(ctrl)
+			s.EXPECT().GetTimestamp().Return(tt.timestamp)
+			tt.setup(utx, tx, s, flowChecker)
+
+			e := &StandardTxExecutor{
+				Backend: &Backend{
+					Config: &config.Config{
+						DTime: dTime,
+					},
+					Bootstrapped: &utils.Atomic[bool]{},
+					FlowChecker:  flowChecker,
+					Ctx:          &snow.Context{},
+				},
+				Tx:    tx,
+				State: s,
+			}
+			e.Bootstrapped.Set(true)
+			err := tx.Unsigned.Visit(e)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
 func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 	type test struct {
 		name        string
@@ -1512,6 +1779,8 @@ func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil)
 				env.state.EXPECT().GetSubnetTransformation(env.unsignedTx.Subnet).Return(nil, database.ErrNotFound).Times(1)
 				env.fx.EXPECT().VerifyPermission(gomock.Any(), env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil)
+				env.state.EXPECT().GetTimestamp().Return(time.Time{})
+				env.state.EXPECT().GetFeePrice().Return(uint64(0))
 				env.flowChecker.EXPECT().VerifySpend(
 					gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 				).Return(ErrFlowCheckFailed)
@@ -1544,6 +1813,8 @@ func TestStandardExecutorTransformSubnetTx(t *testing.T) {
 				env.state.EXPECT().GetSubnetOwner(env.unsignedTx.Subnet).Return(subnetOwner, nil).Times(1)
 				env.state.EXPECT().GetSubnetTransformation(env.unsignedTx.Subnet).Return(nil, database.ErrNotFound).Times(1)
 				env.fx.EXPECT().VerifyPermission(env.unsignedTx, env.unsignedTx.SubnetAuth, env.tx.Creds[len(env.tx.Creds)-1], subnetOwner).Return(nil).Times(1)
+				env.state.EXPECT().GetTimestamp().Return(time.Time{})
+				env.state.EXPECT().GetFeePrice().Return(uint64(0))
 				env.flowChecker.EXPECT().VerifySpend(
 					env.unsignedTx, env.state, env.unsignedTx.Ins, env.unsignedTx.Outs, env.tx.Creds[:len(env.tx.Creds)-1], gomock.Any(),
 				).Return(nil).Times(1)
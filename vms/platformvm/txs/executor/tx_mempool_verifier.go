@@ -82,6 +82,14 @@ func (v *MempoolTxVerifier) BaseTx(tx *txs.BaseTx) error {
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) SetSubnetMetadataTx(tx *txs.SetSubnetMetadataTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) ExitSubnetValidatorTx(tx *txs.ExitSubnetValidatorTx) error {
+	return v.standardTx(tx)
+}
+
 func (v *MempoolTxVerifier) standardTx(tx txs.UnsignedTx) error {
 	baseState, err := v.standardBaseState()
 	if err != nil {
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+// exitSubnetValidatorMessageLen is the length of the message returned by
+// ExitSubnetValidatorMessage.
+const exitSubnetValidatorMessageLen = wrappers.IntLen + 3*ids.IDLen + ids.NodeIDLen
+
+var (
+	_ UnsignedTx = (*ExitSubnetValidatorTx)(nil)
+
+	ErrExitPrimaryNetworkValidator = errors.New("can't remove primary network validator with ExitSubnetValidatorTx")
+	ErrInvalidExitSignature        = errors.New("invalid exit signature")
+	ErrStakingCertificateTooLarge  = errors.New("staking certificate too large")
+	ErrWrongStakingCertificate     = errors.New("staking certificate doesn't match node ID")
+)
+
+// Removes a validator from a subnet, authorized by the validator itself rather
+// than by the subnet owner.
+type ExitSubnetValidatorTx struct {
+	BaseTx `serialize:"true"`
+	// The node to remove from the subnet.
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The subnet to remove the node from.
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// DER encoded staking certificate of [NodeID]. If empty, [Signature] must
+	// be signed by the BLS key that [NodeID] registered on the primary network.
+	StakingCertificate types.JSONByteSlice `serialize:"true" json:"stakingCertificate"`
+	// Signature over [ExitSubnetValidatorMessage], proving that the issuer
+	// controls [NodeID].
+	Signature types.JSONByteSlice `serialize:"true" json:"signature"`
+}
+
+func (tx *ExitSubnetValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrExitPrimaryNetworkValidator
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+
+	if len(tx.StakingCertificate) == 0 {
+		if len(tx.Signature) != bls.SignatureLen {
+			return fmt.Errorf("%w: BLS signature length %d != %d", ErrInvalidExitSignature, len(tx.Signature), bls.SignatureLen)
+		}
+	} else {
+		if len(tx.StakingCertificate) > staking.MaxCertificateLen {
+			return fmt.Errorf("%w: %d > %d", ErrStakingCertificateTooLarge, len(tx.StakingCertificate), staking.MaxCertificateLen)
+		}
+		if len(tx.Signature) == 0 || len(tx.Signature) > staking.MaxRSAKeyByteLen {
+			return fmt.Errorf("%w: staking signature length %d", ErrInvalidExitSignature, len(tx.Signature))
+		}
+		cert, err := staking.ParseCertificate(tx.StakingCertificate)
+		if err != nil {
+			return fmt.Errorf("failed to parse staking certificate: %w", err)
+		}
+		if nodeID := ids.NodeIDFromCert(cert); nodeID != tx.NodeID {
+			return fmt.Errorf("%w: %s != %s", ErrWrongStakingCertificate, nodeID, tx.NodeID)
+		}
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *ExitSubnetValidatorTx) Visit(visitor Visitor) error {
+	return visitor.ExitSubnetValidatorTx(tx)
+}
+
+// ExitSubnetValidatorMessage returns the message that [nodeID] must sign to
+// remove itself from [subnetID].
+//
+// The message commits to the ID of the tx that added the validator, so a
+// signature can't be replayed against a later validation period of the same
+// node.
+func ExitSubnetValidatorMessage(
+	networkID uint32,
+	chainID ids.ID,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	validatorTxID ids.ID,
+) []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, exitSubnetValidatorMessageLen),
+	}
+	p.PackInt(networkID)
+	p.PackFixedBytes(chainID[:])
+	p.PackFixedBytes(subnetID[:])
+	p.PackFixedBytes(nodeID.Bytes())
+	p.PackFixedBytes(validatorTxID[:])
+	return p.Bytes
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+func TestExitSubnetValidatorTxSerialization(t *testing.T) {
+	require := require.New(t)
+
+	unsignedTx := &ExitSubnetValidatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Outs:         []*avax.TransferableOutput{},
+			Ins:          []*avax.TransferableInput{},
+			Memo:         []byte{1, 2, 3},
+		}},
+		NodeID:             ids.GenerateTestNodeID(),
+		Subnet:             ids.GenerateTestID(),
+		StakingCertificate: []byte{4, 5, 6},
+		Signature:          []byte{7, 8, 9},
+	}
+	var unsignedTxIntf UnsignedTx = unsignedTx
+	txBytes, err := Codec.Marshal(Version, &unsignedTxIntf)
+	require.NoError(err)
+
+	var parsedTxIntf UnsignedTx
+	_, err = Codec.Unmarshal(txBytes, &parsedTxIntf)
+	require.NoError(err)
+	require.Equal(unsignedTx, parsedTxIntf)
+}
+
+func TestExitSubnetValidatorTxSyntacticVerify(t *testing.T) {
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+	certBytes := tlsCert.Leaf.Raw
+	certNodeID := ids.NodeIDFromCert(staking.CertificateFromX509(tlsCert.Leaf))
+
+	tests := []struct {
+		name        string
+		tx          *ExitSubnetValidatorTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx: BaseTx{SyntacticallyVerified: true},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "primary network",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx: validBaseTx,
+				Subnet: constants.PrimaryNetworkID,
+			},
+			expectedErr: ErrExitPrimaryNetworkValidator,
+		},
+		{
+			name: "invalid BaseTx",
+			tx: &ExitSubnetValidatorTx{
+				Subnet:    ids.GenerateTestID(),
+				Signature: make([]byte, bls.SignatureLen),
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "wrong BLS signature length",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx:    validBaseTx,
+				Subnet:    ids.GenerateTestID(),
+				Signature: make([]byte, bls.SignatureLen-1),
+			},
+			expectedErr: ErrInvalidExitSignature,
+		},
+		{
+			name: "staking certificate too large",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx:             validBaseTx,
+				Subnet:             ids.GenerateTestID(),
+				StakingCertificate: make([]byte, staking.MaxCertificateLen+1),
+				Signature:          []byte{1},
+			},
+			expectedErr: ErrStakingCertificateTooLarge,
+		},
+		{
+			name: "missing staking signature",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx:             validBaseTx,
+				NodeID:             certNodeID,
+				Subnet:             ids.GenerateTestID(),
+				StakingCertificate: certBytes,
+			},
+			expectedErr: ErrInvalidExitSignature,
+		},
+		{
+			name: "staking certificate of another node",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx:             validBaseTx,
+				NodeID:             ids.GenerateTestNodeID(),
+				Subnet:             ids.GenerateTestID(),
+				StakingCertificate: certBytes,
+				Signature:          []byte{1},
+			},
+			expectedErr: ErrWrongStakingCertificate,
+		},
+		{
+			name: "BLS signature passes verification",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx:    validBaseTx,
+				NodeID:    ids.GenerateTestNodeID(),
+				Subnet:    ids.GenerateTestID(),
+				Signature: make([]byte, bls.SignatureLen),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "staking signature passes verification",
+			tx: &ExitSubnetValidatorTx{
+				BaseTx:             validBaseTx,
+				NodeID:             certNodeID,
+				Subnet:             ids.GenerateTestID(),
+				StakingCertificate: certBytes,
+				Signature:          []byte{1},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := tt.tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tt.tx.SyntacticallyVerified)
+		})
+	}
+}
+
+func TestExitSubnetValidatorMessage(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID       = ids.GenerateTestID()
+		subnetID      = ids.GenerateTestID()
+		nodeID        = ids.GenerateTestNodeID()
+		validatorTxID = ids.GenerateTestID()
+	)
+	msg := ExitSubnetValidatorMessage(constants.UnitTestID, chainID, subnetID, nodeID, validatorTxID)
+	require.Len(msg, exitSubnetValidatorMessageLen)
+
+	// Every field is committed to.
+	require.NotEqual(msg, ExitSubnetValidatorMessage(constants.MainnetID, chainID, subnetID, nodeID, validatorTxID))
+	require.NotEqual(msg, ExitSubnetValidatorMessage(constants.UnitTestID, ids.GenerateTestID(), subnetID, nodeID, validatorTxID))
+	require.NotEqual(msg, ExitSubnetValidatorMessage(constants.UnitTestID, chainID, ids.GenerateTestID(), nodeID, validatorTxID))
+	require.NotEqual(msg, ExitSubnetValidatorMessage(constants.UnitTestID, chainID, subnetID, ids.GenerateTestNodeID(), validatorTxID))
+	require.NotEqual(msg, ExitSubnetValidatorMessage(constants.UnitTestID, chainID, subnetID, nodeID, ids.GenerateTestID()))
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/complexity"
+)
+
+// gasWeights is the amount of gas charged for each unit of tx complexity. The
+// gas consumed by a block determines the next fee price, so the weights are
+// part of block verification and must not be modified.
+var gasWeights = complexity.Dimensions{
+	Bytes:         1,
+	UTXOReads:     100,
+	UTXOWrites:    100,
+	Signatures:    200,
+	StakerChanges: 1_000,
+}
+
+// Fee returns the amount of AVAX [tx] must burn to be accepted into a block
+// with the provided [timestamp] on top of a chain with the provided [price].
+//
+// Prior to the E upgrade, the static fee is required regardless of [price].
+func Fee(cfg *config.Config, timestamp time.Time, price uint64, tx txs.UnsignedTx) (uint64, error) {
+	staticFee := StaticFee(cfg, timestamp, tx)
+	return Scale(cfg, timestamp, price, staticFee)
+}
+
+// Scale returns the amount of AVAX a tx with [staticFee] must burn to be
+// accepted into a block with the provided [timestamp] on top of a chain with
+// the provided [price].
+func Scale(cfg *config.Config, timestamp time.Time, price uint64, staticFee uint64) (uint64, error) {
+	if !cfg.IsEActivated(timestamp) {
+		return staticFee, nil
+	}
+	return cfg.DynamicFeeConfig.Fee(staticFee, price)
+}
+
+// Gas returns the amount of gas consumed by a block containing [blkTxs].
+func Gas(blkTxs []*txs.Tx) (uint64, error) {
+	var (
+		dimensions complexity.Dimensions
+		err        error
+	)
+	for _, tx := range blkTxs {
+		dimensions, err = dimensions.Add(complexity.Calculate(tx))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return dimensions.Gas(gasWeights)
+}
+
+// NextPrice returns the price after a block with the provided [timestamp] and
+// [blkTxs] is accepted on top of a chain with the provided [price].
+//
+// Prior to the E upgrade, the price is never updated.
+func NextPrice(cfg *config.Config, timestamp time.Time, price uint64, blkTxs []*txs.Tx) (uint64, error) {
+	if !cfg.IsEActivated(timestamp) {
+		return price, nil
+	}
+	gas, err := Gas(blkTxs)
+	if err != nil {
+		return 0, err
+	}
+	return cfg.DynamicFeeConfig.NextPrice(price, gas), nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestFee(t *testing.T) {
+	tests := []struct {
+		name        string
+		preE        bool
+		price       uint64
+		expectedFee uint64
+	}{
+		{
+			name:        "pre E",
+			preE:        true,
+			price:       3 * gas.PriceDenominator,
+			expectedFee: 10,
+		},
+		{
+			name:        "min price",
+			price:       0,
+			expectedFee: 10,
+		},
+		{
+			name:        "scaled",
+			price:       3 * gas.PriceDenominator,
+			expectedFee: 30,
+		},
+		{
+			name:        "max price",
+			price:       100 * gas.PriceDenominator,
+			expectedFee: 40,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := &config.Config{
+				TxFee: 10,
+				DynamicFeeConfig: gas.Config{
+					TargetBlockGas:    1_000,
+					MaxPrice:          4 * gas.PriceDenominator,
+					ChangeDenominator: 8,
+				},
+			}
+			if test.preE {
+				cfg.ETime = mockable.MaxTime
+			}
+
+			fee, err := Fee(cfg, time.Time{}, test.price, &txs.BaseTx{})
+			require.NoError(err)
+			require.Equal(test.expectedFee, fee)
+		})
+	}
+}
+
+func TestNextPrice(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &config.Config{
+		DynamicFeeConfig: gas.Config{
+			TargetBlockGas:    34,
+			MaxPrice:          4 * gas.PriceDenominator,
+			ChangeDenominator: 8,
+		},
+	}
+	blkTxs := []*txs.Tx{
+		{Unsigned: &txs.BaseTx{}},
+		{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{{}},
+		}}},
+	}
+	blkTxs[0].SetBytes(nil, []byte{0})
+	blkTxs[1].SetBytes(nil, []byte{1})
+	blkGas, err := Gas(blkTxs)
+	require.NoError(err)
+	require.Equal(2*gasWeights.Bytes+gasWeights.UTXOWrites, blkGas)
+
+	// The block consumes twice the target above the target, so the price
+	// increases by 2/8.
+	price, err := NextPrice(cfg, time.Time{}, gas.PriceDenominator, blkTxs)
+	require.NoError(err)
+	require.Equal(uint64(5*gas.PriceDenominator/4), price)
+
+	cfg.ETime = mockable.MaxTime
+	price, err = NextPrice(cfg, time.Time{}, gas.PriceDenominator, blkTxs)
+	require.NoError(err)
+	require.Equal(uint64(gas.PriceDenominator), price)
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = (*staticCalculator)(nil)
+
+// StaticFee returns the minimum amount of AVAX [tx] must burn to be accepted
+// into a block with the provided [timestamp].
+func StaticFee(cfg *config.Config, timestamp time.Time, tx txs.UnsignedTx) uint64 {
+	c := &staticCalculator{
+		config:    cfg,
+		timestamp: timestamp,
+	}
+	// staticCalculator never returns an error
+	_ = tx.Visit(c)
+	return c.fee
+}
+
+type staticCalculator struct {
+	// inputs
+	config    *config.Config
+	timestamp time.Time
+
+	// outputs
+	fee uint64
+}
+
+func (c *staticCalculator) AddValidatorTx(*txs.AddValidatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkValidatorFee
+	return nil
+}
+
+func (c *staticCalculator) AddSubnetValidatorTx(*txs.AddSubnetValidatorTx) error {
+	c.fee = c.config.AddSubnetValidatorFee
+	return nil
+}
+
+func (c *staticCalculator) AddDelegatorTx(*txs.AddDelegatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	return nil
+}
+
+func (c *staticCalculator) CreateChainTx(*txs.CreateChainTx) error {
+	c.fee = c.config.GetCreateBlockchainTxFee(c.timestamp)
+	return nil
+}
+
+func (c *staticCalculator) CreateSubnetTx(*txs.CreateSubnetTx) error {
+	c.fee = c.config.GetCreateSubnetTxFee(c.timestamp)
+	return nil
+}
+
+func (*staticCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil // no fees
+}
+
+func (*staticCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return nil // no fees
+}
+
+func (c *staticCalculator) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticCalculator) TransformSubnetTx(*txs.TransformSubnetTx) error {
+	c.fee = c.config.TransformSubnetTxFee
+	return nil
+}
+
+func (c *staticCalculator) TransferSubnetOwnershipTx(*txs.TransferSubnetOwnershipTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticCalculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if tx.Subnet != constants.PrimaryNetworkID {
+		c.fee = c.config.AddSubnetValidatorFee
+	} else {
+		c.fee = c.config.AddPrimaryNetworkValidatorFee
+	}
+	return nil
+}
+
+func (c *staticCalculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if tx.Subnet != constants.PrimaryNetworkID {
+		c.fee = c.config.AddSubnetDelegatorFee
+	} else {
+		c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	}
+	return nil
+}
+
+func (c *staticCalculator) BaseTx(*txs.BaseTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticCalculator) SetSubnetMetadataTx(*txs.SetSubnetMetadataTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticCalculator) ExitSubnetValidatorTx(*txs.ExitSubnetValidatorTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticCalculator) ImportTx(*txs.ImportTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *staticCalculator) ExportTx(*txs.ExportTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
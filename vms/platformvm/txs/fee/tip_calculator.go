@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// TipCalculator calculates the amount of AVAX a tx burns above the fee it is
+// required to pay.
+type TipCalculator struct {
+	config      *config.Config
+	avaxAssetID ids.ID
+	clk         *mockable.Clock
+	state       state.Chain
+}
+
+func NewTipCalculator(
+	cfg *config.Config,
+	avaxAssetID ids.ID,
+	clk *mockable.Clock,
+	state state.Chain,
+) *TipCalculator {
+	return &TipCalculator{
+		config:      cfg,
+		avaxAssetID: avaxAssetID,
+		clk:         clk,
+		state:       state,
+	}
+}
+
+// Tip returns the amount of AVAX [tx] burns above its fee. If [tx] doesn't burn
+// enough AVAX to pay its fee, 0 is returned.
+func (c *TipCalculator) Tip(tx *txs.Tx) uint64 {
+	burned, err := txs.Burned(tx.Unsigned, c.avaxAssetID)
+	if err != nil {
+		return 0
+	}
+	fee, err := Fee(c.config, c.clk.Time(), c.state.GetFeePrice(), tx.Unsigned)
+	if err != nil || burned < fee {
+		return 0
+	}
+	return burned - fee
+}
+
+// FeePrice returns the fee price that tips are currently calculated with.
+func (c *TipCalculator) FeePrice() uint64 {
+	return c.state.GetFeePrice()
+}
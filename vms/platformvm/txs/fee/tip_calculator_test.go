@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestTip(t *testing.T) {
+	avaxAssetID := ids.GenerateTestID()
+	cfg := &config.Config{
+		TxFee:                         10,
+		CreateAssetTxFee:              20,
+		CreateSubnetTxFee:             30,
+		AddPrimaryNetworkDelegatorFee: 40,
+		AddSubnetDelegatorFee:         50,
+		DynamicFeeConfig: gas.Config{
+			TargetBlockGas:    1_000,
+			MaxPrice:          10 * gas.PriceDenominator,
+			ChangeDenominator: 8,
+		},
+	}
+	newBaseTx := func(consumed uint64, produced uint64) txs.BaseTx {
+		return txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{{
+				Asset: avax.Asset{ID: avaxAssetID},
+				In:    &secp256k1fx.TransferInput{Amt: consumed},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out:   &secp256k1fx.TransferOutput{Amt: produced},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		preAP3      bool
+		preE        bool
+		price       uint64
+		tx          txs.UnsignedTx
+		expectedTip uint64
+	}{
+		{
+			name:        "base tx",
+			tx:          &txs.BaseTx{BaseTx: newBaseTx(100, 85).BaseTx},
+			expectedTip: 5,
+		},
+		{
+			name:        "exact fee",
+			tx:          &txs.ExportTx{BaseTx: newBaseTx(100, 90)},
+			expectedTip: 0,
+		},
+		{
+			name:        "insufficient fee",
+			tx:          &txs.ImportTx{BaseTx: newBaseTx(100, 95)},
+			expectedTip: 0,
+		},
+		{
+			name:        "create subnet pre AP3",
+			preAP3:      true,
+			tx:          &txs.CreateSubnetTx{BaseTx: newBaseTx(100, 70)},
+			expectedTip: 10,
+		},
+		{
+			name: "subnet delegator",
+			tx: &txs.AddPermissionlessDelegatorTx{
+				BaseTx: newBaseTx(100, 40),
+				Subnet: ids.GenerateTestID(),
+			},
+			expectedTip: 10,
+		},
+		{
+			name: "primary network delegator",
+			tx: &txs.AddPermissionlessDelegatorTx{
+				BaseTx: newBaseTx(100, 40),
+				Subnet: constants.PrimaryNetworkID,
+			},
+			expectedTip: 20,
+		},
+		{
+			name:        "dynamic fee",
+			price:       2 * gas.PriceDenominator,
+			tx:          &txs.BaseTx{BaseTx: newBaseTx(100, 75).BaseTx},
+			expectedTip: 5,
+		},
+		{
+			name:        "dynamic fee pre E",
+			preE:        true,
+			price:       2 * gas.PriceDenominator,
+			tx:          &txs.BaseTx{BaseTx: newBaseTx(100, 75).BaseTx},
+			expectedTip: 15,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			cfg := *cfg
+			if test.preAP3 {
+				cfg.ApricotPhase3Time = mockable.MaxTime
+			}
+			if test.preE {
+				cfg.ETime = mockable.MaxTime
+			}
+
+			chainState := state.NewMockChain(ctrl)
+			chainState.EXPECT().GetFeePrice().Return(test.price).AnyTimes()
+
+			clk := &mockable.Clock{}
+			clk.Set(time.Unix(1607133207, 0))
+
+			calculator := NewTipCalculator(&cfg, avaxAssetID, clk, chainState)
+			tip := calculator.Tip(&txs.Tx{Unsigned: test.tx})
+			require.Equal(t, test.expectedTip, tip)
+		})
+	}
+}
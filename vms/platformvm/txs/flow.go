@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var _ Visitor = (*flowVisitor)(nil)
+
+// Flow returns the inputs consumed and the outputs produced by [tx]. This
+// includes imported inputs, as well as staked and exported outputs.
+func Flow(tx UnsignedTx) ([]*avax.TransferableInput, []*avax.TransferableOutput, error) {
+	v := &flowVisitor{}
+	if err := tx.Visit(v); err != nil {
+		return nil, nil, err
+	}
+	return v.ins, v.outs, nil
+}
+
+// Burned returns the amount of [assetID] consumed, but not produced, by [tx].
+func Burned(tx UnsignedTx, assetID ids.ID) (uint64, error) {
+	ins, outs, err := Flow(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var consumed, produced uint64
+	for _, in := range ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		consumed, err = math.Add64(consumed, in.Input().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, out := range outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		produced, err = math.Add64(produced, out.Output().Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return math.Sub(consumed, produced)
+}
+
+type flowVisitor struct {
+	ins  []*avax.TransferableInput
+	outs []*avax.TransferableOutput
+}
+
+func (v *flowVisitor) AddValidatorTx(tx *AddValidatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	v.outs = append(v.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (v *flowVisitor) AddSubnetValidatorTx(tx *AddSubnetValidatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) AddDelegatorTx(tx *AddDelegatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	v.outs = append(v.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (v *flowVisitor) CreateChainTx(tx *CreateChainTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) CreateSubnetTx(tx *CreateSubnetTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) ImportTx(tx *ImportTx) error {
+	v.baseTx(&tx.BaseTx)
+	v.ins = append(v.ins, tx.ImportedInputs...)
+	return nil
+}
+
+func (v *flowVisitor) ExportTx(tx *ExportTx) error {
+	v.baseTx(&tx.BaseTx)
+	v.outs = append(v.outs, tx.ExportedOutputs...)
+	return nil
+}
+
+func (*flowVisitor) AdvanceTimeTx(*AdvanceTimeTx) error {
+	return nil
+}
+
+func (*flowVisitor) RewardValidatorTx(*RewardValidatorTx) error {
+	return nil
+}
+
+func (v *flowVisitor) RemoveSubnetValidatorTx(tx *RemoveSubnetValidatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) TransformSubnetTx(tx *TransformSubnetTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) AddPermissionlessValidatorTx(tx *AddPermissionlessValidatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	v.outs = append(v.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (v *flowVisitor) AddPermissionlessDelegatorTx(tx *AddPermissionlessDelegatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	v.outs = append(v.outs, tx.StakeOuts...)
+	return nil
+}
+
+func (v *flowVisitor) TransferSubnetOwnershipTx(tx *TransferSubnetOwnershipTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) BaseTx(tx *BaseTx) error {
+	v.baseTx(tx)
+	return nil
+}
+
+func (v *flowVisitor) SetSubnetMetadataTx(tx *SetSubnetMetadataTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) ExitSubnetValidatorTx(tx *ExitSubnetValidatorTx) error {
+	v.baseTx(&tx.BaseTx)
+	return nil
+}
+
+func (v *flowVisitor) baseTx(tx *BaseTx) {
+	v.ins = append(v.ins, tx.Ins...)
+	v.outs = append(v.outs, tx.Outs...)
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestBurned(t *testing.T) {
+	var (
+		avaxAssetID  = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+	)
+	newIn := func(assetID ids.ID, amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			In:     &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	newOut := func(assetID ids.ID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	newBaseTx := func(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) BaseTx {
+		return BaseTx{BaseTx: avax.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		}}
+	}
+
+	tests := []struct {
+		name           string
+		tx             UnsignedTx
+		expectedIns    int
+		expectedBurned uint64
+	}{
+		{
+			name: "base tx",
+			tx: &CreateSubnetTx{
+				BaseTx: newBaseTx(
+					[]*avax.TransferableInput{
+						newIn(avaxAssetID, 10),
+						newIn(otherAssetID, 5),
+					},
+					[]*avax.TransferableOutput{
+						newOut(avaxAssetID, 7),
+						newOut(otherAssetID, 5),
+					},
+				),
+			},
+			expectedIns:    2,
+			expectedBurned: 3,
+		},
+		{
+			name: "import tx",
+			tx: &ImportTx{
+				BaseTx: newBaseTx(
+					nil,
+					[]*avax.TransferableOutput{
+						newOut(avaxAssetID, 7),
+					},
+				),
+				ImportedInputs: []*avax.TransferableInput{
+					newIn(avaxAssetID, 8),
+				},
+			},
+			expectedIns:    1,
+			expectedBurned: 1,
+		},
+		{
+			name: "export tx",
+			tx: &ExportTx{
+				BaseTx: newBaseTx(
+					[]*avax.TransferableInput{
+						newIn(avaxAssetID, 10),
+					},
+					[]*avax.TransferableOutput{
+						newOut(avaxAssetID, 2),
+					},
+				),
+				ExportedOutputs: []*avax.TransferableOutput{
+					newOut(avaxAssetID, 6),
+				},
+			},
+			expectedIns:    1,
+			expectedBurned: 2,
+		},
+		{
+			name: "stake",
+			tx: &AddDelegatorTx{
+				BaseTx: newBaseTx(
+					[]*avax.TransferableInput{
+						newIn(avaxAssetID, 10),
+					},
+					nil,
+				),
+				StakeOuts: []*avax.TransferableOutput{
+					newOut(avaxAssetID, 9),
+				},
+			},
+			expectedIns:    1,
+			expectedBurned: 1,
+		},
+		{
+			name:        "no inputs or outputs",
+			tx:          &RewardValidatorTx{},
+			expectedIns: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ins, _, err := Flow(test.tx)
+			require.NoError(err)
+			require.Len(ins, test.expectedIns)
+
+			burned, err := Burned(test.tx, avaxAssetID)
+			require.NoError(err)
+			require.Equal(test.expectedBurned, burned)
+		})
+	}
+}
@@ -84,6 +84,16 @@ func (i *issuer) BaseTx(*txs.BaseTx) error {
 	return nil
 }
 
+func (i *issuer) SetSubnetMetadataTx(*txs.SetSubnetMetadataTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) ExitSubnetValidatorTx(*txs.ExitSubnetValidatorTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
 func (i *issuer) AddPermissionlessValidatorTx(*txs.AddPermissionlessValidatorTx) error {
 	i.m.addStakerTx(i.tx)
 	return nil
@@ -6,14 +6,23 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/google/btree"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ava-labs/avalanchego/cache"
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/txheap"
 )
 
@@ -29,12 +38,15 @@ const (
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	pendingTxIDsTreeDegree = 2
 )
 
 var (
 	_ Mempool = (*mempool)(nil)
 
 	errMempoolFull = errors.New("mempool is full")
+	errEvicted     = errors.New("evicted by a tx paying a higher fee rate")
 )
 
 type BlockTimer interface {
@@ -44,6 +56,15 @@ type BlockTimer interface {
 	ResetBlockTimer()
 }
 
+type TipCalculator interface {
+	// Tip returns the amount of AVAX [tx] burns above the fee it is required
+	// to pay.
+	Tip(tx *txs.Tx) uint64
+	// FeePrice returns the fee price that tips are currently calculated with.
+	// Tips must be recalculated whenever it changes.
+	FeePrice() uint64
+}
+
 type Mempool interface {
 	// we may want to be able to stop valid transactions
 	// from entering the mempool, e.g. during blocks creation
@@ -59,9 +80,13 @@ type Mempool interface {
 	// (both decision and staker) are included into Standard blocks.
 	// HasTxs allow to check for availability of any mempool transaction.
 	HasTxs() bool
-	// PeekTxs returns the next txs for Banff blocks
-	// up to maxTxsBytes without removing them from the mempool.
-	PeekTxs(maxTxsBytes int) []*txs.Tx
+	// PeekTxs returns the next txs for Banff blocks, up to maxTxsBytes and
+	// consuming up to maxTxsGas in total, without removing them from the
+	// mempool.
+	// Txs are returned in order of decreasing tip per byte, with ties broken
+	// in favor of the tx that was added first. The first tx is returned even if
+	// it exceeds the limits, so that every tx can be included.
+	PeekTxs(maxTxsBytes int, maxTxsGas uint64) []*txs.Tx
 
 	HasStakerTx() bool
 	// PeekStakerTx returns the next stakerTx without removing it from mempool.
@@ -74,6 +99,33 @@ type Mempool interface {
 	// possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
+
+	// NumTxs returns the number of txs currently in the mempool.
+	NumTxs() int
+	// PendingTxs returns up to [limit] of the txs currently in the mempool
+	// whose IDs are greater than [startAfter], ordered by txID.
+	PendingTxs(startAfter ids.ID, limit int) []PendingTx
+	// DroppedTxs returns the cached dropped txs, from least to most recently
+	// marked or queried.
+	DroppedTxs() []DroppedTx
+}
+
+// PendingTx is a tx in the mempool along with when it was added.
+type PendingTx struct {
+	Tx *txs.Tx
+	// Staker is true if the tx is a staker tx, rather than a decision tx.
+	Staker bool
+	// Tip is the amount of AVAX the tx currently burns above its fee
+	Tip   uint64
+	Added time.Time
+}
+
+// DroppedTx is a tx that was dropped from the mempool along with the reason it
+// was dropped.
+type DroppedTx struct {
+	TxID    ids.ID
+	Reason  error
+	Dropped time.Time
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -89,18 +141,40 @@ type mempool struct {
 	unissuedStakerTxs   txheap.Heap
 
 	// Key: Tx ID
-	// Value: Verification error
-	droppedTxIDs *cache.LRU[ids.ID, error]
+	// Value: Tx and the time it was added
+	pendingTxs map[ids.ID]PendingTx
+	// IDs of the pending txs, ordered so that they can be paged through
+	// without copying the entire mempool.
+	pendingTxIDs *btree.BTreeG[ids.ID]
+
+	// Min-heap of the pending txs, with the lowest priority tx on top. Used
+	// to order txs for block building and to pick txs to evict when the
+	// mempool is full.
+	byPriority heap.Map[ids.ID, priorityTx]
+	nextAge    uint64
+	// Fee price the tips in [byPriority] were calculated with
+	tipsFeePrice uint64
+
+	// Key: Tx ID
+	// Value: Verification error and the time the tx was dropped
+	//
+	// Holds at most [droppedTxIDsCacheSize] entries, evicting the least
+	// recently used entry first.
+	droppedTxIDs linkedhashmap.LinkedHashmap[ids.ID, DroppedTx]
 
 	consumedUTXOs set.Set[ids.ID]
 
-	blkTimer BlockTimer
+	blkTimer      BlockTimer
+	tipCalculator TipCalculator
+	clk           *mockable.Clock
 }
 
 func NewMempool(
 	namespace string,
 	registerer prometheus.Registerer,
 	blkTimer BlockTimer,
+	tipCalculator TipCalculator,
+	clk *mockable.Clock,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -135,10 +209,15 @@ func NewMempool(
 		bytesAvailable:       maxMempoolSize,
 		unissuedDecisionTxs:  unissuedDecisionTxs,
 		unissuedStakerTxs:    unissuedStakerTxs,
-		droppedTxIDs:         &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		pendingTxs:           make(map[ids.ID]PendingTx),
+		pendingTxIDs:         btree.NewG(pendingTxIDsTreeDegree, ids.ID.Less),
+		byPriority:           heap.NewMap[ids.ID, priorityTx](lowerPriority),
+		droppedTxIDs:         linkedhashmap.New[ids.ID, DroppedTx](),
 		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
 		dropIncoming:         false, // enable tx adding by default
 		blkTimer:             blkTimer,
+		tipCalculator:        tipCalculator,
+		clk:                  clk,
 	}, nil
 }
 
@@ -165,13 +244,9 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	if len(txBytes) > targetTxSize {
 		return fmt.Errorf("tx %s size (%d) > target size (%d)", txID, len(txBytes), targetTxSize)
 	}
-	if len(txBytes) > m.bytesAvailable {
-		return fmt.Errorf("%w, tx %s size (%d) exceeds available space (%d)",
-			errMempoolFull,
-			txID,
-			len(txBytes),
-			m.bytesAvailable,
-		)
+
+	if _, err := fee.Gas([]*txs.Tx{tx}); err != nil {
+		return fmt.Errorf("tx %s gas: %w", txID, err)
 	}
 
 	inputs := tx.Unsigned.InputIDs()
@@ -179,6 +254,26 @@ func (m *mempool) Add(tx *txs.Tx) error {
 		return fmt.Errorf("tx %s conflicts with a transaction in the mempool", txID)
 	}
 
+	// The priorities of the txs must be up to date before they are compared
+	// against this tx.
+	m.updateTips()
+
+	// If the mempool is full, lower priority txs may be evicted to make room
+	// for this tx.
+	var toEvict []*txs.Tx
+	if len(txBytes) > m.bytesAvailable {
+		var ok bool
+		toEvict, ok = m.lowerPriorityTxs(m.newPriorityTx(tx), len(txBytes)-m.bytesAvailable)
+		if !ok {
+			return fmt.Errorf("%w, tx %s size (%d) exceeds available space (%d)",
+				errMempoolFull,
+				txID,
+				len(txBytes),
+				m.bytesAvailable,
+			)
+		}
+	}
+
 	if err := tx.Unsigned.Visit(&issuer{
 		m:  m,
 		tx: tx,
@@ -186,11 +281,16 @@ func (m *mempool) Add(tx *txs.Tx) error {
 		return err
 	}
 
+	m.Remove(toEvict)
+	for _, evictedTx := range toEvict {
+		m.MarkDropped(evictedTx.ID(), errEvicted)
+	}
+
 	// Mark these UTXOs as consumed in the mempool
 	m.consumedUTXOs.Union(inputs)
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxIDs.Delete(txID)
 
 	m.blkTimer.ResetBlockTimer()
 	return nil
@@ -222,28 +322,36 @@ func (m *mempool) HasTxs() bool {
 	return m.unissuedDecisionTxs.Len() > 0 || m.unissuedStakerTxs.Len() > 0
 }
 
-func (m *mempool) PeekTxs(maxTxsBytes int) []*txs.Tx {
-	txs := m.unissuedDecisionTxs.List()
-	txs = append(txs, m.unissuedStakerTxs.List()...)
+func (m *mempool) PeekTxs(maxTxsBytes int, maxTxsGas uint64) []*txs.Tx {
+	m.updateTips()
+
+	prioritizedTxs := heap.MapValues(m.byPriority)
+	slices.SortFunc(prioritizedTxs, higherPriority)
 
-	size := 0
-	for i, tx := range txs {
-		size += len(tx.Bytes())
-		if size > maxTxsBytes {
-			return txs[:i]
+	var (
+		peekedTxs = make([]*txs.Tx, 0, len(prioritizedTxs))
+		size      = 0
+		gas       uint64
+	)
+	for _, prioritizedTx := range prioritizedTxs {
+		size += int(prioritizedTx.size)
+		gas += prioritizedTx.gas
+		if (size > maxTxsBytes || gas > maxTxsGas) && len(peekedTxs) > 0 {
+			break
 		}
+		peekedTxs = append(peekedTxs, prioritizedTx.tx)
 	}
-	return txs
+	return peekedTxs
 }
 
 func (m *mempool) addDecisionTx(tx *txs.Tx) {
 	m.unissuedDecisionTxs.Add(tx)
-	m.register(tx)
+	m.register(tx, false)
 }
 
 func (m *mempool) addStakerTx(tx *txs.Tx) {
 	m.unissuedStakerTxs.Add(tx)
-	m.register(tx)
+	m.register(tx, true)
 }
 
 func (m *mempool) HasStakerTx() bool {
@@ -275,18 +383,71 @@ func (m *mempool) PeekStakerTx() *txs.Tx {
 }
 
 func (m *mempool) MarkDropped(txID ids.ID, reason error) {
-	m.droppedTxIDs.Put(txID, reason)
+	m.droppedTxIDs.Put(txID, DroppedTx{
+		TxID:    txID,
+		Reason:  reason,
+		Dropped: m.clk.Time(),
+	})
+	if m.droppedTxIDs.Len() > droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) error {
-	err, _ := m.droppedTxIDs.Get(txID)
-	return err
+	droppedTx, ok := m.droppedTxIDs.Get(txID)
+	if !ok {
+		return nil
+	}
+	m.droppedTxIDs.Put(txID, droppedTx) // Mark [txID] as MRU.
+	return droppedTx.Reason
+}
+
+func (m *mempool) NumTxs() int {
+	return len(m.pendingTxs)
+}
+
+func (m *mempool) PendingTxs(startAfter ids.ID, limit int) []PendingTx {
+	var pendingTxs []PendingTx
+	m.pendingTxIDs.AscendGreaterOrEqual(startAfter, func(txID ids.ID) bool {
+		if len(pendingTxs) >= limit {
+			return false
+		}
+		if txID != startAfter {
+			pendingTx := m.pendingTxs[txID]
+			pendingTx.Tip = m.tipCalculator.Tip(pendingTx.Tx)
+			pendingTxs = append(pendingTxs, pendingTx)
+		}
+		return true
+	})
+	return pendingTxs
+}
+
+func (m *mempool) DroppedTxs() []DroppedTx {
+	droppedTxs := make([]DroppedTx, 0, m.droppedTxIDs.Len())
+	txIter := m.droppedTxIDs.NewIterator()
+	for txIter.Next() {
+		droppedTxs = append(droppedTxs, txIter.Value())
+	}
+	return droppedTxs
 }
 
-func (m *mempool) register(tx *txs.Tx) {
+func (m *mempool) register(tx *txs.Tx, staker bool) {
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
+
+	txID := tx.ID()
+	prioritizedTx := m.newPriorityTx(tx)
+	m.byPriority.Push(txID, prioritizedTx)
+	m.nextAge++
+
+	m.pendingTxs[txID] = PendingTx{
+		Tx:     tx,
+		Staker: staker,
+		Added:  m.clk.Time(),
+	}
+	m.pendingTxIDs.ReplaceOrInsert(txID)
 }
 
 func (m *mempool) deregister(tx *txs.Tx) {
@@ -294,6 +455,98 @@ func (m *mempool) deregister(tx *txs.Tx) {
 	m.bytesAvailable += len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 
+	txID := tx.ID()
+	m.byPriority.Remove(txID)
+	delete(m.pendingTxs, txID)
+	m.pendingTxIDs.Delete(txID)
+
 	inputs := tx.Unsigned.InputIDs()
 	m.consumedUTXOs.Difference(inputs)
 }
+
+// updateTips recalculates the tips of the txs in the mempool if the fee price
+// has changed since they were last calculated.
+func (m *mempool) updateTips() {
+	feePrice := m.tipCalculator.FeePrice()
+	if feePrice == m.tipsFeePrice {
+		return
+	}
+	m.tipsFeePrice = feePrice
+
+	for _, prioritizedTx := range heap.MapValues(m.byPriority) {
+		prioritizedTx.tip = m.tipCalculator.Tip(prioritizedTx.tx)
+		m.byPriority.Push(prioritizedTx.tx.ID(), prioritizedTx)
+	}
+}
+
+// newPriorityTx returns the priority [tx] would have if it were added to the
+// mempool now.
+func (m *mempool) newPriorityTx(tx *txs.Tx) priorityTx {
+	// Add verifies that the gas of [tx] doesn't overflow.
+	gas, _ := fee.Gas([]*txs.Tx{tx})
+	return priorityTx{
+		tx:   tx,
+		tip:  m.tipCalculator.Tip(tx),
+		size: uint64(len(tx.Bytes())),
+		gas:  gas,
+		age:  m.nextAge,
+	}
+}
+
+// lowerPriorityTxs returns the lowest priority txs in the mempool, each with a
+// lower priority than [tx], that free at least [bytesNeeded] bytes once
+// removed. If the lower priority txs do not occupy at least [bytesNeeded]
+// bytes, false is returned.
+func (m *mempool) lowerPriorityTxs(tx priorityTx, bytesNeeded int) ([]*txs.Tx, bool) {
+	var (
+		popped     []priorityTx
+		bytesFreed int
+	)
+	for bytesFreed < bytesNeeded {
+		_, lowest, ok := m.byPriority.Peek()
+		if !ok || !higherPriority(tx, lowest) {
+			break
+		}
+		m.byPriority.Pop()
+		popped = append(popped, lowest)
+		bytesFreed += int(lowest.size)
+	}
+
+	// The popped txs are restored so that the caller can decide whether to
+	// remove them.
+	lowerPriorityTxs := make([]*txs.Tx, len(popped))
+	for i, prioritizedTx := range popped {
+		m.byPriority.Push(prioritizedTx.tx.ID(), prioritizedTx)
+		lowerPriorityTxs[i] = prioritizedTx.tx
+	}
+	return lowerPriorityTxs, bytesFreed >= bytesNeeded
+}
+
+type priorityTx struct {
+	tx   *txs.Tx
+	tip  uint64
+	size uint64
+	gas  uint64
+	// age is the order in which the tx was added to the mempool
+	age uint64
+}
+
+// higherPriority returns true if [a] pays a higher tip per byte than [b]. Ties
+// are broken in favor of the tx that was added to the mempool first.
+func higherPriority(a, b priorityTx) bool {
+	// Compare a.tip/a.size against b.tip/b.size without losing precision.
+	aHi, aLo := bits.Mul64(a.tip, b.size)
+	bHi, bLo := bits.Mul64(b.tip, a.size)
+	switch {
+	case aHi != bHi:
+		return aHi > bHi
+	case aLo != bLo:
+		return aLo > bLo
+	default:
+		return a.age < b.age
+	}
+}
+
+func lowerPriority(a, b priorityTx) bool {
+	return higherPriority(b, a)
+}
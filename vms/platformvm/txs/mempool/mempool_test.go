@@ -13,10 +13,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -26,6 +30,23 @@ type noopBlkTimer struct{}
 
 func (*noopBlkTimer) ResetBlockTimer() {}
 
+var _ TipCalculator = (*testTipCalculator)(nil)
+
+type testTipCalculator struct {
+	feePrice uint64
+	// tips maps txIDs to their tips. Txs that aren't in the map don't pay a
+	// tip.
+	tips map[ids.ID]uint64
+}
+
+func (c *testTipCalculator) Tip(tx *txs.Tx) uint64 {
+	return c.tips[tx.ID()]
+}
+
+func (c *testTipCalculator) FeePrice() uint64 {
+	return c.feePrice
+}
+
 var preFundedKeys = secp256k1.TestKeys()
 
 // shows that valid tx is not added to mempool if this would exceed its maximum
@@ -34,7 +55,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &testTipCalculator{}, &mockable.Clock{})
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(1)
@@ -58,7 +79,7 @@ func TestDecisionTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &testTipCalculator{}, &mockable.Clock{})
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(2)
@@ -82,7 +103,7 @@ func TestDecisionTxsInMempool(t *testing.T) {
 		require.Equal(tx, retrieved)
 
 		// we can peek it
-		peeked := mpool.PeekTxs(math.MaxInt)
+		peeked := mpool.PeekTxs(math.MaxInt, math.MaxUint64)
 
 		// tx will be among those peeked,
 		// in NO PARTICULAR ORDER
@@ -110,7 +131,7 @@ func TestProposalTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &testTipCalculator{}, &mockable.Clock{})
 	require.NoError(err)
 
 	// The proposal txs are ordered by decreasing start time. This means after
@@ -145,7 +166,7 @@ func TestProposalTxsInMempool(t *testing.T) {
 
 		{
 			// we can peek it
-			peeked := mpool.PeekTxs(math.MaxInt)
+			peeked := mpool.PeekTxs(math.MaxInt, math.MaxUint64)
 			require.Len(peeked, i+1)
 
 			// tx will be among those peeked,
@@ -171,6 +192,252 @@ func TestProposalTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestPendingAndDroppedTxs(t *testing.T) {
+	require := require.New(t)
+
+	clk := &mockable.Clock{}
+	now := time.Unix(1607133207, 0)
+	clk.Set(now)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, &testTipCalculator{}, clk)
+	require.NoError(err)
+
+	decisionTxs, err := createTestDecisionTxs(2)
+	require.NoError(err)
+	proposalTxs, err := createTestProposalTxs(1)
+	require.NoError(err)
+	proposalTx := proposalTxs[0]
+
+	pendingTxs := []PendingTx{
+		{
+			Tx:     proposalTx,
+			Staker: true,
+			Added:  now,
+		},
+	}
+	require.NoError(mpool.Add(proposalTx))
+	for _, tx := range decisionTxs {
+		require.NoError(mpool.Add(tx))
+		pendingTxs = append(pendingTxs, PendingTx{
+			Tx:    tx,
+			Added: now,
+		})
+	}
+	slices.SortFunc(pendingTxs, func(a, b PendingTx) bool {
+		return a.Tx.ID().Less(b.Tx.ID())
+	})
+
+	// Paging through the mempool should return every tx exactly once, ordered
+	// by txID.
+	require.Equal(3, mpool.NumTxs())
+	require.Equal(pendingTxs[:2], mpool.PendingTxs(ids.Empty, 2))
+	require.Equal(pendingTxs[2:], mpool.PendingTxs(pendingTxs[1].Tx.ID(), 2))
+	require.Empty(mpool.PendingTxs(pendingTxs[2].Tx.ID(), 2))
+
+	// Removing the last returned tx must not cause the next page to skip or
+	// repeat any txs.
+	mpool.Remove([]*txs.Tx{pendingTxs[1].Tx})
+	require.Equal(2, mpool.NumTxs())
+	require.Equal(pendingTxs[2:], mpool.PendingTxs(pendingTxs[1].Tx.ID(), 2))
+
+	errTest := errors.New("test error")
+	for i := 0; i < droppedTxIDsCacheSize+1; i++ {
+		mpool.MarkDropped(ids.ID{byte(i)}, errTest)
+	}
+	droppedTxs := mpool.DroppedTxs()
+	require.Len(droppedTxs, droppedTxIDsCacheSize)
+	require.Equal(ids.ID{1}, droppedTxs[0].TxID)
+	require.Equal(ids.ID{droppedTxIDsCacheSize}, droppedTxs[droppedTxIDsCacheSize-1].TxID)
+	require.Nil(mpool.GetDropReason(ids.ID{0}))
+
+	// Querying a dropped tx should mark it as the most recently used, so it is
+	// evicted last.
+	require.ErrorIs(mpool.GetDropReason(ids.ID{1}), errTest)
+	mpool.MarkDropped(ids.ID{droppedTxIDsCacheSize + 1}, errTest)
+	require.ErrorIs(mpool.GetDropReason(ids.ID{1}), errTest)
+	require.Nil(mpool.GetDropReason(ids.ID{2}))
+
+	// Re-adding a dropped tx should clear its drop reason
+	removedTx := pendingTxs[1].Tx
+	mpool.MarkDropped(removedTx.ID(), errTest)
+	require.NoError(mpool.Add(removedTx))
+	require.Nil(mpool.GetDropReason(removedTx.ID()))
+	require.Len(mpool.DroppedTxs(), droppedTxIDsCacheSize-1)
+}
+
+func TestPeekTxsOrderedByTip(t *testing.T) {
+	require := require.New(t)
+
+	decisionTxs, err := createTestDecisionTxs(3)
+	require.NoError(err)
+
+	// All the test txs have the same size, so they are ordered by tip. Ties
+	// are broken by the order the txs were added.
+	tips := &testTipCalculator{
+		tips: map[ids.ID]uint64{
+			decisionTxs[1].ID(): 2,
+			decisionTxs[2].ID(): 2,
+		},
+	}
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, tips, &mockable.Clock{})
+	require.NoError(err)
+
+	for _, tx := range decisionTxs {
+		require.NoError(mpool.Add(tx))
+	}
+
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[1],
+			decisionTxs[2],
+			decisionTxs[0],
+		},
+		mpool.PeekTxs(math.MaxInt, math.MaxUint64),
+	)
+
+	// Only the highest paying txs are returned if the size is limited
+	txSize := len(decisionTxs[0].Bytes())
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[1],
+		},
+		mpool.PeekTxs(2*txSize-1, math.MaxUint64),
+	)
+
+	// Only the highest paying txs are returned if the gas is limited
+	txGas, err := fee.Gas(decisionTxs[:1])
+	require.NoError(err)
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[1],
+			decisionTxs[2],
+		},
+		mpool.PeekTxs(math.MaxInt, 3*txGas-1),
+	)
+
+	// The highest paying tx is returned even if it exceeds the limits
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[1],
+		},
+		mpool.PeekTxs(0, 0),
+	)
+}
+
+func TestEvictLowerPriorityTxs(t *testing.T) {
+	require := require.New(t)
+
+	decisionTxs, err := createTestDecisionTxs(4)
+	require.NoError(err)
+
+	tips := &testTipCalculator{
+		tips: map[ids.ID]uint64{
+			decisionTxs[0].ID(): 1,
+			decisionTxs[1].ID(): 3,
+			decisionTxs[2].ID(): 2,
+			decisionTxs[3].ID(): 1,
+		},
+	}
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, tips, &mockable.Clock{})
+	require.NoError(err)
+
+	// Only allow 2 txs to fit into the mempool
+	txSize := len(decisionTxs[0].Bytes())
+	mpool.(*mempool).bytesAvailable = 2 * txSize
+
+	require.NoError(mpool.Add(decisionTxs[0]))
+	require.NoError(mpool.Add(decisionTxs[1]))
+
+	// A tx paying a higher tip evicts the lowest paying tx
+	require.NoError(mpool.Add(decisionTxs[2]))
+	require.False(mpool.Has(decisionTxs[0].ID()))
+	require.True(mpool.Has(decisionTxs[1].ID()))
+	require.True(mpool.Has(decisionTxs[2].ID()))
+	require.ErrorIs(mpool.GetDropReason(decisionTxs[0].ID()), errEvicted)
+
+	// A tx not paying a higher tip than any tx in the mempool is rejected
+	err = mpool.Add(decisionTxs[3])
+	require.ErrorIs(err, errMempoolFull)
+	require.False(mpool.Has(decisionTxs[3].ID()))
+	require.True(mpool.Has(decisionTxs[1].ID()))
+	require.True(mpool.Has(decisionTxs[2].ID()))
+	require.Len(mpool.PeekTxs(math.MaxInt, math.MaxUint64), 2)
+}
+
+func TestTipsUpdatedOnFeePriceChange(t *testing.T) {
+	require := require.New(t)
+
+	decisionTxs, err := createTestDecisionTxs(3)
+	require.NoError(err)
+
+	tips := &testTipCalculator{
+		tips: map[ids.ID]uint64{
+			decisionTxs[0].ID(): 3,
+			decisionTxs[1].ID(): 2,
+		},
+	}
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, tips, &mockable.Clock{})
+	require.NoError(err)
+
+	// Only allow 2 txs to fit into the mempool
+	txSize := len(decisionTxs[0].Bytes())
+	mpool.(*mempool).bytesAvailable = 2 * txSize
+
+	require.NoError(mpool.Add(decisionTxs[0]))
+	require.NoError(mpool.Add(decisionTxs[1]))
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[0],
+			decisionTxs[1],
+		},
+		mpool.PeekTxs(math.MaxInt, math.MaxUint64),
+	)
+
+	// Raising the fee price lowers the tips of the txs that were already
+	// added. A new tx is compared against the updated tips, so it evicts the
+	// tx that no longer pays a tip.
+	tips.feePrice = 1
+	tips.tips = map[ids.ID]uint64{
+		decisionTxs[1].ID(): 1,
+		decisionTxs[2].ID(): 2,
+	}
+	require.NoError(mpool.Add(decisionTxs[2]))
+	require.False(mpool.Has(decisionTxs[0].ID()))
+	require.True(mpool.Has(decisionTxs[1].ID()))
+	require.True(mpool.Has(decisionTxs[2].ID()))
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[2],
+			decisionTxs[1],
+		},
+		mpool.PeekTxs(math.MaxInt, math.MaxUint64),
+	)
+
+	// Txs are reordered by their updated tips when building blocks
+	tips.feePrice = 2
+	tips.tips = map[ids.ID]uint64{
+		decisionTxs[1].ID(): 5,
+		decisionTxs[2].ID(): 2,
+	}
+	require.Equal(
+		[]*txs.Tx{
+			decisionTxs[1],
+			decisionTxs[2],
+		},
+		mpool.PeekTxs(math.MaxInt, math.MaxUint64),
+	)
+	for _, pendingTx := range mpool.PendingTxs(ids.Empty, 2) {
+		require.Equal(tips.tips[pendingTx.Tx.ID()], pendingTx.Tip)
+	}
+}
+
 func createTestDecisionTxs(count int) ([]*txs.Tx, error) {
 	decisionTxs := make([]*txs.Tx, 0, count)
 	for i := uint32(0); i < uint32(count); i++ {
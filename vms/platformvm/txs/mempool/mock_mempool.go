@@ -64,6 +64,20 @@ func (mr *MockMempoolMockRecorder) DisableAdding() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAdding", reflect.TypeOf((*MockMempool)(nil).DisableAdding))
 }
 
+// DroppedTxs mocks base method.
+func (m *MockMempool) DroppedTxs() []DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedTxs")
+	ret0, _ := ret[0].([]DroppedTx)
+	return ret0
+}
+
+// DroppedTxs indicates an expected call of DroppedTxs.
+func (mr *MockMempoolMockRecorder) DroppedTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedTxs", reflect.TypeOf((*MockMempool)(nil).DroppedTxs))
+}
+
 // EnableAdding mocks base method.
 func (m *MockMempool) EnableAdding() {
 	m.ctrl.T.Helper()
@@ -158,6 +172,20 @@ func (mr *MockMempoolMockRecorder) MarkDropped(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDropped", reflect.TypeOf((*MockMempool)(nil).MarkDropped), arg0, arg1)
 }
 
+// NumTxs mocks base method.
+func (m *MockMempool) NumTxs() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NumTxs")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// NumTxs indicates an expected call of NumTxs.
+func (mr *MockMempoolMockRecorder) NumTxs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumTxs", reflect.TypeOf((*MockMempool)(nil).NumTxs))
+}
+
 // PeekStakerTx mocks base method.
 func (m *MockMempool) PeekStakerTx() *txs.Tx {
 	m.ctrl.T.Helper()
@@ -173,17 +201,31 @@ func (mr *MockMempoolMockRecorder) PeekStakerTx() *gomock.Call {
 }
 
 // PeekTxs mocks base method.
-func (m *MockMempool) PeekTxs(arg0 int) []*txs.Tx {
+func (m *MockMempool) PeekTxs(arg0 int, arg1 uint64) []*txs.Tx {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PeekTxs", arg0)
+	ret := m.ctrl.Call(m, "PeekTxs", arg0, arg1)
 	ret0, _ := ret[0].([]*txs.Tx)
 	return ret0
 }
 
 // PeekTxs indicates an expected call of PeekTxs.
-func (mr *MockMempoolMockRecorder) PeekTxs(arg0 interface{}) *gomock.Call {
+func (mr *MockMempoolMockRecorder) PeekTxs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeekTxs", reflect.TypeOf((*MockMempool)(nil).PeekTxs), arg0, arg1)
+}
+
+// PendingTxs mocks base method.
+func (m *MockMempool) PendingTxs(arg0 ids.ID, arg1 int) []PendingTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingTxs", arg0, arg1)
+	ret0, _ := ret[0].([]PendingTx)
+	return ret0
+}
+
+// PendingTxs indicates an expected call of PendingTxs.
+func (mr *MockMempoolMockRecorder) PendingTxs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeekTxs", reflect.TypeOf((*MockMempool)(nil).PeekTxs), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingTxs", reflect.TypeOf((*MockMempool)(nil).PendingTxs), arg0, arg1)
 }
 
 // Remove mocks base method.
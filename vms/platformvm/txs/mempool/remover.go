@@ -67,6 +67,16 @@ func (r *remover) BaseTx(*txs.BaseTx) error {
 	return nil
 }
 
+func (r *remover) SetSubnetMetadataTx(*txs.SetSubnetMetadataTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) ExitSubnetValidatorTx(*txs.ExitSubnetValidatorTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
 func (r *remover) AddPermissionlessValidatorTx(*txs.AddPermissionlessValidatorTx) error {
 	r.m.removeStakerTx(r.tx)
 	return nil
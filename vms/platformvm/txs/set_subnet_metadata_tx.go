@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+const (
+	MaxSubnetNameLen    = 64
+	MaxSubnetWebsiteLen = 256
+	MaxSubnetContactLen = 256
+)
+
+var (
+	_ UnsignedTx = (*SetSubnetMetadataTx)(nil)
+
+	ErrSetPrimaryNetworkMetadata = errors.New("cannot set the metadata of the primary network")
+	ErrSubnetMetadataTooLong     = errors.New("subnet metadata too long")
+	ErrSubnetMetadataNotUTF8     = errors.New("subnet metadata is not valid UTF-8")
+)
+
+// SubnetMetadata is human readable information describing a subnet.
+type SubnetMetadata struct {
+	Name    string `serialize:"true" json:"name"`
+	Website string `serialize:"true" json:"website"`
+	Contact string `serialize:"true" json:"contact"`
+}
+
+func (m *SubnetMetadata) Verify() error {
+	for _, field := range []struct {
+		name   string
+		value  string
+		maxLen int
+	}{
+		{name: "name", value: m.Name, maxLen: MaxSubnetNameLen},
+		{name: "website", value: m.Website, maxLen: MaxSubnetWebsiteLen},
+		{name: "contact", value: m.Contact, maxLen: MaxSubnetContactLen},
+	} {
+		if len(field.value) > field.maxLen {
+			return fmt.Errorf("%w: %s is %d bytes > %d", ErrSubnetMetadataTooLong, field.name, len(field.value), field.maxLen)
+		}
+		if !utf8.ValidString(field.value) {
+			return fmt.Errorf("%w: %s", ErrSubnetMetadataNotUTF8, field.name)
+		}
+	}
+	return nil
+}
+
+type SetSubnetMetadataTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of the subnet this tx is modifying
+	Subnet ids.ID `serialize:"true" json:"subnetID"`
+	// Metadata that replaces the current metadata of the subnet
+	Metadata SubnetMetadata `serialize:"true" json:"metadata"`
+	// Proves that the issuer has the right to modify the subnet.
+	SubnetAuth verify.Verifiable `serialize:"true" json:"subnetAuthorization"`
+}
+
+func (tx *SetSubnetMetadataTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified:
+		// already passed syntactic verification
+		return nil
+	case tx.Subnet == constants.PrimaryNetworkID:
+		return ErrSetPrimaryNetworkMetadata
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := verify.All(&tx.Metadata, tx.SubnetAuth); err != nil {
+		return err
+	}
+
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SetSubnetMetadataTx) Visit(visitor Visitor) error {
+	return visitor.SetSubnetMetadataTx(tx)
+}
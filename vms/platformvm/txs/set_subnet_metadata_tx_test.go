@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSetSubnetMetadataTxSerialization(t *testing.T) {
+	require := require.New(t)
+
+	unsignedTx := &SetSubnetMetadataTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Outs:         []*avax.TransferableOutput{},
+			Ins:          []*avax.TransferableInput{},
+			Memo:         []byte{1, 2, 3},
+		}},
+		Subnet: ids.GenerateTestID(),
+		Metadata: SubnetMetadata{
+			Name:    "subnet",
+			Website: "https://example.com",
+			Contact: "admin@example.com",
+		},
+		SubnetAuth: &secp256k1fx.Input{
+			SigIndices: []uint32{3},
+		},
+	}
+	var unsignedTxIntf UnsignedTx = unsignedTx
+	txBytes, err := Codec.Marshal(Version, &unsignedTxIntf)
+	require.NoError(err)
+
+	var parsedTxIntf UnsignedTx
+	_, err = Codec.Unmarshal(txBytes, &parsedTxIntf)
+	require.NoError(err)
+	require.Equal(unsignedTx, parsedTxIntf)
+}
+
+func TestSetSubnetMetadataTxSyntacticVerify(t *testing.T) {
+	type test struct {
+		name        string
+		txFunc      func(*gomock.Controller) *SetSubnetMetadataTx
+		expectedErr error
+	}
+
+	var (
+		networkID = uint32(1337)
+		chainID   = ids.GenerateTestID()
+	)
+
+	ctx := &snow.Context{
+		ChainID:   chainID,
+		NetworkID: networkID,
+	}
+
+	// A BaseTx that already passed syntactic verification.
+	verifiedBaseTx := BaseTx{
+		SyntacticallyVerified: true,
+	}
+	// Sanity check.
+	require.NoError(t, verifiedBaseTx.SyntacticVerify(ctx))
+
+	// A BaseTx that passes syntactic verification.
+	validBaseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		},
+	}
+	// Sanity check.
+	require.NoError(t, validBaseTx.SyntacticVerify(ctx))
+	// Make sure we're not caching the verification result.
+	require.False(t, validBaseTx.SyntacticallyVerified)
+
+	// A BaseTx that fails syntactic verification.
+	invalidBaseTx := BaseTx{}
+
+	tests := []test{
+		{
+			name: "nil tx",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return nil
+			},
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return &SetSubnetMetadataTx{BaseTx: verifiedBaseTx}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid subnetID",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return &SetSubnetMetadataTx{
+					BaseTx: validBaseTx,
+					Subnet: constants.PrimaryNetworkID,
+				}
+			},
+			expectedErr: ErrSetPrimaryNetworkMetadata,
+		},
+		{
+			name: "invalid BaseTx",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return &SetSubnetMetadataTx{
+					// Set subnetID so we don't error on that check.
+					Subnet: ids.GenerateTestID(),
+					BaseTx: invalidBaseTx,
+				}
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "name too long",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return &SetSubnetMetadataTx{
+					BaseTx: validBaseTx,
+					Subnet: ids.GenerateTestID(),
+					Metadata: SubnetMetadata{
+						Name: strings.Repeat("a", MaxSubnetNameLen+1),
+					},
+				}
+			},
+			expectedErr: ErrSubnetMetadataTooLong,
+		},
+		{
+			name: "website too long",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return &SetSubnetMetadataTx{
+					BaseTx: validBaseTx,
+					Subnet: ids.GenerateTestID(),
+					Metadata: SubnetMetadata{
+						Website: strings.Repeat("a", MaxSubnetWebsiteLen+1),
+					},
+				}
+			},
+			expectedErr: ErrSubnetMetadataTooLong,
+		},
+		{
+			name: "contact not UTF-8",
+			txFunc: func(*gomock.Controller) *SetSubnetMetadataTx {
+				return &SetSubnetMetadataTx{
+					BaseTx: validBaseTx,
+					Subnet: ids.GenerateTestID(),
+					Metadata: SubnetMetadata{
+						Contact: string([]byte{0xff}),
+					},
+				}
+			},
+			expectedErr: ErrSubnetMetadataNotUTF8,
+		},
+		{
+			name: "invalid subnetAuth",
+			txFunc: func(ctrl *gomock.Controller) *SetSubnetMetadataTx {
+				// This SubnetAuth fails verification.
+				invalidSubnetAuth := verify.NewMockVerifiable(ctrl)
+				invalidSubnetAuth.EXPECT().Verify().Return(errInvalidSubnetAuth)
+				return &SetSubnetMetadataTx{
+					// Set subnetID so we don't error on that check.
+					Subnet:     ids.GenerateTestID(),
+					BaseTx:     validBaseTx,
+					SubnetAuth: invalidSubnetAuth,
+				}
+			},
+			expectedErr: errInvalidSubnetAuth,
+		},
+		{
+			name: "passes verification",
+			txFunc: func(ctrl *gomock.Controller) *SetSubnetMetadataTx {
+				// This SubnetAuth passes verification.
+				validSubnetAuth := verify.NewMockVerifiable(ctrl)
+				validSubnetAuth.EXPECT().Verify().Return(nil)
+				return &SetSubnetMetadataTx{
+					// Set subnetID so we don't error on that check.
+					Subnet: ids.GenerateTestID(),
+					BaseTx: validBaseTx,
+					Metadata: SubnetMetadata{
+						Name:    strings.Repeat("a", MaxSubnetNameLen),
+						Website: strings.Repeat("a", MaxSubnetWebsiteLen),
+						Contact: strings.Repeat("a", MaxSubnetContactLen),
+					},
+					SubnetAuth: validSubnetAuth,
+				}
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			tx := tt.txFunc(ctrl)
+			err := tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.True(tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -20,4 +20,6 @@ type Visitor interface {
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
 	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
 	BaseTx(*BaseTx) error
+	SetSubnetMetadataTx(*SetSubnetMetadataTx) error
+	ExitSubnetValidatorTx(*ExitSubnetValidatorTx) error
 }
@@ -5,6 +5,7 @@ package platformvm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -52,10 +54,13 @@ import (
 )
 
 var (
-	_ snowmanblock.ChainVM       = (*VM)(nil)
-	_ secp256k1fx.VM             = (*VM)(nil)
-	_ validators.State           = (*VM)(nil)
-	_ validators.SubnetConnector = (*VM)(nil)
+	_ snowmanblock.ChainVM        = (*VM)(nil)
+	_ snowmanblock.RollbackableVM = (*VM)(nil)
+	_ secp256k1fx.VM              = (*VM)(nil)
+	_ validators.State            = (*VM)(nil)
+	_ validators.SubnetConnector  = (*VM)(nil)
+
+	errRollbackBeforePruned = errors.New("cannot roll back before the state is pruned and indexed")
 )
 
 type VM struct {
@@ -178,7 +183,13 @@ func (vm *VM) Initialize(
 
 	// Note: There is a circular dependency between the mempool and block
 	//       builder which is broken by passing in the vm.
-	mempool, err := mempool.NewMempool("mempool", registerer, vm)
+	mempool, err := mempool.NewMempool(
+		"mempool",
+		registerer,
+		vm,
+		fee.NewTipCalculator(&vm.Config, vm.ctx.AVAXAssetID, &vm.clock, vm.state),
+		&vm.clock,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
@@ -470,3 +481,18 @@ func (vm *VM) VerifyHeightIndex(_ context.Context) error {
 func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
 	return vm.state.GetBlockIDAtHeight(height)
 }
+
+// Rollback reverts the accepted chain to the block accepted at [height].
+//
+// vm.ctx.Lock should be held
+func (vm *VM) Rollback(ctx context.Context, height uint64) error {
+	// The pruning and indexing of the state is performed asynchronously, so
+	// the state can't be reloaded while it is running.
+	if !vm.pruned.Get() {
+		return errRollbackBeforePruned
+	}
+	if err := vm.manager.Rollback(height); err != nil {
+		return err
+	}
+	return vm.SetPreference(ctx, vm.manager.LastAccepted())
+}
@@ -6,6 +6,7 @@ package platformvm
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"errors"
 	"testing"
 	"time"
@@ -36,6 +37,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
@@ -56,6 +58,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/proposervm"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	smcon "github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -1413,7 +1417,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	chainRouter := &router.ChainRouter{}
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(logging.NoLog{}, metrics, "dummyNamespace", constants.DefaultNetworkCompressionType, 10*time.Second)
+	mc, err := message.NewCreator(logging.NoLog{}, metrics, "dummyNamespace", constants.DefaultNetworkCompressionType, 10*time.Second, nil)
 	require.NoError(err)
 
 	require.NoError(chainRouter.Initialize(
@@ -2189,6 +2193,93 @@ func TestTransferSubnetOwnershipTx(t *testing.T) {
 	require.Equal(expectedOwner, subnetOwner)
 }
 
+func TestSetSubnetMetadataTx(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t)
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Create a subnet
+	createSubnetTx, err := vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*secp256k1.PrivateKey{keys[0]},
+		keys[0].Address(),
+	)
+	require.NoError(err)
+	subnetID := createSubnetTx.ID()
+
+	require.NoError(vm.Builder.AddUnverifiedTx(createSubnetTx))
+	createSubnetBlock, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(createSubnetBlock.Verify(context.Background()))
+	require.NoError(createSubnetBlock.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+
+	_, err = vm.state.GetSubnetMetadata(subnetID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Set the metadata of the subnet
+	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.fx)
+	ins, outs, _, signers, err := utxoHandler.Spend(
+		vm.state,
+		[]*secp256k1.PrivateKey{keys[0]},
+		0,
+		vm.TxFee,
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+
+	subnetAuth, subnetSigners, err := utxoHandler.Authorize(
+		vm.state,
+		subnetID,
+		[]*secp256k1.PrivateKey{keys[0]},
+	)
+	require.NoError(err)
+	signers = append(signers, subnetSigners)
+
+	metadata := txs.SubnetMetadata{
+		Name:    "my subnet",
+		Website: "https://example.com",
+		Contact: "admin@example.com",
+	}
+	setSubnetMetadataTx, err := txs.NewSigned(
+		&txs.SetSubnetMetadataTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    vm.ctx.NetworkID,
+				BlockchainID: vm.ctx.ChainID,
+				Ins:          ins,
+				Outs:         outs,
+			}},
+			Subnet:     subnetID,
+			Metadata:   metadata,
+			SubnetAuth: subnetAuth,
+		},
+		txs.Codec,
+		signers,
+	)
+	require.NoError(err)
+
+	require.NoError(vm.Builder.AddUnverifiedTx(setSubnetMetadataTx))
+	setSubnetMetadataBlock, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	setSubnetMetadataRawBlock := setSubnetMetadataBlock.(*blockexecutor.Block).Block
+	require.IsType(&block.BanffStandardBlock{}, setSubnetMetadataRawBlock)
+	require.Contains(setSubnetMetadataRawBlock.Txs(), setSubnetMetadataTx)
+
+	require.NoError(setSubnetMetadataBlock.Verify(context.Background()))
+	require.NoError(setSubnetMetadataBlock.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+
+	subnetMetadata, err := vm.state.GetSubnetMetadata(subnetID)
+	require.NoError(err)
+	require.Equal(&metadata, subnetMetadata)
+}
+
 func TestBaseTx(t *testing.T) {
 	require := require.New(t)
 	vm, _, _ := defaultVM(t)
@@ -2263,3 +2354,156 @@ func TestBaseTx(t *testing.T) {
 	require.NoError(baseTxBlock.Accept(context.Background()))
 	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
 }
+
+func TestRollbackWithProposerVMRestart(t *testing.T) {
+	require := require.New(t)
+
+	_, genesisBytes := defaultGenesis(t)
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	var (
+		db        = memdb.New()
+		atomicDB  = prefixdb.New([]byte{1}, db)
+		m         = atomic.NewMemory(atomicDB)
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = banffForkTime.Add(time.Second)
+	)
+	newVMs := func() (*proposervm.VM, *VM) {
+		vm := &VM{Config: config.Config{
+			Chains:                 chains.TestManager,
+			Validators:             validators.NewManager(),
+			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			TxFee:                  defaultTxFee,
+			CreateSubnetTxFee:      100 * defaultTxFee,
+			MinStakeDuration:       defaultMinStakingDuration,
+			MaxStakeDuration:       defaultMaxStakingDuration,
+			RewardConfig:           defaultRewardConfig,
+			BanffTime:              banffForkTime,
+		}}
+		vm.clock.Set(startTime)
+
+		proVM := proposervm.New(
+			vm,
+			time.Time{},
+			0,
+			0,
+			proposervm.DefaultNumHistoricalBlocks,
+			tlsCert.PrivateKey.(crypto.Signer),
+			staking.CertificateFromX509(tlsCert.Leaf),
+		)
+		proVM.Set(startTime)
+
+		ctx := defaultContext(t)
+		ctx.NodeID = nodeID
+		ctx.SharedMemory = m.NewSharedMemory(ctx.ChainID)
+		valState := ctx.ValidatorState.(*validators.TestState)
+		valState.GetMinimumHeightF = func(context.Context) (uint64, error) {
+			return 0, nil
+		}
+		valState.GetCurrentHeightF = func(context.Context) (uint64, error) {
+			return 0, nil
+		}
+		valState.GetValidatorSetF = func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return nil, nil
+		}
+
+		appSender := &common.SenderTest{
+			SendAppGossipF: func(context.Context, []byte) error {
+				return nil
+			},
+		}
+
+		ctx.Lock.Lock()
+		require.NoError(proVM.Initialize(
+			context.Background(),
+			ctx,
+			prefixdb.New([]byte{0}, db),
+			genesisBytes,
+			nil,
+			[]byte(`{"rollback-journal-enabled":true}`),
+			make(chan common.Message, 1),
+			nil,
+			appSender,
+		))
+		require.NoError(proVM.SetState(context.Background(), snow.NormalOp))
+
+		lastAcceptedID, err := proVM.LastAccepted(context.Background())
+		require.NoError(err)
+		require.NoError(proVM.SetPreference(context.Background(), lastAcceptedID))
+		return proVM, vm
+	}
+	shutdown := func(proVM *proposervm.VM, vm *VM) {
+		require.NoError(proVM.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}
+	createSubnet := func(proVM *proposervm.VM, vm *VM) (*txs.Tx, smcon.Block) {
+		tx, err := vm.txBuilder.NewCreateSubnetTx(
+			1,
+			[]ids.ShortID{keys[0].PublicKey().Address()},
+			[]*secp256k1.PrivateKey{keys[0]},
+			keys[0].PublicKey().Address(),
+		)
+		require.NoError(err)
+		require.NoError(vm.Builder.AddUnverifiedTx(tx))
+
+		blk, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(blk.Accept(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), blk.ID()))
+		return tx, blk
+	}
+
+	// The state is pruned and indexed asynchronously when the chain is first
+	// created. Rolling back requires it to be done.
+	proVM, vm := newVMs()
+	vm.ctx.Lock.Unlock()
+	require.Eventually(vm.pruned.Get, 10*time.Second, 10*time.Millisecond)
+	vm.ctx.Lock.Lock()
+	shutdown(proVM, vm)
+
+	proVM, vm = newVMs()
+	require.NoError(proVM.VerifyHeightIndex(context.Background()))
+
+	var (
+		subnetTxs []*txs.Tx
+		blks      []smcon.Block
+	)
+	for i := 0; i < 3; i++ {
+		tx, blk := createSubnet(proVM, vm)
+		subnetTxs = append(subnetTxs, tx)
+		blks = append(blks, blk)
+	}
+
+	rollbackBlk := blks[0]
+	require.NoError(proVM.Rollback(context.Background(), rollbackBlk.Height()))
+	shutdown(proVM, vm)
+
+	// The rollback is persisted when the chain is restarted.
+	proVM, vm = newVMs()
+	defer shutdown(proVM, vm)
+
+	lastAcceptedID, err := proVM.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(rollbackBlk.ID(), lastAcceptedID)
+
+	innerLastAccepted, err := vm.manager.GetBlock(vm.manager.LastAccepted())
+	require.NoError(err)
+	require.Equal(rollbackBlk.Height(), innerLastAccepted.Height())
+
+	_, err = proVM.GetBlockIDAtHeight(context.Background(), blks[1].Height())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, _, err = vm.state.GetTx(subnetTxs[0].ID())
+	require.NoError(err)
+	for _, tx := range subnetTxs[1:] {
+		_, _, err := vm.state.GetTx(tx.ID())
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	// The chain keeps being built on top of the block it was rolled back to.
+	_, blk := createSubnet(proVM, vm)
+	require.Equal(rollbackBlk.ID(), blk.Parent())
+	require.Equal(rollbackBlk.Height()+1, blk.Height())
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var errRollbackAboveLastAccepted = errors.New("cannot roll back above the last accepted block")
+
+// Rollback rewinds the inner VM to [height] and then rewinds the proposervm's
+// last accepted block and height index to match.
+//
+// vm.ctx.Lock should be held
+func (vm *VM) Rollback(ctx context.Context, height uint64) error {
+	innerVM, ok := vm.ChainVM.(block.RollbackableVM)
+	if !ok {
+		return block.ErrRollbackableVMNotImplemented
+	}
+
+	lastAcceptedID, err := vm.State.GetLastAccepted()
+	if errors.Is(err, database.ErrNotFound) {
+		// No post fork blocks have been accepted, so only the inner VM needs
+		// to be rolled back.
+		return innerVM.Rollback(ctx, height)
+	}
+	if err != nil {
+		return err
+	}
+
+	lastAccepted, err := vm.getPostForkBlock(ctx, lastAcceptedID)
+	if err != nil {
+		return err
+	}
+	lastAcceptedHeight := lastAccepted.Height()
+	if height > lastAcceptedHeight {
+		return fmt.Errorf("%w: %d > %d", errRollbackAboveLastAccepted, height, lastAcceptedHeight)
+	}
+	if height == lastAcceptedHeight {
+		return nil
+	}
+
+	if !vm.hIndexer.IsRepaired() {
+		return block.ErrIndexIncomplete
+	}
+	forkHeight, err := vm.State.GetForkHeight()
+	if err != nil {
+		return err
+	}
+
+	// The new last accepted block is looked up before any state is modified so
+	// that rolling back past the oldest historical block fails cleanly.
+	var newLastAcceptedID ids.ID
+	if height >= forkHeight {
+		newLastAcceptedID, err = vm.State.GetBlockIDAtHeight(height)
+		if err != nil {
+			return fmt.Errorf("failed to get block at height %d: %w", height, err)
+		}
+	}
+
+	// Note: If the node stops after the inner VM is rolled back, but before the
+	// proposervm's state is committed, the proposervm's last accepted block is
+	// repaired to match the inner VM's when the VM is next initialized.
+	if err := innerVM.Rollback(ctx, height); err != nil {
+		return err
+	}
+
+	deleteFrom := height + 1
+	if deleteFrom < forkHeight {
+		deleteFrom = forkHeight
+	}
+	for deleteHeight := deleteFrom; deleteHeight <= lastAcceptedHeight; deleteHeight++ {
+		blockToDelete, err := vm.State.GetBlockIDAtHeight(deleteHeight)
+		if err != nil {
+			return err
+		}
+
+		if err := vm.State.DeleteBlockIDAtHeight(deleteHeight); err != nil {
+			return err
+		}
+		if err := vm.State.DeleteBlock(blockToDelete); err != nil {
+			return err
+		}
+	}
+
+	if height < forkHeight {
+		// We are rolling back past the fork, so we should forget about all of
+		// our proposervm indices.
+		if err := vm.State.DeleteLastAccepted(); err != nil {
+			return err
+		}
+		if err := vm.State.DeleteForkHeight(); err != nil {
+			return err
+		}
+	} else if err := vm.State.SetLastAccepted(newLastAcceptedID); err != nil {
+		return err
+	}
+	if err := vm.db.Commit(); err != nil {
+		return err
+	}
+
+	// Cached inner blocks may still report having been accepted.
+	vm.innerBlkCache.Flush()
+
+	vm.ctx.Log.Info("rolled back accepted chain",
+		zap.Uint64("previousHeight", lastAcceptedHeight),
+		zap.Uint64("height", height),
+	)
+	return vm.setLastAcceptedMetadata(ctx)
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+type rollbackableVM struct {
+	*block.TestVM
+	*block.TestRollbackableVM
+}
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+
+	coreGenBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV:    0,
+		TimestampV: genesisTimestamp,
+		BytesV:     utils.RandomBytes(1024),
+	}
+	acceptedBlocks := []snowman.Block{coreGenBlk}
+
+	coreVM := &rollbackableVM{
+		TestVM: &block.TestVM{
+			TestVM: common.TestVM{
+				T: t,
+				InitializeF: func(context.Context, *snow.Context, database.Database, []byte, []byte, []byte, chan<- common.Message, []*common.Fx, common.AppSender) error {
+					return nil
+				},
+			},
+			LastAcceptedF: func(context.Context) (ids.ID, error) {
+				return acceptedBlocks[len(acceptedBlocks)-1].ID(), nil
+			},
+			GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+				for _, blk := range acceptedBlocks {
+					if blkID == blk.ID() {
+						return blk, nil
+					}
+				}
+				return nil, errUnknownBlock
+			},
+			ParseBlockF: func(_ context.Context, b []byte) (snowman.Block, error) {
+				for _, blk := range acceptedBlocks {
+					if bytes.Equal(b, blk.Bytes()) {
+						return blk, nil
+					}
+				}
+				return nil, errUnknownBlock
+			},
+			VerifyHeightIndexF: func(context.Context) error {
+				return nil
+			},
+			GetBlockIDAtHeightF: func(_ context.Context, height uint64) (ids.ID, error) {
+				if height >= uint64(len(acceptedBlocks)) {
+					return ids.ID{}, database.ErrNotFound
+				}
+				return acceptedBlocks[height].ID(), nil
+			},
+		},
+		TestRollbackableVM: &block.TestRollbackableVM{
+			T: t,
+			RollbackF: func(_ context.Context, height uint64) error {
+				acceptedBlocks = acceptedBlocks[:height+1]
+				return nil
+			},
+		},
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.NodeID = ids.NodeIDFromCert(pTestCert)
+	ctx.ValidatorState = &validators.TestState{
+		T: t,
+		GetMinimumHeightF: func(context.Context) (uint64, error) {
+			return coreGenBlk.HeightV, nil
+		},
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return defaultPChainHeight, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return nil, nil
+		},
+	}
+
+	proVM := New(
+		coreVM,
+		time.Time{},
+		0,
+		DefaultMinBlockDelay,
+		DefaultNumHistoricalBlocks,
+		pTestSigner,
+		pTestCert,
+	)
+
+	require.NoError(proVM.Initialize(
+		context.Background(),
+		ctx,
+		prefixdb.New([]byte{}, memdb.New()),
+		[]byte("genesis state"),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	))
+
+	lastAcceptedID, err := proVM.LastAccepted(context.Background())
+	require.NoError(err)
+
+	require.NoError(proVM.SetState(context.Background(), snow.NormalOp))
+	require.NoError(proVM.SetPreference(context.Background(), lastAcceptedID))
+
+	// proBlocks[i] is the proposervm block accepted at height i+1
+	var proBlocks []snowman.Block
+	issueBlock := func() {
+		lastAcceptedBlock := acceptedBlocks[len(acceptedBlocks)-1]
+		innerBlock := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV:    lastAcceptedBlock.ID(),
+			HeightV:    lastAcceptedBlock.Height() + 1,
+			TimestampV: lastAcceptedBlock.Timestamp(),
+			BytesV:     utils.RandomBytes(1024),
+		}
+
+		coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+			return innerBlock, nil
+		}
+		proBlock, err := proVM.BuildBlock(context.Background())
+		require.NoError(err)
+
+		require.NoError(proBlock.Verify(context.Background()))
+		require.NoError(proVM.SetPreference(context.Background(), proBlock.ID()))
+		require.NoError(proBlock.Accept(context.Background()))
+
+		acceptedBlocks = append(acceptedBlocks, innerBlock)
+		proBlocks = append(proBlocks, proBlock)
+	}
+
+	for i := 0; i < 4; i++ {
+		issueBlock()
+	}
+
+	err = proVM.Rollback(context.Background(), 5)
+	require.ErrorIs(err, errRollbackAboveLastAccepted)
+
+	require.NoError(proVM.Rollback(context.Background(), 2))
+
+	lastAcceptedID, err = proVM.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(proBlocks[1].ID(), lastAcceptedID)
+
+	blkID, err := proVM.GetBlockIDAtHeight(context.Background(), 2)
+	require.NoError(err)
+	require.Equal(proBlocks[1].ID(), blkID)
+
+	_, err = proVM.GetBlockIDAtHeight(context.Background(), 3)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The chain can be extended after being rolled back
+	proBlocks = proBlocks[:2]
+	require.NoError(proVM.SetPreference(context.Background(), lastAcceptedID))
+	issueBlock()
+
+	lastAcceptedID, err = proVM.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(proBlocks[2].ID(), lastAcceptedID)
+
+	// Rolling back past the fork forgets the proposervm indices
+	require.NoError(proVM.Rollback(context.Background(), 0))
+
+	lastAcceptedID, err = proVM.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(coreGenBlk.ID(), lastAcceptedID)
+
+	_, err = proVM.State.GetForkHeight()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(proVM.Shutdown(context.Background()))
+}
+
+func TestRollbackNotImplemented(t *testing.T) {
+	require := require.New(t)
+
+	_, _, proVM, _, _ := initTestProposerVM(t, time.Time{}, 0)
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	err := proVM.Rollback(context.Background(), 0)
+	require.ErrorIs(err, block.ErrRollbackableVMNotImplemented)
+}
@@ -36,6 +36,7 @@ type HeightIndexGetter interface {
 
 type HeightIndexWriter interface {
 	SetForkHeight(height uint64) error
+	DeleteForkHeight() error
 	SetBlockIDAtHeight(height uint64, blkID ids.ID) error
 	DeleteBlockIDAtHeight(height uint64) error
 }
@@ -128,6 +129,10 @@ func (hi *heightIndex) SetForkHeight(height uint64) error {
 	return database.PutUInt64(hi.metadataDB, forkKey, height)
 }
 
+func (hi *heightIndex) DeleteForkHeight() error {
+	return hi.metadataDB.Delete(forkKey)
+}
+
 func (hi *heightIndex) GetCheckpoint() (ids.ID, error) {
 	return database.GetID(hi.metadataDB, checkpointKey)
 }
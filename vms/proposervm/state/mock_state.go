@@ -95,6 +95,20 @@ func (mr *MockStateMockRecorder) DeleteCheckpoint() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCheckpoint", reflect.TypeOf((*MockState)(nil).DeleteCheckpoint))
 }
 
+// DeleteForkHeight mocks base method.
+func (m *MockState) DeleteForkHeight() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForkHeight")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForkHeight indicates an expected call of DeleteForkHeight.
+func (mr *MockStateMockRecorder) DeleteForkHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForkHeight", reflect.TypeOf((*MockState)(nil).DeleteForkHeight))
+}
+
 // DeleteLastAccepted mocks base method.
 func (m *MockState) DeleteLastAccepted() error {
 	m.ctrl.T.Helper()
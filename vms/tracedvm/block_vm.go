@@ -25,6 +25,7 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.RollbackableVM               = (*blockVM)(nil)
 )
 
 type blockVM struct {
@@ -32,6 +33,7 @@ type blockVM struct {
 	buildBlockVM block.BuildBlockWithContextChainVM
 	batchedVM    block.BatchedChainVM
 	ssVM         block.StateSyncableVM
+	rollbackVM   block.RollbackableVM
 	// ChainVM tags
 	initializeTag              string
 	buildBlockTag              string
@@ -59,18 +61,22 @@ type blockVM struct {
 	getLastStateSummaryTag        string
 	parseStateSummaryTag          string
 	getStateSummaryTag            string
-	tracer                        trace.Tracer
+	// RollbackableVM tags
+	rollbackTag string
+	tracer      trace.Tracer
 }
 
 func NewBlockVM(vm block.ChainVM, name string, tracer trace.Tracer) block.ChainVM {
 	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	rollbackVM, _ := vm.(block.RollbackableVM)
 	return &blockVM{
 		ChainVM:                       vm,
 		buildBlockVM:                  buildBlockVM,
 		batchedVM:                     batchedVM,
 		ssVM:                          ssVM,
+		rollbackVM:                    rollbackVM,
 		initializeTag:                 fmt.Sprintf("%s.initialize", name),
 		buildBlockTag:                 fmt.Sprintf("%s.buildBlock", name),
 		parseBlockTag:                 fmt.Sprintf("%s.parseBlock", name),
@@ -93,6 +99,7 @@ func NewBlockVM(vm block.ChainVM, name string, tracer trace.Tracer) block.ChainV
 		getLastStateSummaryTag:        fmt.Sprintf("%s.getLastStateSummary", name),
 		parseStateSummaryTag:          fmt.Sprintf("%s.parseStateSummary", name),
 		getStateSummaryTag:            fmt.Sprintf("%s.getStateSummary", name),
+		rollbackTag:                   fmt.Sprintf("%s.rollback", name),
 		tracer:                        tracer,
 	}
 }
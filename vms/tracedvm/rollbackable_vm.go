@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) Rollback(ctx context.Context, height uint64) error {
+	if vm.rollbackVM == nil {
+		return block.ErrRollbackableVMNotImplemented
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.rollbackTag, oteltrace.WithAttributes(
+		attribute.Int64("height", int64(height)),
+	))
+	defer span.End()
+
+	return vm.rollbackVM.Rollback(ctx, height)
+}
@@ -10,6 +10,8 @@ import (
 
 	stdcontext "context"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -95,6 +97,21 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.RemoveSubnetValidatorTx, error)
 
+	// NewExitSubnetValidatorTx removes [nodeID] from the validator set of
+	// [subnetID] on behalf of the node itself.
+	//
+	// - [stakingCertificate] is the DER encoded staking certificate of
+	//   [nodeID]. If empty, [signature] must be signed by the BLS key that
+	//   [nodeID] registered on the primary network.
+	// - [signature] is a signature over [txs.ExitSubnetValidatorMessage].
+	NewExitSubnetValidatorTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		stakingCertificate []byte,
+		signature []byte,
+		options ...common.Option,
+	) (*txs.ExitSubnetValidatorTx, error)
+
 	// NewAddDelegatorTx creates a new delegator to a validator on the primary
 	// network.
 	//
@@ -134,6 +151,17 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.CreateSubnetTx, error)
 
+	// NewSetSubnetMetadataTx replaces the metadata of the specified subnet.
+	//
+	// - [subnetID] specifies the subnet to be modified.
+	// - [metadata] specifies the human readable name, website and contact of
+	//   the subnet.
+	NewSetSubnetMetadataTx(
+		subnetID ids.ID,
+		metadata *txs.SubnetMetadata,
+		options ...common.Option,
+	) (*txs.SetSubnetMetadataTx, error)
+
 	// NewImportTx creates an import transaction that attempts to consume all
 	// the available UTXOs and import the funds to [to].
 	//
@@ -290,8 +318,14 @@ func (b *builder) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
 ) (*txs.CreateSubnetTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.CreateSubnetTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
+		b.backend.AVAXAssetID(): txFee,
 	}
 	for _, out := range outputs {
 		assetID := out.AssetID()
@@ -303,7 +337,6 @@ func (b *builder) NewBaseTx(
 	}
 	toStake := map[ids.ID]uint64{}
 
-	ops := common.NewOptions(options)
 	inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -330,13 +363,18 @@ func (b *builder) NewAddValidatorTx(
 	options ...common.Option,
 ) (*txs.AddValidatorTx, error) {
 	avaxAssetID := b.backend.AVAXAssetID()
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.AddPrimaryNetworkValidatorFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		avaxAssetID: b.backend.AddPrimaryNetworkValidatorFee(),
+		avaxAssetID: txFee,
 	}
 	toStake := map[ids.ID]uint64{
 		avaxAssetID: vdr.Wght,
 	}
-	ops := common.NewOptions(options)
 	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -362,11 +400,16 @@ func (b *builder) NewAddSubnetValidatorTx(
 	vdr *txs.SubnetValidator,
 	options ...common.Option,
 ) (*txs.AddSubnetValidatorTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.AddSubnetValidatorFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.AddSubnetValidatorFee(),
+		b.backend.AVAXAssetID(): txFee,
 	}
 	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -395,11 +438,16 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	subnetID ids.ID,
 	options ...common.Option,
 ) (*txs.RemoveSubnetValidatorTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.BaseTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+		b.backend.AVAXAssetID(): txFee,
 	}
 	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -424,19 +472,61 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	}, nil
 }
 
+func (b *builder) NewExitSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	stakingCertificate []byte,
+	signature []byte,
+	options ...common.Option,
+) (*txs.ExitSubnetValidatorTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.BaseTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): txFee,
+	}
+	toStake := map[ids.ID]uint64{}
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.ExitSubnetValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		NodeID:             nodeID,
+		Subnet:             subnetID,
+		StakingCertificate: stakingCertificate,
+		Signature:          signature,
+	}, nil
+}
+
 func (b *builder) NewAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.AddDelegatorTx, error) {
 	avaxAssetID := b.backend.AVAXAssetID()
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.AddPrimaryNetworkDelegatorFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		avaxAssetID: b.backend.AddPrimaryNetworkDelegatorFee(),
+		avaxAssetID: txFee,
 	}
 	toStake := map[ids.ID]uint64{
 		b.backend.AVAXAssetID(): vdr.Wght,
 	}
-	ops := common.NewOptions(options)
 	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -465,11 +555,16 @@ func (b *builder) NewCreateChainTx(
 	chainName string,
 	options ...common.Option,
 ) (*txs.CreateChainTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.CreateBlockchainTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.CreateBlockchainTxFee(),
+		b.backend.AVAXAssetID(): txFee,
 	}
 	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -502,11 +597,16 @@ func (b *builder) NewCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.CreateSubnetTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.CreateSubnetTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.CreateSubnetTxFee(),
+		b.backend.AVAXAssetID(): txFee,
 	}
 	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -525,6 +625,45 @@ func (b *builder) NewCreateSubnetTx(
 	}, nil
 }
 
+func (b *builder) NewSetSubnetMetadataTx(
+	subnetID ids.ID,
+	metadata *txs.SubnetMetadata,
+	options ...common.Option,
+) (*txs.SetSubnetMetadataTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.BaseTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): txFee,
+	}
+	toStake := map[ids.ID]uint64{}
+	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetAuth, err := b.authorizeSubnet(subnetID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txs.SetSubnetMetadataTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         outputs,
+			Memo:         ops.Memo(),
+		}},
+		Subnet:     subnetID,
+		Metadata:   *metadata,
+		SubnetAuth: subnetAuth,
+	}, nil
+}
+
 func (b *builder) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -536,11 +675,15 @@ func (b *builder) NewImportTx(
 		return nil, err
 	}
 
+	txFee, err := addTip(b.backend.BaseTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		addrs           = ops.Addresses(b.addrs)
 		minIssuanceTime = ops.MinIssuanceTime()
 		avaxAssetID     = b.backend.AVAXAssetID()
-		txFee           = b.backend.BaseTxFee()
 
 		importedInputs  = make([]*avax.TransferableInput, 0, len(utxos))
 		importedAmounts = make(map[ids.ID]uint64)
@@ -636,8 +779,14 @@ func (b *builder) NewExportTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
 ) (*txs.ExportTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.BaseTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.BaseTxFee(),
+		b.backend.AVAXAssetID(): txFee,
 	}
 	for _, out := range outputs {
 		assetID := out.AssetID()
@@ -649,7 +798,6 @@ func (b *builder) NewExportTx(
 	}
 
 	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
 	inputs, changeOutputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -686,12 +834,17 @@ func (b *builder) NewTransformSubnetTx(
 	uptimeRequirement uint32,
 	options ...common.Option,
 ) (*txs.TransformSubnetTx, error) {
+	ops := common.NewOptions(options)
+	txFee, err := addTip(b.backend.TransformSubnetTxFee(), ops)
+	if err != nil {
+		return nil, err
+	}
+
 	toBurn := map[ids.ID]uint64{
-		b.backend.AVAXAssetID(): b.backend.TransformSubnetTxFee(),
+		b.backend.AVAXAssetID(): txFee,
 		assetID:                 maxSupply - initialSupply,
 	}
 	toStake := map[ids.ID]uint64{}
-	ops := common.NewOptions(options)
 	inputs, outputs, _, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -737,17 +890,22 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 	shares uint32,
 	options ...common.Option,
 ) (*txs.AddPermissionlessValidatorTx, error) {
-	avaxAssetID := b.backend.AVAXAssetID()
-	toBurn := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	txFee := b.backend.AddSubnetValidatorFee()
 	if vdr.Subnet == constants.PrimaryNetworkID {
-		toBurn[avaxAssetID] = b.backend.AddPrimaryNetworkValidatorFee()
-	} else {
-		toBurn[avaxAssetID] = b.backend.AddSubnetValidatorFee()
+		txFee = b.backend.AddPrimaryNetworkValidatorFee()
+	}
+	txFee, err := addTip(txFee, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): txFee,
 	}
 	toStake := map[ids.ID]uint64{
 		assetID: vdr.Wght,
 	}
-	ops := common.NewOptions(options)
 	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -779,17 +937,22 @@ func (b *builder) NewAddPermissionlessDelegatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.AddPermissionlessDelegatorTx, error) {
-	avaxAssetID := b.backend.AVAXAssetID()
-	toBurn := map[ids.ID]uint64{}
+	ops := common.NewOptions(options)
+	txFee := b.backend.AddSubnetDelegatorFee()
 	if vdr.Subnet == constants.PrimaryNetworkID {
-		toBurn[avaxAssetID] = b.backend.AddPrimaryNetworkDelegatorFee()
-	} else {
-		toBurn[avaxAssetID] = b.backend.AddSubnetDelegatorFee()
+		txFee = b.backend.AddPrimaryNetworkDelegatorFee()
+	}
+	txFee, err := addTip(txFee, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	toBurn := map[ids.ID]uint64{
+		b.backend.AVAXAssetID(): txFee,
 	}
 	toStake := map[ids.ID]uint64{
 		assetID: vdr.Wght,
 	}
-	ops := common.NewOptions(options)
 	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
 	if err != nil {
 		return nil, err
@@ -885,6 +1048,10 @@ func (b *builder) spend(
 		return nil, nil, nil, err
 	}
 
+	// The remaining amounts to burn are tracked in a copy so that the
+	// caller's map isn't modified.
+	amountsToBurn = maps.Clone(amountsToBurn)
+
 	addrs := options.Addresses(b.addrs)
 	minIssuanceTime := options.MinIssuanceTime()
 
@@ -1087,6 +1254,11 @@ func (b *builder) spend(
 	return inputs, changeOutputs, stakeOutputs, nil
 }
 
+// addTip returns [txFee] increased by the tip specified in [options].
+func addTip(txFee uint64, options *common.Options) (uint64, error) {
+	return math.Add64(txFee, options.Tip())
+}
+
 func (b *builder) authorizeSubnet(subnetID ids.ID, options *common.Options) (*secp256k1fx.Input, error) {
 	subnetTx, err := b.backend.GetTx(options.Context(), subnetID)
 	if err != nil {
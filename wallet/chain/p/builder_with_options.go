@@ -89,6 +89,22 @@ func (b *builderWithOptions) RemoveSubnetValidatorTx(
 	)
 }
 
+func (b *builderWithOptions) NewExitSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	stakingCertificate []byte,
+	signature []byte,
+	options ...common.Option,
+) (*txs.ExitSubnetValidatorTx, error) {
+	return b.Builder.NewExitSubnetValidatorTx(
+		nodeID,
+		subnetID,
+		stakingCertificate,
+		signature,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -129,6 +145,18 @@ func (b *builderWithOptions) NewCreateSubnetTx(
 	)
 }
 
+func (b *builderWithOptions) NewSetSubnetMetadataTx(
+	subnetID ids.ID,
+	metadata *txs.SubnetMetadata,
+	options ...common.Option,
+) (*txs.SetSubnetMetadataTx, error) {
+	return b.Builder.NewSetSubnetMetadataTx(
+		subnetID,
+		metadata,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -8,7 +8,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 )
 
 var _ Context = (*context)(nil)
@@ -105,6 +107,62 @@ func NewContext(
 	}
 }
 
+// NewContextWithFeeState returns a copy of [ctx] whose fees are scaled by the
+// fee price reported by platform.getFeeState, increased by [marginPercent]
+// percent. If the reported fees aren't dynamic, [ctx] is returned unchanged.
+//
+// The price may change with every accepted block. The margin allows issued txs
+// to remain valid if the price increases before they are included. Any AVAX
+// burned above the required fee is treated as a tip.
+func NewContextWithFeeState(
+	ctx Context,
+	feeState *platformvm.GetFeeStateReply,
+	marginPercent uint64,
+) (Context, error) {
+	if !feeState.Dynamic {
+		return ctx, nil
+	}
+
+	price := uint64(feeState.Price)
+	margin, err := math.Mul64(price, marginPercent)
+	if err != nil {
+		return nil, err
+	}
+	price, err = math.Add64(price, margin/100)
+	if err != nil {
+		return nil, err
+	}
+	fees := []uint64{
+		ctx.BaseTxFee(),
+		ctx.CreateSubnetTxFee(),
+		ctx.TransformSubnetTxFee(),
+		ctx.CreateBlockchainTxFee(),
+		ctx.AddPrimaryNetworkValidatorFee(),
+		ctx.AddPrimaryNetworkDelegatorFee(),
+		ctx.AddSubnetValidatorFee(),
+		ctx.AddSubnetDelegatorFee(),
+	}
+	for i, fee := range fees {
+		scaledFee, err := feeState.Config.Fee(fee, price)
+		if err != nil {
+			return nil, err
+		}
+		fees[i] = scaledFee
+	}
+	return NewContext(
+		ctx.NetworkID(),
+		ctx.AVAXAssetID(),
+		fees[0],
+		fees[1],
+		fees[2],
+		fees[3],
+		fees[4],
+		fees[5],
+		fees[6],
+		fees[7],
+	), nil
+}
+
 func (c *context) NetworkID() uint32 {
 	return c.networkID
 }
@@ -81,6 +81,22 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueExitSubnetValidatorTx creates, signs, and issues a transaction that
+	// removes a validator of a subnet on behalf of the validator itself.
+	//
+	// - [nodeID] is the validator being removed from [subnetID].
+	// - [stakingCertificate] is the DER encoded staking certificate of
+	//   [nodeID]. If empty, [signature] must be signed by the BLS key that
+	//   [nodeID] registered on the primary network.
+	// - [signature] is a signature over [txs.ExitSubnetValidatorMessage].
+	IssueExitSubnetValidatorTx(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		stakingCertificate []byte,
+		signature []byte,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
 	// IssueAddDelegatorTx creates, signs, and issues a new delegator to a
 	// validator on the primary network.
 	//
@@ -122,6 +138,18 @@ type Wallet interface {
 		options ...common.Option,
 	) (*txs.Tx, error)
 
+	// IssueSetSubnetMetadataTx creates, signs, and issues a transaction that
+	// replaces the metadata of the specified subnet.
+	//
+	// - [subnetID] specifies the subnet to be modified.
+	// - [metadata] specifies the human readable name, website and contact of
+	//   the subnet.
+	IssueSetSubnetMetadataTx(
+		subnetID ids.ID,
+		metadata *txs.SubnetMetadata,
+		options ...common.Option,
+	) (*txs.Tx, error)
+
 	// IssueImportTx creates, signs, and issues an import transaction that
 	// attempts to consume all the available UTXOs and import the funds to [to].
 	//
@@ -321,6 +349,20 @@ func (w *wallet) IssueRemoveSubnetValidatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueExitSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	stakingCertificate []byte,
+	signature []byte,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewExitSubnetValidatorTx(nodeID, subnetID, stakingCertificate, signature, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -359,6 +401,18 @@ func (w *wallet) IssueCreateSubnetTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueSetSubnetMetadataTx(
+	subnetID ids.ID,
+	metadata *txs.SubnetMetadata,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	utx, err := w.builder.NewSetSubnetMetadataTx(subnetID, metadata, options...)
+	if err != nil {
+		return nil, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
@@ -84,6 +84,22 @@ func (w *walletWithOptions) IssueRemoveSubnetValidatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueExitSubnetValidatorTx(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	stakingCertificate []byte,
+	signature []byte,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueExitSubnetValidatorTx(
+		nodeID,
+		subnetID,
+		stakingCertificate,
+		signature,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
@@ -124,6 +140,18 @@ func (w *walletWithOptions) IssueCreateSubnetTx(
 	)
 }
 
+func (w *walletWithOptions) IssueSetSubnetMetadataTx(
+	subnetID ids.ID,
+	metadata *txs.SubnetMetadata,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.Wallet.IssueSetSubnetMetadataTx(
+		subnetID,
+		metadata,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
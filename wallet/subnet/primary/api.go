@@ -5,13 +5,17 @@ package primary
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ava-labs/coreth/ethclient"
 	"github.com/ava-labs/coreth/plugin/evm"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/gorilla/rpc/v2/json2"
+
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
@@ -33,6 +37,12 @@ const (
 	LocalAPIURI   = "http://localhost:9650"
 
 	fetchLimit = 1024
+
+	// dynamicFeeMarginPercent is the amount the P-chain fee price is increased
+	// by when issuing txs, so that they remain valid if the price increases
+	// before they are included. With the default fee config, this covers
+	// roughly two blocks that consume twice the target gas.
+	dynamicFeeMarginPercent = 25
 )
 
 // TODO: Refactor UTXOClient definition to allow the client implementations to
@@ -54,6 +64,13 @@ type UTXOClient interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 }
 
+// isMethodNotFound returns true if [err] reports that the queried API doesn't
+// implement the called method.
+func isMethodNotFound(err error) bool {
+	var rpcErr *json2.Error
+	return errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "can't find method")
+}
+
 type AVAXState struct {
 	PClient platformvm.Client
 	PCTX    p.Context
@@ -82,6 +99,19 @@ func FetchState(
 		return nil, err
 	}
 
+	feeState, err := pClient.GetFeeState(ctx)
+	switch {
+	case isMethodNotFound(err):
+		// Nodes that don't support dynamic fees only charge the static fees.
+	case err != nil:
+		return nil, err
+	default:
+		pCTX, err = p.NewContextWithFeeState(pCTX, feeState, dynamicFeeMarginPercent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	xCTX, err := x.NewContextFromClients(ctx, infoClient, xClient)
 	if err != nil {
 		return nil, err
@@ -34,6 +34,8 @@ type Options struct {
 
 	baseFee *big.Int
 
+	tip uint64
+
 	minIssuanceTimeSet bool
 	minIssuanceTime    uint64
 
@@ -99,6 +101,10 @@ func (o *Options) BaseFee(defaultBaseFee *big.Int) *big.Int {
 	return defaultBaseFee
 }
 
+func (o *Options) Tip() uint64 {
+	return o.tip
+}
+
 func (o *Options) MinIssuanceTime() uint64 {
 	if o.minIssuanceTimeSet {
 		return o.minIssuanceTime
@@ -162,6 +168,15 @@ func WithBaseFee(baseFee *big.Int) Option {
 	}
 }
 
+// WithTip specifies an amount of AVAX to burn on top of the static fee of a
+// P-chain tx. Txs paying a higher tip per byte are prioritized by the P-chain
+// mempool.
+func WithTip(tip uint64) Option {
+	return func(o *Options) {
+		o.tip = tip
+	}
+}
+
 func WithMinIssuanceTime(minIssuanceTime uint64) Option {
 	return func(o *Options) {
 		o.minIssuanceTimeSet = true